@@ -75,6 +75,15 @@ func (as *AccountService) Receive(context actor.Context) {
 	case *message.ImportAccount:
 		account, err := as.importAccount(msg.Wif, msg.OldPass, msg.NewPass)
 		context.Respond(&message.ImportAccountRsp{Account: account, Err: err})
+	case *message.CreateMnemonic:
+		mnemonic, account, err := as.createMnemonic(msg.Passphrase)
+		context.Respond(&message.CreateMnemonicRsp{Mnemonic: mnemonic, Account: account, Err: err})
+	case *message.ImportMnemonic:
+		account, err := as.importMnemonic(msg.Mnemonic, msg.Passphrase)
+		context.Respond(&message.ImportAccountRsp{Account: account, Err: err})
+	case *message.DeriveAccount:
+		account, err := as.deriveAccount(msg.Passphrase)
+		context.Respond(&message.AccountRsp{Account: account, Err: err})
 	case *message.ExportAccount:
 		wif, err := as.exportAccount(msg.Account.Address, msg.Pass)
 		context.Respond(&message.ExportAccountRsp{Wif: wif, Err: err})
@@ -130,6 +139,39 @@ func (as *AccountService) importAccount(wif []byte, old string, new string) (*ty
 	return account, nil
 }
 
+func (as *AccountService) createMnemonic(passphrase string) (string, *types.Account, error) {
+	mnemonic, address, err := as.ks.CreateMnemonic(passphrase)
+	if err != nil {
+		return "", nil, err
+	}
+	return mnemonic, as.addAccount(address), nil
+}
+
+func (as *AccountService) importMnemonic(mnemonic string, passphrase string) (*types.Account, error) {
+	address, err := as.ks.ImportMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return as.addAccount(address), nil
+}
+
+func (as *AccountService) deriveAccount(passphrase string) (*types.Account, error) {
+	address, err := as.ks.DeriveNextKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return as.addAccount(address), nil
+}
+
+func (as *AccountService) addAccount(address []byte) *types.Account {
+	account := types.NewAccount(address)
+	as.accountLock.Lock()
+	as.ks.SaveAddress(address)
+	as.accounts = append(as.accounts, account)
+	as.accountLock.Unlock()
+	return account
+}
+
 func (as *AccountService) exportAccount(address []byte, pass string) ([]byte, error) {
 	wif, err := as.ks.ExportKey(address, pass)
 	if err != nil {
@@ -0,0 +1,285 @@
+package key
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	sha256 "github.com/minio/sha256-simd"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key (BIP32)
+	HardenedKeyStart = 0x80000000
+	// AergoCoinType is the registered coin type of aergo (SLIP44)
+	AergoCoinType = 441
+
+	mnemonicEntropyBits = 256
+	mnemonicSeedLen     = 64
+	mnemonicIterations  = 2048
+)
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrInvalidChildKey = errors.New("invalid child key, try the next index")
+
+	masterKeySecret = []byte("Bitcoin seed")
+)
+
+// NewMnemonic generates a new BIP39 mnemonic of 24 words
+func NewMnemonic() (string, error) {
+	entropy := make([]byte, mnemonicEntropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return entropyToMnemonic(entropy), nil
+}
+
+func entropyToMnemonic(entropy []byte) string {
+	// the first len(entropy)/4 bits of the hash are appended as checksum
+	checksum := sha256.Sum256(entropy)
+	bits := new(big.Int).SetBytes(entropy)
+	csBits := uint(len(entropy) / 4)
+	bits.Lsh(bits, csBits)
+	bits.Or(bits, big.NewInt(int64(checksum[0]>>(8-csBits))))
+
+	n := (len(entropy)*8 + int(csBits)) / 11
+	words := make([]string, n)
+	mask := big.NewInt(2047)
+	for i := n - 1; i >= 0; i-- {
+		words[i] = mnemonicWords[new(big.Int).And(bits, mask).Int64()]
+		bits.Rsh(bits, 11)
+	}
+	return strings.Join(words, " ")
+}
+
+// ValidateMnemonic checks the words and the checksum of the mnemonic
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	n := len(words)
+	if n < 12 || n > 24 || n%3 != 0 {
+		return ErrInvalidMnemonic
+	}
+	bits := new(big.Int)
+	for _, w := range words {
+		i := sort.SearchStrings(mnemonicWords, w)
+		if i == len(mnemonicWords) || mnemonicWords[i] != w {
+			return ErrInvalidMnemonic
+		}
+		bits.Lsh(bits, 11)
+		bits.Or(bits, big.NewInt(int64(i)))
+	}
+	csBits := uint(n / 3)
+	entropyLen := (n*11 - int(csBits)) / 8
+	entropy := make([]byte, entropyLen)
+	b := new(big.Int).Rsh(bits, csBits).Bytes()
+	copy(entropy[entropyLen-len(b):], b)
+	if entropyToMnemonic(entropy) != strings.Join(words, " ") {
+		return ErrInvalidMnemonic
+	}
+	return nil
+}
+
+// MnemonicToSeed returns the BIP39 seed of the mnemonic
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase),
+		mnemonicIterations, mnemonicSeedLen, sha512.New), nil
+}
+
+// AccountPath returns the BIP44 path of aergo account, m/44'/441'/0'/0/index
+func AccountPath(index uint32) []uint32 {
+	return []uint32{
+		HardenedKeyStart + 44,
+		HardenedKeyStart + AergoCoinType,
+		HardenedKeyStart + 0,
+		0,
+		index,
+	}
+}
+
+// DeriveKey derives the private key along the path from the seed (BIP32)
+func DeriveKey(seed []byte, path []uint32) (*btcec.PrivateKey, error) {
+	mac := hmac.New(sha512.New, masterKeySecret)
+	mac.Write(seed)
+	key, chainCode, err := splitKey(mac.Sum(nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		key, chainCode, err = deriveChild(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return privkey, nil
+}
+
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= HardenedKeyStart {
+		data = append([]byte{0x0}, key...)
+	} else {
+		_, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), key)
+		data = pubkey.SerializeCompressed()
+	}
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	return splitKey(mac.Sum(nil), key)
+}
+
+// splitKey makes the key and the chain code from the hmac output. parent is
+// added to the key for child keys
+func splitKey(sum []byte, parent []byte) ([]byte, []byte, error) {
+	n := btcec.S256().N
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Cmp(n) >= 0 {
+		return nil, nil, ErrInvalidChildKey
+	}
+	if parent != nil {
+		k.Add(k, new(big.Int).SetBytes(parent))
+		k.Mod(k, n)
+	}
+	if k.Sign() == 0 {
+		return nil, nil, ErrInvalidChildKey
+	}
+	key := make([]byte, 32)
+	b := k.Bytes()
+	copy(key[32-len(b):], b)
+	return key, sum[32:], nil
+}
+
+var (
+	ErrSeedExists = errors.New("hd seed already exists")
+	ErrNoSeed     = errors.New("no hd seed, create or import mnemonic first")
+
+	// hdSeed is the seed encrypted by the sha256 of the passphrase, which is
+	// kept only to be migrated to hdSeedKeystore
+	hdSeed         = []byte("HDSEED")
+	hdSeedKeystore = []byte("HDSEEDKS")
+	hdIndex        = []byte("HDINDEX")
+)
+
+// CreateMnemonic makes new hd seed of the keystore and returns the mnemonic
+// of it with the address of the first account
+func (ks *Store) CreateMnemonic(pass string) (string, Address, error) {
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		return "", nil, err
+	}
+	addr, err := ks.ImportMnemonic(mnemonic, pass)
+	if err != nil {
+		return "", nil, err
+	}
+	return mnemonic, addr, nil
+}
+
+// ImportMnemonic saves the hd seed of the mnemonic to the keystore and
+// returns the address of the first account
+func (ks *Store) ImportMnemonic(mnemonic string, pass string) (Address, error) {
+	if len(ks.storage.Get(hdSeedKeystore)) != 0 || len(ks.storage.Get(hdSeed)) != 0 {
+		return nil, ErrSeedExists
+	}
+	seed, err := MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.putSeed(seed, pass); err != nil {
+		return nil, err
+	}
+	ks.setHDIndex(0)
+	return ks.DeriveNextKey(pass)
+}
+
+// DeriveNextKey makes the key of the next account along the BIP44 path of
+// aergo and returns it's address
+func (ks *Store) DeriveNextKey(pass string) (Address, error) {
+	seed, err := ks.getSeed(pass)
+	if err != nil {
+		return nil, err
+	}
+	index := ks.getHDIndex()
+	for {
+		privkey, err := DeriveKey(seed, AccountPath(index))
+		index++
+		if err == ErrInvalidChildKey {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ks.setHDIndex(index)
+		return ks.addKey(privkey, pass)
+	}
+}
+
+// putSeed saves the hd seed encrypted like the keys in keystore files
+func (ks *Store) putSeed(seed []byte, pass string) error {
+	c, err := sealWithPass(pass, seed, hdSeedKeystore)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	ks.storage.Set(hdSeedKeystore, data)
+	return nil
+}
+
+// getSeed returns the hd seed opened by the passphrase. the seed in the
+// legacy format is migrated on the first use
+func (ks *Store) getSeed(pass string) ([]byte, error) {
+	if data := ks.storage.Get(hdSeedKeystore); len(data) != 0 {
+		c := &KeystoreCrypto{}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, ErrInvalidKeystore
+		}
+		return openWithPass(c, pass, hdSeedKeystore)
+	}
+	stored := ks.storage.Get(hdSeed)
+	if len(stored) == 0 {
+		return nil, ErrNoSeed
+	} else if len(stored) <= 16 {
+		return nil, ErrInvalidKeystore
+	}
+	salt := stored[:16]
+	seed, err := decrypt(salt, hashBytes(salt, []byte(pass)), stored[16:])
+	if err != nil {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	if err := ks.putSeed(seed, pass); err != nil {
+		return nil, err
+	}
+	ks.storage.Delete(hdSeed)
+	return seed, nil
+}
+
+func (ks *Store) getHDIndex() uint32 {
+	b := ks.storage.Get(hdIndex)
+	if len(b) != 4 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (ks *Store) setHDIndex(index uint32) {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, index)
+	ks.storage.Set(hdIndex, b)
+}
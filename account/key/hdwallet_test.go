@@ -0,0 +1,137 @@
+package key
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("could not make seed : %s", err.Error())
+	}
+	if hex.EncodeToString(seed) != expected {
+		t.Errorf("invalid seed : %x", seed)
+	}
+
+	entropy, _ := hex.DecodeString("7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f")
+	if words := entropyToMnemonic(entropy); words != "legal winner thank year wave sausage worth useful legal winner thank yellow" {
+		t.Errorf("invalid mnemonic : %s", words)
+	}
+
+	for _, invalid := range []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon aergo",
+	} {
+		if err := ValidateMnemonic(invalid); err != ErrInvalidMnemonic {
+			t.Errorf("%s should be invalid, but %v", invalid, err)
+		}
+	}
+
+	words, err := NewMnemonic()
+	if err != nil {
+		t.Fatalf("could not make mnemonic : %s", err.Error())
+	}
+	if err := ValidateMnemonic(words); err != nil {
+		t.Errorf("new mnemonic should be valid : %s", words)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// test vector 1 of BIP32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	vectors := []struct {
+		path []uint32
+		key  string
+	}{
+		{nil, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{[]uint32{HardenedKeyStart}, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{[]uint32{HardenedKeyStart, 1}, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+	}
+	for _, v := range vectors {
+		privkey, err := DeriveKey(seed, v.path)
+		if err != nil {
+			t.Fatalf("could not derive key : %s", err.Error())
+		}
+		if hex.EncodeToString(privkey.Serialize()) != v.key {
+			t.Errorf("invalid key of %v : %x", v.path, privkey.Serialize())
+		}
+	}
+}
+
+func TestStoreMnemonic(t *testing.T) {
+	initTest()
+	defer deinitTest()
+
+	if _, err := ks.DeriveNextKey("pass"); err != ErrNoSeed {
+		t.Errorf("derive should fail without seed, but %v", err)
+	}
+	mnemonic, first, err := ks.CreateMnemonic("pass")
+	if err != nil {
+		t.Fatalf("could not create mnemonic : %s", err.Error())
+	}
+	if _, err := ks.ImportMnemonic(mnemonic, "pass"); err != ErrSeedExists {
+		t.Errorf("import should fail with existing seed, but %v", err)
+	}
+	if _, err := ks.DeriveNextKey("wrong"); err == nil {
+		t.Error("derive should fail with wrong password")
+	}
+	second, err := ks.DeriveNextKey("pass")
+	if err != nil {
+		t.Fatalf("could not derive key : %s", err.Error())
+	}
+	if bytes.Equal(first, second) {
+		t.Error("derived accounts should be different")
+	}
+	if _, err := ks.Unlock(second, "pass"); err != nil {
+		t.Errorf("could not unlock derived account : %s", err.Error())
+	}
+
+	// same accounts are recovered from the mnemonic
+	seed, _ := MnemonicToSeed(mnemonic, "")
+	for i, addr := range []Address{first, second} {
+		privkey, _ := DeriveKey(seed, AccountPath(uint32(i)))
+		if !bytes.Equal(GenerateAddress(privkey.PubKey().ToECDSA()), addr) {
+			t.Errorf("account %d is not recovered", i)
+		}
+	}
+}
+
+func TestMigrateSeed(t *testing.T) {
+	initTest()
+	defer deinitTest()
+
+	// the seed in the legacy format
+	mnemonic, _ := NewMnemonic()
+	seed, _ := MnemonicToSeed(mnemonic, "")
+	salt := bytes.Repeat([]byte{0x01}, 16)
+	encrypted, _ := encrypt(salt, hashBytes(salt, []byte("pass")), seed)
+	ks.storage.Set(hdSeed, append(salt, encrypted...))
+
+	if _, err := ks.ImportMnemonic(mnemonic, "pass"); err != ErrSeedExists {
+		t.Errorf("import should fail with legacy seed, but %v", err)
+	}
+	if _, err := ks.DeriveNextKey("wrong"); err != types.ErrWrongAddressOrPassWord {
+		t.Errorf("derive should fail with wrong password, but %v", err)
+	}
+	first, err := ks.DeriveNextKey("pass")
+	if err != nil {
+		t.Fatalf("could not derive key : %s", err.Error())
+	}
+	if len(ks.storage.Get(hdSeed)) != 0 || len(ks.storage.Get(hdSeedKeystore)) == 0 {
+		t.Error("legacy seed should be migrated on the first use")
+	}
+	privkey, _ := DeriveKey(seed, AccountPath(0))
+	if !bytes.Equal(GenerateAddress(privkey.PubKey().ToECDSA()), first) {
+		t.Error("account of the migrated seed is not matched")
+	}
+	if _, err := ks.DeriveNextKey("pass"); err != nil {
+		t.Errorf("could not derive key from migrated seed : %s", err.Error())
+	}
+}
@@ -0,0 +1,278 @@
+package key
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 1
+	keystoreDirName = "keystore"
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKDF     = "scrypt"
+
+	scryptDKLen   = 32
+	scryptSaltLen = 32
+
+	// bounds of the scrypt cost in a keystore, so a crafted keystore can not
+	// exhaust the memory (128*N*R bytes) or the cpu (128*N*R*P) of the node
+	maxScryptMemory = 256 << 20
+	maxScryptWork   = 1 << 30
+)
+
+// scrypt cost parameters of new keystores. N is 2^18 as recommended for
+// interactive logins
+var (
+	scryptN = 1 << 18
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	ErrInvalidKeystore     = errors.New("invalid keystore")
+	ErrUnsupportedKeystore = errors.New("unsupported keystore version or algorithm")
+)
+
+// Keystore is the portable json format of an encrypted private key
+type Keystore struct {
+	Version int            `json:"version"`
+	Address string         `json:"address"`
+	Crypto  KeystoreCrypto `json:"crypto"`
+}
+
+// KeystoreCrypto has the cipher text and the parameters to decrypt it
+type KeystoreCrypto struct {
+	Cipher     string       `json:"cipher"`
+	CipherText string       `json:"ciphertext"`
+	Nonce      string       `json:"nonce"`
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+}
+
+// ScryptParams is the parameters of scrypt key derivation
+type ScryptParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// validate checks the scrypt parameters read from a keystore are in bounds
+func (p *ScryptParams) validate() error {
+	if p.N < 2 || p.N&(p.N-1) != 0 || p.R < 1 || p.P < 1 || p.DKLen != scryptDKLen {
+		return ErrUnsupportedKeystore
+	}
+	// checked one by one not to overflow
+	memory := 128 * p.N
+	if p.N > maxScryptMemory/128 || p.R > maxScryptMemory/memory {
+		return ErrUnsupportedKeystore
+	}
+	memory *= p.R
+	if p.P > maxScryptWork/memory {
+		return ErrUnsupportedKeystore
+	}
+	return nil
+}
+
+// EncryptKeystore encrypts the private key with the passphrase and returns
+// it in json keystore format
+func EncryptKeystore(key *aergokey, pass string) ([]byte, error) {
+	address := GenerateAddress(&key.PublicKey)
+	// address is authenticated with the key, so it can not be forged
+	c, err := sealWithPass(pass, key.Serialize(), address)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&Keystore{
+		Version: keystoreVersion,
+		Address: types.EncodeAddress(address),
+		Crypto:  *c,
+	}, "", "  ")
+}
+
+// DecryptKeystore returns the private key in json keystore with the
+// passphrase
+func DecryptKeystore(data []byte, pass string) (*aergokey, error) {
+	ksj := &Keystore{}
+	if err := json.Unmarshal(data, ksj); err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	if ksj.Version != keystoreVersion {
+		return nil, ErrUnsupportedKeystore
+	}
+	address, err := types.DecodeAddress(ksj.Address)
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	plainbytes, err := openWithPass(&ksj.Crypto, pass, address)
+	if err != nil {
+		return nil, err
+	}
+	privkey, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), plainbytes)
+	if !bytes.Equal(GenerateAddress(pubkey.ToECDSA()), address) {
+		return nil, ErrInvalidKeystore
+	}
+	return privkey, nil
+}
+
+// sealWithPass encrypts data with the key derived from the passphrase by
+// scrypt. ad is authenticated along with data
+func sealWithPass(pass string, data, ad []byte) (*KeystoreCrypto, error) {
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := ScryptParams{N: scryptN, R: scryptR, P: scryptP, DKLen: scryptDKLen,
+		Salt: hex.EncodeToString(salt)}
+	derived, err := scrypt.Key([]byte(pass), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := newGCM(derived)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &KeystoreCrypto{
+		Cipher:     keystoreCipher,
+		CipherText: hex.EncodeToString(aesgcm.Seal(nil, nonce, data, ad)),
+		Nonce:      hex.EncodeToString(nonce),
+		KDF:        keystoreKDF,
+		KDFParams:  params,
+	}, nil
+}
+
+// openWithPass decrypts the cipher text sealed by sealWithPass
+func openWithPass(c *KeystoreCrypto, pass string, ad []byte) ([]byte, error) {
+	if c.Cipher != keystoreCipher || c.KDF != keystoreKDF {
+		return nil, ErrUnsupportedKeystore
+	}
+	if err := c.KDFParams.validate(); err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(c.KDFParams.Salt)
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	nonce, err := hex.DecodeString(c.Nonce)
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	cipherbytes, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	derived, err := scrypt.Key([]byte(pass), salt, c.KDFParams.N, c.KDFParams.R, c.KDFParams.P, c.KDFParams.DKLen)
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	aesgcm, err := newGCM(derived)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aesgcm.NonceSize() {
+		return nil, ErrInvalidKeystore
+	}
+	plainbytes, err := aesgcm.Open(nil, nonce, cipherbytes, ad)
+	if err != nil {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	return plainbytes, nil
+}
+
+func isKeystoreJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (ks *Store) keystoreFile(addr Address) string {
+	return path.Join(ks.keystorePath, types.EncodeAddress(addr)+".json")
+}
+
+func (ks *Store) readKeystore(addr Address) ([]byte, error) {
+	data, err := ioutil.ReadFile(ks.keystoreFile(addr))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (ks *Store) writeKeystore(addr Address, data []byte) error {
+	if err := os.MkdirAll(ks.keystorePath, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ks.keystoreFile(addr), data, 0600)
+}
+
+// MigrateKeys converts keys in the legacy account db, which are opened by
+// the passphrase, into keystore files and returns their addresses
+func (ks *Store) MigrateKeys(pass string) ([]Address, error) {
+	addrs, err := ks.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+	var migrated []Address
+	for _, addr := range addrs {
+		if data, err := ks.readKeystore(addr); err != nil {
+			return migrated, err
+		} else if data != nil {
+			continue
+		}
+		key, err := ks.migrateKey(addr, pass)
+		if err != nil {
+			return migrated, err
+		} else if key != nil {
+			migrated = append(migrated, addr)
+		}
+	}
+	return migrated, nil
+}
+
+// migrateKey moves the legacy key of the address into a keystore file. it
+// returns nil if there is no legacy key opened by the passphrase
+func (ks *Store) migrateKey(addr Address, pass string) (*aergokey, error) {
+	encryptkey := hashBytes(addr, []byte(pass))
+	dbkey := hashBytes(addr, encryptkey)
+	encrypted := ks.storage.Get(dbkey)
+	if len(encrypted) == 0 {
+		return nil, nil
+	}
+	key, err := decrypt(addr, encryptkey, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	data, err := EncryptKeystore(privkey, pass)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.writeKeystore(addr, data); err != nil {
+		return nil, err
+	}
+	ks.storage.Delete(dbkey)
+	return privkey, nil
+}
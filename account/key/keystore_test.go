@@ -0,0 +1,134 @@
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func TestKeystoreJSON(t *testing.T) {
+	scryptN = 1 << 12
+	privkey, _ := btcec.NewPrivateKey(btcec.S256())
+	data, err := EncryptKeystore(privkey, "pass")
+	if err != nil {
+		t.Fatalf("could not encrypt key : %s", err.Error())
+	}
+	decrypted, err := DecryptKeystore(data, "pass")
+	if err != nil {
+		t.Fatalf("could not decrypt key : %s", err.Error())
+	}
+	if !bytes.Equal(decrypted.Serialize(), privkey.Serialize()) {
+		t.Error("decrypted key is not matched")
+	}
+	if _, err := DecryptKeystore(data, "wrong"); err != types.ErrWrongAddressOrPassWord {
+		t.Errorf("wrong password should fail, but %v", err)
+	}
+	// address is authenticated by the cipher
+	other, _ := btcec.NewPrivateKey(btcec.S256())
+	forged := bytes.Replace(data, []byte(types.EncodeAddress(GenerateAddress(&privkey.PublicKey))),
+		[]byte(types.EncodeAddress(GenerateAddress(&other.PublicKey))), 1)
+	if _, err := DecryptKeystore(forged, "pass"); err == nil {
+		t.Error("forged address should fail")
+	}
+	if _, err := DecryptKeystore([]byte("{}"), "pass"); err != ErrUnsupportedKeystore {
+		t.Errorf("empty keystore should fail, but %v", err)
+	}
+}
+
+func TestKeystoreScryptBounds(t *testing.T) {
+	valid := ScryptParams{N: 1 << 18, R: 8, P: 1, DKLen: scryptDKLen}
+	if err := valid.validate(); err != nil {
+		t.Errorf("default params should be valid, but %v", err)
+	}
+	for _, p := range []ScryptParams{
+		{N: 1 << 30, R: 8, P: 1, DKLen: scryptDKLen},
+		{N: 1 << 18, R: 1 << 20, P: 1, DKLen: scryptDKLen},
+		{N: 1 << 18, R: 8, P: 1 << 20, DKLen: scryptDKLen},
+		{N: 1<<18 + 1, R: 8, P: 1, DKLen: scryptDKLen},
+		{N: 1 << 18, R: 0, P: 1, DKLen: scryptDKLen},
+		{N: 1 << 18, R: 8, P: 1, DKLen: 64},
+	} {
+		if err := p.validate(); err != ErrUnsupportedKeystore {
+			t.Errorf("%+v should be rejected, but %v", p, err)
+		}
+	}
+
+	// a keystore with an excessive cost is rejected before running scrypt
+	privkey, _ := btcec.NewPrivateKey(btcec.S256())
+	scryptN = 1 << 12
+	data, _ := EncryptKeystore(privkey, "pass")
+	data = bytes.Replace(data, []byte(`"n": 4096`), []byte(`"n": 1073741824`), 1)
+	if _, err := DecryptKeystore(data, "pass"); err != ErrUnsupportedKeystore {
+		t.Errorf("keystore with too large n should fail, but %v", err)
+	}
+}
+
+func TestImportKeystore(t *testing.T) {
+	initTest()
+	defer deinitTest()
+	privkey, _ := btcec.NewPrivateKey(btcec.S256())
+	data, _ := EncryptKeystore(privkey, "old")
+
+	addr, err := ks.ImportKey(data, "old", "new")
+	if err != nil {
+		t.Fatalf("could not import key : %s", err.Error())
+	}
+	if !bytes.Equal(addr, GenerateAddress(&privkey.PublicKey)) {
+		t.Error("imported address is not matched")
+	}
+	if _, err := ks.Unlock(addr, "new"); err != nil {
+		t.Errorf("could not unlock with new password : %s", err.Error())
+	}
+	if _, err := ks.ImportKey(data, "old", "new"); err == nil {
+		t.Error("duplicated import should fail")
+	}
+}
+
+// putLegacyKey saves the key in the legacy account db format
+func putLegacyKey(privkey *aergokey, pass string) Address {
+	address := GenerateAddress(&privkey.PublicKey)
+	encryptkey := hashBytes(address, []byte(pass))
+	encrypted, _ := encrypt(address, encryptkey, privkey.Serialize())
+	ks.storage.Set(hashBytes(address, encryptkey), encrypted)
+	ks.SaveAddress(address)
+	return address
+}
+
+func TestMigrateKeys(t *testing.T) {
+	initTest()
+	defer deinitTest()
+	var addrs []Address
+	for _, pass := range []string{"a", "a", "b"} {
+		privkey, _ := btcec.NewPrivateKey(btcec.S256())
+		addrs = append(addrs, putLegacyKey(privkey, pass))
+	}
+
+	migrated, err := ks.MigrateKeys("a")
+	if err != nil {
+		t.Fatalf("could not migrate keys : %s", err.Error())
+	}
+	if len(migrated) != 2 {
+		t.Errorf("2 keys should be migrated, but %d", len(migrated))
+	}
+	for _, addr := range addrs[:2] {
+		if data, _ := ks.readKeystore(addr); data == nil {
+			t.Errorf("keystore file of %s is not written", types.EncodeAddress(addr))
+		}
+		if _, err := ks.Unlock(addr, "a"); err != nil {
+			t.Errorf("could not unlock migrated key : %s", err.Error())
+		}
+	}
+
+	// the rest is migrated on the first use
+	if _, err := ks.Unlock(addrs[2], "a"); err != types.ErrWrongAddressOrPassWord {
+		t.Errorf("wrong password should fail, but %v", err)
+	}
+	if _, err := ks.Unlock(addrs[2], "b"); err != nil {
+		t.Errorf("could not unlock legacy key : %s", err.Error())
+	}
+	if data, _ := ks.readKeystore(addrs[2]); data == nil {
+		t.Error("legacy key should be migrated on unlock")
+	}
+}
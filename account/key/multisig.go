@@ -0,0 +1,176 @@
+package key
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// multisigAddressPrefix is the first byte of multisig addresses. it never
+// collides with compressed public keys (0x02, 0x03) or contracts (0x0C)
+const multisigAddressPrefix = 0x4D
+
+// MaxMultisigKeys is the maximum number of public keys in a multisig account
+const MaxMultisigKeys = 16
+
+// NewMultiSig returns an unsigned multisig of the threshold and the public
+// keys. the public keys are sorted so that the address does not depend on
+// the order of them
+func NewMultiSig(threshold uint32, pubkeys []Address) (*types.MultiSig, error) {
+	keys := make([][]byte, len(pubkeys))
+	copy(keys, pubkeys)
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	ms := &types.MultiSig{
+		Threshold: threshold,
+		Pubkeys:   keys,
+		Signs:     make([][]byte, len(keys)),
+	}
+	if err := validateMultiSig(ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func validateMultiSig(ms *types.MultiSig) error {
+	n := len(ms.GetPubkeys())
+	if n == 0 || n > MaxMultisigKeys || len(ms.GetSigns()) != n ||
+		ms.GetThreshold() == 0 || int(ms.GetThreshold()) > n {
+		return types.ErrInvalidMultisig
+	}
+	for i, pubkey := range ms.GetPubkeys() {
+		if len(pubkey) != types.AddressLength {
+			return types.ErrInvalidMultisig
+		}
+		if i > 0 && bytes.Compare(ms.Pubkeys[i-1], pubkey) >= 0 {
+			return types.ErrInvalidMultisig // not sorted or duplicated
+		}
+		if _, err := btcec.ParsePubKey(pubkey, btcec.S256()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MultisigAddress returns the address of the multisig account
+func MultisigAddress(ms *types.MultiSig) Address {
+	h := sha256.New()
+	binary.Write(h, binary.LittleEndian, ms.GetThreshold())
+	for _, pubkey := range ms.GetPubkeys() {
+		h.Write(pubkey)
+	}
+	return append([]byte{multisigAddressPrefix}, h.Sum(nil)...)
+}
+
+// IsMultisigAddress checks whether the address is of a multisig account
+func IsMultisigAddress(addr Address) bool {
+	return len(addr) == types.AddressLength && addr[0] == multisigAddressPrefix
+}
+
+// ParseMultiSig decodes the multisig in the sign field of a transaction
+func ParseMultiSig(sign []byte) (*types.MultiSig, error) {
+	ms := &types.MultiSig{}
+	if err := proto.Unmarshal(sign, ms); err != nil {
+		return nil, err
+	}
+	if err := validateMultiSig(ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// NewMultisigTx sets the account of txBody to the multisig account and
+// attaches the multisig without any signature
+func NewMultisigTx(txBody *types.TxBody, ms *types.MultiSig) (*types.Tx, error) {
+	txBody.Account = MultisigAddress(ms)
+	txBody.Sign = nil
+	tx := &types.Tx{Body: txBody}
+	if err := setMultiSig(tx, ms); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func setMultiSig(tx *types.Tx, ms *types.MultiSig) error {
+	sign, err := proto.Marshal(ms)
+	if err != nil {
+		return err
+	}
+	tx.Body.Sign = sign
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+func multisigOfTx(tx *types.Tx) (*types.MultiSig, error) {
+	if !IsMultisigAddress(tx.GetBody().GetAccount()) {
+		return nil, types.ErrNotMultisigTx
+	}
+	ms, err := ParseMultiSig(tx.GetBody().GetSign())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(MultisigAddress(ms), tx.GetBody().GetAccount()) {
+		return nil, types.ErrMultisigAddrNotMatch
+	}
+	return ms, nil
+}
+
+// AddMultisigSign puts the sign of the signer to the multisig of tx
+func AddMultisigSign(tx *types.Tx, signer Address, sign []byte) error {
+	ms, err := multisigOfTx(tx)
+	if err != nil {
+		return err
+	}
+	for i, pubkey := range ms.Pubkeys {
+		if bytes.Equal(pubkey, signer) {
+			ms.Signs[i] = sign
+			return setMultiSig(tx, ms)
+		}
+	}
+	return types.ErrNotMultisigMember
+}
+
+// SignMultisigTx co-signs the multisig tx with the key
+func SignMultisigTx(tx *types.Tx, key *aergokey) error {
+	hash := CalculateHashWithoutSign(tx.Body)
+	sign, err := key.Sign(hash)
+	if err != nil {
+		return err
+	}
+	return AddMultisigSign(tx, GenerateAddress(key.PubKey().ToECDSA()), sign.Serialize())
+}
+
+// VerifyMultisigTx checks tx has valid signatures at least the threshold of
+// the multisig account
+func VerifyMultisigTx(tx *types.Tx) error {
+	ms, err := multisigOfTx(tx)
+	if err != nil {
+		return err
+	}
+	hash := CalculateHashWithoutSign(tx.Body)
+	var signed uint32
+	for i, s := range ms.Signs {
+		if len(s) == 0 {
+			continue
+		}
+		sign, err := btcec.ParseSignature(s, btcec.S256())
+		if err != nil {
+			return err
+		}
+		pubkey, _ := btcec.ParsePubKey(ms.Pubkeys[i], btcec.S256())
+		if !sign.Verify(hash, pubkey) {
+			return types.ErrSignNotMatch
+		}
+		signed++
+	}
+	if signed < ms.Threshold {
+		return types.ErrNotEnoughSignatures
+	}
+	return nil
+}
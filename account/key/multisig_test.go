@@ -0,0 +1,126 @@
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func genMultisigKeys(t *testing.T, n int) ([]*aergokey, []Address) {
+	keys := make([]*aergokey, n)
+	addrs := make([]Address, n)
+	for i := 0; i < n; i++ {
+		privkey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("could not create key : %s", err.Error())
+		}
+		keys[i] = privkey
+		addrs[i] = GenerateAddress(&privkey.PublicKey)
+	}
+	return keys, addrs
+}
+
+func TestMultisigAddress(t *testing.T) {
+	_, addrs := genMultisigKeys(t, 3)
+
+	ms, err := NewMultiSig(2, addrs)
+	if err != nil {
+		t.Fatalf("could not create multisig : %s", err.Error())
+	}
+	addr := MultisigAddress(ms)
+	if len(addr) != types.AddressLength || !IsMultisigAddress(addr) {
+		t.Errorf("invalid multisig address : %v", addr)
+	}
+	if IsMultisigAddress(addrs[0]) {
+		t.Error("public key should not be a multisig address")
+	}
+
+	reversed := []Address{addrs[2], addrs[1], addrs[0]}
+	other, _ := NewMultiSig(2, reversed)
+	if !bytes.Equal(addr, MultisigAddress(other)) {
+		t.Error("address should not depend on the order of keys")
+	}
+	other, _ = NewMultiSig(3, addrs)
+	if bytes.Equal(addr, MultisigAddress(other)) {
+		t.Error("address should depend on the threshold")
+	}
+
+	for _, threshold := range []uint32{0, 4} {
+		if _, err := NewMultiSig(threshold, addrs); err != types.ErrInvalidMultisig {
+			t.Errorf("threshold %d should be invalid, but %v", threshold, err)
+		}
+	}
+	if _, err := NewMultiSig(1, []Address{addrs[0], addrs[0]}); err != types.ErrInvalidMultisig {
+		t.Errorf("duplicated keys should be invalid, but %v", err)
+	}
+}
+
+func TestMultisigTx(t *testing.T) {
+	keys, addrs := genMultisigKeys(t, 3)
+	ms, _ := NewMultiSig(2, addrs)
+
+	tx, err := NewMultisigTx(&types.TxBody{Nonce: 1, Recipient: addrs[0], Amount: []byte{10}}, ms)
+	if err != nil {
+		t.Fatalf("could not create multisig tx : %s", err.Error())
+	}
+	if err := VerifyTx(tx); err != types.ErrNotEnoughSignatures {
+		t.Errorf("unsigned tx should fail, but %v", err)
+	}
+
+	if err := SignMultisigTx(tx, keys[0]); err != nil {
+		t.Fatalf("could not sign : %s", err.Error())
+	}
+	if err := VerifyTx(tx); err != types.ErrNotEnoughSignatures {
+		t.Errorf("tx signed by 1 of 2 should fail, but %v", err)
+	}
+
+	if err := SignMultisigTx(tx, keys[2]); err != nil {
+		t.Fatalf("could not sign : %s", err.Error())
+	}
+	if err := VerifyTx(tx); err != nil {
+		t.Errorf("tx signed by 2 of 2 should succeed, but %s", err.Error())
+	}
+	if !bytes.Equal(tx.Hash, tx.CalculateTxHash()) {
+		t.Error("tx hash should be updated on signing")
+	}
+
+	outsider, _ := btcec.NewPrivateKey(btcec.S256())
+	if err := SignMultisigTx(tx, outsider); err != types.ErrNotMultisigMember {
+		t.Errorf("outsider should not sign, but %v", err)
+	}
+
+	tx.Body.Amount = []byte{20}
+	if err := VerifyTx(tx); err != types.ErrSignNotMatch {
+		t.Errorf("modified tx should fail, but %v", err)
+	}
+}
+
+func TestSignMultisigTxWithStore(t *testing.T) {
+	initTest()
+	defer deinitTest()
+
+	var addrs []Address
+	for _, pass := range []string{"a", "b", "c"} {
+		addr, err := ks.CreateKey(pass)
+		if err != nil {
+			t.Fatalf("could not create key : %s", err.Error())
+		}
+		addrs = append(addrs, addr)
+	}
+	ms, _ := NewMultiSig(2, addrs)
+	tx, _ := NewMultisigTx(&types.TxBody{Nonce: 1, Recipient: addrs[0]}, ms)
+
+	if err := ks.SignTx(tx); err != types.ErrShouldUnlockAccount {
+		t.Errorf("sign should fail without unlocked key, but %v", err)
+	}
+	ks.Unlock(addrs[0], "a")
+	ks.Unlock(addrs[1], "b")
+	if err := ks.SignTx(tx); err != nil {
+		t.Fatalf("could not sign : %s", err.Error())
+	}
+	if err := ks.VerifyTx(tx); err != nil {
+		t.Errorf("tx should be verified, but %s", err.Error())
+	}
+}
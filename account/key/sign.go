@@ -36,6 +36,9 @@ func SignTx(tx *types.Tx, key *aergokey) error {
 //SignTx return transaction which signed with unlocked key
 func (ks *Store) SignTx(tx *types.Tx) error {
 	addr := tx.Body.Account
+	if IsMultisigAddress(addr) {
+		return ks.signMultisigTx(tx)
+	}
 	key, exist := ks.unlocked[types.EncodeAddress(addr)]
 	if !exist {
 		return types.ErrShouldUnlockAccount
@@ -43,9 +46,35 @@ func (ks *Store) SignTx(tx *types.Tx) error {
 	return SignTx(tx, key)
 }
 
+// signMultisigTx co-signs the multisig tx with all unlocked keys of members
+func (ks *Store) signMultisigTx(tx *types.Tx) error {
+	ms, err := multisigOfTx(tx)
+	if err != nil {
+		return err
+	}
+	signed := false
+	for _, pubkey := range ms.GetPubkeys() {
+		key, exist := ks.unlocked[types.EncodeAddress(pubkey)]
+		if !exist {
+			continue
+		}
+		if err := SignMultisigTx(tx, key); err != nil {
+			return err
+		}
+		signed = true
+	}
+	if !signed {
+		return types.ErrShouldUnlockAccount
+	}
+	return nil
+}
+
 //VerifyTx return result to varify sign
 func VerifyTx(tx *types.Tx) error {
 	txBody := tx.Body
+	if IsMultisigAddress(txBody.Account) {
+		return VerifyMultisigTx(tx)
+	}
 	hash := CalculateHashWithoutSign(txBody)
 	sign, err := btcec.ParseSignature(txBody.Sign, btcec.S256())
 	if err != nil {
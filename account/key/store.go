@@ -18,8 +18,9 @@ type aergokey = btcec.PrivateKey
 
 // Store stucture of keystore
 type Store struct {
-	unlocked map[string]*aergokey
-	storage  db.DB
+	unlocked     map[string]*aergokey
+	storage      db.DB
+	keystorePath string
 }
 
 // NewStore make new instance of keystore
@@ -28,8 +29,9 @@ func NewStore(storePath string) *Store {
 	dbPath := path.Join(storePath, dbName)
 
 	return &Store{
-		unlocked: map[string]*aergokey{},
-		storage:  db.NewDB(db.LevelImpl, dbPath),
+		unlocked:     map[string]*aergokey{},
+		storage:      db.NewDB(db.LevelImpl, dbPath),
+		keystorePath: path.Join(storePath, keystoreDirName),
 	}
 }
 func (ks *Store) CloseStore() {
@@ -47,16 +49,25 @@ func (ks *Store) CreateKey(pass string) (Address, error) {
 	return ks.addKey(privkey, pass)
 }
 
-//ImportKey is to import encrypted key
+//ImportKey is to import encrypted key in json keystore or legacy format
 func (ks *Store) ImportKey(imported []byte, oldpass string, newpass string) (Address, error) {
-	hash := hashBytes([]byte(oldpass), nil)
-	rehash := hashBytes([]byte(oldpass), hash)
-	key, err := decrypt(hash, rehash, imported)
-	if err != nil {
-		return nil, err
+	var privkey *aergokey
+	if isKeystoreJSON(imported) {
+		var err error
+		privkey, err = DecryptKeystore(imported, oldpass)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		hash := hashBytes([]byte(oldpass), nil)
+		rehash := hashBytes([]byte(oldpass), hash)
+		key, err := decrypt(hash, rehash, imported)
+		if err != nil {
+			return nil, err
+		}
+		privkey, _ = btcec.PrivKeyFromBytes(btcec.S256(), key)
 	}
-	privkey, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), key)
-	address := GenerateAddress(pubkey.ToECDSA())
+	address := GenerateAddress(&privkey.PublicKey)
 	addresses, err := ks.GetAddresses()
 	if err != nil {
 		return nil, err
@@ -73,15 +84,14 @@ func (ks *Store) ImportKey(imported []byte, oldpass string, newpass string) (Add
 	return ks.addKey(privkey, newpass)
 }
 
-//ExportKey is to export encrypted key
+//ExportKey is to export encrypted key in json keystore format
 func (ks *Store) ExportKey(addr Address, pass string) ([]byte, error) {
 	key, err := ks.getKey(addr, pass)
 	if key == nil {
 		return nil, err
 	}
-	hash := hashBytes([]byte(pass), nil)
-	rehash := hashBytes([]byte(pass), hash)
-	return encrypt(hash, rehash, key)
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return EncryptKeystore(privkey, pass)
 }
 
 //Unlock is to unlock account for signing
@@ -107,24 +117,36 @@ func (ks *Store) Lock(addr Address, pass string) (Address, error) {
 }
 
 func (ks *Store) getKey(address []byte, pass string) ([]byte, error) {
-	encryptkey := hashBytes(address, []byte(pass))
-	key := ks.storage.Get(hashBytes(address, encryptkey))
-	if cap(key) == 0 {
+	data, err := ks.readKeystore(address)
+	if err != nil {
+		return nil, err
+	}
+	var privkey *aergokey
+	if data != nil {
+		privkey, err = DecryptKeystore(data, pass)
+	} else {
+		// key in the legacy account db is migrated on the first use
+		privkey, err = ks.migrateKey(address, pass)
+	}
+	if err != nil {
+		return nil, err
+	} else if privkey == nil {
 		return nil, types.ErrWrongAddressOrPassWord
 	}
-	return decrypt(address, encryptkey, key)
+	return privkey.Serialize(), nil
 }
 
 func (ks *Store) addKey(key *btcec.PrivateKey, pass string) (Address, error) {
 	//gen new address
 	address := GenerateAddress(&key.PublicKey)
 	//save pass/address/key
-	encryptkey := hashBytes(address, []byte(pass))
-	encrypted, err := encrypt(address, encryptkey, key.Serialize())
+	data, err := EncryptKeystore(key, pass)
 	if err != nil {
 		return nil, err
 	}
-	ks.storage.Set(hashBytes(address, encryptkey), encrypted)
+	if err := ks.writeKeystore(address, data); err != nil {
+		return nil, err
+	}
 	return address, nil
 }
 
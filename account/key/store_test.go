@@ -1,6 +1,7 @@
 package key
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"testing"
@@ -14,6 +15,8 @@ var (
 )
 
 func initTest() {
+	// light scrypt cost for fast tests
+	scryptN = 1 << 12
 	testDir, _ = ioutil.TempDir("", "test")
 	ks = NewStore(testDir)
 }
@@ -70,8 +73,11 @@ func TestExportKey(t *testing.T) {
 		if err != nil {
 			t.Errorf("could not export key : %s", err.Error())
 		}
-		if len(exported) != 48 {
-			t.Errorf("invalid exported address : length = %d", len(exported))
+		privkey, err := DecryptKeystore(exported, pass)
+		if err != nil {
+			t.Errorf("could not decrypt exported key : %s", err.Error())
+		} else if !bytes.Equal(GenerateAddress(&privkey.PublicKey), addr) {
+			t.Errorf("invalid exported key : %s", exported)
 		}
 	}
 }
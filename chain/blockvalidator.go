@@ -85,6 +85,14 @@ func (bv *BlockValidator) ValidateBody(block *types.Block) error {
 		return nil
 	}
 
+	for _, tx := range txs {
+		if err := CheckMultisig(tx.GetBody(), block.BlockNo()); err != nil {
+			logger.Error().Str("block", block.ID()).Str("hash", enc.ToString(tx.GetHash())).
+				Err(err).Msg("multisig tx before the v2 hardfork")
+			return err
+		}
+	}
+
 	failed, _ := bv.signVerifier.VerifyTxs(&types.TxList{Txs: txs})
 
 	if failed {
@@ -112,7 +120,7 @@ func (bv *BlockValidator) ValidatePost(sdbRoot []byte, receipts types.Receipts,
 		Msg("block root hash validation succeed")
 
 	hdrRoot = block.GetHeader().ReceiptsRootHash
-	receiptsRoot := receipts.MerkleRoot()
+	receiptsRoot := ReceiptsRoot(receipts, block.BlockNo())
 	if !bytes.Equal(hdrRoot, receiptsRoot) {
 		logger.Error().Str("block", block.ID()).
 			Str("hdrroot", enc.ToString(hdrRoot)).
@@ -50,6 +50,7 @@ LOOP:
 		default:
 			blkNo -= Skip
 		}
+		blkNo = cs.cdb.storedBlockNo(blkNo, lastNo)
 	}
 
 	return anchors, lastNo, nil
@@ -78,7 +79,7 @@ func (cs *ChainService) getAnchorsFromHash(blockHash []byte) ChainAnchor {
 		if latestNo == 0 {
 			return anchors
 		}
-		latestNo--
+		latestNo = cs.cdb.storedBlockNo(latestNo-1, latestNo)
 	}
 
 	count := MaxAnchorCount
@@ -100,7 +101,7 @@ func (cs *ChainService) getAnchorsFromHash(blockHash []byte) ChainAnchor {
 			}
 			latestNo = 0
 		} else {
-			latestNo -= dec
+			latestNo = cs.cdb.storedBlockNo(latestNo-dec, latestNo)
 			dec *= 2
 		}
 	}
@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 
 	"github.com/aergoio/aergo-lib/db"
+	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/internal/enc"
@@ -34,8 +35,21 @@ var (
 	ErrNoChainDB       = fmt.Errorf("chaindb not prepared")
 	ErrorLoadBestBlock = errors.New("failed to load latest block from DB")
 
-	latestKey      = []byte(chainDBName + ".latest")
-	receiptsPrefix = []byte("r")
+	latestKey          = []byte(chainDBName + ".latest")
+	hardforkKey        = []byte(chainDBName + ".hardfork")
+	accountIndexKey    = []byte(chainDBName + ".accountIndexFrom")
+	receiptsPrefix     = []byte("r")
+	accountIndexPrefix = []byte("a")
+
+	// ErrNoAccountIndex reports the account index is not enabled.
+	ErrNoAccountIndex = errors.New("account index is not enabled")
+	// ErrInvalidAccountTxCursor reports the cursor of the account txs is malformed.
+	ErrInvalidAccountTxCursor = errors.New("invalid cursor of account txs")
+)
+
+const (
+	accountTxSent     = byte(1)
+	accountTxReceived = byte(2)
 )
 
 // ErrNoBlock reports there is no such a block with id (hash or block number).
@@ -63,6 +77,16 @@ type ChainDB struct {
 	bestBlock atomic.Value // *types.Block
 	//	blocks []*types.Block
 	store db.DB
+
+	// accountIndex enables the index of txs by sender and recipient
+	accountIndex bool
+	// accountIndexFrom is the number of the first block covered by the
+	// account index. The older blocks are not indexed.
+	accountIndexFrom types.BlockNo
+	// snapshotNo is the number of the block which the chain started from by
+	// importing a snapshot. The blocks between the genesis and it are not
+	// stored.
+	snapshotNo types.BlockNo
 }
 
 func NewChainDB() *ChainDB {
@@ -107,6 +131,11 @@ func (cdb *ChainDB) Get(key []byte) []byte {
 	return cdb.store.Get(key)
 }
 
+// NewTx returns a new transaction of the chain DB.
+func (cdb *ChainDB) NewTx() db.Transaction {
+	return cdb.store.NewTx()
+}
+
 func (cdb *ChainDB) GetBestBlock() (*types.Block, error) {
 	//logger.Debug().Uint64("blockno", blockNo).Msg("get best block")
 	var block *types.Block
@@ -126,6 +155,9 @@ func (cdb *ChainDB) loadChainData() error {
 		return nil
 	}
 	latestNo := types.BlockNoFromBytes(latestBytes)
+	if raw := cdb.store.Get(snapshotKey); len(raw) != 0 {
+		cdb.snapshotNo = types.BlockNoFromBytes(raw)
+	}
 	/* TODO: just checking DB
 	cdb.blocks = make([]*types.Block, latestNo+1)
 	for i := uint32(0); i <= latestNo; i++ {
@@ -205,6 +237,44 @@ func (cdb *ChainDB) addGenesisBlock(genesis *types.Genesis) error {
 	return nil
 }
 
+// addSnapshot stores the block of an imported snapshot, or of a state synced
+// from a peer, and connects the block to the main chain. The genesis is stored
+// together unless genesisBlock is nil.
+func (cdb *ChainDB) addSnapshot(genesis []byte, genesisBlock *types.Block, block *types.Block) error {
+	tx := cdb.store.NewTx()
+	if genesisBlock != nil {
+		if err := cdb.addBlock(&tx, genesisBlock); err != nil {
+			return err
+		}
+		tx.Set(types.BlockNoToBytes(0), genesisBlock.BlockHash())
+		tx.Set([]byte(genesisKey), genesis)
+	}
+
+	if err := cdb.addBlock(&tx, block); err != nil {
+		return err
+	}
+	tx.Set(snapshotKey, types.BlockNoToBytes(block.BlockNo()))
+	cdb.connectToChain(&tx, block)
+
+	tx.Commit()
+
+	cdb.snapshotNo = block.BlockNo()
+	return nil
+}
+
+// storedBlockNo returns blockNo, or the number of the nearest block stored
+// before it if the chain started from a snapshot. from is the number of the
+// block visited before blockNo.
+func (cdb *ChainDB) storedBlockNo(blockNo types.BlockNo, from types.BlockNo) types.BlockNo {
+	if blockNo == 0 || blockNo >= cdb.snapshotNo {
+		return blockNo
+	}
+	if from > cdb.snapshotNo {
+		return cdb.snapshotNo
+	}
+	return 0
+}
+
 // GetGenesisInfo returns Genesis info from cdb.
 func (cdb *ChainDB) GetGenesisInfo() *types.Genesis {
 	if b := cdb.Get([]byte(genesisKey)); len(b) != 0 {
@@ -213,6 +283,58 @@ func (cdb *ChainDB) GetGenesisInfo() *types.Genesis {
 	return nil
 }
 
+// checkHardfork returns an error if the chain has connected a block by the
+// fork heights different from those of hardfork. Otherwise, hardfork is
+// stored to be checked at the next start.
+func (cdb *ChainDB) checkHardfork(hardfork *cfg.HardforkConfig) error {
+	if b := cdb.Get(hardforkKey); len(b) != 0 {
+		var stored cfg.HardforkConfig
+		if err := common.GobDecode(b, &stored); err != nil {
+			return err
+		}
+		if err := hardfork.CheckCompatibility(&stored, cdb.getBestBlockNo()); err != nil {
+			return err
+		}
+	}
+
+	b, err := common.GobEncode(hardfork)
+	if err != nil {
+		return err
+	}
+	tx := cdb.store.NewTx()
+	defer tx.Discard()
+	tx.Set(hardforkKey, b)
+	tx.Commit()
+
+	return nil
+}
+
+// setAccountIndex enables or disables the account index. The index covers
+// the blocks from the one following the best block at the time when it was
+// first enabled, and the height is kept in the DB. The height is dropped when
+// the index is disabled, since its entries are not updated by the reorgs
+// meanwhile, and the index starts over when it is enabled again.
+func (cdb *ChainDB) setAccountIndex(enabled bool) {
+	cdb.accountIndex = enabled
+	b := cdb.Get(accountIndexKey)
+	if enabled && len(b) != 0 {
+		cdb.accountIndexFrom = types.BlockNoFromBytes(b)
+		return
+	}
+
+	tx := cdb.store.NewTx()
+	defer tx.Discard()
+	if enabled {
+		cdb.accountIndexFrom = cdb.getBestBlockNo() + 1
+		tx.Set(accountIndexKey, types.BlockNoToBytes(cdb.accountIndexFrom))
+		logger.Info().Uint64("from", cdb.accountIndexFrom).Msg("account index started")
+	} else if len(b) != 0 {
+		tx.Delete(accountIndexKey)
+		logger.Info().Msg("account index dropped")
+	}
+	tx.Commit()
+}
+
 func (cdb *ChainDB) setLatest(newBestBlock *types.Block) (oldLatest types.BlockNo) {
 	oldLatest = cdb.getBestBlockNo()
 
@@ -328,7 +450,7 @@ type txInfo struct {
 	idx       int
 }
 
-func (cdb *ChainDB) addTxsOfBlock(dbTx *db.Transaction, txs []*types.Tx, blockHash []byte) error {
+func (cdb *ChainDB) addTxsOfBlock(dbTx *db.Transaction, txs []*types.Tx, blockHash []byte, blockNo types.BlockNo) error {
 	for i, txEntry := range txs {
 		if err := cdb.addTx(dbTx, txEntry, blockHash, i); err != nil {
 			logger.Error().Err(err).Str("hash", enc.ToString(blockHash)).Int("txidx", i).
@@ -336,11 +458,91 @@ func (cdb *ChainDB) addTxsOfBlock(dbTx *db.Transaction, txs []*types.Tx, blockHa
 
 			return err
 		}
+		if cdb.accountIndex {
+			cdb.addAccountTx(dbTx, txEntry, blockNo, i)
+		}
 	}
 
 	return nil
 }
 
+// deleteAccountTxsOfBlock removes the index entries of the txs in the block
+// which is rolled back from the main chain.
+func (cdb *ChainDB) deleteAccountTxsOfBlock(dbTx *db.Transaction, txs []*types.Tx, blockNo types.BlockNo) {
+	if !cdb.accountIndex {
+		return
+	}
+	for i, tx := range txs {
+		body := tx.GetBody()
+		(*dbTx).Delete(accountTxKey(body.GetAccount(), blockNo, i))
+		if len(body.GetRecipient()) != 0 {
+			(*dbTx).Delete(accountTxKey(body.GetRecipient(), blockNo, i))
+		}
+	}
+}
+
+// addAccountTx indexes the tx for both of its sender and recipient. the key
+// is ordered by descending position in the chain, so the latest tx of an
+// account comes first.
+func (cdb *ChainDB) addAccountTx(dbtx *db.Transaction, tx *types.Tx, blockNo types.BlockNo, idx int) {
+	account := tx.GetBody().GetAccount()
+	recipient := tx.GetBody().GetRecipient()
+
+	if bytes.Equal(account, recipient) {
+		(*dbtx).Set(accountTxKey(account, blockNo, idx), accountTxValue(tx.Hash, accountTxSent|accountTxReceived))
+		return
+	}
+	(*dbtx).Set(accountTxKey(account, blockNo, idx), accountTxValue(tx.Hash, accountTxSent))
+	if len(recipient) != 0 {
+		(*dbtx).Set(accountTxKey(recipient, blockNo, idx), accountTxValue(tx.Hash, accountTxReceived))
+	}
+}
+
+// getAccountTxs returns at most size txs sent or received by the account,
+// latest first, from the position of cursor or from the latest one if cursor
+// is empty. The cursor of the next page is returned if more txs are left.
+// The txs of the blocks before accountIndexFrom are not listed.
+func (cdb *ChainDB) getAccountTxs(account []byte, cursor []byte, size uint32) ([]*types.AccountTx, []byte, error) {
+	if !cdb.accountIndex {
+		return nil, nil, ErrNoAccountIndex
+	}
+	prefix := accountTxPrefix(account)
+	start := prefix
+	if len(cursor) != 0 {
+		if len(cursor) != 12 {
+			return nil, nil, ErrInvalidAccountTxCursor
+		}
+		start = accountTxKey(account, binary.BigEndian.Uint64(cursor[:8]), int(binary.BigEndian.Uint32(cursor[8:])))
+	}
+	it := cdb.store.Iterator(start, prefixEnd(prefix))
+
+	var txs []*types.AccountTx
+	for ; it.Valid(); it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(key) != len(prefix)+12 || len(value) < 1 {
+			return nil, nil, fmt.Errorf("invalid account index: key=%v", enc.ToString(key))
+		}
+		pos := key[len(prefix):]
+		blockNo := ^binary.BigEndian.Uint64(pos[:8])
+		txIdx := ^binary.BigEndian.Uint32(pos[8:])
+		if blockNo < cdb.accountIndexFrom {
+			break
+		}
+		if uint32(len(txs)) >= size {
+			return txs, accountTxCursor(blockNo, txIdx), nil
+		}
+		flag := value[len(value)-1]
+		txs = append(txs, &types.AccountTx{
+			TxHash:   value[:len(value)-1],
+			BlockNo:  blockNo,
+			TxIdx:    int32(txIdx),
+			Sent:     flag&accountTxSent != 0,
+			Received: flag&accountTxReceived != 0,
+		})
+	}
+	return txs, nil, nil
+}
+
 // stor tx info to DB
 func (cdb *ChainDB) addTx(dbtx *db.Transaction, tx *types.Tx, blockHash []byte, idx int) error {
 	txidx := types.TxIdx{
@@ -451,20 +653,30 @@ func (cdb *ChainDB) getTx(txHash []byte) (*types.Tx, *types.TxIdx, error) {
 }
 
 func (cdb *ChainDB) getReceipt(blockHash []byte, blockNo types.BlockNo, idx int32) (*types.Receipt, error) {
+	receipts, err := cdb.getReceipts(blockHash, blockNo)
+	if err != nil {
+		return nil, errors.New("cannot find a receipt")
+	}
+
+	if idx < 0 || idx >= int32(len(receipts)) {
+		return nil, fmt.Errorf("cannot find a receipt: invalid index (%d)", idx)
+	}
+	return receipts[idx], nil
+}
+
+func (cdb *ChainDB) getReceipts(blockHash []byte, blockNo types.BlockNo) (types.Receipts, error) {
 	data := cdb.store.Get(receiptsKey(blockHash, blockNo))
 	if len(data) == 0 {
-		return nil, errors.New("cannot find a receipt")
+		return nil, errors.New("cannot find receipts")
 	}
 	var b bytes.Buffer
 	b.Write(data)
 	var receipts types.Receipts
 	decoder := gob.NewDecoder(&b)
-	decoder.Decode(&receipts)
-
-	if idx < 0 || idx > int32(len(receipts)) {
-		return nil, fmt.Errorf("cannot find a receipt: invalid index (%d)", idx)
+	if err := decoder.Decode(&receipts); err != nil {
+		return nil, err
 	}
-	return receipts[idx], nil
+	return receipts, nil
 }
 
 type ChainTree struct {
@@ -506,6 +718,51 @@ func (cdb *ChainDB) writeReceipts(blockHash []byte, blockNo types.BlockNo, recei
 	dbTx.Commit()
 }
 
+func accountTxPrefix(account []byte) []byte {
+	var key bytes.Buffer
+	key.Write(accountIndexPrefix)
+	key.WriteByte(byte(len(account)))
+	key.Write(account)
+	return key.Bytes()
+}
+
+func accountTxKey(account []byte, blockNo types.BlockNo, idx int) []byte {
+	key := accountTxPrefix(account)
+	pos := make([]byte, 12)
+	binary.BigEndian.PutUint64(pos[:8], ^blockNo)
+	binary.BigEndian.PutUint32(pos[8:], ^uint32(idx))
+	return append(key, pos...)
+}
+
+// accountTxCursor returns the cursor of the account txs which starts at the
+// position of the tx.
+func accountTxCursor(blockNo types.BlockNo, txIdx uint32) []byte {
+	cursor := make([]byte, 12)
+	binary.BigEndian.PutUint64(cursor[:8], blockNo)
+	binary.BigEndian.PutUint32(cursor[8:], txIdx)
+	return cursor
+}
+
+func accountTxValue(txHash []byte, flag byte) []byte {
+	value := make([]byte, len(txHash), len(txHash)+1)
+	copy(value, txHash)
+	return append(value, flag)
+}
+
+// prefixEnd returns the smallest key which is greater than all keys with the
+// prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 func receiptsKey(blockHash []byte, blockNo types.BlockNo) []byte {
 	var key bytes.Buffer
 	key.Write(receiptsPrefix)
@@ -0,0 +1,105 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountIndex(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("", "chaindb")
+	defer os.RemoveAll(tmpdir)
+
+	cdb := NewChainDB()
+	cdb.store = db.NewDB(db.BadgerImpl, tmpdir)
+	cdb.setAccountIndex(true)
+	defer cdb.Close()
+
+	alice := []byte("alice")
+	bob := []byte("bob")
+	newTx := func(hash string, from, to []byte) *types.Tx {
+		return &types.Tx{Hash: []byte(hash), Body: &types.TxBody{Account: from, Recipient: to}}
+	}
+	addBlock := func(blockNo types.BlockNo, txs ...*types.Tx) {
+		dbTx := cdb.store.NewTx()
+		defer dbTx.Discard()
+		assert.NoError(t, cdb.addTxsOfBlock(&dbTx, txs, []byte{byte(blockNo)}, blockNo))
+		dbTx.Commit()
+	}
+
+	addBlock(1, newTx("tx1", alice, bob), newTx("tx2", bob, alice))
+	addBlock(2, newTx("tx3", alice, alice), newTx("tx4", bob, nil))
+
+	txs, next, err := cdb.getAccountTxs(alice, nil, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	if assert.Len(t, txs, 3) {
+		// latest first
+		assert.Equal(t, []byte("tx3"), txs[0].TxHash)
+		assert.True(t, txs[0].Sent && txs[0].Received)
+		assert.Equal(t, []byte("tx2"), txs[1].TxHash)
+		assert.Equal(t, int32(1), txs[1].TxIdx)
+		assert.True(t, txs[1].Received && !txs[1].Sent)
+		assert.Equal(t, []byte("tx1"), txs[2].TxHash)
+		assert.Equal(t, uint64(1), txs[2].BlockNo)
+		assert.True(t, txs[2].Sent && !txs[2].Received)
+	}
+
+	// the pages are not shifted by a new block
+	txs, next, err = cdb.getAccountTxs(alice, nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, accountTxCursor(1, 1), next)
+	addBlock(3, newTx("tx5", bob, alice))
+	txs, next, err = cdb.getAccountTxs(alice, next, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, accountTxCursor(1, 0), next)
+	if assert.Len(t, txs, 1) {
+		assert.Equal(t, []byte("tx2"), txs[0].TxHash)
+	}
+	_, _, err = cdb.getAccountTxs(alice, []byte{1}, 1)
+	assert.Equal(t, ErrInvalidAccountTxCursor, err)
+
+	txs, _, err = cdb.getAccountTxs(bob, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 4)
+
+	// the index of rolled back block is removed
+	dbTx := cdb.store.NewTx()
+	cdb.deleteAccountTxsOfBlock(&dbTx, []*types.Tx{newTx("tx3", alice, alice), newTx("tx4", bob, nil)}, 2)
+	dbTx.Commit()
+
+	txs, _, err = cdb.getAccountTxs(alice, accountTxCursor(2, 0), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, txs, 2) {
+		assert.Equal(t, []byte("tx2"), txs[0].TxHash)
+	}
+	txs, _, err = cdb.getAccountTxs(bob, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 3)
+
+	cdb.setAccountIndex(false)
+	_, _, err = cdb.getAccountTxs(alice, nil, 10)
+	assert.Equal(t, ErrNoAccountIndex, err)
+
+	// the index enabled again starts over from the block following the best
+	// one, and the stale entries of the older blocks are not listed
+	cdb.latest.Store(types.BlockNo(3))
+	cdb.setAccountIndex(true)
+	assert.Equal(t, types.BlockNo(4), cdb.accountIndexFrom)
+	addBlock(4, newTx("tx6", alice, bob))
+	txs, _, err = cdb.getAccountTxs(alice, nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, txs, 1) {
+		assert.Equal(t, []byte("tx6"), txs[0].TxHash)
+	}
+	cdb.setAccountIndex(true)
+	assert.Equal(t, types.BlockNo(4), cdb.accountIndexFrom, "kept across restarts")
+}
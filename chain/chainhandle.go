@@ -26,7 +26,8 @@ var (
 	ErrorNoAncestor = errors.New("not found ancestor")
 	ErrBlockOrphan  = errors.New("block is ohphan, so not connected in chain")
 
-	errBlockStale = errors.New("produced block becomes stale")
+	errBlockStale     = errors.New("produced block becomes stale")
+	errBlockSizeLimit = errors.New("the transactions included exceeded the block size limit")
 
 	InAddBlock = make(chan struct{}, 1)
 )
@@ -90,6 +91,22 @@ func (cs *ChainService) getTx(txHash []byte) (*types.Tx, *types.TxIdx, error) {
 	return tx, txidx, err
 }
 
+func (cs *ChainService) getTxProof(txHash []byte) (*types.TxProof, error) {
+	tx, txidx, err := cs.getTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	block, err := cs.cdb.getBlock(txidx.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &types.TxProof{
+		Tx:         tx,
+		TxIdx:      txidx,
+		MerklePath: types.CalculateTxMerklePath(block.GetBody().GetTxs(), int(txidx.Idx)),
+	}, nil
+}
+
 func (cs *ChainService) getReceipt(txHash []byte) (*types.Receipt, error) {
 	_, i, err := cs.cdb.getTx(txHash)
 	if err != nil {
@@ -102,7 +119,47 @@ func (cs *ChainService) getReceipt(txHash []byte) (*types.Receipt, error) {
 		return nil, errors.New("cannot find a receipt")
 	}
 
-	return cs.cdb.getReceipt(block.BlockHash(), block.GetHeader().BlockNo, i.Idx)
+	receipt, err := cs.cdb.getReceipt(block.BlockHash(), block.GetHeader().BlockNo, i.Idx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range receipt.Events {
+		ev.SetLocation(txHash, block.BlockHash(), block.GetHeader().BlockNo, i.Idx)
+	}
+	return receipt, nil
+}
+
+func (cs *ChainService) getReceipts(blockHash []byte) (types.Receipts, error) {
+	block, err := cs.cdb.getBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	blockNo := block.GetHeader().GetBlockNo()
+	blockInMainChain, err := cs.cdb.GetBlockByNo(blockNo)
+	if err != nil || !bytes.Equal(block.BlockHash(), blockInMainChain.BlockHash()) {
+		return nil, errors.New("cannot find receipts")
+	}
+
+	receipts, err := cs.cdb.getReceipts(block.BlockHash(), blockNo)
+	if err != nil {
+		return nil, err
+	}
+	setReceiptsLocation(block, receipts)
+	return receipts, nil
+}
+
+// setReceiptsLocation fills the location of the events stored in receipts,
+// which are not kept in the receipts DB.
+func setReceiptsLocation(block *types.Block, receipts types.Receipts) {
+	txs := block.GetBody().GetTxs()
+	for i, r := range receipts {
+		if i >= len(txs) {
+			break
+		}
+		for _, ev := range r.Events {
+			ev.SetLocation(txs[i].GetHash(), block.BlockHash(), block.BlockNo(), int32(i))
+		}
+	}
 }
 
 type chainProcessor struct {
@@ -193,10 +250,10 @@ func (cp *chainProcessor) isMain() bool {
 	return cp.mainChain != nil
 }
 
-func (cp *chainProcessor) executeBlock(block *types.Block) error {
-	err := cp.ChainService.executeBlock(cp.state, block)
+func (cp *chainProcessor) executeBlock(block *types.Block) (types.Receipts, error) {
+	receipts, err := cp.ChainService.executeBlock(cp.state, block)
 	cp.state = nil
-	return err
+	return receipts, err
 }
 
 func (cp *chainProcessor) execute() error {
@@ -205,11 +262,10 @@ func (cp *chainProcessor) execute() error {
 	}
 	logger.Debug().Int("blocks to execute", cp.mainChain.Len()).Msg("start to execute")
 
-	var err error
 	for e := cp.mainChain.Front(); e != nil; e = e.Next() {
 		block := e.Value.(*types.Block)
 
-		err = cp.executeBlock(block)
+		receipts, err := cp.executeBlock(block)
 		if err != nil {
 			logger.Error().Str("error", err.Error()).Str("hash", block.ID()).
 				Msg("failed to execute block")
@@ -223,7 +279,11 @@ func (cp *chainProcessor) execute() error {
 		if oldLatest, err = cp.connectToChain(block); err != nil {
 			return err
 		}
-		cp.notifyBlock(block)
+		// A light node doesn't serve the block body to the peers
+		if !cp.lightNode {
+			cp.notifyBlock(block)
+			cp.notifyReceipts(block, receipts, false)
+		}
 		blockNo := block.BlockNo()
 		if logger.IsDebugEnabled() {
 			logger.Debug().
@@ -244,7 +304,8 @@ func (cp *chainProcessor) connectToChain(block *types.Block) (types.BlockNo, err
 
 	oldLatest := cp.cdb.connectToChain(&dbTx, block)
 
-	if err := cp.cdb.addTxsOfBlock(&dbTx, block.GetBody().GetTxs(), block.BlockHash()); err != nil {
+	if err := cp.cdb.addTxsOfBlock(&dbTx, block.GetBody().GetTxs(), block.BlockHash(),
+		block.GetHeader().GetBlockNo()); err != nil {
 		return 0, err
 	}
 
@@ -295,8 +356,26 @@ func (cs *ChainService) addBlock(newBlock *types.Block, usedBstate *state.BlockS
 		}
 	}
 
-	// Check consensus header validity
-	if err := cs.IsBlockValid(newBlock, bestBlock); err != nil {
+	// A light node keeps the block header only
+	if cs.lightNode {
+		if newBlock, err = cs.toLightBlock(newBlock); err != nil {
+			return err
+		}
+	}
+
+	// Check consensus header validity. The chain parameters are read from
+	// the state of the best block, which is the parent unless newBlock
+	// belongs to a side branch or is an orphan. Every block is checked again
+	// by the state of its parent when it is executed.
+	scs, err := cs.SystemState(bestBlock)
+	if err != nil {
+		return err
+	}
+	params, err := ParamsAt(scs, newBlock.BlockNo())
+	if err != nil {
+		return err
+	}
+	if err := cs.IsBlockValid(newBlock, bestBlock, params); err != nil {
 		return err
 	}
 
@@ -398,12 +477,22 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 		bState = state.NewBlockState(cs.sdb.OpenNewStateDB(cs.sdb.GetRoot()))
 
 		exec = NewTxExecutor(block.BlockNo(), block.GetHeader().GetTimestamp(), contract.ChainService)
+
+		if bpID, err := block.BPID(); err == nil {
+			bState.SetBlockProducer([]byte(bpID))
+		}
 	} else {
 		logger.Debug().Uint64("block no", block.BlockNo()).Msg("received block from block factory")
 		// In this case (bState != nil), the transactions has already been
 		// executed by the block factory.
 		commitOnly = true
 	}
+	bState.SetBlockNo(block.BlockNo())
+	if !commitOnly {
+		if err := SeedStakingTotal(bState, block.GetHeader().GetPrevBlockHash()); err != nil {
+			return nil, err
+		}
+	}
 
 	return &blockExecutor{
 		BlockState:       bState,
@@ -495,11 +584,43 @@ func (e *blockExecutor) commit() error {
 	return nil
 }
 
+// validateByParent checks block by the chain parameters read from the state
+// of its parent, on which block is executed. An error reading them fails the
+// block, since the other nodes may not accept it.
+func (cs *ChainService) validateByParent(block *types.Block) error {
+	parent, err := cs.cdb.getBlock(block.GetHeader().GetPrevBlockHash())
+	if err != nil {
+		return err
+	}
+	params, err := cs.chainParams(parent)
+	if err != nil {
+		return err
+	}
+	if BlockBodySize(block) > MaxBlockBodySize(params) {
+		return errBlockSizeLimit
+	}
+	return cs.IsBlockValid(block, parent, params)
+}
+
 //TODO Refactoring: batch
-func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Block) error {
+func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Block) (types.Receipts, error) {
+	if err := cs.validateByParent(block); err != nil {
+		return nil, err
+	}
+
+	// A light node has no state to execute the block on. The parameters and
+	// the BPs are read from a peer with the proofs.
+	if cs.lightNode {
+		if err := cs.Update(block); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	prevRoot := cs.sdb.GetRoot()
 	ex, err := newBlockExecutor(cs, bstate, block)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// contract & state DB update is done during execution.
@@ -507,18 +628,33 @@ func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Bloc
 		// FIXME: is that enough?
 		logger.Error().Err(err).Str("hash", block.ID()).Msg("failed to execute block")
 
-		return err
+		return nil, err
 	}
 
-	cs.cdb.writeReceipts(block.BlockHash(), block.BlockNo(), ex.BlockState.Receipts())
+	// The block is not connected if the consensus fails to follow it, e.g.
+	// the BPs can't be elected, and the state is rolled back.
+	if err := cs.Update(block); err != nil {
+		if rbErr := cs.sdb.Rollback(prevRoot); rbErr != nil {
+			logger.Fatal().Err(rbErr).Str("hash", block.ID()).Msg("failed to roll back the state")
+		}
+		return nil, err
+	}
+
+	receipts := ex.BlockState.Receipts()
+	cs.cdb.writeReceipts(block.BlockHash(), block.BlockNo(), receipts)
 
 	cs.RequestTo(message.MemPoolSvc, &message.MemPoolDel{
 		Block: block,
 	})
 
-	cs.Update(block)
+	if cs.sdb.IsPruning() {
+		libNo, hasLib := cs.LibNo()
+		if err := cs.sdb.Prune(block.BlockNo(), libNo, hasLib); err != nil {
+			logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to prune state")
+		}
+	}
 
-	return nil
+	return receipts, nil
 }
 
 func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, preLoadService int) error {
@@ -528,12 +664,18 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 	}
 	txBody := tx.GetBody()
 
+	if err = CheckGasLimit(txBody, blockNo); err != nil {
+		return err
+	}
+	isV2 := IsV2Fork(blockNo)
+
 	sender, err := bs.GetAccountStateV(txBody.Account)
 	if err != nil {
 		return err
 	}
 
-	err = tx.ValidateWithSenderState(sender.State())
+	maxFee := MaxTxFee(txBody, blockNo)
+	err = tx.ValidateWithSenderStateAndFee(sender.State(), maxFee)
 	if err != nil {
 		return err
 	}
@@ -551,11 +693,21 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 
 	var txFee *big.Int
 	var rv string
+	var events []*types.Event
+	var usedGas uint64
 	switch txBody.Type {
 	case types.TxType_NORMAL:
-		txFee = new(big.Int).SetUint64(CoinbaseFee)
-		sender.SubBalance(txFee)
-		rv, err = contract.Execute(bs, tx, blockNo, ts, sender, receiver, preLoadService)
+		// The fee for the whole gas limit is paid in advance. The fee for the
+		// unused gas is refunded after the execution.
+		sender.SubBalance(maxFee)
+		rv, events, usedGas, err = contract.Execute(bs, tx, blockNo, ts, sender, receiver, preLoadService, isV2)
+		if isV2 {
+			usedGas += types.TxBaseGas
+			txFee = types.GasFee(usedGas, txBody.GetPriceBigInt())
+		} else {
+			txFee = maxFee
+		}
+		sender.AddBalance(new(big.Int).Sub(maxFee, txFee))
 	case types.TxType_GOVERNANCE:
 		txFee = new(big.Int).SetUint64(0)
 		err = executeGovernanceTx(&bs.StateDB, txBody, sender, receiver, blockNo)
@@ -574,7 +726,10 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 				return sErr
 			}
 			bs.BpReward = new(big.Int).Add(new(big.Int).SetBytes(bs.BpReward), txFee).Bytes()
-			bs.AddReceipt(types.NewReceipt(receiver.ID(), err.Error(), ""))
+			receipt := types.NewReceipt(receiver.ID(), err.Error(), "")
+			receipt.FeeUsed = txFee.Bytes()
+			receipt.GasUsed = usedGas
+			bs.AddReceipt(receipt)
 			return nil
 		}
 		return err
@@ -594,22 +749,41 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 
 	bs.BpReward = new(big.Int).Add(new(big.Int).SetBytes(bs.BpReward), txFee).Bytes()
 
+	var receipt *types.Receipt
 	if receiver.IsNew() && txBody.Recipient == nil {
-		bs.AddReceipt(types.NewReceipt(receiver.ID(), "CREATED", rv))
-		return nil
-
+		receipt = types.NewReceipt(receiver.ID(), "CREATED", rv)
+	} else {
+		receipt = types.NewReceipt(receiver.ID(), "SUCCESS", rv)
 	}
-	bs.AddReceipt(types.NewReceipt(receiver.ID(), "SUCCESS", rv))
+	receipt.FeeUsed = txFee.Bytes()
+	receipt.GasUsed = usedGas
+	receipt.Events = events
+	bs.AddReceipt(receipt)
 	return nil
 }
 
+// SendRewardCoinbase sends the block reward to the coinbase account after
+// sharing it with the voters of the block producer. The reward is shared from
+// the v2 hardfork, and the whole reward goes to the coinbase account before.
 func SendRewardCoinbase(bState *state.BlockState, coinbaseAccount []byte) error {
 	bpReward := new(big.Int).SetBytes(bState.BpReward)
-	if bpReward.Cmp(new(big.Int).SetUint64(0)) <= 0 || coinbaseAccount == nil {
+	if bpReward.Cmp(new(big.Int).SetUint64(0)) <= 0 {
 		logger.Debug().Str("reward", new(big.Int).SetBytes(bState.BpReward).String()).Msg("coinbase is skipped")
 		return nil
 	}
 
+	if bpID := bState.BlockProducer(); bpID != nil && IsV2Fork(bState.BlockNo()) {
+		var err error
+		if bpReward, err = shareRewardWithVoters(bState, bpID, bpReward); err != nil {
+			return err
+		}
+	}
+
+	if coinbaseAccount == nil {
+		logger.Debug().Str("reward", bpReward.String()).Msg("coinbase is skipped")
+		return nil
+	}
+
 	receiverID := types.ToAccountID(coinbaseAccount)
 	receiverState, err := bState.GetAccountState(receiverID)
 	if err != nil {
@@ -13,9 +13,12 @@ import (
 
 	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo/account/key"
+	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,3 +120,152 @@ func TestBasicExecuteTx(t *testing.T) {
 	assert.NoError(t, err, "execute governance type")
 
 }
+
+func TestGasFeeExecuteTx(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+	bs := state.NewBlockState(sdb.GetStateDB())
+
+	price := new(big.Int).SetUint64(2)
+	amount := new(big.Int).SetUint64(1000)
+	tx := &types.Tx{Body: &types.TxBody{}}
+	tx.Body.Account = makeTestAddress(t)
+	tx.Body.Recipient = makeTestAddress(t)
+	tx.Body.Nonce = 1
+	tx.Body.Amount = amount.Bytes()
+	tx.Body.Price = price.Bytes()
+	tx.Body.Limit = types.TxBaseGas - 1
+	signTestAddress(t, tx)
+	err := executeTx(bs, tx, 0, 0, contract.ChainService)
+	assert.EqualError(t, err, types.ErrNotEnoughGas.Error(), "execute with too small gas limit")
+
+	sender, err := bs.GetAccountStateV(tx.Body.Account)
+	assert.NoError(t, err, "get sender state")
+	before := sender.Balance()
+
+	tx.Body.Limit = types.TxBaseGas * 10
+	signTestAddress(t, tx)
+	err = executeTx(bs, tx, 0, 0, contract.ChainService)
+	assert.NoError(t, err, "execute with gas limit")
+
+	// only the base gas is charged for a transfer and the rest is refunded
+	fee := types.GasFee(types.TxBaseGas, price)
+	sender, err = bs.GetAccountStateV(tx.Body.Account)
+	assert.NoError(t, err, "get sender state")
+	expected := new(big.Int).Sub(new(big.Int).Sub(before, amount), fee)
+	assert.Equal(t, expected.String(), sender.Balance().String(), "sender balance")
+
+	receipts := bs.Receipts()
+	assert.Equal(t, 1, len(receipts), "receipt count")
+	assert.Equal(t, uint64(types.TxBaseGas), receipts[0].GetGasUsed(), "gas used")
+	assert.Equal(t, fee.Bytes(), receipts[0].GetFeeUsed(), "fee used")
+	assert.Equal(t, fee.Bytes(), bs.BpReward, "bp reward")
+}
+
+func TestLegacyFeeExecuteTx(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+	bs := state.NewBlockState(sdb.GetStateDB())
+
+	// the flat fee is charged until the v2 hardfork
+	defer func(hf *cfg.HardforkConfig) { Hardfork = hf }(Hardfork)
+	Hardfork = &cfg.HardforkConfig{V2: 10}
+
+	tx := &types.Tx{Body: &types.TxBody{}}
+	tx.Body.Account = makeTestAddress(t)
+	tx.Body.Recipient = makeTestAddress(t)
+	tx.Body.Nonce = 1
+	tx.Body.Amount = new(big.Int).SetUint64(1000).Bytes()
+	signTestAddress(t, tx)
+
+	sender, err := bs.GetAccountStateV(tx.Body.Account)
+	assert.NoError(t, err, "get sender state")
+	before := sender.Balance()
+
+	err = executeTx(bs, tx, 9, 0, contract.ChainService)
+	assert.NoError(t, err, "execute without price before the fork")
+
+	fee := new(big.Int).SetUint64(types.LegacyTxFee)
+	sender, err = bs.GetAccountStateV(tx.Body.Account)
+	assert.NoError(t, err, "get sender state")
+	expected := new(big.Int).Sub(new(big.Int).Sub(before, tx.Body.GetAmountBigInt()), fee)
+	assert.Equal(t, expected.String(), sender.Balance().String(), "sender balance")
+	assert.Equal(t, fee.Bytes(), bs.Receipts()[0].GetFeeUsed(), "fee used")
+	assert.Equal(t, uint64(0), bs.Receipts()[0].GetGasUsed(), "no gas used before the fork")
+}
+
+func TestRewardBeforeFork(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+
+	// the reward is shared with the voters from the v2 hardfork
+	defer func(hf *cfg.HardforkConfig) { Hardfork = hf }(Hardfork)
+	Hardfork = &cfg.HardforkConfig{V2: 10}
+
+	bpID := []byte("bp")
+	voteResult := map[string]*big.Int{base58.Encode(bpID): big.NewInt(100)}
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	assert.NoError(t, err, "open system state")
+	assert.NoError(t, system.InitVoteResult(scs, &voteResult), "init vote result")
+	assert.NoError(t, sdb.GetStateDB().StageContractState(scs), "stage system state")
+
+	for _, tc := range []struct {
+		no     types.BlockNo
+		reward int64
+	}{
+		{9, 1000},
+		{10, 1000 * system.BpRewardRate / 100},
+	} {
+		bs := state.NewBlockState(sdb.GetStateDB())
+		bs.SetBlockNo(tc.no)
+		bs.SetBlockProducer(bpID)
+		bs.BpReward = big.NewInt(1000).Bytes()
+
+		coinbase := makeTestAddress(t)
+		assert.NoError(t, SendRewardCoinbase(bs, coinbase), "send reward")
+
+		receiver, err := bs.GetAccountStateV(coinbase)
+		assert.NoError(t, err, "get coinbase state")
+		assert.Equal(t, big.NewInt(tc.reward).String(), receiver.Balance().String(), "coinbase reward of block %d", tc.no)
+	}
+}
+
+func TestFindStakers(t *testing.T) {
+	defer func(get func([]byte) (*types.Block, error)) { getBlockByHash = get }(getBlockByHash)
+
+	governanceTx := func(account []byte, cmd byte) *types.Tx {
+		return &types.Tx{Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   []byte{cmd},
+			Type:      types.TxType_GOVERNANCE,
+		}}
+	}
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	b1 := types.NewBlock(genesis, nil, nil, []*types.Tx{
+		governanceTx(a, 's'),
+		{Body: &types.TxBody{Account: b, Recipient: []byte(types.AergoSystem), Payload: []byte{'s'}}},
+	}, nil, 1)
+	b2 := types.NewBlock(b1, nil, nil, []*types.Tx{
+		governanceTx(c, 's'),
+		governanceTx(a, 'v'),
+		governanceTx(a, 's'),
+	}, nil, 2)
+
+	blocks := make(map[string]*types.Block)
+	for _, block := range []*types.Block{genesis, b1, b2} {
+		blocks[string(block.BlockHash())] = block
+	}
+	getBlockByHash = func(hash []byte) (*types.Block, error) {
+		return blocks[string(hash)], nil
+	}
+
+	stakers, err := findStakers(b2.BlockHash())
+	assert.NoError(t, err, "find stakers")
+	assert.Equal(t, [][]byte{c, a}, stakers, "senders of the staking txs")
+	stakers, err = findStakers(b1.BlockHash())
+	assert.NoError(t, err, "find stakers")
+	assert.Equal(t, [][]byte{a}, stakers, "senders of the staking txs")
+}
@@ -32,12 +32,20 @@ var (
 	logger = log.NewLogger("chain")
 
 	ErrBlockExist = errors.New("block already exist")
+	// ErrStatePruned reports the state of the block was deleted by the state
+	// pruning.
+	ErrStatePruned = errors.New("state of the block is pruned")
+	// ErrNotMainChainBlock reports the block is not in the main chain.
+	ErrNotMainChainBlock = errors.New("block is not in the main chain")
 )
 
 // Core represents a storage layer of a blockchain (chain & state DB).
 type Core struct {
 	cdb *ChainDB
 	sdb *state.ChainStateDB
+
+	// lightNode keeps only the block headers without the state.
+	lightNode bool
 }
 
 // NewCore returns an instance of Core.
@@ -112,6 +120,50 @@ func (core *Core) initGenesis(genesis *types.Genesis) (*types.Block, error) {
 	return genesisBlock, nil
 }
 
+// SetStatePruning sets the number of the recent blocks whose state is kept.
+// The state pruning is disabled if keep is 0 or in the archive mode, which
+// keeps the states of all blocks.
+func (core *Core) SetStatePruning(keep int, archive bool) {
+	if archive && keep > 0 {
+		logger.Warn().Int("keep", keep).Msg("state pruning is disabled in the archive mode")
+		keep = 0
+	}
+	core.sdb.SetPruning(keep)
+
+	if archive && core.sdb.IsStatePruned(0) {
+		logger.Warn().Msg("the state db was pruned before. the states of the old blocks are not available")
+	}
+}
+
+// getStateDB returns the state db at the block of blockHash, or blockNo if no
+// hash is given. The latest state db is returned for message.LatestBlockNo.
+func (core *Core) getStateDB(blockNo types.BlockNo, blockHash []byte) (*state.StateDB, error) {
+	if core.lightNode {
+		return nil, ErrLightNode
+	}
+	if len(blockHash) == 0 && blockNo == message.LatestBlockNo {
+		return core.sdb.GetStateDB(), nil
+	}
+	if len(blockHash) == 0 {
+		var err error
+		if blockHash, err = core.cdb.getHashByNo(blockNo); err != nil {
+			return nil, err
+		}
+	}
+	block, err := core.cdb.getBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	blockNo = block.GetHeader().GetBlockNo()
+	if mainHash, err := core.cdb.getHashByNo(blockNo); err != nil || !bytes.Equal(mainHash, blockHash) {
+		return nil, ErrNotMainChainBlock
+	}
+	if core.sdb.IsStatePruned(blockNo) {
+		return nil, ErrStatePruned
+	}
+	return core.sdb.OpenNewStateDB(block.GetHeader().GetBlocksRootHash()), nil
+}
+
 // Close closes chain & state DB.
 func (core *Core) Close() {
 	if core.sdb != nil {
@@ -137,10 +189,15 @@ type IChainHandler interface {
 	getBlock(blockHash []byte) (*types.Block, error)
 	getBlockByNo(blockNo types.BlockNo) (*types.Block, error)
 	getTx(txHash []byte) (*types.Tx, *types.TxIdx, error)
+	getTxProof(txHash []byte) (*types.TxProof, error)
 	getReceipt(txHash []byte) (*types.Receipt, error)
+	getReceipts(blockHash []byte) (types.Receipts, error)
 	getVote(addr []byte) (*types.VoteList, error)
 	getVotes(n int) (*types.VoteList, error)
 	getStaking(addr []byte) (*types.Staking, error)
+	getLibBlock() (*types.Block, error)
+	getChainParams() (*types.ChainParamList, error)
+	addEvidence(ev *types.DoubleSignEvidence) (bool, error)
 	addBlock(newBlock *types.Block, usedBstate *state.BlockState, peerID peer.ID) error
 	handleMissing(stopHash []byte, Hashes [][]byte) (message.BlockHash, types.BlockNo, types.BlockNo)
 	getAnchorsNew() (ChainAnchor, types.BlockNo, error)
@@ -175,16 +232,30 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		logger.Fatal().Err(err).Msg("failed to initialize DB")
 		panic(err)
 	}
+	cs.cdb.setAccountIndex(cfg.Blockchain.AccountIndex)
+	getBlockByHash = cs.cdb.getBlock
+	if cfg.Hardfork != nil {
+		Hardfork = cfg.Hardfork
+	}
+	if err = cs.cdb.checkHardfork(Hardfork); err != nil {
+		logger.Fatal().Err(err).Msg("incompatible hardfork config")
+		panic(err)
+	}
+	cs.SetStatePruning(cfg.Blockchain.StatePruning, cfg.Blockchain.Archive)
+	if cs.lightNode = cfg.Blockchain.LightNode; cs.lightNode && cfg.Consensus.EnableBp {
+		logger.Error().Msg("a light node can't produce blocks")
+		panic("invalid config: blockchain")
+	}
 
 	if err = Init(cfg.Blockchain.MaxBlockSize,
 		cfg.Blockchain.CoinbaseAccount,
-		types.DefaultCoinbaseFee,
 		cfg.Consensus.EnableBp,
 		cfg.Blockchain.MaxAnchorCount,
 		cfg.Blockchain.UseFastSyncer); err != nil {
 		logger.Error().Err(err).Msg("failed to init chainservice")
 		panic("invalid config: blockchain")
 	}
+	system.SetDefaultParams(cs.defaultChainParams())
 
 	cs.validator = NewBlockValidator(cs.sdb)
 	cs.BaseComponent = component.NewBaseComponent(message.ChainSvc, cs, logger)
@@ -219,6 +290,12 @@ func (cs *ChainService) CDBReader() consensus.ChainDbReader {
 	return cs.cdb
 }
 
+// CDB returns cs.cdb as a consensus.ChainDB, which the consensus keeping its
+// own state in the chain DB writes to.
+func (cs *ChainService) CDB() consensus.ChainDB {
+	return cs.cdb
+}
+
 // SetChainConsensus sets cs.cc to cc.
 func (cs *ChainService) SetChainConsensus(cc consensus.ChainConsensus) {
 	cs.ChainConsensus = cc
@@ -266,6 +343,18 @@ func (cs *ChainService) notifyBlock(block *types.Block) {
 		})
 }
 
+// notifyReceipts sends the receipts of a block connected to (or removed from,
+// if removed is true) the main chain to the RPC service.
+func (cs *ChainService) notifyReceipts(block *types.Block, receipts types.Receipts, removed bool) {
+	setReceiptsLocation(block, receipts)
+	cs.BaseComponent.TellTo(message.RPCSvc,
+		&message.NotifyBlockReceipts{
+			Block:    block,
+			Receipts: receipts,
+			Removed:  removed,
+		})
+}
+
 // Receive actor message
 func (cs *ChainService) Receive(context actor.Context) {
 
@@ -274,7 +363,9 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetAnchors, //TODO move to ChainWorker (need chain lock)
 		*message.GetMissing,
 		*message.GetAncestor,
-		*message.GetQuery:
+		*message.GetQuery,
+		*message.SyncState,
+		*message.AddEvidence:
 		cs.chainManager.Request(msg, context.Sender())
 
 		//pass to chainWorker
@@ -283,13 +374,21 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetState,
 		*message.GetStateAndProof,
 		*message.GetTx,
+		*message.GetTxProof,
 		*message.GetReceipt,
+		*message.GetReceipts,
+		*message.GetAccountTxs,
 		*message.GetABI,
 		*message.GetStateQuery,
 		*message.SyncBlockState,
 		*message.GetElected,
 		*message.GetVote,
-		*message.GetStaking:
+		*message.GetStaking,
+		*message.GetLibBlock,
+		*message.GetStateData,
+		*message.GetStorageChunk,
+		*message.GetEvidence,
+		*message.GetChainParams:
 		cs.chainWorker.Request(msg, context.Sender())
 
 		//handle directly
@@ -332,6 +431,9 @@ func (cs *ChainService) GetChainTree() ([]byte, error) {
 }
 
 func (cs *ChainService) getVotes(n int) (*types.VoteList, error) {
+	if cs.lightNode {
+		return nil, ErrLightNode
+	}
 	scs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 	if err != nil {
 		return nil, err
@@ -340,6 +442,9 @@ func (cs *ChainService) getVotes(n int) (*types.VoteList, error) {
 }
 
 func (cs *ChainService) getVote(addr []byte) (*types.VoteList, error) {
+	if cs.lightNode {
+		return nil, ErrLightNode
+	}
 	scs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 	if err != nil {
 		return nil, err
@@ -363,6 +468,9 @@ func (cs *ChainService) getVote(addr []byte) (*types.VoteList, error) {
 }
 
 func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
+	if cs.lightNode {
+		return nil, ErrLightNode
+	}
 	scs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 	if err != nil {
 		return nil, err
@@ -374,10 +482,25 @@ func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	return staking, nil
 }
 
+// getLibBlock returns the last irreversible block. Its state is kept even with
+// the state pruning, so it is served to the peers syncing the state.
+func (cs *ChainService) getLibBlock() (*types.Block, error) {
+	if cs.ChainConsensus == nil {
+		return nil, errors.New("no consensus to decide the lib")
+	}
+	libNo, hasLib := cs.LibNo()
+	if !hasLib || libNo == 0 {
+		return nil, errors.New("no lib block")
+	}
+	return cs.cdb.GetBlockByNo(libNo)
+}
+
 type ChainManager struct {
 	*SubComponent
 	IChainHandler //to use chain APIs
 	*Core         // TODO remove after moving GetQuery to ChainWorker
+
+	stateSync *stateSync
 }
 
 type ChainWorker struct {
@@ -468,15 +591,27 @@ func (cm *ChainManager) Receive(context actor.Context) {
 	case *message.GetQuery: //TODO move to ChainWorker (Currently, contract doesn't support parallel execution)
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
-		ctrState, err := cm.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(msg.Contract))
+		var ctrState *state.ContractState
+		states, err := cm.getStateDB(msg.BlockNo, msg.BlockHash)
+		if err == nil {
+			ctrState, err = states.OpenContractStateAccount(types.ToAccountID(msg.Contract))
+		}
 		if err != nil {
 			logger.Error().Str("hash", enc.ToString(msg.Contract)).Err(err).Msg("failed to get state for contract")
 			context.Respond(message.GetQueryRsp{Result: nil, Err: err})
 		} else {
-			bs := state.NewBlockState(cm.sdb.OpenNewStateDB(cm.sdb.GetRoot()))
+			// the sql db of the contract is read at the recovery point of
+			// the contract state
+			bs := state.NewBlockState(cm.sdb.OpenNewStateDB(states.GetRoot()))
 			ret, err := contract.Query(msg.Contract, bs, ctrState, msg.Queryinfo)
 			context.Respond(message.GetQueryRsp{Result: ret, Err: err})
 		}
+	case *message.SyncState:
+		context.Respond(cm.syncState(msg))
+	case *message.AddEvidence:
+		if _, err := cm.addEvidence(msg.Evidence); err != nil {
+			logger.Info().Err(err).Msg("invalid double-sign evidence")
+		}
 	default:
 		debug := fmt.Sprintf("[%s] Missed message. (%v) %s", cm.name, reflect.TypeOf(msg), msg)
 		logger.Debug().Msg(debug)
@@ -506,8 +641,16 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Err:   err,
 		})
 	case *message.GetState:
-		id := types.ToAccountID(msg.Account)
-		accState, err := cw.sdb.GetStateDB().GetAccountState(id)
+		var accState *types.State
+		var err error
+		if cw.lightNode {
+			accState, err = cw.getRemoteState(msg.Account, msg.BlockNo, msg.BlockHash)
+		} else {
+			var states *state.StateDB
+			if states, err = cw.getStateDB(msg.BlockNo, msg.BlockHash); err == nil {
+				accState, err = states.GetAccountState(types.ToAccountID(msg.Account))
+			}
+		}
 		if err != nil {
 			logger.Error().Str("hash", enc.ToString(msg.Account)).Err(err).Msg("failed to get state for account")
 		}
@@ -516,13 +659,31 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Err:   err,
 		})
 	case *message.GetStateAndProof:
-		id := types.ToAccountID(msg.Account)
-		stateProof, err := cw.sdb.GetStateDB().GetStateAndProof(id[:], msg.Root, msg.Compressed)
+		var stateProof *types.StateProof
+		var varProofs []*types.ContractVarProof
+		var err error
+		if cw.lightNode {
+			root := msg.Root
+			if len(root) == 0 {
+				root, err = cw.lightStateRoot(message.LatestBlockNo, nil)
+			}
+			if err == nil {
+				stateProof, varProofs, err = getRemoteStateProof(cw, msg.Account, root, msg.StorageKeys, msg.Compressed)
+			}
+		} else {
+			id := types.ToAccountID(msg.Account)
+			states := cw.sdb.GetStateDB()
+			stateProof, err = states.GetStateAndProof(id[:], msg.Root, msg.Compressed)
+			if err == nil && stateProof.GetInclusion() {
+				varProofs, err = states.GetVarsAndProofs(msg.StorageKeys, stateProof.GetState().GetStorageRoot(), msg.Compressed)
+			}
+		}
 		if err != nil {
 			logger.Error().Str("hash", enc.ToString(msg.Account)).Err(err).Msg("failed to get state for account")
 		}
 		context.Respond(message.GetStateAndProofRsp{
 			StateProof: stateProof,
+			VarProofs:  varProofs,
 			Err:        err,
 		})
 	case *message.GetTx:
@@ -532,14 +693,38 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			TxIds: txIdx,
 			Err:   err,
 		})
+	case *message.GetTxProof:
+		proof, err := cw.getTxProof(msg.TxHash)
+		context.Respond(message.GetTxProofRsp{
+			Proof: proof,
+			Err:   err,
+		})
 	case *message.GetReceipt:
 		receipt, err := cw.getReceipt(msg.TxHash)
 		context.Respond(message.GetReceiptRsp{
 			Receipt: receipt,
 			Err:     err,
 		})
+	case *message.GetReceipts:
+		receipts, err := cw.getReceipts(msg.BlockHash)
+		context.Respond(message.GetReceiptsRsp{
+			Receipts: receipts,
+			Err:      err,
+		})
+	case *message.GetAccountTxs:
+		txs, next, err := cw.cdb.getAccountTxs(msg.Account, msg.Cursor, msg.Size)
+		context.Respond(message.GetAccountTxsRsp{
+			Txs:         txs,
+			NextCursor:  next,
+			IndexedFrom: cw.cdb.accountIndexFrom,
+			Err:         err,
+		})
 	case *message.GetABI:
-		contractState, err := cw.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(msg.Contract))
+		var contractState *state.ContractState
+		states, err := cw.getStateDB(msg.BlockNo, msg.BlockHash)
+		if err == nil {
+			contractState, err = states.OpenContractStateAccount(types.ToAccountID(msg.Contract))
+		}
 		if err == nil {
 			abi, err := contract.GetABI(contractState)
 			context.Respond(message.GetABIRsp{
@@ -558,7 +743,11 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 		var err error
 
 		id := types.ToAccountID(msg.ContractAddress)
-		contractProof, err = cw.sdb.GetStateDB().GetStateAndProof(id[:], msg.Root, msg.Compressed)
+		if cw.lightNode {
+			err = ErrLightNode
+		} else {
+			contractProof, err = cw.sdb.GetStateDB().GetStateAndProof(id[:], msg.Root, msg.Compressed)
+		}
 		if err != nil {
 			logger.Error().Str("hash", enc.ToString(msg.ContractAddress)).Err(err).Msg("failed to get state for account")
 		} else if contractProof.Inclusion {
@@ -600,6 +789,39 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Staking: staking,
 			Err:     err,
 		})
+	case *message.GetLibBlock:
+		block, err := cw.getLibBlock()
+		context.Respond(message.GetLibBlockRsp{
+			Block: block,
+			Err:   err,
+		})
+	case *message.GetStateData:
+		data, err := cw.sdb.GetStateData(msg.Root, msg.Hashes, stateDataMaxSize)
+		context.Respond(message.GetStateDataRsp{
+			Data: data,
+			Err:  err,
+		})
+	case *message.GetStorageChunk:
+		keys, values, proofs, hasNext, err := cw.sdb.GetStorageChunk(msg.Root, msg.Start, msg.Size, stateDataMaxSize)
+		context.Respond(message.GetStorageChunkRsp{
+			Keys:    keys,
+			Values:  values,
+			Proofs:  proofs,
+			HasNext: hasNext,
+			Err:     err,
+		})
+	case *message.GetEvidence:
+		evidences, err := cw.cdb.getEvidences()
+		context.Respond(message.GetEvidenceRsp{
+			Evidences: evidences,
+			Err:       err,
+		})
+	case *message.GetChainParams:
+		params, err := cw.getChainParams()
+		context.Respond(message.GetChainParamsRsp{
+			Params: params,
+			Err:    err,
+		})
 	default:
 		debug := fmt.Sprintf("[%s] Missed message. (%v) %s", cw.name, reflect.TypeOf(msg), msg)
 		logger.Debug().Msg(debug)
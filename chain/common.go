@@ -7,17 +7,25 @@ package chain
 
 import (
 	"errors"
+	"math/big"
+
+	"github.com/aergoio/aergo/account/key"
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
 )
 
 var (
 	// MaxBlockSize is the maximum size of a block.
 	MaxBlockSize    uint32
 	CoinbaseAccount []byte
-	CoinbaseFee     uint64
 	MaxAnchorCount  int
 	UseFastSyncer   bool
+	// Hardfork is the block numbers from which the changed consensus rules
+	// are applied. All the rules are applied by default.
+	Hardfork = &cfg.HardforkConfig{}
 )
 
 var (
@@ -25,7 +33,7 @@ var (
 )
 
 // Init initializes the blockchain-related parameters.
-func Init(maxBlockSize uint32, coinbaseAccountStr string, coinbaseFee uint64, isBp bool, maxAnchorCount int, useFastSyncer bool) error {
+func Init(maxBlockSize uint32, coinbaseAccountStr string, isBp bool, maxAnchorCount int, useFastSyncer bool) error {
 	var err error
 
 	MaxBlockSize = maxBlockSize
@@ -43,8 +51,68 @@ func Init(maxBlockSize uint32, coinbaseAccountStr string, coinbaseFee uint64, is
 		}
 	}
 
-	CoinbaseFee = coinbaseFee
 	MaxAnchorCount = maxAnchorCount
 	UseFastSyncer = useFastSyncer
 	return nil
 }
+
+// MaxBlockBodySize returns the maximum block body size by the maximum block
+// size in params.
+//
+// TODO: This is not an exact size. Let's make it exact!
+func MaxBlockBodySize(params *consensus.ChainParams) uint32 {
+	return params.MaxBlockSize - uint32(proto.Size(&types.BlockHeader{}))
+}
+
+// BlockBodySize returns the size of the body of block, which is limited by
+// MaxBlockBodySize.
+func BlockBodySize(block *types.Block) uint32 {
+	size := 0
+	for _, tx := range block.GetBody().GetTxs() {
+		size += proto.Size(tx)
+	}
+	return uint32(size)
+}
+
+// IsV2Fork reports whether the consensus rules of version 2 are applied to the
+// block of no.
+func IsV2Fork(no types.BlockNo) bool {
+	return Hardfork.IsV2Fork(no)
+}
+
+// MaxTxFee returns the fee which a normal tx in the block of no pays in
+// advance. Before the v2 hardfork, a tx pays the flat fee regardless of the
+// gas used.
+func MaxTxFee(txBody *types.TxBody, no types.BlockNo) *big.Int {
+	if IsV2Fork(no) {
+		return txBody.GetMaxFee()
+	}
+	return new(big.Int).SetUint64(types.LegacyTxFee)
+}
+
+// CheckGasLimit returns an error if a normal tx in the block of no can't pay
+// even the base gas. The gas limit isn't checked before the v2 hardfork.
+func CheckGasLimit(txBody *types.TxBody, no types.BlockNo) error {
+	if IsV2Fork(no) && txBody.GetType() == types.TxType_NORMAL && txBody.GetGasLimit() < types.TxBaseGas {
+		return types.ErrNotEnoughGas
+	}
+	return nil
+}
+
+// CheckMultisig returns an error if a tx in the block of no is sent from a
+// multisig account, which is allowed from the v2 hardfork.
+func CheckMultisig(txBody *types.TxBody, no types.BlockNo) error {
+	if !IsV2Fork(no) && key.IsMultisigAddress(txBody.GetAccount()) {
+		return types.ErrMultisigNotAllowed
+	}
+	return nil
+}
+
+// ReceiptsRoot returns the receipts root of the block of no, which is computed
+// by the receipt encoding in effect at the block.
+func ReceiptsRoot(receipts types.Receipts, no types.BlockNo) []byte {
+	if IsV2Fork(no) {
+		return receipts.MerkleRoot()
+	}
+	return receipts.LegacyMerkleRoot()
+}
@@ -0,0 +1,88 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var evidenceKey = []byte(chainDBName + ".evidence")
+
+// addEvidence stores ev after verifying it by the block interval effective
+// at the blocks in ev, which is read from the state of the parent of the
+// first block. It reports false if ev is already stored.
+func (cs *ChainService) addEvidence(ev *types.DoubleSignEvidence) (bool, error) {
+	parent, err := cs.cdb.getBlock(ev.GetHeader1().GetPrevBlockHash())
+	if err != nil {
+		return false, err
+	}
+	params, err := cs.chainParams(parent)
+	if err != nil {
+		return false, err
+	}
+	return cs.cdb.addEvidence(ev, params.BlockIntervalSec)
+}
+
+// addEvidence stores ev after verifying it by blockIntervalSec. It reports
+// false if ev is already stored.
+func (cdb *ChainDB) addEvidence(ev *types.DoubleSignEvidence, blockIntervalSec int64) (bool, error) {
+	offender, err := evidence.Verify(ev, blockIntervalSec)
+	if err != nil {
+		return false, err
+	}
+
+	list, err := cdb.getEvidenceList()
+	if err != nil {
+		return false, err
+	}
+
+	id := evidence.ID(ev)
+	for _, e := range list.Evidences {
+		if bytes.Equal(evidence.ID(e), id) {
+			return false, nil
+		}
+	}
+	list.Evidences = append(list.Evidences, ev)
+
+	data, err := proto.Marshal(list)
+	if err != nil {
+		return false, err
+	}
+
+	dbTx := cdb.store.NewTx()
+	defer dbTx.Discard()
+	dbTx.Set(evidenceKey, data)
+	dbTx.Commit()
+
+	logger.Warn().Str("offender", types.EncodeAddress(offender)).
+		Uint64("no1", ev.GetHeader1().GetBlockNo()).Uint64("no2", ev.GetHeader2().GetBlockNo()).
+		Msg("double-sign evidence stored")
+
+	return true, nil
+}
+
+// getEvidences returns all the evidence stored.
+func (cdb *ChainDB) getEvidences() ([]*types.DoubleSignEvidence, error) {
+	list, err := cdb.getEvidenceList()
+	if err != nil {
+		return nil, err
+	}
+	return list.Evidences, nil
+}
+
+func (cdb *ChainDB) getEvidenceList() (*types.EvidenceList, error) {
+	list := &types.EvidenceList{}
+	if data := cdb.store.Get(evidenceKey); len(data) != 0 {
+		if err := proto.Unmarshal(data, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -9,7 +9,9 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 )
@@ -44,6 +46,93 @@ func executeGovernanceTx(states *state.StateDB, txBody *types.TxBody, sender, re
 	return err
 }
 
+// shareRewardWithVoters distributes the voters' share of reward to the voters
+// of bpID in the system contract, and returns the rest for the BP.
+func shareRewardWithVoters(bState *state.BlockState, bpID []byte, reward *big.Int) (*big.Int, error) {
+	aid := types.ToAccountID([]byte(types.AergoSystem))
+	scs, err := bState.OpenContractStateAccount(aid)
+	if err != nil {
+		return nil, err
+	}
+
+	bpShare, err := system.DistributeReward(scs, bpID, reward, bState.BlockNo())
+	if err != nil {
+		return nil, err
+	}
+	if bpShare.Cmp(reward) == 0 {
+		return reward, nil
+	}
+	if err = bState.StageContractState(scs); err != nil {
+		return nil, err
+	}
+
+	logger.Debug().Str("bp", enc.ToString(bpID)).Str("voters", new(big.Int).Sub(reward, bpShare).String()).
+		Msg("reward shared with voters")
+
+	return bpShare, nil
+}
+
+// getBlockByHash reads the blocks before the v2 hardfork to find the stakers
+// at the fork block. It is set by the chain service.
+var getBlockByHash func(hash []byte) (*types.Block, error)
+
+// SeedStakingTotal counts the staking made before the v2 hardfork into the
+// staking total of the governance at the fork block, whose parent is prevHash.
+// The stakers are found from the staking txs of the blocks before the fork.
+func SeedStakingTotal(bState *state.BlockState, prevHash []byte) error {
+	if Hardfork.V2 == 0 || bState.BlockNo() != Hardfork.V2 {
+		return nil
+	}
+	stakers, err := findStakers(prevHash)
+	if err != nil {
+		return err
+	}
+
+	aid := types.ToAccountID([]byte(types.AergoSystem))
+	scs, err := bState.OpenContractStateAccount(aid)
+	if err != nil {
+		return err
+	}
+	if err = system.SeedStakingTotal(scs, stakers); err != nil {
+		return err
+	}
+	logger.Info().Uint64("no", bState.BlockNo()).Int("stakers", len(stakers)).Msg("staking total seeded")
+
+	return bState.StageContractState(scs)
+}
+
+// findStakers returns the senders of the staking txs in the block of hash and
+// its ancestors, in the order of their first staking from the latest block.
+func findStakers(hash []byte) ([][]byte, error) {
+	if getBlockByHash == nil {
+		return nil, errors.New("no chain to find the stakers before the v2 hardfork")
+	}
+
+	var stakers [][]byte
+	found := make(map[string]bool)
+	for {
+		block, err := getBlockByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range block.GetBody().GetTxs() {
+			txBody := tx.GetBody()
+			if txBody.GetType() != types.TxType_GOVERNANCE || string(txBody.GetRecipient()) != types.AergoSystem ||
+				len(txBody.GetPayload()) == 0 || txBody.GetPayload()[0] != 's' {
+				continue
+			}
+			if account := txBody.GetAccount(); !found[string(account)] {
+				found[string(account)] = true
+				stakers = append(stakers, account)
+			}
+		}
+		if block.BlockNo() == 0 {
+			return stakers, nil
+		}
+		hash = block.GetHeader().GetPrevBlockHash()
+	}
+}
+
 // InitGenesisBPs opens system contract and put initial voting result
 // it also set *State in Genesis to use statedb
 func InitGenesisBPs(states *state.StateDB, bps []string) error {
@@ -60,6 +149,12 @@ func InitGenesisBPs(states *state.StateDB, bps []string) error {
 	if err = system.InitVoteResult(scs, &voteResult); err != nil {
 		return err
 	}
+	// nothing is staked before the v2 hardfork at the genesis
+	if Hardfork.V2 == 0 {
+		if err = system.SeedStakingTotal(scs, nil); err != nil {
+			return err
+		}
+	}
 	if err = states.StageContractState(scs); err != nil {
 		return err
 	}
@@ -69,3 +164,89 @@ func InitGenesisBPs(states *state.StateDB, bps []string) error {
 
 	return nil
 }
+
+// defaultChainParams returns the chain parameters given by the config, which
+// are active until they are changed by the governance.
+func (cs *ChainService) defaultChainParams() []*types.ChainParam {
+	blockInterval := cs.cfg.Consensus.BlockInterval
+	if blockInterval <= 0 {
+		blockInterval = consensus.DefaultBlockIntervalSec
+	}
+	return []*types.ChainParam{
+		{Name: system.ParamBlockInterval, Value: uint64(blockInterval)},
+		{Name: system.ParamBpCount, Value: uint64(cs.cfg.Consensus.DposBpNumber)},
+		{Name: system.ParamBpRewardRate, Value: system.BpRewardRate},
+		{Name: system.ParamMaxBlockSize, Value: uint64(cs.cfg.Blockchain.MaxBlockSize)},
+	}
+}
+
+// SystemState returns the state of the system contract at block. A light node
+// reads it from a peer with the proofs against the state root of block, except
+// the genesis state which it keeps as well.
+func (cs *ChainService) SystemState(block *types.Block) (*state.ContractState, error) {
+	root := block.GetHeader().GetBlocksRootHash()
+	if cs.lightNode && block.BlockNo() != 0 {
+		return cs.lightSystemState(root)
+	}
+	return cs.sdb.OpenNewStateDB(root).OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+}
+
+// activeChainParams returns the chain parameters effective for the block next
+// to block.
+func (cs *ChainService) activeChainParams(block *types.Block) ([]*types.ChainParam, error) {
+	scs, err := cs.SystemState(block)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := system.GetParams(scs, block.BlockNo()+1)
+	if err != nil {
+		return nil, err
+	}
+
+	params := cs.defaultChainParams()
+	for _, p := range params {
+		for _, c := range changed {
+			if c.GetName() == p.GetName() {
+				p.Value, p.BlockNo = c.GetValue(), c.GetBlockNo()
+			}
+		}
+	}
+	return params, nil
+}
+
+// ParamsAt returns the chain parameters effective at the block of no. scs is
+// the state of the system contract at the parent block, so that every node
+// validates the block by the same values.
+func ParamsAt(scs *state.ContractState, no types.BlockNo) (*consensus.ChainParams, error) {
+	interval, err := system.GetParamOrDefault(scs, system.ParamBlockInterval, no)
+	if err != nil {
+		return nil, err
+	}
+	size, err := system.GetParamOrDefault(scs, system.ParamMaxBlockSize, no)
+	if err != nil {
+		return nil, err
+	}
+	return &consensus.ChainParams{BlockIntervalSec: int64(interval), MaxBlockSize: uint32(size)}, nil
+}
+
+// chainParams returns the chain parameters effective at the block next to
+// parent.
+func (cs *ChainService) chainParams(parent *types.Block) (*consensus.ChainParams, error) {
+	scs, err := cs.SystemState(parent)
+	if err != nil {
+		return nil, err
+	}
+	return ParamsAt(scs, parent.BlockNo()+1)
+}
+
+func (cs *ChainService) getChainParams() (*types.ChainParamList, error) {
+	best, err := cs.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	params, err := cs.activeChainParams(best)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ChainParamList{Params: params}, nil
+}
@@ -0,0 +1,149 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/pkg/proof"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+// lightQueryTimeout is the time to wait for a peer to respond to the state
+// query of a light node.
+const lightQueryTimeout = time.Second * 2
+
+// ErrLightNode reports the state is not kept by a light node.
+var ErrLightNode = errors.New("state is not kept by a light node")
+
+// toLightBlock verifies the hash and the body of block, and returns the block
+// without body, which is what a light node keeps. The header is verified by
+// the consensus (e.g. BP signature and time slot, or commit certificate)
+// before it is added.
+func (cs *ChainService) toLightBlock(block *types.Block) (*types.Block, error) {
+	if block.GetHeader() == nil {
+		return nil, errors.New("block has no header")
+	}
+	light := &types.Block{Header: block.GetHeader(), Commit: block.GetCommit()}
+	if !bytes.Equal(light.BlockHash(), block.GetHash()) {
+		return nil, fmt.Errorf("invalid hash of block %d", block.BlockNo())
+	}
+	if len(block.GetBody().GetTxs()) > 0 {
+		if err := cs.validator.ValidateBody(block); err != nil {
+			return nil, err
+		}
+	}
+	return light, nil
+}
+
+// lightStateRoot returns the state root in the header of the block of
+// blockHash, or blockNo if no hash is given. The root of the best block is
+// returned for message.LatestBlockNo.
+func (core *Core) lightStateRoot(blockNo types.BlockNo, blockHash []byte) ([]byte, error) {
+	var block *types.Block
+	var err error
+	switch {
+	case len(blockHash) != 0:
+		block, err = core.cdb.getBlock(blockHash)
+	case blockNo != message.LatestBlockNo:
+		block, err = core.cdb.GetBlockByNo(blockNo)
+	default:
+		block, err = core.cdb.GetBestBlock()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return block.GetHeader().GetBlocksRootHash(), nil
+}
+
+// getRemoteStateProof queries the state of account at root and the variables
+// of its storage at storageKeys to a peer keeping the state. The proofs are
+// verified against root, so that a light node can trust the state as much as
+// the block header.
+func getRemoteStateProof(requester component.IComponentRequester, account, root []byte, storageKeys [][]byte,
+	compressed bool) (*types.StateProof, []*types.ContractVarProof, error) {
+	result, err := requester.RequestToFutureResult(message.P2PSvc,
+		&message.GetStateProof{Root: root, Account: account, StorageKeys: storageKeys, Compressed: compressed},
+		lightQueryTimeout, "chain.getRemoteStateProof")
+	if err != nil {
+		return nil, nil, err
+	}
+	rsp := result.(*message.GetStateProofRsp)
+	if rsp.Err != nil {
+		return nil, nil, rsp.Err
+	}
+	if err := proof.VerifyState(root, account, rsp.StateProof); err != nil {
+		return nil, nil, err
+	}
+	if !rsp.StateProof.GetInclusion() {
+		// no account, so no variable
+		return rsp.StateProof, nil, nil
+	}
+	if len(rsp.VarProofs) != len(storageKeys) {
+		return nil, nil, proof.ErrNoProof
+	}
+	storageRoot := rsp.StateProof.GetState().GetStorageRoot()
+	for i, vp := range rsp.VarProofs {
+		if err := proof.VerifyStorageVar(storageRoot, storageKeys[i], vp); err != nil {
+			return nil, nil, err
+		}
+	}
+	return rsp.StateProof, rsp.VarProofs, nil
+}
+
+// lightSystemState returns the state of the system contract at root, which is
+// read from a peer by a light node. Only the storage at system.ConsensusKeys
+// is fetched, which is what the BP election and the chain parameters read.
+func (cs *ChainService) lightSystemState(root []byte) (*state.ContractState, error) {
+	keys := system.ConsensusKeys()
+	ids := make([][]byte, len(keys))
+	for i, key := range keys {
+		id := types.GetHashID(key)
+		ids[i] = id[:]
+	}
+	_, varProofs, err := getRemoteStateProof(cs.BaseComponent, []byte(types.AergoSystem), root, ids, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// the verified variables are set to an empty contract state
+	scs, err := cs.sdb.OpenNewStateDB(nil).OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		return nil, err
+	}
+	for i, vp := range varProofs {
+		if vp.GetInclusion() {
+			if err := scs.SetData(keys[i], vp.GetValue()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return scs, nil
+}
+
+// getRemoteState returns the state of account at the block, which is queried
+// to a peer by a light node.
+func (cw *ChainWorker) getRemoteState(account []byte, blockNo types.BlockNo, blockHash []byte) (*types.State, error) {
+	root, err := cw.lightStateRoot(blockNo, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	sp, _, err := getRemoteStateProof(cw, account, root, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if !sp.GetInclusion() {
+		return &types.State{}, nil
+	}
+	return sp.GetState(), nil
+}
@@ -0,0 +1,117 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLightBlock(t *testing.T) {
+	cs := &ChainService{validator: NewBlockValidator(nil)}
+	defer cs.validator.Stop()
+
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block := types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	hash := block.BlockHash()
+
+	light, err := cs.toLightBlock(block)
+	assert.NoError(t, err)
+	assert.Nil(t, light.GetBody())
+	assert.Equal(t, hash, light.GetHash())
+	assert.Equal(t, block.GetHeader(), light.GetHeader())
+
+	// the hash doesn't match the header
+	block.Hash = genesis.BlockHash()
+	_, err = cs.toLightBlock(block)
+	assert.Error(t, err)
+	block.Hash = hash
+
+	// the body doesn't match the txs root of the header
+	block.Body.Txs = []*types.Tx{{Hash: []byte("tx"), Body: &types.TxBody{}}}
+	_, err = cs.toLightBlock(block)
+	assert.Equal(t, ErrorBlockVerifyTxRoot, err)
+}
+
+// stateProofRequester responds to message.GetStateProof with the proofs of
+// states, as a peer keeping the state does. The proofs of the variables are
+// changed by tamper if it is set.
+type stateProofRequester struct {
+	component.IComponentRequester
+	states *state.StateDB
+	tamper func(vps []*types.ContractVarProof) []*types.ContractVarProof
+}
+
+func (r *stateProofRequester) RequestToFutureResult(targetCompName string, msg interface{}, timeout time.Duration, tip string) (interface{}, error) {
+	req := msg.(*message.GetStateProof)
+	id := types.ToAccountID(req.Account)
+	sp, err := r.states.GetStateAndProof(id[:], req.Root, req.Compressed)
+	if err != nil {
+		return nil, err
+	}
+	vps, err := r.states.GetVarsAndProofs(req.StorageKeys, sp.GetState().GetStorageRoot(), req.Compressed)
+	if err != nil {
+		return nil, err
+	}
+	if r.tamper != nil {
+		vps = r.tamper(vps)
+	}
+	return &message.GetStateProofRsp{StateProof: sp, VarProofs: vps}, nil
+}
+
+func TestGetRemoteStateProof(t *testing.T) {
+	core, closeCore := newTestCore(t)
+	defer closeCore()
+	_, err := core.initGenesis(types.GetTestGenesis())
+	assert.NoError(t, err)
+
+	root := core.sdb.GetRoot()
+	keys := system.ConsensusKeys()
+	ids := make([][]byte, len(keys))
+	for i, key := range keys {
+		id := types.GetHashID(key)
+		ids[i] = id[:]
+	}
+	account := []byte(types.AergoSystem)
+	requester := &stateProofRequester{states: core.sdb.GetStateDB()}
+
+	sp, vps, err := getRemoteStateProof(requester, account, root, ids, true)
+	assert.NoError(t, err)
+	assert.True(t, sp.GetInclusion())
+	assert.Equal(t, len(ids), len(vps))
+
+	scs, err := core.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(account))
+	assert.NoError(t, err)
+	for i, key := range keys {
+		value, err := scs.GetData(key)
+		assert.NoError(t, err)
+		assert.Equal(t, len(value) != 0, vps[i].GetInclusion())
+		if vps[i].GetInclusion() {
+			assert.Equal(t, value, vps[i].GetValue())
+		}
+	}
+
+	// the variables must be proven by the storage root
+	requester.tamper = func(vps []*types.ContractVarProof) []*types.ContractVarProof {
+		vps[0].Value = []byte("forged")
+		return vps
+	}
+	_, _, err = getRemoteStateProof(requester, account, root, ids, true)
+	assert.Error(t, err)
+
+	// a proof for each variable
+	requester.tamper = func(vps []*types.ContractVarProof) []*types.ContractVarProof {
+		return vps[1:]
+	}
+	_, _, err = getRemoteStateProof(requester, account, root, ids, true)
+	assert.Error(t, err)
+}
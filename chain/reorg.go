@@ -25,6 +25,8 @@ type reorganizer struct {
 	brStartBlock *types.Block
 	newBlocks    []*types.Block //roll forward target blocks
 	oldBlocks    []*types.Block //roll back target blocks
+
+	newReceipts []types.Receipts //receipts of the rolled forward blocks
 }
 
 type ErrReorgBlock struct {
@@ -111,6 +113,8 @@ func (cs *ChainService) reorg(topBlock *types.Block) error {
 		return err
 	}
 
+	reorg.notifyReceipts()
+
 	logger.Info().Msg("reorg end")
 
 	return nil
@@ -130,6 +134,29 @@ func (reorg *reorganizer) swapChain() error {
 	return nil
 }
 
+// notifyReceipts notifies the removal of the receipts of the old blocks and
+// then the receipts of the new blocks. It must be called after the swap, so
+// that the subscribers see only the blocks of the main chain.
+func (reorg *reorganizer) notifyReceipts() {
+	cs := reorg.cs
+	if cs.lightNode {
+		return
+	}
+
+	for _, oldBlock := range reorg.oldBlocks {
+		receipts, err := cs.cdb.getReceipts(oldBlock.BlockHash(), oldBlock.BlockNo())
+		if err != nil {
+			logger.Warn().Err(err).Str("hash", oldBlock.ID()).Msg("failed to get receipts of rolled back block")
+			continue
+		}
+		cs.notifyReceipts(oldBlock, receipts, true)
+	}
+
+	for i := len(reorg.newBlocks) - 1; i >= 0; i-- {
+		cs.notifyReceipts(reorg.newBlocks[i], reorg.newReceipts[i], false)
+	}
+}
+
 func (reorg *reorganizer) swapTxMapping() error {
 	// newblock/oldblock
 	// push mempool (old - tx)
@@ -144,6 +171,19 @@ func (reorg *reorganizer) swapTxMapping() error {
 		}
 	}
 
+	// delete account index of old blocks. it is keyed by the position of tx,
+	// so it must be removed even if the tx is included again in new blocks
+	if cdb.accountIndex {
+		for _, oldBlock := range reorg.oldBlocks {
+			dbTx := cs.cdb.store.NewTx()
+			defer dbTx.Discard()
+
+			cdb.deleteAccountTxsOfBlock(&dbTx, oldBlock.GetBody().GetTxs(), oldBlock.GetHeader().GetBlockNo())
+
+			dbTx.Commit()
+		}
+	}
+
 	// insert new tx mapping
 	for i := len(reorg.newBlocks) - 1; i >= 0; i-- {
 		newBlock := reorg.newBlocks[i]
@@ -155,7 +195,8 @@ func (reorg *reorganizer) swapTxMapping() error {
 		dbTx := cs.cdb.store.NewTx()
 		defer dbTx.Discard()
 
-		if err := cdb.addTxsOfBlock(&dbTx, newBlock.GetBody().GetTxs(), newBlock.BlockHash()); err != nil {
+		if err := cdb.addTxsOfBlock(&dbTx, newBlock.GetBody().GetTxs(), newBlock.BlockHash(),
+			newBlock.GetHeader().GetBlockNo()); err != nil {
 			return err
 		}
 
@@ -286,9 +327,7 @@ func (reorg *reorganizer) rollbackChain() error {
 			brStartBlock.ID())
 	}
 
-	reorg.cs.Update(brStartBlock)
-
-	return nil
+	return reorg.cs.Update(brStartBlock)
 }
 
 /*
@@ -299,6 +338,7 @@ func (reorg *reorganizer) rollbackChain() error {
 func (reorg *reorganizer) rollforwardChain() error {
 	cs := reorg.cs
 
+	reorg.newReceipts = make([]types.Receipts, len(reorg.newBlocks))
 	for i := len(reorg.newBlocks) - 1; i >= 0; i-- {
 		newBlock := reorg.newBlocks[i]
 		newBlockNo := newBlock.GetHeader().GetBlockNo()
@@ -306,9 +346,11 @@ func (reorg *reorganizer) rollforwardChain() error {
 		logger.Debug().Str("hash", enc.ToString(newBlock.Hash)).Uint64("blockNo", newBlockNo).
 			Msg("rollforward block")
 
-		if err := cs.executeBlock(nil, newBlock); err != nil {
+		receipts, err := cs.executeBlock(nil, newBlock)
+		if err != nil {
 			return err
 		}
+		reorg.newReceipts[i] = receipts
 	}
 
 	return nil
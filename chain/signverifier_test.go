@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/aergoio/aergo/account/key"
+	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/types"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/assert"
@@ -177,3 +178,23 @@ func BenchmarkVerify10000txSerial(b *testing.B) {
 		}
 	}
 }
+
+func TestMultisigBeforeFork(t *testing.T) {
+	defer func(hf *cfg.HardforkConfig) { Hardfork = hf }(Hardfork)
+	Hardfork = &cfg.HardforkConfig{V2: 10}
+
+	addrs := make([]key.Address, 2)
+	for i := range addrs {
+		privkey, err := btcec.NewPrivateKey(btcec.S256())
+		assert.NoError(t, err, "generate key")
+		addrs[i] = key.GenerateAddress(&privkey.PublicKey)
+	}
+	ms, err := key.NewMultiSig(1, addrs)
+	assert.NoError(t, err, "new multisig")
+	tx, err := key.NewMultisigTx(&types.TxBody{Nonce: 1, Recipient: addrs[0]}, ms)
+	assert.NoError(t, err, "new multisig tx")
+
+	assert.Equal(t, types.ErrMultisigNotAllowed, CheckMultisig(tx.GetBody(), 9), "multisig before the fork")
+	assert.NoError(t, CheckMultisig(tx.GetBody(), 10), "multisig from the fork")
+	assert.NoError(t, CheckMultisig(&types.TxBody{Account: addrs[0]}, 9), "single key before the fork")
+}
@@ -0,0 +1,488 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// A snapshot file starts with snapshotMagic and the version, which are
+// followed by records. Each record consists of its kind, the length of the
+// data and the data. The records are written in the following order:
+//
+//	genesis info, genesis block, snapshot block,
+//	(account, [code], storage...)... in the order of account ids,
+//	sql... of the contracts which have a sql database,
+//	end (sha256 of all the preceding bytes)
+//
+// The state is verified against the state root of the snapshot block, which
+// must have the hash trusted by the user. The sql databases are not in the
+// state, so they are verified by the digest, the sha256 in the end record,
+// which must be trusted too.
+const (
+	snapshotMagic   = "AERGOSNAP"
+	snapshotVersion = byte(1)
+
+	// snapshotSQLChunk is the size of the sql database file written in a
+	// record.
+	snapshotSQLChunk = 1 << 20
+	// snapshotMaxRecord limits the length of a record to read.
+	snapshotMaxRecord = 16 << 20
+)
+
+const (
+	snapshotGenesis byte = iota + 1
+	snapshotBlock
+	snapshotAccount
+	snapshotCode
+	snapshotStorage
+	snapshotSQL
+	snapshotEnd
+)
+
+var (
+	snapshotKey = []byte(chainDBName + ".snapshot")
+
+	// ErrInvalidSnapshot reports the snapshot file is malformed or corrupted.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+// snapshotEncoder writes the records of a snapshot. It implements
+// state.SnapshotWriter.
+type snapshotEncoder struct {
+	w    *bufio.Writer
+	hash hash.Hash
+	sql  []string
+}
+
+func newSnapshotEncoder(w io.Writer) (*snapshotEncoder, error) {
+	e := &snapshotEncoder{
+		w:    bufio.NewWriter(w),
+		hash: sha256.New(),
+	}
+	header := append([]byte(snapshotMagic), snapshotVersion)
+	if err := e.writeRaw(header); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *snapshotEncoder) writeRaw(data []byte) error {
+	e.hash.Write(data)
+	_, err := e.w.Write(data)
+	return err
+}
+
+func (e *snapshotEncoder) write(kind byte, data ...[]byte) error {
+	var size int
+	for _, d := range data {
+		size += len(d)
+	}
+	header := make([]byte, 1+binary.MaxVarintLen64)
+	header[0] = kind
+	n := binary.PutUvarint(header[1:], uint64(size))
+	if err := e.writeRaw(header[:1+n]); err != nil {
+		return err
+	}
+	for _, d := range data {
+		if err := e.writeRaw(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *snapshotEncoder) writeBlock(kind byte, block *types.Block) error {
+	data, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return e.write(kind, data)
+}
+
+func (e *snapshotEncoder) Account(id []byte, raw []byte) error {
+	st := &types.State{}
+	if err := proto.Unmarshal(raw, st); err != nil {
+		return err
+	}
+	if st.SqlRecoveryPoint > 0 {
+		e.sql = append(e.sql, types.AccountID(types.ToHashID(id)).String())
+	}
+	return e.write(snapshotAccount, id, raw)
+}
+
+func (e *snapshotEncoder) Code(code []byte) error {
+	return e.write(snapshotCode, code)
+}
+
+func (e *snapshotEncoder) Storage(key []byte, value []byte) error {
+	return e.write(snapshotStorage, key, value)
+}
+
+// writeSQL writes the sql database file of a contract.
+func (e *snapshotEncoder) writeSQL(dbName string) error {
+	f, err := os.Open(contract.DatabaseFile(dbName))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	name := make([]byte, binary.MaxVarintLen64)
+	name = append(name[:binary.PutUvarint(name, uint64(len(dbName)))], dbName...)
+	buf := make([]byte, snapshotSQLChunk)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			if err := e.write(snapshotSQL, name, buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// finish writes the end record and flushes the snapshot. It returns the
+// digest of the snapshot.
+func (e *snapshotEncoder) finish() ([]byte, error) {
+	digest := e.hash.Sum(nil)
+	if err := e.write(snapshotEnd, digest); err != nil {
+		return nil, err
+	}
+	return digest, e.w.Flush()
+}
+
+// snapshotDecoder reads the records of a snapshot.
+type snapshotDecoder struct {
+	r    *bufio.Reader
+	hash hash.Hash
+	sum  []byte
+}
+
+func newSnapshotDecoder(r io.Reader) (*snapshotDecoder, error) {
+	d := &snapshotDecoder{
+		r:    bufio.NewReader(r),
+		hash: sha256.New(),
+	}
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, ErrInvalidSnapshot
+	}
+	if version := header[len(snapshotMagic)]; version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", version)
+	}
+	d.hash.Write(header)
+	return d, nil
+}
+
+// read returns the kind and the data of the next record. The hash of the
+// preceding bytes is kept for the end record.
+func (d *snapshotDecoder) read() (byte, []byte, error) {
+	d.sum = d.hash.Sum(nil)
+
+	kind, err := d.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if size > snapshotMaxRecord {
+		return 0, nil, ErrInvalidSnapshot
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		return 0, nil, err
+	}
+
+	header := make([]byte, 1+binary.MaxVarintLen64)
+	header[0] = kind
+	n := binary.PutUvarint(header[1:], size)
+	d.hash.Write(header[:1+n])
+	d.hash.Write(data)
+
+	return kind, data, nil
+}
+
+func (d *snapshotDecoder) expect(kind byte) ([]byte, error) {
+	k, data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	if k != kind {
+		return nil, ErrInvalidSnapshot
+	}
+	return data, nil
+}
+
+// readBlock reads a block and verifies its hash. The block of the snapshot
+// must be signed by its producer.
+func (d *snapshotDecoder) readBlock() (*types.Block, error) {
+	data, err := d.expect(snapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+	block := &types.Block{}
+	if err := proto.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	if block.GetHeader() == nil {
+		return nil, ErrInvalidSnapshot
+	}
+	hash := block.Hash
+	block.Hash = nil
+	if !bytes.Equal(block.BlockHash(), hash) {
+		return nil, fmt.Errorf("invalid hash of snapshot block %d", block.GetHeader().GetBlockNo())
+	}
+	if block.BlockNo() != 0 {
+		if valid, err := block.VerifySign(); err != nil || !valid {
+			return nil, fmt.Errorf("invalid signature of snapshot block %s", block.ID())
+		}
+	}
+	return block, nil
+}
+
+// ExportSnapshot writes the state at the main chain block of blockNo, with the
+// contract storages and the sql databases of contracts, to w. The best block
+// is used if blockNo is 0. It returns the block and the digest of the
+// snapshot.
+func (core *Core) ExportSnapshot(w io.Writer, blockNo types.BlockNo) (*types.Block, []byte, error) {
+	if blockNo == 0 {
+		blockNo = core.cdb.getBestBlockNo()
+		if blockNo == 0 {
+			return nil, nil, errors.New("no block to export after the genesis")
+		}
+	}
+	block, err := core.cdb.GetBlockByNo(blockNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if core.sdb.IsStatePruned(blockNo) {
+		return nil, nil, ErrStatePruned
+	}
+	genesisBlock, err := core.cdb.GetBlockByNo(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	genesis := core.cdb.GetGenesisInfo()
+	if genesis == nil {
+		return nil, nil, errors.New("genesis info is not found")
+	}
+
+	e, err := newSnapshotEncoder(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := e.write(snapshotGenesis, genesis.Bytes()); err != nil {
+		return nil, nil, err
+	}
+	if err := e.writeBlock(snapshotBlock, genesisBlock); err != nil {
+		return nil, nil, err
+	}
+	if err := e.writeBlock(snapshotBlock, block); err != nil {
+		return nil, nil, err
+	}
+	if err := core.sdb.ExportSnapshot(block.GetHeader().GetBlocksRootHash(), e); err != nil {
+		return nil, nil, err
+	}
+	for _, dbName := range e.sql {
+		if err := e.writeSQL(dbName); err != nil {
+			return nil, nil, err
+		}
+	}
+	digest, err := e.finish()
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, digest, nil
+}
+
+// ImportSnapshot starts the chain from the block of a snapshot read from r.
+// The chain must be empty. The block must have blockHash, which is trusted by
+// the user, and the state of the snapshot is verified against the state root
+// of the block. The genesis of the snapshot must be the local genesis if it is
+// given. The sql databases of contracts are verified by the trusted digest of
+// the snapshot, which is required if there are any. The sql database files
+// are removed on failure. It returns the block of the snapshot.
+func (core *Core) ImportSnapshot(r io.Reader, genesis *types.Genesis, blockHash, digest []byte) (*types.Block, error) {
+	if len(blockHash) == 0 {
+		return nil, errors.New("trusted hash of the snapshot block is required")
+	}
+	sqlFiles := make(map[string]*os.File)
+	block, err := core.importSnapshot(r, genesis, blockHash, digest, sqlFiles)
+	if err != nil {
+		for dbName, f := range sqlFiles {
+			f.Close()
+			os.Remove(f.Name())
+			os.Remove(contract.DatabaseFile(dbName))
+		}
+		return nil, err
+	}
+	return block, nil
+}
+
+func (core *Core) importSnapshot(r io.Reader, localGenesis *types.Genesis, blockHash, digest []byte,
+	sqlFiles map[string]*os.File) (*types.Block, error) {
+	if gh, _ := core.cdb.getHashByNo(0); len(gh) != 0 {
+		return nil, errors.New("snapshot can be imported only to an empty chain")
+	}
+	imp, err := core.sdb.NewSnapshotImporter()
+	if err != nil {
+		return nil, err
+	}
+	d, err := newSnapshotDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisInfo, err := d.expect(snapshotGenesis)
+	if err != nil {
+		return nil, err
+	}
+	genesis := types.GetGenesisFromBytes(genesisInfo)
+	if genesis == nil {
+		return nil, ErrInvalidSnapshot
+	}
+	genesisBlock, err := d.readBlock()
+	if err != nil {
+		return nil, err
+	}
+	block, err := d.readBlock()
+	if err != nil {
+		return nil, err
+	}
+	if genesisBlock.BlockNo() != 0 || block.BlockNo() == 0 ||
+		genesisBlock.GetHeader().GetTimestamp() != genesis.Timestamp {
+		return nil, ErrInvalidSnapshot
+	}
+	if !bytes.Equal(block.BlockHash(), blockHash) {
+		return nil, fmt.Errorf("block hash of snapshot %s doesn't match", block.ID())
+	}
+	if localGenesis != nil && (!bytes.Equal(genesis.ChainID(), localGenesis.ChainID()) ||
+		genesis.Timestamp != localGenesis.Timestamp) {
+		return nil, errors.New("genesis of snapshot doesn't match the local genesis")
+	}
+	if chainID := block.GetHeader().GetChainID(); len(chainID) != 0 && !bytes.Equal(chainID, genesis.ChainID()) {
+		return nil, errors.New("chain id of snapshot block doesn't match the genesis")
+	}
+
+	// recovery points of the sql databases
+	sqlRPs := make(map[string]uint64)
+
+	for {
+		kind, data, err := d.read()
+		if err == io.EOF {
+			return nil, ErrInvalidSnapshot
+		} else if err != nil {
+			return nil, err
+		}
+		if kind == snapshotEnd {
+			if !bytes.Equal(data, d.sum) {
+				return nil, errors.New("snapshot is corrupted")
+			}
+			if len(digest) != 0 && !bytes.Equal(data, digest) {
+				return nil, errors.New("digest of snapshot doesn't match")
+			}
+			break
+		}
+		switch kind {
+		case snapshotAccount, snapshotStorage:
+			if len(data) < trie.HashLength {
+				return nil, ErrInvalidSnapshot
+			}
+			key, value := data[:trie.HashLength], data[trie.HashLength:]
+			if kind == snapshotStorage {
+				err = imp.Storage(key, value)
+				break
+			}
+			if err = imp.Account(key, value); err != nil {
+				break
+			}
+			st := &types.State{}
+			if err = proto.Unmarshal(value, st); err == nil && st.SqlRecoveryPoint > 0 {
+				sqlRPs[types.AccountID(types.ToHashID(key)).String()] = st.SqlRecoveryPoint
+			}
+		case snapshotCode:
+			err = imp.Code(data)
+		case snapshotSQL:
+			if len(digest) == 0 {
+				return nil, errors.New("sql databases in snapshot can not be verified without its digest")
+			}
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return nil, ErrInvalidSnapshot
+			}
+			dbName, chunk := string(data[n:n+int(size)]), data[n+int(size):]
+			// only the databases of the contracts in the state are written
+			if _, ok := sqlRPs[dbName]; !ok {
+				return nil, fmt.Errorf("unknown sql database in snapshot: %s", dbName)
+			}
+			// the databases are written to temporary files until the
+			// snapshot is verified
+			f, ok := sqlFiles[dbName]
+			if !ok {
+				if f, err = os.Create(contract.DatabaseFile(dbName) + ".snapshot"); err != nil {
+					return nil, err
+				}
+				sqlFiles[dbName] = f
+			}
+			_, err = f.Write(chunk)
+		default:
+			return nil, ErrInvalidSnapshot
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for dbName, rp := range sqlRPs {
+		f, ok := sqlFiles[dbName]
+		if !ok {
+			return nil, fmt.Errorf("sql database of %s is missing in snapshot", dbName)
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(f.Name(), contract.DatabaseFile(dbName)); err != nil {
+			return nil, err
+		}
+		if err := contract.TruncateDatabase(dbName, rp); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := imp.Finish(block.BlockNo(), block.GetHeader().GetBlocksRootHash()); err != nil {
+		return nil, err
+	}
+	if err := core.cdb.addSnapshot(genesisInfo, genesisBlock, block); err != nil {
+		return nil, err
+	}
+	logger.Info().Uint64("no", block.BlockNo()).Str("hash", block.ID()).
+		Str("stateroot", enc.ToString(block.GetHeader().GetBlocksRootHash())).Msg("snapshot imported")
+
+	return block, nil
+}
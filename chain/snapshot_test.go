@@ -0,0 +1,150 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package chain
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCore(t *testing.T) (*Core, func()) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	core, err := NewCore(string(db.BadgerImpl), dir, false)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return core, func() {
+		core.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// rewriteSnapshot re-encodes the records of a snapshot, which are changed by
+// fn, with the valid end record as an attacker can do.
+func rewriteSnapshot(t *testing.T, data []byte, fn func(kind byte, data []byte) []byte) []byte {
+	d, err := newSnapshotDecoder(bytes.NewReader(data))
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	e, err := newSnapshotEncoder(&buf)
+	assert.NoError(t, err)
+	for {
+		kind, record, err := d.read()
+		if err == io.EOF || kind == snapshotEnd {
+			break
+		}
+		assert.NoError(t, err)
+		assert.NoError(t, e.write(kind, fn(kind, record)))
+	}
+	_, err = e.finish()
+	assert.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestSnapshotImport(t *testing.T) {
+	src, closeSrc := newTestCore(t)
+	defer closeSrc()
+
+	genesis := types.GetTestGenesis()
+	genesisBlock, err := src.initGenesis(genesis)
+	assert.NoError(t, err)
+
+	// a signed block on the genesis state
+	bpKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	block := types.NewBlock(genesisBlock, genesisBlock.GetHeader().GetBlocksRootHash(), nil, nil, nil, 1)
+	assert.NoError(t, block.Sign(bpKey))
+	dbTx := src.cdb.store.NewTx()
+	assert.NoError(t, src.cdb.addBlock(&dbTx, block))
+	src.cdb.connectToChain(&dbTx, block)
+	dbTx.Commit()
+
+	var buf bytes.Buffer
+	exported, digest, err := src.ExportSnapshot(&buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, block.BlockHash(), exported.BlockHash())
+	snapshot := buf.Bytes()
+
+	importSnapshot := func(data []byte, genesis *types.Genesis, blockHash, digest []byte) error {
+		dst, closeDst := newTestCore(t)
+		defer closeDst()
+		_, err := dst.ImportSnapshot(bytes.NewReader(data), genesis, blockHash, digest)
+		if err == nil {
+			assert.Equal(t, block.GetHeader().GetBlocksRootHash(), dst.sdb.GetRoot())
+			best, err := dst.cdb.GetBestBlock()
+			assert.NoError(t, err)
+			assert.Equal(t, block.BlockHash(), best.BlockHash())
+		}
+		return err
+	}
+	hash := block.BlockHash()
+
+	assert.NoError(t, importSnapshot(snapshot, genesis, hash, digest))
+	assert.NoError(t, importSnapshot(snapshot, nil, hash, nil))
+
+	// the hash of the block must be trusted
+	assert.Error(t, importSnapshot(snapshot, genesis, nil, nil))
+	assert.Error(t, importSnapshot(snapshot, genesis, genesisBlock.BlockHash(), nil))
+	assert.Error(t, importSnapshot(snapshot, genesis, hash, genesisBlock.BlockHash()))
+
+	// other chain
+	other := types.GetTestGenesis()
+	other.ID.Magic = "other"
+	assert.Error(t, importSnapshot(snapshot, other, hash, nil))
+
+	// corrupted
+	corrupted := append([]byte{}, snapshot...)
+	corrupted[len(corrupted)-40]++
+	assert.Error(t, importSnapshot(corrupted, genesis, hash, nil))
+
+	// the state doesn't match the block
+	tampered := rewriteSnapshot(t, snapshot, func(kind byte, data []byte) []byte {
+		if kind != snapshotAccount {
+			return data
+		}
+		st := &types.State{}
+		assert.NoError(t, proto.Unmarshal(data[32:], st))
+		st.Nonce++
+		raw, err := proto.Marshal(st)
+		assert.NoError(t, err)
+		return append(append([]byte{}, data[:32]...), raw...)
+	})
+	assert.Error(t, importSnapshot(tampered, genesis, hash, nil))
+
+	// the block is not signed by its producer
+	var forged *types.Block
+	tampered = rewriteSnapshot(t, snapshot, func(kind byte, data []byte) []byte {
+		b := &types.Block{}
+		if kind != snapshotBlock || proto.Unmarshal(data, b) != nil || b.BlockNo() == 0 {
+			return data
+		}
+		b.Header.Sign = []byte("forged")
+		b.Hash = nil
+		b.BlockHash()
+		forged = b
+		raw, err := proto.Marshal(b)
+		assert.NoError(t, err)
+		return raw
+	})
+	assert.Error(t, importSnapshot(tampered, genesis, forged.BlockHash(), nil))
+
+	// a failed import doesn't prevent the next one
+	dst, closeDst := newTestCore(t)
+	defer closeDst()
+	_, err = dst.ImportSnapshot(bytes.NewReader(corrupted), genesis, hash, nil)
+	assert.Error(t, err)
+	_, err = dst.ImportSnapshot(bytes.NewReader(snapshot), genesis, hash, digest)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,101 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+// stateSyncHashCount is the number of the state data requested at once while
+// the state is synced from a peer.
+const stateSyncHashCount = 256
+
+// stateDataMaxSize is the maximum size of the state data served to a peer at
+// once.
+const stateDataMaxSize = 1 << 22
+
+var errNoStateSync = errors.New("state sync is not started")
+
+// stateSync rebuilds the state of a block fetched from a peer, so that the
+// chain starts from the block without executing the blocks before it.
+type stateSync struct {
+	block *types.Block
+	sync  *state.StateSync
+}
+
+// startStateSync starts the sync of the state of block. It is available only
+// when the chain has no block but the genesis block.
+func (core *Core) startStateSync(block *types.Block) (*stateSync, error) {
+	if core.cdb.getBestBlockNo() != 0 {
+		return nil, errors.New("state sync is available only on an empty chain")
+	}
+	if block.GetHeader() == nil || block.BlockNo() == 0 {
+		return nil, errors.New("invalid block to sync the state")
+	}
+	hash := block.Hash
+	block.Hash = nil
+	if !bytes.Equal(block.BlockHash(), hash) {
+		return nil, fmt.Errorf("invalid hash of block %d to sync the state", block.BlockNo())
+	}
+	genesis := core.cdb.GetGenesisInfo()
+	if chainID := block.GetHeader().GetChainID(); len(chainID) != 0 && genesis != nil && !bytes.Equal(chainID, genesis.ChainID()) {
+		return nil, errors.New("chain id of the block doesn't match the genesis")
+	}
+	sync, err := core.sdb.NewStateSync(block.GetHeader().GetBlocksRootHash())
+	if err != nil {
+		return nil, err
+	}
+	logger.Info().Uint64("no", block.BlockNo()).Str("hash", block.ID()).Msg("state sync started")
+	return &stateSync{block: block, sync: sync}, nil
+}
+
+// nextStateSync returns what to fetch next. When the whole state is fetched, it
+// connects the block to the chain.
+func (core *Core) nextStateSync(ss *stateSync) *message.SyncStateRsp {
+	if hashes := ss.sync.Missing(stateSyncHashCount); len(hashes) > 0 {
+		return &message.SyncStateRsp{Hashes: hashes}
+	}
+	if root, start := ss.sync.StorageRequest(); root != nil {
+		return &message.SyncStateRsp{StorageRoot: root, StorageStart: start}
+	}
+	if err := ss.sync.Finish(ss.block.BlockNo()); err != nil {
+		return &message.SyncStateRsp{Err: err}
+	}
+	if err := core.cdb.addSnapshot(nil, nil, ss.block); err != nil {
+		return &message.SyncStateRsp{Err: err}
+	}
+	logger.Info().Uint64("no", ss.block.BlockNo()).Str("hash", ss.block.ID()).Msg("state sync finished")
+	return &message.SyncStateRsp{Done: true}
+}
+
+// syncState handles a message of the state sync from the syncer.
+func (cm *ChainManager) syncState(msg *message.SyncState) *message.SyncStateRsp {
+	var err error
+	if msg.Block != nil {
+		cm.stateSync, err = cm.startStateSync(msg.Block)
+	} else if cm.stateSync == nil {
+		err = errNoStateSync
+	} else if len(msg.Hashes) > 0 {
+		err = cm.stateSync.sync.AddData(msg.Hashes, msg.Data)
+	} else {
+		err = cm.stateSync.sync.AddStorage(msg.Keys, msg.Values, msg.Proofs, msg.HasNext)
+	}
+	if err != nil {
+		cm.stateSync = nil
+		return &message.SyncStateRsp{Err: err}
+	}
+	rsp := cm.nextStateSync(cm.stateSync)
+	if rsp.Done || rsp.Err != nil {
+		cm.stateSync = nil
+	}
+	return rsp
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"syscall"
 
@@ -14,6 +15,12 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+var (
+	newMnemonic    bool
+	importMnemonic string
+	keystoreFile   string
+)
+
 func init() {
 	accountCmd := &cobra.Command{
 		Use:               "account [flags] subcommand",
@@ -24,6 +31,10 @@ func init() {
 
 	newCmd.Flags().StringVar(&pw, "password", "", "Password")
 	newCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	newCmd.Flags().BoolVar(&newMnemonic, "mnemonic", false, "Create new hd seed with mnemonic and its first account")
+
+	deriveCmd.Flags().StringVar(&pw, "password", "", "Password")
+	deriveCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
 
 	listCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
 
@@ -36,7 +47,8 @@ func init() {
 	lockCmd.Flags().StringVar(&pw, "password", "", "Password")
 
 	importCmd.Flags().StringVar(&importFormat, "if", "", "Base58 import format string")
-	importCmd.MarkFlagRequired("if")
+	importCmd.Flags().StringVar(&keystoreFile, "keystore", "", "Path to json keystore file to import instead of --if")
+	importCmd.Flags().StringVar(&importMnemonic, "mnemonic", "", "Mnemonic words of hd seed to import instead of --if")
 	importCmd.Flags().StringVar(&pw, "password", "", "Password when exporting")
 	importCmd.Flags().StringVar(&to, "newpassword", "", "Password to be reset")
 	importCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
@@ -45,6 +57,10 @@ func init() {
 	exportCmd.MarkFlagRequired("address")
 	exportCmd.Flags().StringVar(&pw, "password", "", "Password")
 	exportCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	exportCmd.Flags().StringVar(&keystoreFile, "keystore", "", "Path to json keystore file to write instead of printing import format")
+
+	migrateCmd.Flags().StringVar(&pw, "password", "", "Password")
+	migrateCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
 
 	voteCmd.Flags().StringVar(&address, "address", "", "Account address of voter")
 	voteCmd.MarkFlagRequired("address")
@@ -59,8 +75,10 @@ func init() {
 	unstakingCmd.MarkFlagRequired("address")
 	unstakingCmd.Flags().StringVar(&amount, "amount", "0", "Amount of staking")
 	unstakingCmd.MarkFlagRequired("amount")
+	claimCmd.Flags().StringVar(&address, "address", "", "Account address")
+	claimCmd.MarkFlagRequired("address")
 
-	accountCmd.AddCommand(newCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, voteCmd, stakingCmd, unstakingCmd)
+	accountCmd.AddCommand(newCmd, deriveCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, migrateCmd, voteCmd, stakingCmd, unstakingCmd, claimCmd)
 	rootCmd.AddCommand(accountCmd)
 }
 
@@ -79,6 +97,10 @@ var newCmd = &cobra.Command{
 				return
 			}
 		}
+		if newMnemonic {
+			execNewMnemonic(cmd, &param)
+			return
+		}
 		var msg *types.Account
 		var addr []byte
 		if cmd.Flags().Changed("path") == false {
@@ -106,6 +128,66 @@ var newCmd = &cobra.Command{
 	},
 }
 
+func execNewMnemonic(cmd *cobra.Command, param *types.Personal) {
+	var msg *types.MnemonicAccount
+	var err error
+	if cmd.Flags().Changed("path") == false {
+		msg, err = client.CreateMnemonicAccount(context.Background(), param)
+	} else {
+		dataEnvPath := os.ExpandEnv(dataDir)
+		ks := key.NewStore(dataEnvPath)
+		defer ks.CloseStore()
+		msg = &types.MnemonicAccount{Account: &types.Account{}}
+		msg.Mnemonic, msg.Account.Address, err = ks.CreateMnemonic(param.Passphrase)
+		if err == nil {
+			err = ks.SaveAddress(msg.Account.Address)
+		}
+	}
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println("Mnemonic: " + msg.GetMnemonic())
+	cmd.Println(types.EncodeAddress(msg.GetAccount().GetAddress()))
+}
+
+var deriveCmd = &cobra.Command{
+	Use:   "derive [flags]",
+	Short: "Derive next account from hd seed in the node or cli",
+	Run: func(cmd *cobra.Command, args []string) {
+		var param types.Personal
+		var err error
+		if pw != "" {
+			param.Passphrase = pw
+		} else {
+			param.Passphrase, err = getPasswd(cmd, false)
+			if err != nil {
+				cmd.Printf("Failed get password: %s\n", err.Error())
+				return
+			}
+		}
+		var addr []byte
+		if cmd.Flags().Changed("path") == false {
+			var msg *types.Account
+			msg, err = client.DeriveAccount(context.Background(), &param)
+			addr = msg.GetAddress()
+		} else {
+			dataEnvPath := os.ExpandEnv(dataDir)
+			ks := key.NewStore(dataEnvPath)
+			defer ks.CloseStore()
+			addr, err = ks.DeriveNextKey(param.Passphrase)
+			if err == nil {
+				err = ks.SaveAddress(addr)
+			}
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(types.EncodeAddress(addr))
+	},
+}
+
 var listCmd = &cobra.Command{
 	Use:   "list [flags]",
 	Short: "Get account list in the node or cli",
@@ -187,7 +269,19 @@ var importCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 		var address []byte
-		importBuf, err := types.DecodePrivKey(importFormat)
+		if importMnemonic != "" {
+			execImportMnemonic(cmd)
+			return
+		}
+		var importBuf []byte
+		if keystoreFile != "" {
+			importBuf, err = ioutil.ReadFile(keystoreFile)
+		} else if importFormat != "" {
+			importBuf, err = types.DecodePrivKey(importFormat)
+		} else {
+			cmd.Print("Error: required flag(s) \"if\", \"keystore\" or \"mnemonic\" not set")
+			return
+		}
 		if err != nil {
 			cmd.Printf("Failed to decode input: %s\n", err.Error())
 			return
@@ -230,6 +324,44 @@ var importCmd = &cobra.Command{
 	},
 }
 
+func execImportMnemonic(cmd *cobra.Command) {
+	var err error
+	param := &types.MnemonicFormat{Mnemonic: importMnemonic}
+	if to != "" {
+		param.Passphrase = to
+	} else if pw != "" {
+		param.Passphrase = pw
+	} else {
+		param.Passphrase, err = getPasswd(cmd, true)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+	}
+	var address []byte
+	if cmd.Flags().Changed("path") == false {
+		msg, errRemote := client.ImportMnemonic(context.Background(), param)
+		if errRemote != nil {
+			cmd.Printf("Failed: %s\n", errRemote.Error())
+			return
+		}
+		address = msg.GetAddress()
+	} else {
+		dataEnvPath := os.ExpandEnv(dataDir)
+		ks := key.NewStore(dataEnvPath)
+		defer ks.CloseStore()
+		address, err = ks.ImportMnemonic(param.Mnemonic, param.Passphrase)
+		if err == nil {
+			err = ks.SaveAddress(address)
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+	}
+	cmd.Println(types.EncodeAddress(address))
+}
+
 var exportCmd = &cobra.Command{
 	Use:   "export [flags]",
 	Short: "Export account",
@@ -258,10 +390,42 @@ var exportCmd = &cobra.Command{
 			}
 			result = wif
 		}
+		if keystoreFile != "" {
+			if err := ioutil.WriteFile(keystoreFile, result, 0600); err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+			}
+			return
+		}
 		cmd.Println(types.EncodePrivKey(result))
 	},
 }
 
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [flags]",
+	Short: "Convert accounts of the password in legacy db into json keystore files",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		pass := pw
+		if pass == "" {
+			pass, err = getPasswd(cmd, false)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+		}
+		dataEnvPath := os.ExpandEnv(dataDir)
+		ks := key.NewStore(dataEnvPath)
+		defer ks.CloseStore()
+		migrated, err := ks.MigrateKeys(pass)
+		for _, addr := range migrated {
+			cmd.Println(types.EncodeAddress(addr))
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+		}
+	},
+}
+
 func parsePersonalParam(cmd *cobra.Command) (*types.Personal, error) {
 	var err error
 	param := &types.Personal{Account: &types.Account{}}
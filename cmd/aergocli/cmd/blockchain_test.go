@@ -4,8 +4,8 @@ import (
 	"encoding/hex"
 	"testing"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv/encoding/json"
 	"github.com/golang/mock/gomock"
 	"github.com/mr-tron/base58/base58"
 	"github.com/stretchr/testify/assert"
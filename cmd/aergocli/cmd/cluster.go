@@ -0,0 +1,51 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Change the members of the raft cluster",
+}
+
+var clusterAddCmd = &cobra.Command{
+	Use:   "add <nodeID>",
+	Short: "Add a node to the raft cluster",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		changeMembership(cmd, false, args[0])
+	},
+}
+
+var clusterRemoveCmd = &cobra.Command{
+	Use:   "remove <nodeID>",
+	Short: "Remove a node from the raft cluster",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		changeMembership(cmd, true, args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clusterCmd)
+	clusterCmd.AddCommand(clusterAddCmd)
+	clusterCmd.AddCommand(clusterRemoveCmd)
+}
+
+func changeMembership(cmd *cobra.Command, remove bool, nodeID string) {
+	_, err := client.ChangeMembership(context.Background(), &types.MembershipChange{Remove: remove, NodeID: nodeID})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println("membership change proposed")
+}
@@ -10,8 +10,8 @@ import (
 	"errors"
 	"io/ioutil"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -59,7 +59,7 @@ func execCommitTX(cmd *cobra.Command, args []string) error {
 
 	if jsonTx != "" {
 		var msg *types.CommitResultList
-		txlist, err := util.ParseBase58Tx([]byte(jsonTx))
+		txlist, err := jsonconv.ParseBase58Tx([]byte(jsonTx))
 		if err != nil {
 			return errors.New("Failed to parse --jsontx\n" + err.Error())
 		}
@@ -11,7 +11,9 @@ import (
 	"os"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
+	trieproof "github.com/aergoio/aergo/pkg/proof"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -57,22 +59,32 @@ func init() {
 	}
 	stateQueryCmd.Flags().StringVar(&stateroot, "root", "", "Query the state at a specified state root")
 	stateQueryCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
+	stateQueryCmd.Flags().BoolVar(&verify, "verify", false, "Verify the proof against the state root")
+	stateQueryCmd.Flags().StringVar(&trustedNode, "trustednode", "", "Get the state root to verify against from the trusted node (host:port)")
+
+	abiCmd := &cobra.Command{
+		Use:   "abi [flags] contract",
+		Short: "Get ABI of the contract",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runGetABICmd,
+	}
+	abiCmd.Flags().Uint64Var(&stateBlockNo, "blockno", 0, "Get the ABI at a specified block number")
+	abiCmd.Flags().StringVar(&stateBlockHash, "blockhash", "", "Get the ABI at a specified block hash")
+
+	queryCmd := &cobra.Command{
+		Use:   "query [flags] contract funcname '[argument...]'",
+		Short: "Query contract by executing read-only function",
+		Args:  cobra.MinimumNArgs(2),
+		Run:   runQueryCmd,
+	}
+	queryCmd.Flags().Uint64Var(&stateBlockNo, "blockno", 0, "Query the contract at a specified block number")
+	queryCmd.Flags().StringVar(&stateBlockHash, "blockhash", "", "Query the contract at a specified block hash")
 
 	contractCmd.AddCommand(
 		deployCmd,
 		callCmd,
-		&cobra.Command{
-			Use:   "abi [flags] contract",
-			Short: "Get ABI of the contract",
-			Args:  cobra.MinimumNArgs(1),
-			Run:   runGetABICmd,
-		},
-		&cobra.Command{
-			Use:   "query [flags] contract funcname '[argument...]'",
-			Short: "Query contract by executing read-only function",
-			Args:  cobra.MinimumNArgs(2),
-			Run:   runQueryCmd,
-		},
+		abiCmd,
+		queryCmd,
 		stateQueryCmd,
 	)
 	rootCmd.AddCommand(contractCmd)
@@ -84,7 +96,7 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	state, err := client.GetState(context.Background(), &types.SingleBytes{Value: creator})
+	state, err := client.GetState(context.Background(), &types.AccountAtBlock{Account: creator})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -132,7 +144,7 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 			}
 			argLen = len(args[1])
 		}
-		code, err := util.DecodeCode(data)
+		code, err := jsonconv.DecodeCode(data)
 		payload = make([]byte, 4+len(code)+argLen)
 		binary.LittleEndian.PutUint32(payload[0:], uint32(len(code)+4))
 		codeLen := copy(payload[4:], code)
@@ -180,7 +192,7 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 	if nonce == 0 {
-		state, err := client.GetState(context.Background(), &types.SingleBytes{Value: caller})
+		state, err := client.GetState(context.Background(), &types.AccountAtBlock{Account: caller})
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -205,7 +217,7 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 	}
 
 	if !toJson {
-		abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
+		abi, err := client.GetABI(context.Background(), &types.AccountAtBlock{Account: contract})
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -241,7 +253,7 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 	}
 
 	if toJson {
-		fmt.Println(util.TxConvBase58Addr(sign))
+		fmt.Println(jsonconv.TxConvBase58Addr(sign))
 		return
 	}
 	txs := []*types.Tx{sign}
@@ -260,7 +272,11 @@ func runGetABICmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
+	in := &types.AccountAtBlock{Account: contract}
+	if in.BlockNo, in.BlockHash, err = stateBlock(); err != nil {
+		log.Fatal(err)
+	}
+	abi, err := client.GetABI(context.Background(), in)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -290,6 +306,9 @@ func runQueryCmd(cmd *cobra.Command, args []string) {
 		ContractAddress: contract,
 		Queryinfo:       callinfo,
 	}
+	if query.BlockNo, query.BlockHash, err = stateBlock(); err != nil {
+		log.Fatal(err)
+	}
 
 	ret, err := client.QueryContract(context.Background(), query)
 	if err != nil {
@@ -312,6 +331,13 @@ func runQueryStateCmd(cmd *cobra.Command, args []string) {
 			return
 		}
 	}
+	if verify {
+		// the proof is queried at the root to verify against
+		root, err = getVerifyRoot(root)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 	stateQuery := &types.StateQuery{
 		ContractAddress: contract,
 		VarName:         args[1],
@@ -325,5 +351,11 @@ func runQueryStateCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if verify {
+		err = trieproof.VerifyStateQuery(root, contract, stateQuery.VarName, stateQuery.VarIndex, ret)
+		if err != nil {
+			log.Fatalf("proof verification failed: %s", err.Error())
+		}
+	}
 	cmd.Println(ret)
 }
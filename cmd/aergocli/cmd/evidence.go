@@ -0,0 +1,95 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var evidenceCmd = &cobra.Command{
+	Use:   "evidence",
+	Short: "Double-sign evidence of the block producers",
+}
+
+var evidenceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the double-sign evidence stored in the node",
+	Run:   execListEvidence,
+}
+
+var evidenceSlashCmd = &cobra.Command{
+	Use:   "slash <index>",
+	Short: "Slash the staking of the BP by the evidence of the index in the list",
+	Args:  cobra.ExactArgs(1),
+	Run:   execSlash,
+}
+
+func init() {
+	rootCmd.AddCommand(evidenceCmd)
+	evidenceCmd.AddCommand(evidenceListCmd)
+	evidenceCmd.AddCommand(evidenceSlashCmd)
+	evidenceSlashCmd.Flags().StringVar(&address, "address", "", "Account address of sender")
+	evidenceSlashCmd.MarkFlagRequired("address")
+}
+
+func execListEvidence(cmd *cobra.Command, args []string) {
+	msg, err := client.ListEvidence(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(jsonconv.EvidenceListToString(msg))
+}
+
+func execSlash(cmd *cobra.Command, args []string) {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	idx, err := strconv.Atoi(args[0])
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+
+	msg, err := client.ListEvidence(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	if idx < 0 || idx >= len(msg.GetEvidences()) {
+		cmd.Printf("Failed: no evidence of index %d\n", idx)
+		return
+	}
+	ev, err := proto.Marshal(msg.GetEvidences()[idx])
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   append([]byte{'e'}, ev...),
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	rsp, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(base58.Encode(rsp.Hash), rsp.Error)
+}
@@ -11,8 +11,8 @@ import (
 
 	"github.com/mr-tron/base58/base58"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/spf13/cobra"
 )
 
@@ -58,7 +58,7 @@ func execGetBlock(cmd *cobra.Command, args []string) {
 
 	msg, err := client.GetBlock(context.Background(), &aergorpc.SingleBytes{Value: blockQuery})
 	if nil == err {
-		cmd.Println(util.BlockConvBase58Addr(msg))
+		cmd.Println(jsonconv.BlockConvBase58Addr(msg))
 	} else {
 		cmd.Printf("Failed: %s\n", err.Error())
 	}
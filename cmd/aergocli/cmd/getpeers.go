@@ -8,8 +8,8 @@ package cmd
 import (
 	"context"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +30,7 @@ func execGetPeers(cmd *cobra.Command, args []string) {
 		return
 	}
 	// address and peerid should be encoded, respectively
-	cmd.Println(util.PeerListToString(msg))
+	cmd.Println(jsonconv.PeerListToString(msg))
 }
 
 func Must(a0 string, a1 error) string {
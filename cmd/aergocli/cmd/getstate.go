@@ -7,11 +7,16 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
+	trieproof "github.com/aergoio/aergo/pkg/proof"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 )
 
 var getstateCmd = &cobra.Command{
@@ -24,8 +29,12 @@ func init() {
 	getstateCmd.Flags().StringVar(&address, "address", "", "Get state from the address")
 	getstateCmd.MarkFlagRequired("address")
 	getstateCmd.Flags().StringVar(&stateroot, "root", "", "Get the state at a specified state root")
+	getstateCmd.Flags().Uint64Var(&stateBlockNo, "blockno", 0, "Get the state at a specified block number")
+	getstateCmd.Flags().StringVar(&stateBlockHash, "blockhash", "", "Get the state at a specified block hash")
 	getstateCmd.Flags().BoolVar(&proof, "proof", false, "Get the proof for the state")
 	getstateCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
+	getstateCmd.Flags().BoolVar(&verify, "verify", false, "Get the proof and verify it against the state root")
+	getstateCmd.Flags().StringVar(&trustedNode, "trustednode", "", "Get the state root to verify against from the trusted node (host:port)")
 	getstateCmd.Flags().BoolVar(&staking, "staking", false, "Get the staking info from the address")
 	getstateCmd.Flags().StringVar(&unit, "unit", "aergo", "display unit of balance")
 	rootCmd.AddCommand(getstateCmd)
@@ -53,7 +62,7 @@ func execGetState(cmd *cobra.Command, args []string) {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		amount, err := util.ConvertUnit(msg.GetAmountBigInt(), unit)
+		amount, err := jsonconv.ConvertUnit(msg.GetAmountBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -64,16 +73,29 @@ func execGetState(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	if !proof {
+	if verify {
+		// the proof is queried at the root to verify against
+		root, err = getVerifyRoot(root)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+	if !proof && !verify {
 		// NOTE GetState first queries the statedb buffer.
 		// So the prefered way to get the state is with a proof
-		msg, err := client.GetState(context.Background(),
-			&types.SingleBytes{Value: addr})
+		in := &types.AccountAtBlock{Account: addr}
+		in.BlockNo, in.BlockHash, err = stateBlock()
+		if err != nil {
+			cmd.Printf("decode error: %s", err.Error())
+			return
+		}
+		msg, err := client.GetState(context.Background(), in)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		balance, err := util.ConvertUnit(msg.GetBalanceBigInt(), unit)
+		balance, err := jsonconv.ConvertUnit(msg.GetBalanceBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -87,9 +109,18 @@ func execGetState(cmd *cobra.Command, args []string) {
 			&types.AccountAndRoot{Account: addr, Root: root, Compressed: compressed})
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
+			if verify {
+				os.Exit(1)
+			}
 			return
 		}
-		balance, err := util.ConvertUnit(msg.GetState().GetBalanceBigInt(), unit)
+		if verify {
+			if err := trieproof.VerifyState(root, addr, msg); err != nil {
+				cmd.Printf("Failed: proof verification: %s\n", err.Error())
+				os.Exit(1)
+			}
+		}
+		balance, err := jsonconv.ConvertUnit(msg.GetState().GetBalanceBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -99,3 +130,48 @@ func execGetState(cmd *cobra.Command, args []string) {
 	}
 
 }
+
+// stateBlock returns the block of the state queries given by --blockno or
+// --blockhash. the latest block is queried if neither is given
+func stateBlock() (types.BlockNo, []byte, error) {
+	if len(stateBlockHash) == 0 {
+		return stateBlockNo, nil, nil
+	}
+	blockHash, err := base58.Decode(stateBlockHash)
+	if err != nil {
+		return 0, nil, err
+	}
+	return stateBlockNo, blockHash, nil
+}
+
+// getVerifyRoot returns the state root to verify proofs against. the root
+// given by the user is trusted, otherwise it is of the best block of the
+// trusted node. without either, the root of the queried node is used, which
+// only checks the consistency of its answer
+func getVerifyRoot(root []byte) ([]byte, error) {
+	if len(root) != 0 {
+		return root, nil
+	}
+	rootClient := client
+	if len(trustedNode) != 0 {
+		opts := []grpc.DialOption{grpc.WithInsecure()}
+		trusted, ok := util.GetClient(trustedNode, opts).(*util.ConnClient)
+		if !ok {
+			return nil, fmt.Errorf("internal error. wrong RPC client type")
+		}
+		defer trusted.Close()
+		rootClient = trusted
+	} else {
+		fmt.Fprintln(os.Stderr, "Warning: the state root is from the queried node; "+
+			"give --root or --trustednode to verify against a trusted one")
+	}
+	status, err := rootClient.Blockchain(context.Background(), &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	block, err := rootClient.GetBlock(context.Background(), &types.SingleBytes{Value: status.GetBestBlockHash()})
+	if err != nil {
+		return nil, err
+	}
+	return block.GetHeader().GetBlocksRootHash(), nil
+}
@@ -6,10 +6,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +25,7 @@ var gettxCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(gettxCmd)
+	gettxCmd.Flags().BoolVar(&proof, "proof", false, "Get the merkle path of the tx in block and verify it against the block header")
 	// args := make([]string, 0, 10)
 	// args = append(args, "subCommand")
 	// blockCmd.SetArgs(args)
@@ -35,16 +37,42 @@ func execGetTX(cmd *cobra.Command, args []string) {
 		cmd.Printf("Failed decode: %s", err.Error())
 		return
 	}
+	if proof {
+		execGetTXProof(cmd, txHash)
+		return
+	}
 	msg, err := client.GetTX(context.Background(), &aergorpc.SingleBytes{Value: txHash})
 	if err == nil {
-		cmd.Println(util.TxConvBase58Addr(msg))
+		cmd.Println(jsonconv.TxConvBase58Addr(msg))
 	} else {
 		msgblock, err := client.GetBlockTX(context.Background(), &aergorpc.SingleBytes{Value: txHash})
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		cmd.Println(util.TxInBlockConvBase58Addr(msgblock))
+		cmd.Println(jsonconv.TxInBlockConvBase58Addr(msgblock))
 	}
 
 }
+
+func execGetTXProof(cmd *cobra.Command, txHash []byte) {
+	msg, err := client.GetTXProof(context.Background(), &aergorpc.SingleBytes{Value: txHash})
+	if err != nil {
+		cmd.Printf("Failed: %s", err.Error())
+		return
+	}
+	block, err := client.GetBlock(context.Background(), &aergorpc.SingleBytes{Value: msg.GetTxIdx().GetBlockHash()})
+	if err != nil {
+		cmd.Printf("Failed: %s", err.Error())
+		return
+	}
+	if !bytes.Equal(msg.GetTx().GetHash(), txHash) {
+		cmd.Printf("Failed: proof verification: %s\n", aergorpc.ErrTxProofNotMatch.Error())
+		return
+	}
+	if err := msg.Verify(block.GetHeader()); err != nil {
+		cmd.Printf("Failed: proof verification: %s\n", err.Error())
+		return
+	}
+	cmd.Println(jsonconv.TxProofConvBase58Addr(msg))
+}
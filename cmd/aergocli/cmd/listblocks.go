@@ -7,6 +7,7 @@ package cmd
 
 import (
 	"context"
+	"math"
 
 	"github.com/mr-tron/base58/base58"
 
@@ -25,6 +26,10 @@ var gbhHeight int32
 var gbhSize int
 var gbhOffset int
 var gbhAsc bool
+var gbhFrom uint64
+var gbhTo uint64
+var gbhBody bool
+var gbhCursor string
 
 func init() {
 	rootCmd.AddCommand(listblockheadersCmd)
@@ -34,6 +39,10 @@ func init() {
 	listblockheadersCmd.Flags().IntVar(&gbhSize, "size", 20, "Max list size")
 	listblockheadersCmd.Flags().IntVar(&gbhOffset, "offset", 0, "Offset")
 	listblockheadersCmd.Flags().BoolVar(&gbhAsc, "asc", false, "Order by")
+	listblockheadersCmd.Flags().Uint64Var(&gbhFrom, "from", 0, "First block number of range")
+	listblockheadersCmd.Flags().Uint64Var(&gbhTo, "to", 0, "Last block number of range (default: best block)")
+	listblockheadersCmd.Flags().BoolVar(&gbhBody, "body", false, "List blocks with their bodies")
+	listblockheadersCmd.Flags().StringVar(&gbhCursor, "cursor", "", "Cursor of next page returned by previous listblocks with range")
 
 }
 
@@ -47,17 +56,35 @@ func execListBlockHeaders(cmd *cobra.Command, args []string) {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-	} else if cmd.Flags().Changed("height") == false {
-		cmd.Printf("Error: required flag(s) \"hash\" or \"height\"not set")
+	} else if cmd.Flags().Changed("height") == false && !isRangeList(cmd) {
+		cmd.Printf("Error: required flag(s) \"hash\", \"height\" or \"from\", \"to\" not set")
 		return
 	}
+	var cursor []byte
+	if cmd.Flags().Changed("cursor") == true {
+		cursor, err = base58.Decode(gbhCursor)
+		if err != nil {
+			cmd.Printf("Failed: %s", err.Error())
+			return
+		}
+	}
 
 	uparams := &types.ListParams{
-		Hash:   blockHash,
-		Height: uint64(gbhHeight),
-		Size:   uint32(gbhSize),
-		Offset: uint32(gbhOffset),
-		Asc:    gbhAsc,
+		Hash:     blockHash,
+		Height:   uint64(gbhHeight),
+		Size:     uint32(gbhSize),
+		Offset:   uint32(gbhOffset),
+		Asc:      gbhAsc,
+		From:     gbhFrom,
+		To:       gbhTo,
+		WithBody: gbhBody,
+		Cursor:   cursor,
+		Range:    isRangeList(cmd),
+	}
+
+	if isRangeList(cmd) && !cmd.Flags().Changed("to") {
+		// to the best block, it is clamped by server
+		uparams.To = math.MaxUint64
 	}
 
 	msg, err := client.ListBlockHeaders(context.Background(), uparams)
@@ -67,3 +94,8 @@ func execListBlockHeaders(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println(util.JSON(msg))
 }
+
+// isRangeList reports whether blocks are listed by range of block numbers
+func isRangeList(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("from") || cmd.Flags().Changed("to") || cmd.Flags().Changed("cursor")
+}
@@ -0,0 +1,56 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var listtxsCmd = &cobra.Command{
+	Use:   "listtxs [flags] address",
+	Short: "Get transactions sent or received by an account",
+	Long:  "Get transactions sent or received by an account, latest first.\nIt requires the account index of aergosvr (blockchain.accountindex)\nand lists the transactions from IndexedFrom, the block where the index started",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   execListTxs,
+}
+var ltSize int
+var ltCursor string
+
+func init() {
+	rootCmd.AddCommand(listtxsCmd)
+
+	listtxsCmd.Flags().IntVar(&ltSize, "size", 20, "Max list size")
+	listtxsCmd.Flags().StringVar(&ltCursor, "cursor", "", "Cursor of next page returned by previous listtxs")
+}
+
+func execListTxs(cmd *cobra.Command, args []string) {
+	account, err := types.DecodeAddress(args[0])
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	params := &types.AccountTxsParams{
+		Account: account,
+		Size:    uint32(ltSize),
+	}
+	if ltCursor != "" {
+		if params.Cursor, err = base58.Decode(ltCursor); err != nil {
+			cmd.Printf("Failed: invalid cursor %s\n", err.Error())
+			return
+		}
+	}
+	msg, err := client.ListAccountTxs(context.Background(), params)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(jsonconv.AccountTxListConvBase58Addr(msg))
+}
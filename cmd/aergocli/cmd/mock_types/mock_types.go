@@ -53,6 +53,24 @@ func (mr *MockAergoRPCServiceClientMockRecorder) Blockchain(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blockchain", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).Blockchain), varargs...)
 }
 
+// ChangeMembership mocks base method
+func (m *MockAergoRPCServiceClient) ChangeMembership(arg0 context.Context, arg1 *types.MembershipChange, arg2 ...grpc.CallOption) (*types.Empty, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangeMembership", varargs...)
+	ret0, _ := ret[0].(*types.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeMembership indicates an expected call of ChangeMembership
+func (mr *MockAergoRPCServiceClientMockRecorder) ChangeMembership(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMembership", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ChangeMembership), varargs...)
+}
+
 // CommitTX mocks base method
 func (m *MockAergoRPCServiceClient) CommitTX(arg0 context.Context, arg1 *types.TxList, arg2 ...grpc.CallOption) (*types.CommitResultList, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -89,6 +107,42 @@ func (mr *MockAergoRPCServiceClientMockRecorder) CreateAccount(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).CreateAccount), varargs...)
 }
 
+// CreateMnemonicAccount mocks base method
+func (m *MockAergoRPCServiceClient) CreateMnemonicAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.MnemonicAccount, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateMnemonicAccount", varargs...)
+	ret0, _ := ret[0].(*types.MnemonicAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMnemonicAccount indicates an expected call of CreateMnemonicAccount
+func (mr *MockAergoRPCServiceClientMockRecorder) CreateMnemonicAccount(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMnemonicAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).CreateMnemonicAccount), varargs...)
+}
+
+// DeriveAccount mocks base method
+func (m *MockAergoRPCServiceClient) DeriveAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.Account, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeriveAccount", varargs...)
+	ret0, _ := ret[0].(*types.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeriveAccount indicates an expected call of DeriveAccount
+func (mr *MockAergoRPCServiceClientMockRecorder) DeriveAccount(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).DeriveAccount), varargs...)
+}
+
 // ExportAccount mocks base method
 func (m *MockAergoRPCServiceClient) ExportAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -108,7 +162,7 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ExportAccount(arg0, arg1 interf
 }
 
 // GetABI mocks base method
-func (m *MockAergoRPCServiceClient) GetABI(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.ABI, error) {
+func (m *MockAergoRPCServiceClient) GetABI(arg0 context.Context, arg1 *types.AccountAtBlock, arg2 ...grpc.CallOption) (*types.ABI, error) {
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
@@ -179,6 +233,24 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetBlockTX(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetBlockTX), varargs...)
 }
 
+// GetChainParams mocks base method
+func (m *MockAergoRPCServiceClient) GetChainParams(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.ChainParamList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetChainParams", varargs...)
+	ret0, _ := ret[0].(*types.ChainParamList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainParams indicates an expected call of GetChainParams
+func (mr *MockAergoRPCServiceClientMockRecorder) GetChainParams(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainParams", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetChainParams), varargs...)
+}
+
 // GetPeers mocks base method
 func (m *MockAergoRPCServiceClient) GetPeers(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.PeerList, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -234,7 +306,7 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetStaking(arg0, arg1 interface
 }
 
 // GetState mocks base method
-func (m *MockAergoRPCServiceClient) GetState(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.State, error) {
+func (m *MockAergoRPCServiceClient) GetState(arg0 context.Context, arg1 *types.AccountAtBlock, arg2 ...grpc.CallOption) (*types.State, error) {
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
@@ -287,6 +359,24 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetTX(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetTX), varargs...)
 }
 
+// GetTXProof mocks base method
+func (m *MockAergoRPCServiceClient) GetTXProof(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.TxProof, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTXProof", varargs...)
+	ret0, _ := ret[0].(*types.TxProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTXProof indicates an expected call of GetTXProof
+func (mr *MockAergoRPCServiceClientMockRecorder) GetTXProof(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTXProof", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetTXProof), varargs...)
+}
+
 // GetVotes mocks base method
 func (m *MockAergoRPCServiceClient) GetVotes(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.VoteList, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -323,6 +413,42 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ImportAccount(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ImportAccount), varargs...)
 }
 
+// ImportMnemonic mocks base method
+func (m *MockAergoRPCServiceClient) ImportMnemonic(arg0 context.Context, arg1 *types.MnemonicFormat, arg2 ...grpc.CallOption) (*types.Account, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportMnemonic", varargs...)
+	ret0, _ := ret[0].(*types.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportMnemonic indicates an expected call of ImportMnemonic
+func (mr *MockAergoRPCServiceClientMockRecorder) ImportMnemonic(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMnemonic", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ImportMnemonic), varargs...)
+}
+
+// ListAccountTxs mocks base method
+func (m *MockAergoRPCServiceClient) ListAccountTxs(arg0 context.Context, arg1 *types.AccountTxsParams, arg2 ...grpc.CallOption) (*types.AccountTxList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAccountTxs", varargs...)
+	ret0, _ := ret[0].(*types.AccountTxList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountTxs indicates an expected call of ListAccountTxs
+func (mr *MockAergoRPCServiceClientMockRecorder) ListAccountTxs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountTxs", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListAccountTxs), varargs...)
+}
+
 // ListBlockHeaders mocks base method
 func (m *MockAergoRPCServiceClient) ListBlockHeaders(arg0 context.Context, arg1 *types.ListParams, arg2 ...grpc.CallOption) (*types.BlockHeaderList, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -359,6 +485,42 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ListBlockStream(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlockStream", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListBlockStream), varargs...)
 }
 
+// ListEventStream mocks base method
+func (m *MockAergoRPCServiceClient) ListEventStream(arg0 context.Context, arg1 *types.FilterInfo, arg2 ...grpc.CallOption) (types.AergoRPCService_ListEventStreamClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEventStream", varargs...)
+	ret0, _ := ret[0].(types.AergoRPCService_ListEventStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventStream indicates an expected call of ListEventStream
+func (mr *MockAergoRPCServiceClientMockRecorder) ListEventStream(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventStream", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEventStream), varargs...)
+}
+
+// ListEvidence mocks base method
+func (m *MockAergoRPCServiceClient) ListEvidence(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.EvidenceList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEvidence", varargs...)
+	ret0, _ := ret[0].(*types.EvidenceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvidence indicates an expected call of ListEvidence
+func (mr *MockAergoRPCServiceClientMockRecorder) ListEvidence(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvidence", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEvidence), varargs...)
+}
+
 // LockAccount mocks base method
 func (m *MockAergoRPCServiceClient) LockAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.Account, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -0,0 +1,183 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var (
+	msThreshold uint32
+	msKeys      string
+)
+
+func init() {
+	multisigCmd := &cobra.Command{
+		Use:   "multisig [flags] subcommand",
+		Short: "Multi-signature account command",
+	}
+	rootCmd.AddCommand(multisigCmd)
+
+	msAddressCmd := &cobra.Command{
+		Use:   "address [flags]",
+		Short: "Make an address of M-of-N multisig account",
+		RunE:  execMultisigAddress,
+	}
+	msAddressCmd.Flags().Uint32Var(&msThreshold, "threshold", 1, "number of signatures required (M)")
+	msAddressCmd.Flags().StringVar(&msKeys, "keys", "", "comma separated addresses of members (N)")
+	msAddressCmd.MarkFlagRequired("keys")
+
+	msNewTxCmd := &cobra.Command{
+		Use:   "newtx [flags]",
+		Short: "Make an unsigned transaction of multisig account",
+		RunE:  execMultisigNewTx,
+	}
+	msNewTxCmd.Flags().Uint32Var(&msThreshold, "threshold", 1, "number of signatures required (M)")
+	msNewTxCmd.Flags().StringVar(&msKeys, "keys", "", "comma separated addresses of members (N)")
+	msNewTxCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction body json, the account and sign are overwritten")
+	msNewTxCmd.MarkFlagRequired("keys")
+	msNewTxCmd.MarkFlagRequired("jsontx")
+
+	msSignCmd := &cobra.Command{
+		Use:   "sign [flags]",
+		Short: "Co-sign a transaction of multisig account",
+		RunE:  execMultisigSign,
+	}
+	msSignCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to co-sign")
+	msSignCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data/cli", "path to data directory")
+	msSignCmd.Flags().StringVar(&address, "address", "", "address of member account to use for signing")
+	msSignCmd.Flags().StringVar(&pw, "password", "", "local account password")
+	msSignCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
+	msSignCmd.MarkFlagRequired("jsontx")
+
+	msSubmitCmd := &cobra.Command{
+		Use:   "submit [flags]",
+		Short: "Commit a transaction of multisig account signed enough",
+		RunE:  execMultisigSubmit,
+	}
+	msSubmitCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to commit")
+	msSubmitCmd.MarkFlagRequired("jsontx")
+
+	multisigCmd.AddCommand(msAddressCmd, msNewTxCmd, msSignCmd, msSubmitCmd)
+}
+
+func parseMultiSig() (*types.MultiSig, error) {
+	var pubkeys []key.Address
+	for _, k := range strings.Split(msKeys, ",") {
+		addr, err := types.DecodeAddress(strings.TrimSpace(k))
+		if err != nil {
+			return nil, errors.New("Failed to parse --keys\n" + err.Error())
+		}
+		pubkeys = append(pubkeys, addr)
+	}
+	return key.NewMultiSig(msThreshold, pubkeys)
+}
+
+func parseMultisigTx() (*types.Tx, error) {
+	txs, err := jsonconv.ParseBase58Tx([]byte(jsonTx))
+	if err != nil {
+		return nil, errors.New("Failed to parse --jsontx\n" + err.Error())
+	}
+	if len(txs) != 1 {
+		return nil, errors.New("Failed to parse --jsontx\nneed a transaction")
+	}
+	return txs[0], nil
+}
+
+func execMultisigAddress(cmd *cobra.Command, args []string) error {
+	ms, err := parseMultiSig()
+	if err != nil {
+		return err
+	}
+	cmd.Println(types.EncodeAddress(key.MultisigAddress(ms)))
+	return nil
+}
+
+func execMultisigNewTx(cmd *cobra.Command, args []string) error {
+	ms, err := parseMultiSig()
+	if err != nil {
+		return err
+	}
+	body, err := jsonconv.ParseBase58TxBody([]byte(jsonTx))
+	if err != nil {
+		return errors.New("Failed to parse --jsontx\n" + err.Error())
+	}
+	tx, err := key.NewMultisigTx(body, ms)
+	if err != nil {
+		return err
+	}
+	cmd.Println(jsonconv.TxConvBase58Addr(tx))
+	return nil
+}
+
+func execMultisigSign(cmd *cobra.Command, args []string) error {
+	tx, err := parseMultisigTx()
+	if err != nil {
+		return err
+	}
+	if privKey != "" {
+		rawKey, err := base58.Decode(privKey)
+		if err != nil {
+			return err
+		}
+		signKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), rawKey)
+		err = key.SignMultisigTx(tx, signKey)
+		if err != nil {
+			return err
+		}
+	} else if cmd.Flags().Changed("path") || cmd.Flags().Changed("address") {
+		signer, err := types.DecodeAddress(address)
+		if err != nil {
+			return err
+		}
+		ks := key.NewStore(os.ExpandEnv(dataDir))
+		defer ks.CloseStore()
+		sign, err := ks.Sign(signer, pw, key.CalculateHashWithoutSign(tx.Body))
+		if err != nil {
+			return err
+		}
+		err = key.AddMultisigSign(tx, signer, sign)
+		if err != nil {
+			return err
+		}
+	} else {
+		// co-signed by unlocked member keys in the node
+		tx, err = client.SignTX(context.Background(), tx)
+		if err != nil {
+			return err
+		}
+	}
+	cmd.Println(jsonconv.TxConvBase58Addr(tx))
+	return nil
+}
+
+func execMultisigSubmit(cmd *cobra.Command, args []string) error {
+	tx, err := parseMultisigTx()
+	if err != nil {
+		return err
+	}
+	if err := key.VerifyMultisigTx(tx); err != nil {
+		return err
+	}
+	msg, err := client.CommitTX(context.Background(), &types.TxList{Txs: []*types.Tx{tx}})
+	if err != nil {
+		return errors.New("Failed request to aergo server\n" + err.Error())
+	}
+	for i, r := range msg.Results {
+		cmd.Println(i+1, ":", base58.Encode(r.Hash), r.Error, r.Detail)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var (
+	paramName    string
+	paramValue   uint64
+	paramBlockNo uint64
+)
+
+var paramCmd = &cobra.Command{
+	Use:   "param",
+	Short: "Chain parameters changed by the governance",
+}
+
+var paramListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the chain parameters active for the next block",
+	Run:   execListParams,
+}
+
+var paramProposeCmd = &cobra.Command{
+	Use:   "propose",
+	Short: "Propose to change a chain parameter from a block, and approve it",
+	Run:   execParamCmd('p'),
+}
+
+var paramApproveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve the proposal to change a chain parameter",
+	Run:   execParamCmd('a'),
+}
+
+var paramExecuteCmd = &cobra.Command{
+	Use:   "execute",
+	Short: "Execute the proposal approved by more than 2/3 of the total staking",
+	Run:   execParamCmd('x'),
+}
+
+func init() {
+	rootCmd.AddCommand(paramCmd)
+	paramCmd.AddCommand(paramListCmd)
+	for _, c := range []*cobra.Command{paramProposeCmd, paramApproveCmd, paramExecuteCmd} {
+		paramCmd.AddCommand(c)
+		c.Flags().StringVar(&address, "address", "", "Account address of sender")
+		c.MarkFlagRequired("address")
+		c.Flags().StringVar(&paramName, "name", "", "Name of the parameter (blockinterval, maxblocksize, bprewardrate, bpcount)")
+		c.MarkFlagRequired("name")
+		c.Flags().Uint64Var(&paramValue, "value", 0, "New value of the parameter")
+		c.MarkFlagRequired("value")
+		c.Flags().Uint64Var(&paramBlockNo, "blockno", 0, "Block number from which the value is effective")
+		c.MarkFlagRequired("blockno")
+	}
+}
+
+func execListParams(cmd *cobra.Command, args []string) {
+	msg, err := client.GetChainParams(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(util.JSON(msg))
+}
+
+func execParamCmd(systemCmd byte) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		account, err := types.DecodeAddress(address)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		param, err := proto.Marshal(&types.ChainParam{Name: paramName, Value: paramValue, BlockNo: paramBlockNo})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+
+		tx := &types.Tx{
+			Body: &types.TxBody{
+				Account:   account,
+				Recipient: []byte(types.AergoSystem),
+				Payload:   append([]byte{systemCmd}, param...),
+				Type:      types.TxType_GOVERNANCE,
+			},
+		}
+		rsp, err := client.SendTX(context.Background(), tx)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(base58.Encode(rsp.Hash), rsp.Error)
+	}
+}
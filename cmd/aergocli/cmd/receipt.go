@@ -7,6 +7,7 @@ package cmd
 
 import (
 	"context"
+	"io"
 	"log"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
@@ -15,6 +16,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	streamContract  string
+	streamEventName string
+	streamFrom      uint64
+	streamTo        uint64
+)
+
 func init() {
 	receiptCmd := &cobra.Command{
 		Use:   "receipt [flags] subcommand",
@@ -22,7 +30,18 @@ func init() {
 	}
 	rootCmd.AddCommand(receiptCmd)
 
+	streamCmd := &cobra.Command{
+		Use:   "stream [flags]",
+		Short: "Stream receipts and contract events of new blocks",
+		Run:   execReceiptStream,
+	}
+	streamCmd.Flags().StringVar(&streamContract, "address", "", "Contract address to filter")
+	streamCmd.Flags().StringVar(&streamEventName, "event", "", "Event name to filter")
+	streamCmd.Flags().Uint64Var(&streamFrom, "from", 0, "Block number to start from (default: next block)")
+	streamCmd.Flags().Uint64Var(&streamTo, "to", 0, "Block number to stop at (default: no limit)")
+
 	receiptCmd.AddCommand(
+		streamCmd,
 		&cobra.Command{
 			Use:   "get [flags] tx_hash",
 			Short: "Get a receipt",
@@ -41,3 +60,32 @@ func init() {
 		},
 	)
 }
+
+func execReceiptStream(cmd *cobra.Command, args []string) {
+	filter := &aergorpc.FilterInfo{
+		EventName: streamEventName,
+		Blockfrom: streamFrom,
+		Blockto:   streamTo,
+	}
+	if streamContract != "" {
+		contract, err := aergorpc.DecodeAddress(streamContract)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filter.ContractAddress = contract
+	}
+	stream, err := client.ListEventStream(context.Background(), filter)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		cmd.Println(util.JSON(msg))
+	}
+}
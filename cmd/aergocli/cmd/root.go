@@ -36,10 +36,15 @@ var (
 	amount string
 	unit   string
 
-	address    string
-	stateroot  string
-	proof      bool
-	compressed bool
+	address     string
+	stateroot   string
+	proof       bool
+	compressed  bool
+	verify      bool
+	trustedNode string
+
+	stateBlockNo   uint64
+	stateBlockHash string
 
 	staking bool
 
@@ -9,8 +9,8 @@ import (
 	"context"
 	"errors"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -41,7 +41,7 @@ func execSendTX(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.New("Wrong address in --to flag\n" + err.Error())
 	}
-	amountBigInt, err := util.ParseUnit(amount)
+	amountBigInt, err := jsonconv.ParseUnit(amount)
 	if err != nil {
 		return errors.New("Wrong value in --amount flag\n" + err.Error())
 	}
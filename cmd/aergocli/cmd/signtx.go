@@ -5,8 +5,8 @@ import (
 	"os"
 
 	"github.com/aergoio/aergo/account/key"
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
@@ -35,7 +35,7 @@ var signCmd = &cobra.Command{
 			cmd.Printf("need to transaction json input")
 			return
 		}
-		param, err := util.ParseBase58TxBody([]byte(jsonTx))
+		param, err := jsonconv.ParseBase58TxBody([]byte(jsonTx))
 		if err != nil {
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
@@ -90,7 +90,7 @@ var signCmd = &cobra.Command{
 		}
 
 		if nil == err && msg != nil {
-			cmd.Println(util.TxConvBase58Addr(msg))
+			cmd.Println(jsonconv.TxConvBase58Addr(msg))
 		} else {
 			cmd.Printf("Failed: %s\n", err.Error())
 		}
@@ -106,7 +106,7 @@ var verifyCmd = &cobra.Command{
 			cmd.Printf("need to transaction json input")
 			return
 		}
-		param, err := util.ParseBase58Tx([]byte(jsonTx))
+		param, err := jsonconv.ParseBase58Tx([]byte(jsonTx))
 		if err != nil {
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
@@ -118,7 +118,7 @@ var verifyCmd = &cobra.Command{
 				return
 			}
 			if msg.Tx != nil {
-				cmd.Println(util.TxConvBase58Addr(msg.Tx))
+				cmd.Println(jsonconv.TxConvBase58Addr(msg.Tx))
 			} else {
 				cmd.Println(msg.Error)
 			}
@@ -128,7 +128,7 @@ var verifyCmd = &cobra.Command{
 				cmd.Printf("Failed: %s\n", err.Error())
 				return
 			}
-			cmd.Println(util.TxConvBase58Addr(param[0]))
+			cmd.Println(jsonconv.TxConvBase58Addr(param[0]))
 		}
 	},
 }
@@ -71,3 +71,31 @@ func sendStaking(cmd *cobra.Command, s bool) error {
 	cmd.Println(base58.Encode(msg.Hash), msg.Error)
 	return nil
 }
+
+var claimCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Claim the reward for the votes from aergo system",
+	RunE:  execClaim,
+}
+
+func execClaim(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   []byte{'c'},
+			Limit:     0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	msg, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		return err
+	}
+	cmd.Println(base58.Encode(msg.Hash), msg.Error)
+	return nil
+}
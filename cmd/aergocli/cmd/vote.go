@@ -75,7 +75,7 @@ func execVote(cmd *cobra.Command, args []string) {
 	txs := make([]*types.Tx, 1)
 
 	state, err := client.GetState(context.Background(),
-		&types.SingleBytes{Value: account})
+		&types.AccountAtBlock{Account: account})
 	if err != nil {
 		cmd.Printf("Failed: %s\n", err.Error())
 		return
@@ -8,8 +8,8 @@ package util
 import (
 	"fmt"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv/encoding/json"
 	protobuf "github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
 )
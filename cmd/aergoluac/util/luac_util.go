@@ -19,8 +19,8 @@ import (
 	"runtime"
 	"unsafe"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
 )
 
 var (
@@ -78,7 +78,7 @@ func DumpFromFile(srcFileName string) error {
 		return errors.New(C.GoString(errMsg))
 	}
 
-	fmt.Println(util.EncodeCode(b.Bytes()))
+	fmt.Println(jsonconv.EncodeCode(b.Bytes()))
 	return nil
 }
 
@@ -115,7 +115,7 @@ func DumpFromStdin() error {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	fmt.Println(util.EncodeCode(b.Bytes()))
+	fmt.Println(jsonconv.EncodeCode(b.Bytes()))
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl"
 	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/jsonrpc"
 	"github.com/aergoio/aergo/mempool"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/pkg/component"
@@ -140,14 +141,24 @@ func rootRun(cmd *cobra.Command, args []string) {
 	var restSvc component.IComponent
 	if cfg.EnableRest {
 		svrlog.Info().Msg("Start REST server")
-		restSvc = rest.NewRestService(cfg, chainSvc)
+		restSvc = rest.NewRestService(cfg, chainSvc, rpcSvc.ActualServer())
 	} else {
 		svrlog.Info().Msg("Do not start REST server")
 	}
 
+	var jsonrpcSvc component.IComponent
+	if cfg.EnableJSONRPC {
+		svrlog.Info().Msg("Start JSON-RPC server")
+		js := jsonrpc.NewJSONRPCService(cfg, rpcSvc.ActualServer())
+		rpcSvc.AddListener(js)
+		jsonrpcSvc = js
+	} else {
+		svrlog.Info().Msg("Do not start JSON-RPC server")
+	}
+
 	// Register services to Hub. Don't need to do nil-check since Register
 	// function skips nil parameters.
-	compMng.Register(chainSvc, mpoolSvc, rpcSvc, syncSvc, p2pSvc, accountSvc, restSvc)
+	compMng.Register(chainSvc, mpoolSvc, rpcSvc, syncSvc, p2pSvc, accountSvc, restSvc, jsonrpcSvc)
 
 	consensusSvc, err := impl.New(cfg, chainSvc, compMng)
 	if err != nil {
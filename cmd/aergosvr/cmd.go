@@ -67,6 +67,7 @@ var initGenesis = &cobra.Command{
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "fail to init a blockchain core (error:%s)\n", err)
 		}
+		core.SetStatePruning(cfg.Blockchain.StatePruning, cfg.Blockchain.Archive)
 
 		err = core.InitGenesisBlock(genesis)
 		if err != nil {
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotBlockNo   uint64
+	snapshotBlockHash string
+	snapshotDigest    string
+	snapshotGenesis   string
+)
+
+func init() {
+	exportSnapshot.Flags().StringVar(&dataDir, "dir", "", "Data directory")
+	exportSnapshot.Flags().Uint64Var(&snapshotBlockNo, "blockno", 0, "Block number of the snapshot (default: the best block)")
+	importSnapshot.Flags().StringVar(&dataDir, "dir", "", "Data directory")
+	importSnapshot.Flags().StringVar(&snapshotBlockHash, "blockhash", "", "Trusted hash of the snapshot block (required)")
+	importSnapshot.Flags().StringVar(&snapshotDigest, "digest", "", "Trusted digest of the snapshot, required if it has sql databases of contracts")
+	importSnapshot.Flags().StringVar(&snapshotGenesis, "genesis", "", "Genesis json file of the chain (default: the default genesis)")
+
+	snapshotCmd.AddCommand(exportSnapshot, importSnapshot)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export or import a state snapshot",
+}
+
+var exportSnapshot = &cobra.Command{
+	Use:   "export",
+	Short: "Export the state at a block to a snapshot file",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: aergosvr snapshot export {snapshot file} --dir {data directory} --blockno {block number}")
+			return
+		}
+		if dataDir == "" {
+			dataDir = cfg.DataDir
+		}
+
+		file, err := os.Create(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to create %s (error:%s)\n", args[0], err)
+			return
+		}
+		defer file.Close()
+
+		core, err := chain.NewCore(cfg.DbType, dataDir, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to init a blockchain core (error:%s)\n", err)
+			return
+		}
+		defer core.Close()
+
+		block, digest, err := core.ExportSnapshot(file, snapshotBlockNo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to export a snapshot (error:%s)\n", err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "snapshot of block %d (%s) is exported to %s (digest:%s)\n",
+			block.BlockNo(), block.ID(), args[0], enc.ToString(digest))
+	},
+}
+
+var importSnapshot = &cobra.Command{
+	Use:   "import",
+	Short: "Start a new chain from a snapshot file",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 || snapshotBlockHash == "" {
+			fmt.Fprintln(os.Stderr, "Usage: aergosvr snapshot import {snapshot file} --dir {data directory} --blockhash {trusted block hash} [--digest {trusted digest}] [--genesis {genesis.json}]")
+			return
+		}
+		if dataDir == "" {
+			dataDir = cfg.DataDir
+		}
+
+		blockHash, err := enc.ToBytes(snapshotBlockHash)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid block hash %s (error:%s)\n", snapshotBlockHash, err)
+			return
+		}
+		var digest []byte
+		if snapshotDigest != "" {
+			if digest, err = enc.ToBytes(snapshotDigest); err != nil {
+				fmt.Fprintf(os.Stderr, "invalid digest %s (error:%s)\n", snapshotDigest, err)
+				return
+			}
+		}
+		genesis := types.GetDefaultGenesis()
+		if snapshotGenesis != "" {
+			if genesis, err = readGenesis(snapshotGenesis); err != nil {
+				fmt.Fprintf(os.Stderr, "fail to read %s (error:%s)\n", snapshotGenesis, err)
+				return
+			}
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to open %s (error:%s)\n", args[0], err)
+			return
+		}
+		defer file.Close()
+
+		// the data directory is removed if the import fails, so it must be
+		// new
+		if empty, err := isEmptyDir(dataDir); err != nil || !empty {
+			fmt.Fprintf(os.Stderr, "%s is not an empty directory\n", dataDir)
+			return
+		}
+		if err := os.MkdirAll(dataDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "fail to create %s (error:%s)\n", dataDir, err)
+			return
+		}
+		core, err := chain.NewCore(cfg.DbType, dataDir, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to init a blockchain core (error:%s)\n", err)
+			os.RemoveAll(dataDir)
+			return
+		}
+
+		block, err := core.ImportSnapshot(file, genesis, blockHash, digest)
+		core.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to import a snapshot (error:%s)\n", err)
+			os.RemoveAll(dataDir)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "chain starts from block %d (%s) in (%s)\n", block.BlockNo(), block.ID(), dataDir)
+	},
+}
+
+func readGenesis(path string) (*types.Genesis, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	genesis := new(types.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// isEmptyDir returns true if dir doesn't exist or has no file.
+func isEmptyDir(dir string) (bool, error) {
+	d, err := os.Open(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer d.Close()
+
+	if _, err := d.Readdirnames(1); err == io.EOF {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
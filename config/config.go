@@ -41,11 +41,13 @@ func (ctx *ServerContext) GetDefaultConfig() interface{} {
 		BaseConfig: ctx.GetDefaultBaseConfig(),
 		RPC:        ctx.GetDefaultRPCConfig(),
 		REST:       ctx.GetDefaultRESTConfig(),
+		JSONRPC:    ctx.GetDefaultJSONRPCConfig(),
 		P2P:        ctx.GetDefaultP2PConfig(),
 		Blockchain: ctx.GetDefaultBlockchainConfig(),
 		Mempool:    ctx.GetDefaultMempoolConfig(),
 		Consensus:  ctx.GetDefaultConsensusConfig(),
 		Monitor:	ctx.GetDefaultMonitorConfig(),
+		Hardfork:   ctx.GetDefaultHardforkConfig(),
 	}
 }
 
@@ -56,6 +58,7 @@ func (ctx *ServerContext) GetDefaultBaseConfig() BaseConfig {
 		EnableProfile:  false,
 		ProfilePort:    6060,
 		EnableRest:     false,
+		EnableJSONRPC:  false,
 		EnableTestmode: false,
 		Personal:       true,
 	}
@@ -76,6 +79,12 @@ func (ctx *ServerContext) GetDefaultRESTConfig() *RESTConfig {
 	}
 }
 
+func (ctx *ServerContext) GetDefaultJSONRPCConfig() *JSONRPCConfig {
+	return &JSONRPCConfig{
+		JSONRPCPort: 8545,
+	}
+}
+
 func (ctx *ServerContext) GetDefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		NetProtocolAddr: "",
@@ -97,6 +106,11 @@ func (ctx *ServerContext) GetDefaultBlockchainConfig() *BlockchainConfig {
 		CoinbaseAccount: "",
 		MaxAnchorCount:  20,
 		UseFastSyncer:   false,
+		AccountIndex:    false,
+		StatePruning:    0,
+		Archive:         false,
+		StateSync:       false,
+		LightNode:       false,
 	}
 }
 
@@ -105,6 +119,10 @@ func (ctx *ServerContext) GetDefaultMempoolConfig() *MempoolConfig {
 		ShowMetrics:    false,
 		VerifierNumber: runtime.NumCPU(),
 		DumpFilePath:   ctx.ExpandPathEnv("$HOME/mempool.dump"),
+		SizeLimit:      1000000,
+		AccountLimit:   1000,
+		OrphanTTL:      3600,
+		PriceBump:      10,
 	}
 }
 
@@ -113,6 +131,7 @@ func (ctx *ServerContext) GetDefaultConsensusConfig() *ConsensusConfig {
 		EnableBp:      true,
 		BlockInterval: consensus.DefaultBlockIntervalSec,
 		DposBpNumber:  consensus.DefaultDposBpNumber,
+		DposEpoch:     consensus.DefaultDposEpoch,
 		BpIds:         nil,
 	}
 }
@@ -124,3 +143,9 @@ func (ctx *ServerContext) GetDefaultMonitorConfig() *MonitorConfig {
 	}
 
 }
+
+func (ctx *ServerContext) GetDefaultHardforkConfig() *HardforkConfig {
+	return &HardforkConfig{
+		V2: 0,
+	}
+}
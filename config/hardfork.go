@@ -0,0 +1,36 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// HardforkConfig defines the block numbers from which the changed consensus
+// rules are applied. Every node of a chain must have the same values. A new
+// chain applies all the rules from the genesis block.
+type HardforkConfig struct {
+	V2 types.BlockNo `mapstructure:"v2" description:"a block number from which the consensus rules of version 2 are applied"`
+}
+
+// IsV2Fork reports whether the rules of version 2 are applied to the block of
+// no.
+func (c *HardforkConfig) IsV2Fork(no types.BlockNo) bool {
+	return no >= c.V2
+}
+
+// CheckCompatibility returns an error if the fork heights of c differ from
+// those of stored, which the chain has been run with, at a block no more than
+// best. Such a block has already been connected by the old rules.
+func (c *HardforkConfig) CheckCompatibility(stored *HardforkConfig, best types.BlockNo) error {
+	if stored.V2 != c.V2 && (stored.V2 <= best || c.V2 <= best) {
+		return fmt.Errorf("the v2 hardfork height changed from %d to %d, but the best block is %d",
+			stored.V2, c.V2, best)
+	}
+	return nil
+}
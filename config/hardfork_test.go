@@ -0,0 +1,22 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardforkCompatibility(t *testing.T) {
+	c := &HardforkConfig{V2: 100}
+	assert.False(t, c.IsV2Fork(99))
+	assert.True(t, c.IsV2Fork(100))
+
+	assert.NoError(t, c.CheckCompatibility(&HardforkConfig{V2: 100}, 200), "same heights")
+	assert.NoError(t, c.CheckCompatibility(&HardforkConfig{V2: 150}, 50), "no block connected at the heights")
+	assert.Error(t, c.CheckCompatibility(&HardforkConfig{V2: 150}, 120), "block connected by the new height")
+	assert.Error(t, c.CheckCompatibility(&HardforkConfig{V2: 50}, 70), "block connected by the old height")
+}
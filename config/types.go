@@ -16,11 +16,13 @@ type Config struct {
 	BaseConfig `mapstructure:",squash"`
 	RPC        *RPCConfig        `mapstructure:"rpc"`
 	REST       *RESTConfig       `mapstructure:"rest"`
+	JSONRPC    *JSONRPCConfig    `mapstructure:"jsonrpc"`
 	P2P        *P2PConfig        `mapstructure:"p2p"`
 	Blockchain *BlockchainConfig `mapstructure:"blockchain"`
 	Mempool    *MempoolConfig    `mapstructure:"mempool"`
 	Consensus  *ConsensusConfig  `mapstructure:"consensus"`
 	Monitor    *MonitorConfig	 `mapstructure:"monitor"`
+	Hardfork   *HardforkConfig   `mapstructure:"hardfork"`
 }
 
 // BaseConfig defines base configurations for aergo server
@@ -30,6 +32,7 @@ type BaseConfig struct {
 	EnableProfile  bool   `mapstructure:"enableprofile" description:"enable profiling"`
 	ProfilePort    int    `mapstructure:"profileport" description:"profiling port (default:6060)"`
 	EnableRest     bool   `mapstructure:"enablerest" description:"enable rest port for testing"`
+	EnableJSONRPC  bool   `mapstructure:"enablejsonrpc" description:"enable json-rpc port"`
 	EnableTestmode bool   `mapstructure:"enabletestmode" description:"enable unsafe test mode"`
 	Personal       bool   `mapstructure:"personal" description:"enable personal account service"`
 }
@@ -52,6 +55,11 @@ type RESTConfig struct {
 	RestPort int `mapstructure:"restport" description:"Rest port(default:8080)"`
 }
 
+// JSONRPCConfig defines configurations for json-rpc server
+type JSONRPCConfig struct {
+	JSONRPCPort int `mapstructure:"jsonrpcport" description:"JSON-RPC port for http and websocket(default:8545)"`
+}
+
 // P2PConfig defines configurations for p2p service
 type P2PConfig struct {
 	// N2N (peer-to-peer) network
@@ -73,6 +81,11 @@ type BlockchainConfig struct {
 	CoinbaseAccount string `mapstructure:"coinbaseaccount" description:"wallet address for coinbase"`
 	MaxAnchorCount  int    `mapstructure:"maxanchorcount" description:"maximun anchor count for sync"`
 	UseFastSyncer   bool   `mapstructure:"usefastsyncer" description:"Enable FastSyncer"`
+	AccountIndex    bool   `mapstructure:"accountindex" description:"Enable index of txs by sender and recipient account"`
+	StatePruning    int    `mapstructure:"statepruning" description:"number of recent blocks whose state is kept (0 disables state pruning)"`
+	Archive         bool   `mapstructure:"archive" description:"keep the state of every block for the historical state queries (disables state pruning)"`
+	StateSync       bool   `mapstructure:"statesync" description:"sync the state at the lib block of a peer instead of all the blocks when the chain is empty (requires usefastsyncer)"`
+	LightNode       bool   `mapstructure:"lightnode" description:"follow only the block headers without keeping the state, and query the state with proofs to the peers"`
 }
 
 // MempoolConfig defines configurations for mempool service
@@ -80,14 +93,21 @@ type MempoolConfig struct {
 	ShowMetrics    bool   `mapstructure:"showmetrics" description:"show mempool metric periodically"`
 	VerifierNumber int    `mapstructure:"verifiers" description:"number of concurrent verifier"`
 	DumpFilePath   string `mapstructure:"dumpfilepath" description:"file path for recording mempool at process termintation"`
+	SizeLimit      int    `mapstructure:"sizelimit" description:"maximum number of transactions in mempool"`
+	AccountLimit   int    `mapstructure:"accountlimit" description:"maximum number of orphan transactions of an account in mempool"`
+	OrphanTTL      int64  `mapstructure:"orphanttl" description:"time to live of orphan transactions which are not continuous in nonce (sec)"`
+	PriceBump      int    `mapstructure:"pricebump" description:"minimum price increase (percent) to replace a transaction with same nonce"`
 }
 
 // ConsensusConfig defines configurations for consensus service
 type ConsensusConfig struct {
 	EnableBp      bool     `mapstructure:"enablebp" description:"enable block production"`
 	EnableDpos    bool     `mapstructure:"enabledpos" description:"enable DPoS consensus"`
+	EnableBft     bool     `mapstructure:"enablebft" description:"enable BFT consensus among the block producers of bpids"`
+	EnableRaft    bool     `mapstructure:"enableraft" description:"enable Raft consensus among the block producers of bpids"`
 	BlockInterval int64    `mapstructure:"blockinterval" description:"block production interval (sec)"`
 	DposBpNumber  uint16   `mapstructure:"dposbps" description:"the number of DPoS block producers"`
+	DposEpoch     uint64   `mapstructure:"dposepoch" description:"the number of blocks in a DPoS election epoch (0: no election)"`
 	BpIds         []string `mapstructure:"bpids" description:"the IDs of the block producers"`
 }
 
@@ -117,6 +137,7 @@ dbtype = "{{.BaseConfig.DbType}}"
 enableprofile = {{.BaseConfig.EnableProfile}}
 profileport = {{.BaseConfig.ProfilePort}}
 enablerest = {{.BaseConfig.EnableRest}}
+enablejsonrpc = {{.BaseConfig.EnableJSONRPC}}
 enabletestmode = {{.BaseConfig.EnableTestmode}}
 personal = {{.BaseConfig.Personal}}
 
@@ -132,6 +153,9 @@ nsallowcors = {{.RPC.NSAllowCORS}}
 [rest]
 restport = "{{.REST.RestPort}}"
 
+[jsonrpc]
+jsonrpcport = {{.JSONRPC.JSONRPCPort}}
+
 [p2p]
 # Set address and port to which the inbound peers connect, and don't set loopback address or private network unless used in local network 
 netprotocoladdr = "{{.P2P.NetProtocolAddr}}"
@@ -155,17 +179,29 @@ maxblocksize = {{.Blockchain.MaxBlockSize}}
 coinbaseaccount = "{{.Blockchain.CoinbaseAccount}}"
 maxanchorcount = "{{.Blockchain.MaxAnchorCount}}"
 usefastsyncer = "{{.Blockchain.UseFastSyncer}}"
+accountindex = {{.Blockchain.AccountIndex}}
+statepruning = {{.Blockchain.StatePruning}}
+archive = {{.Blockchain.Archive}}
+statesync = {{.Blockchain.StateSync}}
+lightnode = {{.Blockchain.LightNode}}
 
 [mempool]
 showmetrics = {{.Mempool.ShowMetrics}}
 verifiers = {{.Mempool.VerifierNumber}}
 dumpfilepath = "{{.Mempool.DumpFilePath}}"
+sizelimit = {{.Mempool.SizeLimit}}
+accountlimit = {{.Mempool.AccountLimit}}
+orphanttl = {{.Mempool.OrphanTTL}}
+pricebump = {{.Mempool.PriceBump}}
 
 [consensus]
 enablebp = {{.Consensus.EnableBp}}
 enabledpos = {{.Consensus.EnableDpos}}
+enablebft = {{.Consensus.EnableBft}}
+enableraft = {{.Consensus.EnableRaft}}
 blockinterval = {{.Consensus.BlockInterval}}
 dposbps = {{.Consensus.DposBpNumber}}
+dposepoch = {{.Consensus.DposEpoch}}
 bpids = [{{range .Consensus.BpIds}}
 "{{.}}", {{end}}
 ]
@@ -173,4 +209,7 @@ bpids = [{{range .Consensus.BpIds}}
 [monitor]
 protocol = "{{.Monitor.ServerProtocol}}"
 endpoint = "{{.Monitor.ServerEndpoint}}"
+
+[hardfork]
+v2 = {{.Hardfork.V2}}
 `
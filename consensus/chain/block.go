@@ -1,16 +1,18 @@
 package chain
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
-	"github.com/golang/protobuf/proto"
 )
 
 var (
@@ -55,21 +57,35 @@ func GetBestBlock(hs component.ICompSyncRequester) *types.Block {
 	return result.(message.GetBestBlockRsp).Block
 }
 
-// MaxBlockBodySize returns the maximum block body size.
-//
-// TODO: This is not an exact size. Let's make it exact!
-func MaxBlockBodySize() uint32 {
-	return chain.MaxBlockSize - uint32(proto.Size(&types.BlockHeader{}))
+// chainParams returns the chain parameters effective at the block of no, which
+// is executed on bState, the state of its parent.
+func chainParams(bState *state.BlockState, no types.BlockNo) (*consensus.ChainParams, error) {
+	scs, err := bState.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		return nil, err
+	}
+	return chain.ParamsAt(scs, no)
 }
 
 // GenerateBlock generate & return a new block
 func GenerateBlock(hs component.ICompSyncRequester, prevBlock *types.Block, bState *state.BlockState, txOp TxOp, ts int64) (*types.Block, error) {
-	txs, err := GatherTXs(hs, bState, txOp, MaxBlockBodySize())
+	bState.SetBlockNo(prevBlock.BlockNo() + 1)
+	if err := chain.SeedStakingTotal(bState, prevBlock.BlockHash()); err != nil {
+		return nil, err
+	}
+
+	params, err := chainParams(bState, prevBlock.BlockNo()+1)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := GatherTXs(hs, bState, txOp, chain.MaxBlockBodySize(params))
 	if err != nil {
 		return nil, err
 	}
 
 	block := types.NewBlock(prevBlock, bState.GetRoot(), bState.Receipts(), txs, chain.CoinbaseAccount, ts)
+	block.Header.ReceiptsRootHash = chain.ReceiptsRoot(bState.Receipts(), block.BlockNo())
 	if len(txs) != 0 && logger.IsDebugEnabled() {
 		logger.Debug().
 			Str("txroothash", types.EncodeB64(block.GetHeader().GetTxsRootHash())).
@@ -80,6 +96,65 @@ func GenerateBlock(hs component.ICompSyncRequester, prevBlock *types.Block, bSta
 	return block, nil
 }
 
+// ExecuteBlock executes the transactions of block produced by another BP on
+// bState and checks the result against the block header, so that block can be
+// voted for before it is connected.
+func ExecuteBlock(block *types.Block, bState *state.BlockState, txOp TxOp) error {
+	txs := block.GetBody().GetTxs()
+
+	params, err := chainParams(bState, block.BlockNo())
+	if err != nil {
+		return err
+	}
+	if chain.BlockBodySize(block) > chain.MaxBlockBodySize(params) {
+		return errBlockSizeLimit
+	}
+
+	select {
+	case chain.InAddBlock <- struct{}{}:
+	default:
+		return errors.New("best block changed in chainservice")
+	}
+	defer func() {
+		<-chain.InAddBlock
+	}()
+
+	bState.SetBlockNo(block.BlockNo())
+	if bpID, err := block.BPID(); err == nil {
+		bState.SetBlockProducer([]byte(bpID))
+	}
+	if err := chain.SeedStakingTotal(bState, block.GetHeader().GetPrevBlockHash()); err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if err := txOp.Apply(bState, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := chain.SendRewardCoinbase(bState, block.GetHeader().GetCoinbaseAccount()); err != nil {
+		return err
+	}
+
+	if err := contract.SaveRecoveryPoint(bState); err != nil {
+		return err
+	}
+
+	if err := bState.Update(); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(block.GetHeader().GetBlocksRootHash(), bState.GetRoot()) {
+		return errors.New("state root hash mismatch")
+	}
+	if !bytes.Equal(block.GetHeader().GetReceiptsRootHash(), chain.ReceiptsRoot(bState.Receipts(), block.BlockNo())) {
+		return errors.New("receipts root hash mismatch")
+	}
+
+	return nil
+}
+
 // ConnectBlock send an AddBlock request to the chain service.
 func ConnectBlock(hs component.ICompSyncRequester, block *types.Block, blockState *state.BlockState) error {
 	// blockState does not include a valid BlockHash since it is constructed
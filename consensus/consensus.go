@@ -21,6 +21,10 @@ const (
 
 	// DefaultDposBpNumber is the default number of block producers.
 	DefaultDposBpNumber = 23
+
+	// DefaultDposEpoch is the default number of blocks in a DPoS election
+	// epoch.
+	DefaultDposEpoch = 1000
 )
 
 var (
@@ -41,6 +45,13 @@ func InitBlockInterval(blockIntervalSec int64) {
 	}
 }
 
+// ChainParams is the chain parameters effective at a block. The governance
+// may change them, so they are read from the state of the parent block.
+type ChainParams struct {
+	BlockIntervalSec int64
+	MaxBlockSize     uint32
+}
+
 // ErrorConsensus is a basic error struct for consensus modules.
 type ErrorConsensus struct {
 	Msg string
@@ -73,14 +84,29 @@ type ChainDbReader interface {
 	Get(key []byte) []byte
 }
 
-// ChainConsensus includes chainstatus and validation API.
+// ChainDB is a reader and writer interface for the ChainDB.
+type ChainDB interface {
+	ChainDbReader
+	NewTx() db.Transaction
+}
+
+// ChainConsensus includes chainstatus and validation API. IsBlockValid
+// checks block by params, the chain parameters effective at block.
 type ChainConsensus interface {
 	SetStateDB(sdb *state.ChainStateDB)
 	IsTransactionValid(tx *types.Tx) bool
-	IsBlockValid(block *types.Block, bestBlock *types.Block) error
-	Update(block *types.Block)
+	IsBlockValid(block *types.Block, bestBlock *types.Block, params *ChainParams) error
+	Update(block *types.Block) error
 	Save(tx db.Transaction) error
 	NeedReorganization(rootNo types.BlockNo) bool
+	LibNo() (types.BlockNo, bool)
+}
+
+// SystemStateReader reads the state of the system contract at a block. The
+// chain service implements it, so that the consensus of a light node, which
+// keeps no state, reads the vote result from a peer with the proofs.
+type SystemStateReader interface {
+	SystemState(block *types.Block) (*state.ContractState, error)
 }
 
 // BlockFactory is an interface for a block factory implementation.
@@ -0,0 +1,168 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var logger = log.NewLogger("bft")
+
+// BFT is a permissioned BFT consensus, where a fixed set of validators decide
+// each block by the rounds of propose, prevote and precommit exchanged over
+// p2p. A block is final as soon as it is committed by more than 2/3 of the
+// validators, whose precommits are kept in the block as a commit certificate.
+//
+// BFT is also registered as the consensus service receiving the proposals and
+// the votes from peers.
+type BFT struct {
+	*component.BaseComponent
+	vs   *validatorSet
+	bf   *BlockFactory
+	quit chan interface{}
+	ca   types.ChainAccessor
+}
+
+// New returns a new BFT consensus. The validators are the BPs of the genesis
+// info if any, or cfg.Consensus.BpIds. The messages of the validators are
+// signed with the chain ID of the genesis info.
+func New(cfg *config.Config, cdb consensus.ChainDbReader, hub *component.ComponentHub) (consensus.Consensus, error) {
+	var chainID []byte
+	if genesis := cdb.GetGenesisInfo(); genesis != nil {
+		chainID = genesis.ChainID()
+		if len(genesis.BPs) > 0 {
+			logger.Debug().Msg("use BPs from the genesis info")
+			cfg.Consensus.BpIds = genesis.BPs
+		}
+	}
+
+	consensus.InitBlockInterval(cfg.Consensus.BlockInterval)
+
+	vs, err := newValidatorSet(chainID, cfg.Consensus.BpIds)
+	if err != nil {
+		return nil, err
+	}
+
+	quitC := make(chan interface{})
+	bft := &BFT{
+		vs:   vs,
+		bf:   NewBlockFactory(hub, vs, quitC),
+		quit: quitC,
+	}
+	bft.BaseComponent = component.NewBaseComponent(message.ConsensusSvc, bft, logger)
+	hub.Register(bft)
+
+	return bft, nil
+}
+
+// BeforeStart does nothing.
+func (bft *BFT) BeforeStart() {}
+
+// AfterStart does nothing.
+func (bft *BFT) AfterStart() {}
+
+// BeforeStop does nothing.
+func (bft *BFT) BeforeStop() {}
+
+// Statistics returns nil.
+func (bft *BFT) Statistics() *map[string]interface{} {
+	return nil
+}
+
+// Receive passes the proposals and the votes of peers to the block factory.
+func (bft *BFT) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *types.BftProposal, *types.BftVote:
+		bft.bf.enqueue(msg)
+	}
+}
+
+// Ticker returns a time.Ticker for the main consensus loop.
+func (bft *BFT) Ticker() *time.Ticker {
+	return time.NewTicker(consensus.BlockInterval / 10)
+}
+
+// QueueJob send the current time to jq, which triggers the timeouts of the
+// rounds.
+func (bft *BFT) QueueJob(now time.Time, jq chan<- interface{}) {
+	select {
+	case jq <- now:
+	default:
+	}
+}
+
+// BlockFactory returns the BlockFactory interface in BFT.
+func (bft *BFT) BlockFactory() consensus.BlockFactory {
+	return bft.bf
+}
+
+// QuitChan returns the channel from which consensus-related goroutines check
+// when shutdown is initiated.
+func (bft *BFT) QuitChan() chan interface{} {
+	return bft.quit
+}
+
+// SetChainAccessor sets bft.ca to chainAccessor.
+func (bft *BFT) SetChainAccessor(chainAccessor types.ChainAccessor) {
+	bft.ca = chainAccessor
+	bft.bf.ca = chainAccessor
+}
+
+// SetStateDB sets sdb to the block factory. This method is called only once
+// during the boot sequence.
+func (bft *BFT) SetStateDB(sdb *state.ChainStateDB) {
+	bft.bf.sdb = sdb
+}
+
+// IsTransactionValid checks the BFT consensus level validity of a transaction.
+func (bft *BFT) IsTransactionValid(tx *types.Tx) bool {
+	return true
+}
+
+// IsBlockValid checks that block is produced by a validator and committed by
+// more than 2/3 of the validators.
+func (bft *BFT) IsBlockValid(block *types.Block, bestBlock *types.Block, params *consensus.ChainParams) error {
+	if err := bft.vs.verifyProducer(block); err != nil {
+		return err
+	}
+
+	return bft.vs.verifyCommit(block)
+}
+
+// Update lets the block factory start the consensus on the next block.
+func (bft *BFT) Update(block *types.Block) error {
+	bft.bf.enqueue(block)
+	return nil
+}
+
+// Save has nothing to do.
+func (bft *BFT) Save(tx db.Transaction) error {
+	return nil
+}
+
+// NeedReorganization returns false since a committed block is never reverted.
+func (bft *BFT) NeedReorganization(rootNo types.BlockNo) bool {
+	return false
+}
+
+// LibNo returns the number of the best block, which is irreversible once it
+// is committed.
+func (bft *BFT) LibNo() (types.BlockNo, bool) {
+	if best, _ := bft.ca.GetBestBlock(); best != nil {
+		return best.BlockNo(), true
+	}
+	return 0, false
+}
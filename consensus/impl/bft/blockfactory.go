@@ -0,0 +1,474 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"runtime"
+	"time"
+
+	bc "github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	jobQueueMax = 1000
+
+	// pendingMax is the maximum number of the messages for the next height,
+	// which are kept until the block of the current height is connected.
+	pendingMax = 100
+)
+
+type txExec struct {
+	execTx bc.TxExecFn
+}
+
+func newTxExec(blockNo types.BlockNo, ts int64) chain.TxOp {
+	// Block hash not determined yet
+	return &txExec{
+		execTx: bc.NewTxExecutor(blockNo, ts, contract.BlockFactory),
+	}
+}
+
+func (te *txExec) Apply(bState *state.BlockState, tx *types.Tx) error {
+	err := te.execTx(bState, tx)
+	return err
+}
+
+// BlockFactory runs the rounds of the BFT consensus. All the proposals, the
+// votes, the connected blocks and the ticks are handled one by one in Start,
+// which is the only goroutine accessing the consensus state.
+type BlockFactory struct {
+	*component.ComponentHub
+	jobQueue chan interface{}
+	quit     <-chan interface{}
+	vs       *validatorSet
+	id       peer.ID
+	privKey  crypto.PrivKey
+	txOp     chain.TxOp
+	sdb      *state.ChainStateDB
+	ca       types.ChainAccessor
+
+	best    *types.Block
+	hs      *heightState
+	pending []interface{}
+	bStates map[string]*state.BlockState
+}
+
+// NewBlockFactory returns a new BlockFactory.
+func NewBlockFactory(hub *component.ComponentHub, vs *validatorSet, quitC <-chan interface{}) *BlockFactory {
+	bf := &BlockFactory{
+		ComponentHub: hub,
+		jobQueue:     make(chan interface{}, jobQueueMax),
+		quit:         quitC,
+		vs:           vs,
+		id:           p2p.NodeID(),
+		privKey:      p2p.NodePrivKey(),
+	}
+
+	bf.txOp = chain.NewCompTxOp(
+		chain.TxOpFn(func(bState *state.BlockState, txIn *types.Tx) error {
+			select {
+			case <-bf.quit:
+				return chain.ErrQuit
+			default:
+				return nil
+			}
+		}),
+	)
+
+	return bf
+}
+
+// JobQueue returns the queue for the ticks of the consensus.
+func (bf *BlockFactory) JobQueue() chan<- interface{} {
+	return bf.jobQueue
+}
+
+// enqueue adds a job without blocking the caller. The job is dropped if the
+// queue is full, e.g. the block factory isn't started on a non-validator.
+func (bf *BlockFactory) enqueue(job interface{}) {
+	select {
+	case bf.jobQueue <- job:
+	default:
+	}
+}
+
+// Start runs the BFT consensus.
+func (bf *BlockFactory) Start() {
+	defer logger.Info().Msg("shutdown initiated. stop the service")
+
+	runtime.LockOSThread()
+
+	if !bf.vs.Has(bf.id) {
+		logger.Fatal().Str("id", enc.ToString([]byte(bf.id))).Msg("BP is not a validator of the BFT consensus")
+	}
+
+	best, err := bf.ca.GetBestBlock()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to get the best block")
+	}
+	bf.enterHeight(best)
+
+	for {
+		select {
+		case e := <-bf.jobQueue:
+			switch job := e.(type) {
+			case time.Time:
+				bf.onTick(job)
+			case *types.Block:
+				if job.BlockNo() >= bf.hs.height {
+					bf.enterHeight(job)
+				}
+			case *types.BftProposal:
+				bf.onProposal(job)
+			case *types.BftVote:
+				bf.onVote(job)
+			}
+		case <-bf.quit:
+			return
+		}
+	}
+}
+
+// roundTimeout returns the timeout of each step at round, which increases
+// with rounds so that the validators eventually catch up with each other.
+func roundTimeout(round uint32) time.Duration {
+	return consensus.BlockInterval * time.Duration(round+1)
+}
+
+func (bf *BlockFactory) enterHeight(best *types.Block) {
+	bf.best = best
+	bf.hs = newHeightState(best.BlockNo() + 1)
+	bf.bStates = make(map[string]*state.BlockState)
+	bf.startRound(0)
+
+	logger.Debug().Uint64("height", bf.hs.height).Str("best", best.ID()).Msg("start the consensus on block")
+
+	pending := bf.pending
+	bf.pending = nil
+	for _, msg := range pending {
+		switch m := msg.(type) {
+		case *types.BftProposal:
+			bf.onProposal(m)
+		case *types.BftVote:
+			bf.onVote(m)
+		}
+	}
+}
+
+func (bf *BlockFactory) startRound(round uint32) {
+	hs := bf.hs
+	hs.round = round
+	hs.step = stepPropose
+	hs.proposed = false
+	hs.deadline = bf.proposeTime().Add(roundTimeout(round))
+}
+
+// proposeTime returns the time to propose a block, which is one block
+// interval after the best block.
+func (bf *BlockFactory) proposeTime() time.Time {
+	t := time.Unix(0, bf.best.GetHeader().GetTimestamp()).Add(consensus.BlockInterval)
+	if now := time.Now(); t.Before(now) {
+		return now
+	}
+	return t
+}
+
+func (bf *BlockFactory) onTick(now time.Time) {
+	hs := bf.hs
+	switch hs.step {
+	case stepPropose:
+		if !hs.proposed && bf.vs.proposer(hs.height, hs.round) == bf.id && !now.Before(bf.proposeTime()) {
+			bf.propose()
+		} else if now.After(hs.deadline) {
+			bf.prevote()
+		}
+	case stepPrevote:
+		if now.After(hs.deadline) {
+			bf.precommit(nil)
+		}
+	case stepPrecommit:
+		if now.After(hs.deadline) {
+			bf.startRound(hs.round + 1)
+		}
+	case stepCommit:
+		// retry if the committed block is not connected yet
+		if now.After(hs.deadline) {
+			bf.connect(hs.locked)
+		}
+	}
+	bf.advance()
+}
+
+func (bf *BlockFactory) onProposal(proposal *types.BftProposal) {
+	hs := bf.hs
+	block := proposal.GetBlock()
+	if !bf.isCurrent(block.BlockNo(), proposal) {
+		return
+	}
+	round := proposal.GetRound()
+	if _, exist := hs.proposals[round]; exist || hs.rejected[round] {
+		return
+	}
+
+	if err := bf.vs.verifyProposal(proposal); err != nil {
+		logger.Info().Err(err).Uint64("no", block.BlockNo()).Msg("invalid proposal")
+		return
+	}
+
+	// An invalid block proposed by the right proposer is prevoted nil.
+	if err := bf.validateBlock(block); err != nil {
+		logger.Info().Err(err).Uint64("no", block.BlockNo()).Uint32("round", round).Str("hash", block.ID()).
+			Msg("invalid block proposed")
+		hs.rejected[round] = true
+	} else {
+		hs.proposals[round] = block
+	}
+	bf.advance()
+}
+
+// validateBlock checks that block follows the best block, is produced by a
+// validator and results in the state of its header. The resulting block state
+// is kept to connect block when it is committed.
+func (bf *BlockFactory) validateBlock(block *types.Block) error {
+	key := string(block.BlockHash())
+	if _, exist := bf.bStates[key]; exist {
+		return nil
+	}
+
+	if !bytes.Equal(block.GetHeader().GetPrevBlockHash(), bf.best.BlockHash()) {
+		return &consensus.ErrorConsensus{Msg: "block not following the best block"}
+	}
+	if !bytes.Equal(block.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(block.GetBody().GetTxs())) {
+		return &consensus.ErrorConsensus{Msg: "bad txs root hash"}
+	}
+	if err := bf.vs.verifyProducer(block); err != nil {
+		return err
+	}
+
+	blockState := bf.sdb.NewBlockState(bf.best.GetHeader().GetBlocksRootHash())
+	txOp := chain.NewCompTxOp(
+		bf.txOp,
+		newTxExec(block.BlockNo(), block.GetHeader().GetTimestamp()),
+	)
+	if err := chain.ExecuteBlock(block, blockState, txOp); err != nil {
+		return err
+	}
+	bf.bStates[key] = blockState
+
+	return nil
+}
+
+func (bf *BlockFactory) onVote(vote *types.BftVote) {
+	if !bf.isCurrent(vote.GetHeight(), vote) {
+		return
+	}
+
+	id, err := bf.vs.verifyVote(vote)
+	if err != nil {
+		logger.Info().Err(err).Uint64("height", vote.GetHeight()).Msg("invalid vote")
+		return
+	}
+
+	if bf.hs.votes(vote.GetType(), vote.GetRound()).add(id, vote) {
+		bf.advance()
+	}
+}
+
+// isCurrent reports whether msg is for the current height. The messages for
+// the next height are kept until the current block is connected.
+func (bf *BlockFactory) isCurrent(height types.BlockNo, msg interface{}) bool {
+	if height == bf.hs.height+1 && len(bf.pending) < pendingMax {
+		bf.pending = append(bf.pending, msg)
+	}
+	return height == bf.hs.height && bf.hs.step != stepCommit
+}
+
+// advance moves the consensus forward as long as the collected proposals and
+// votes allow.
+func (bf *BlockFactory) advance() {
+	for bf.advanceStep() {
+	}
+}
+
+func (bf *BlockFactory) advanceStep() bool {
+	hs := bf.hs
+	if hs.step == stepCommit {
+		return false
+	}
+
+	quorum := bf.vs.quorum()
+
+	// A block precommitted by a quorum is committed at any round.
+	if block, round, set := hs.committed(quorum); block != nil {
+		bf.commit(block, round, set)
+		return false
+	}
+
+	// Catch up with the later round, where a quorum has already voted.
+	for round, set := range hs.prevotes {
+		if round > hs.round && set.size() >= quorum {
+			bf.startRound(round)
+			return true
+		}
+	}
+	for round, set := range hs.precommits {
+		if round > hs.round && set.size() >= quorum {
+			bf.startRound(round)
+			return true
+		}
+	}
+
+	switch hs.step {
+	case stepPropose:
+		if _, exist := hs.proposals[hs.round]; exist || hs.rejected[hs.round] {
+			bf.prevote()
+			return true
+		}
+	case stepPrevote:
+		if hash, ok := hs.votes(types.BftPrevote, hs.round).majority(quorum); ok {
+			bf.precommit(hash)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (bf *BlockFactory) propose() {
+	hs := bf.hs
+	hs.proposed = true
+
+	// The locked block is proposed again to be decided.
+	hs.unlock(bf.vs.quorum())
+	block := hs.locked
+	if block == nil {
+		var err error
+		if block, err = bf.generateBlock(); err != nil {
+			logger.Info().Err(err).Msg("failed to produce block")
+			return
+		}
+	}
+
+	proposal, err := types.NewBftProposal(bf.vs.chainID, hs.round, block, bf.privKey)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sign proposal")
+		return
+	}
+	hs.proposals[hs.round] = block
+	bf.Tell(message.P2PSvc, &message.NotifyBftProposal{Proposal: proposal})
+
+	logger.Info().Uint64("no", block.BlockNo()).Uint32("round", hs.round).Str("id", block.ID()).
+		Msg("block proposed")
+}
+
+func (bf *BlockFactory) generateBlock() (*types.Block, error) {
+	ts := time.Now().UnixNano()
+	blockState := bf.sdb.NewBlockState(bf.best.GetHeader().GetBlocksRootHash())
+	blockState.SetBlockProducer([]byte(bf.id))
+
+	txOp := chain.NewCompTxOp(
+		bf.txOp,
+		newTxExec(bf.hs.height, ts),
+	)
+
+	block, err := chain.GenerateBlock(bf, bf.best, blockState, txOp, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	block.Header.ChainID = bf.vs.chainID
+	if err := block.Sign(bf.privKey); err != nil {
+		return nil, err
+	}
+	bf.bStates[string(block.BlockHash())] = blockState
+
+	return block, nil
+}
+
+// prevote votes for the locked block if any, or the valid block proposed at
+// the current round. The locked block is released first by a later polka.
+func (bf *BlockFactory) prevote() {
+	hs := bf.hs
+
+	hs.unlock(bf.vs.quorum())
+	bf.vote(types.BftPrevote, hs.prevoteHash())
+	hs.step = stepPrevote
+	hs.deadline = time.Now().Add(roundTimeout(hs.round))
+}
+
+// precommit votes for the block of hash, which is prevoted by a quorum, and
+// locks it. A nil hash unlocks the locked block if a quorum has prevoted nil.
+func (bf *BlockFactory) precommit(hash []byte) {
+	hs := bf.hs
+
+	if hash != nil {
+		if block := hs.block(hash); block != nil {
+			hs.lock(block, hs.round)
+		} else {
+			// the block is never proposed to this validator
+			hash = nil
+		}
+	} else {
+		hs.unlock(bf.vs.quorum())
+	}
+
+	bf.vote(types.BftPrecommit, hash)
+	hs.step = stepPrecommit
+	hs.deadline = time.Now().Add(roundTimeout(hs.round))
+}
+
+func (bf *BlockFactory) vote(kind uint32, hash []byte) {
+	hs := bf.hs
+
+	vote, err := types.NewBftVote(bf.vs.chainID, kind, hs.height, hs.round, hash, bf.privKey)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sign vote")
+		return
+	}
+	hs.votes(kind, hs.round).add(bf.id, vote)
+	bf.Tell(message.P2PSvc, &message.NotifyBftVote{Vote: vote})
+}
+
+// commit connects block with the precommits of set as its commit certificate.
+// The consensus on the next height starts when the chain service updates the
+// consensus with the block.
+func (bf *BlockFactory) commit(block *types.Block, round uint32, set *voteSet) {
+	hs := bf.hs
+	block.Commit = &types.BftCommit{Round: round, Votes: set.votesFor(block.BlockHash())}
+	hs.lock(block, round)
+	hs.step = stepCommit
+
+	logger.Info().Uint64("no", block.BlockNo()).Uint32("round", round).Str("id", block.ID()).
+		Int("precommits", len(block.Commit.Votes)).Msg("block committed")
+
+	bf.connect(block)
+}
+
+func (bf *BlockFactory) connect(block *types.Block) {
+	bf.hs.deadline = time.Now().Add(roundTimeout(0))
+
+	// The block state of the produced block is used only once, and the block
+	// is executed again on retry.
+	key := string(block.BlockHash())
+	blockState := bf.bStates[key]
+	delete(bf.bStates, key)
+
+	if err := chain.ConnectBlock(bf, block, blockState); err != nil {
+		logger.Error().Err(err).Msg("failed to connect the committed block")
+	}
+}
@@ -0,0 +1,186 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+// step is the step of a round.
+type step int
+
+const (
+	stepPropose step = iota
+	stepPrevote
+	stepPrecommit
+	stepCommit
+)
+
+// voteSet is the set of the votes of a type at a round.
+type voteSet struct {
+	votes map[peer.ID]*types.BftVote
+	count map[string]int
+}
+
+func newVoteSet() *voteSet {
+	return &voteSet{
+		votes: make(map[peer.ID]*types.BftVote),
+		count: make(map[string]int),
+	}
+}
+
+// add adds vote cast by id. It reports false if id has already voted.
+func (s *voteSet) add(id peer.ID, vote *types.BftVote) bool {
+	if _, exist := s.votes[id]; exist {
+		return false
+	}
+	s.votes[id] = vote
+	s.count[string(vote.GetBlockHash())]++
+
+	return true
+}
+
+// size returns the number of the validators who voted.
+func (s *voteSet) size() int {
+	return len(s.votes)
+}
+
+// majority returns the block hash voted by quorum. The hash is nil for the
+// votes for no block.
+func (s *voteSet) majority(quorum int) ([]byte, bool) {
+	for hash, n := range s.count {
+		if n >= quorum {
+			if len(hash) == 0 {
+				return nil, true
+			}
+			return []byte(hash), true
+		}
+	}
+	return nil, false
+}
+
+// votesFor returns the votes for the block of hash.
+func (s *voteSet) votesFor(hash []byte) []*types.BftVote {
+	var votes []*types.BftVote
+	for _, vote := range s.votes {
+		if bytes.Equal(vote.GetBlockHash(), hash) {
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
+// heightState is the state of the consensus on the block of a height.
+type heightState struct {
+	height   types.BlockNo
+	round    uint32
+	step     step
+	deadline time.Time
+	proposed bool
+
+	proposals  map[uint32]*types.Block
+	prevotes   map[uint32]*voteSet
+	precommits map[uint32]*voteSet
+
+	// rejected is the rounds where an invalid block is proposed, which is
+	// prevoted nil.
+	rejected map[uint32]bool
+
+	// locked is the block precommitted by this validator at lockedRound.
+	// Only locked is prevoted until a quorum prevotes for another block or
+	// for no block at a later round (a proof of lock change).
+	locked      *types.Block
+	lockedRound uint32
+}
+
+func newHeightState(height types.BlockNo) *heightState {
+	return &heightState{
+		height:     height,
+		proposals:  make(map[uint32]*types.Block),
+		prevotes:   make(map[uint32]*voteSet),
+		precommits: make(map[uint32]*voteSet),
+		rejected:   make(map[uint32]bool),
+	}
+}
+
+// votes returns the votes of kind at round.
+func (hs *heightState) votes(kind uint32, round uint32) *voteSet {
+	sets := hs.prevotes
+	if kind == types.BftPrecommit {
+		sets = hs.precommits
+	}
+
+	set, exist := sets[round]
+	if !exist {
+		set = newVoteSet()
+		sets[round] = set
+	}
+	return set
+}
+
+// block returns the proposed block of hash, or nil if it is not proposed.
+func (hs *heightState) block(hash []byte) *types.Block {
+	for _, block := range hs.proposals {
+		if bytes.Equal(block.BlockHash(), hash) {
+			return block
+		}
+	}
+	return nil
+}
+
+// lock locks block precommitted at round.
+func (hs *heightState) lock(block *types.Block, round uint32) {
+	hs.locked = block
+	hs.lockedRound = round
+}
+
+// unlock releases the locked block if a quorum has prevoted for another block
+// or for no block at a round after the lock, up to the current round.
+func (hs *heightState) unlock(quorum int) {
+	if hs.locked == nil {
+		return
+	}
+
+	for round, set := range hs.prevotes {
+		if round <= hs.lockedRound || round > hs.round {
+			continue
+		}
+		if hash, ok := set.majority(quorum); ok && !bytes.Equal(hash, hs.locked.BlockHash()) {
+			hs.locked = nil
+			return
+		}
+	}
+}
+
+// prevoteHash returns the hash of the block to prevote at the current round:
+// the locked block if any, or the valid block proposed at the round. It is nil
+// if no valid block is proposed.
+func (hs *heightState) prevoteHash() []byte {
+	if hs.locked != nil {
+		return hs.locked.BlockHash()
+	}
+	if block, exist := hs.proposals[hs.round]; exist {
+		return block.BlockHash()
+	}
+	return nil
+}
+
+// committed returns the block precommitted by a quorum at any round, along
+// with the round and its precommits. The block is nil if none is committed.
+func (hs *heightState) committed(quorum int) (*types.Block, uint32, *voteSet) {
+	for round, set := range hs.precommits {
+		if hash, ok := set.majority(quorum); ok && hash != nil {
+			if block := hs.block(hash); block != nil {
+				return block, round, set
+			}
+		}
+	}
+	return nil, 0, nil
+}
@@ -0,0 +1,116 @@
+package bft
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func addVotes(t *testing.T, vs *validatorSet, hs *heightState, votes []*types.BftVote) {
+	for _, vote := range votes {
+		id, err := vs.verifyVote(vote)
+		assert.NoError(t, err)
+		hs.votes(vote.GetType(), vote.GetRound()).add(id, vote)
+	}
+}
+
+func newTestBlocks(t *testing.T, key crypto.PrivKey) (*types.Block, *types.Block) {
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	a := types.NewBlock(genesis, []byte("a"), nil, nil, nil, 1)
+	b := types.NewBlock(genesis, []byte("b"), nil, nil, nil, 2)
+	assert.NoError(t, a.Sign(key))
+	assert.NoError(t, b.Sign(key))
+	return a, b
+}
+
+func TestPrevoteHash(t *testing.T) {
+	_, keys := newTestValidators(t, 4)
+	a, b := newTestBlocks(t, keys[0])
+	hs := newHeightState(1)
+
+	// nothing proposed or an invalid block proposed
+	assert.Nil(t, hs.prevoteHash())
+	hs.rejected[0] = true
+	assert.Nil(t, hs.prevoteHash())
+
+	hs.round = 1
+	hs.proposals[1] = a
+	assert.Equal(t, a.BlockHash(), hs.prevoteHash())
+
+	// the locked block is prevoted whatever is proposed
+	hs.lock(b, 0)
+	assert.Equal(t, b.BlockHash(), hs.prevoteHash())
+}
+
+func TestUnlock(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+	a, b := newTestBlocks(t, keys[0])
+	hs := newHeightState(1)
+	hs.proposals[0] = a
+	hs.proposals[1] = b
+
+	hs.lock(a, 1)
+	hs.round = 3
+
+	// a polka at or before the locked round keeps the lock
+	addVotes(t, vs, hs, prevotes(t, keys[:3], 1, 0, b.BlockHash()))
+	addVotes(t, vs, hs, prevotes(t, keys[:3], 1, 1, nil))
+	hs.unlock(vs.quorum())
+	assert.Equal(t, a, hs.locked)
+
+	// a polka for the locked block keeps the lock
+	addVotes(t, vs, hs, prevotes(t, keys[:3], 1, 2, a.BlockHash()))
+	hs.unlock(vs.quorum())
+	assert.Equal(t, a, hs.locked)
+
+	// votes short of a quorum keep the lock
+	addVotes(t, vs, hs, prevotes(t, keys[:2], 1, 3, b.BlockHash()))
+	hs.unlock(vs.quorum())
+	assert.Equal(t, a, hs.locked)
+
+	// a polka at a round later than the current round is ignored
+	addVotes(t, vs, hs, prevotes(t, keys[:3], 1, 4, nil))
+	hs.unlock(vs.quorum())
+	assert.Equal(t, a, hs.locked)
+
+	// a polka for another block after the locked round unlocks
+	addVotes(t, vs, hs, prevotes(t, keys[2:3], 1, 3, b.BlockHash()))
+	hs.unlock(vs.quorum())
+	assert.Nil(t, hs.locked)
+
+	// a polka for no block after the locked round unlocks
+	hs.lock(a, 3)
+	hs.round = 4
+	hs.unlock(vs.quorum())
+	assert.Nil(t, hs.locked)
+}
+
+func TestCommitted(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+	a, b := newTestBlocks(t, keys[0])
+	hs := newHeightState(1)
+	hs.round = 2
+
+	// a quorum for no block or short of a quorum commits nothing
+	addVotes(t, vs, hs, precommits(t, keys[:3], 1, 0, nil))
+	addVotes(t, vs, hs, precommits(t, keys[:2], 1, 1, a.BlockHash()))
+	block, _, _ := hs.committed(vs.quorum())
+	assert.Nil(t, block)
+
+	// a block never proposed to this validator is not committed
+	addVotes(t, vs, hs, precommits(t, keys[:3], 1, 3, b.BlockHash()))
+	block, _, _ = hs.committed(vs.quorum())
+	assert.Nil(t, block)
+
+	// a block is committed at a round other than the current round
+	hs.proposals[3] = b
+	block, round, set := hs.committed(vs.quorum())
+	assert.Equal(t, b, block)
+	assert.Equal(t, uint32(3), round)
+	assert.Len(t, set.votesFor(b.BlockHash()), 3)
+
+	b.Commit = &types.BftCommit{Round: round, Votes: set.votesFor(b.BlockHash())}
+	assert.NoError(t, vs.verifyCommit(b))
+}
@@ -0,0 +1,162 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+// validatorSet is the set of the validators, which propose blocks in turn and
+// vote for them.
+type validatorSet struct {
+	*bp.Cluster
+	size    uint16
+	chainID []byte
+}
+
+func newValidatorSet(chainID []byte, ids []string) (*validatorSet, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no validators for the BFT consensus")
+	}
+
+	c, err := bp.NewCluster(ids, uint16(len(ids)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatorSet{Cluster: c, size: uint16(len(ids)), chainID: chainID}, nil
+}
+
+// quorum returns the number of the votes required for a decision, which is
+// more than 2/3 of the validators.
+func (vs *validatorSet) quorum() int {
+	return int(vs.size)*2/3 + 1
+}
+
+// proposer returns the ID of the validator proposing a block at round of
+// height.
+func (vs *validatorSet) proposer(height types.BlockNo, round uint32) peer.ID {
+	idx := (height + types.BlockNo(round)) % types.BlockNo(vs.size)
+	id, _ := vs.BpIndex2ID(uint16(idx))
+	return id
+}
+
+// verifyVote returns the validator who cast vote after checking its signature.
+func (vs *validatorSet) verifyVote(vote *types.BftVote) (peer.ID, error) {
+	if kind := vote.GetType(); kind != types.BftPrevote && kind != types.BftPrecommit {
+		return peer.ID(""), &consensus.ErrorConsensus{Msg: fmt.Sprintf("unknown vote type %d", kind)}
+	}
+
+	id, err := vote.Validator()
+	if err != nil {
+		return peer.ID(""), &consensus.ErrorConsensus{Msg: "bad public key in vote", Err: err}
+	}
+
+	if !vs.Has(id) {
+		return peer.ID(""), &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("vote from %v, which is not a validator", enc.ToString([]byte(id))),
+		}
+	}
+
+	if valid, err := vote.VerifySign(vs.chainID); !valid {
+		return peer.ID(""), &consensus.ErrorConsensus{Msg: "bad vote signature", Err: err}
+	}
+
+	return id, nil
+}
+
+// verifyProducer checks that block is signed by a validator for the chain.
+func (vs *validatorSet) verifyProducer(block *types.Block) error {
+	if !bytes.Equal(block.GetHeader().GetChainID(), vs.chainID) {
+		return &consensus.ErrorConsensus{Msg: "block of another chain"}
+	}
+
+	id, err := block.BPID()
+	if err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad public key in block", Err: err}
+	}
+
+	if !vs.Has(id) {
+		return &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("BP %v is not a validator", block.BPID2Str()),
+		}
+	}
+
+	if valid, err := block.VerifySign(); !valid {
+		return &consensus.ErrorConsensus{Msg: "bad block signature", Err: err}
+	}
+
+	return nil
+}
+
+// verifyProposal checks that proposal is signed by the proposer of its round.
+// The proposed block may be produced by another validator at an earlier round,
+// and it is validated separately so that an invalid block is prevoted nil.
+func (vs *validatorSet) verifyProposal(proposal *types.BftProposal) error {
+	block := proposal.GetBlock()
+	if block.GetHeader() == nil {
+		return &consensus.ErrorConsensus{Msg: "no block in proposal"}
+	}
+
+	id, err := proposal.Proposer()
+	if err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad public key in proposal", Err: err}
+	}
+
+	if id != vs.proposer(block.BlockNo(), proposal.GetRound()) {
+		return &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("%v is not permitted to propose at round %d of block %d",
+				enc.ToString([]byte(id)), proposal.GetRound(), block.BlockNo()),
+		}
+	}
+
+	if valid, err := proposal.VerifySign(vs.chainID); !valid {
+		return &consensus.ErrorConsensus{Msg: "bad proposal signature", Err: err}
+	}
+
+	return nil
+}
+
+// verifyCommit checks the commit certificate of block, which must include the
+// precommits of a quorum for block at the same round.
+func (vs *validatorSet) verifyCommit(block *types.Block) error {
+	commit := block.GetCommit()
+	if commit == nil {
+		return &consensus.ErrorConsensus{Msg: "no commit certificate in block"}
+	}
+
+	hash := block.BlockHash()
+	voted := make(map[peer.ID]bool)
+	for _, vote := range commit.GetVotes() {
+		if vote.GetType() != types.BftPrecommit || vote.GetHeight() != block.BlockNo() ||
+			vote.GetRound() != commit.GetRound() || !bytes.Equal(vote.GetBlockHash(), hash) {
+			return &consensus.ErrorConsensus{Msg: "vote not for the block in commit certificate"}
+		}
+
+		id, err := vs.verifyVote(vote)
+		if err != nil {
+			return err
+		}
+		voted[id] = true
+	}
+
+	if len(voted) < vs.quorum() {
+		return &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("insufficient precommits in commit certificate: %d (required: %d)",
+				len(voted), vs.quorum()),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package bft
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+var testChainID = types.GetTestGenesis().ChainID()
+
+func newTestValidators(t *testing.T, n int) (*validatorSet, []crypto.PrivKey) {
+	keys := make([]crypto.PrivKey, n)
+	ids := make([]string, n)
+	for i := 0; i < n; i++ {
+		var err error
+		keys[i], _, err = crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		assert.NoError(t, err)
+		id, err := peer.IDFromPublicKey(keys[i].GetPublic())
+		assert.NoError(t, err)
+		ids[i] = peer.IDB58Encode(id)
+	}
+
+	vs, err := newValidatorSet(testChainID, ids)
+	assert.NoError(t, err)
+
+	return vs, keys
+}
+
+func newVotes(t *testing.T, kind uint32, keys []crypto.PrivKey, height types.BlockNo, round uint32, hash []byte) []*types.BftVote {
+	votes := make([]*types.BftVote, len(keys))
+	for i, key := range keys {
+		var err error
+		votes[i], err = types.NewBftVote(testChainID, kind, height, round, hash, key)
+		assert.NoError(t, err)
+	}
+	return votes
+}
+
+func precommits(t *testing.T, keys []crypto.PrivKey, height types.BlockNo, round uint32, hash []byte) []*types.BftVote {
+	return newVotes(t, types.BftPrecommit, keys, height, round, hash)
+}
+
+func prevotes(t *testing.T, keys []crypto.PrivKey, height types.BlockNo, round uint32, hash []byte) []*types.BftVote {
+	return newVotes(t, types.BftPrevote, keys, height, round, hash)
+}
+
+func TestVerifyCommit(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+	assert.Equal(t, 3, vs.quorum())
+
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block := types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	block.Header.ChainID = testChainID
+	assert.NoError(t, block.Sign(keys[0]))
+	assert.NoError(t, vs.verifyProducer(block))
+	hash := block.BlockHash()
+
+	// no certificate
+	assert.Error(t, vs.verifyCommit(block))
+
+	block.Commit = &types.BftCommit{Round: 1, Votes: precommits(t, keys[:3], 1, 1, hash)}
+	assert.NoError(t, vs.verifyCommit(block))
+
+	// a vote counts once
+	votes := block.Commit.Votes
+	block.Commit.Votes = append(votes[:2], votes[0])
+	assert.Error(t, vs.verifyCommit(block))
+
+	// votes at another round
+	block.Commit = &types.BftCommit{Round: 0, Votes: precommits(t, keys[:3], 1, 1, hash)}
+	assert.Error(t, vs.verifyCommit(block))
+
+	// votes for another block
+	block.Commit = &types.BftCommit{Round: 1, Votes: precommits(t, keys[:3], 1, 1, genesis.BlockHash())}
+	assert.Error(t, vs.verifyCommit(block))
+
+	// votes of a non-validator
+	outsider, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	block.Commit = &types.BftCommit{Round: 1, Votes: precommits(t, []crypto.PrivKey{keys[0], keys[1], outsider}, 1, 1, hash)}
+	assert.Error(t, vs.verifyCommit(block))
+}
+
+func TestVerifyProposal(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block := types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	assert.NoError(t, block.Sign(keys[0]))
+
+	for round := uint32(0); round < 4; round++ {
+		for i, key := range keys {
+			proposal, err := types.NewBftProposal(testChainID, round, block, key)
+			assert.NoError(t, err)
+
+			id, _ := peer.IDFromPublicKey(key.GetPublic())
+			if id == vs.proposer(1, round) {
+				assert.NoError(t, vs.verifyProposal(proposal), "validator %d at round %d", i, round)
+			} else {
+				assert.Error(t, vs.verifyProposal(proposal), "validator %d at round %d", i, round)
+			}
+		}
+	}
+}
+
+func TestVoteSet(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+	set := newVoteSet()
+
+	votes := precommits(t, keys[:2], 1, 0, []byte("block"))
+	votes = append(votes, precommits(t, keys[2:], 1, 0, nil)...)
+	for _, vote := range votes {
+		id, err := vs.verifyVote(vote)
+		assert.NoError(t, err)
+		assert.True(t, set.add(id, vote))
+		assert.False(t, set.add(id, vote))
+	}
+	assert.Equal(t, 4, set.size())
+
+	_, ok := set.majority(vs.quorum())
+	assert.False(t, ok)
+	hash, ok := set.majority(2)
+	assert.True(t, ok)
+	assert.Len(t, set.votesFor(hash), 2)
+}
+
+func TestVerifyChainID(t *testing.T) {
+	vs, keys := newTestValidators(t, 4)
+	otherChainID := []byte("other chain")
+
+	vote, err := types.NewBftVote(testChainID, types.BftPrevote, 1, 0, []byte("block"), keys[0])
+	assert.NoError(t, err)
+	_, err = vs.verifyVote(vote)
+	assert.NoError(t, err)
+
+	// a vote replayed from another chain
+	vote, err = types.NewBftVote(otherChainID, types.BftPrevote, 1, 0, []byte("block"), keys[0])
+	assert.NoError(t, err)
+	_, err = vs.verifyVote(vote)
+	assert.Error(t, err)
+
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block := types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	assert.NoError(t, block.Sign(keys[0]))
+
+	id, _ := peer.IDFromPublicKey(keys[0].GetPublic())
+	for round := uint32(0); round < 4; round++ {
+		if vs.proposer(1, round) != id {
+			continue
+		}
+		proposal, err := types.NewBftProposal(otherChainID, round, block, keys[0])
+		assert.NoError(t, err)
+		assert.Error(t, vs.verifyProposal(proposal))
+	}
+
+	// a block of another chain
+	block = types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	block.Header.ChainID = otherChainID
+	assert.NoError(t, block.Sign(keys[0]))
+	assert.Error(t, vs.verifyProducer(block))
+
+	// a block without the chain ID
+	block = types.NewBlock(genesis, []byte("root"), nil, nil, nil, 1)
+	assert.NoError(t, block.Sign(keys[0]))
+	assert.Error(t, vs.verifyProducer(block))
+}
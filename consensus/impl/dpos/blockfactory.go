@@ -45,28 +45,26 @@ func (te *txExec) Apply(bState *state.BlockState, tx *types.Tx) error {
 // BlockFactory is the main data structure for DPoS block factory.
 type BlockFactory struct {
 	*component.ComponentHub
-	jobQueue         chan interface{}
-	workerQueue      chan *bpInfo
-	bpTimeoutC       chan interface{}
-	quit             <-chan interface{}
-	maxBlockBodySize uint32
-	ID               string
-	privKey          crypto.PrivKey
-	txOp             chain.TxOp
-	sdb              *state.ChainStateDB
+	jobQueue    chan interface{}
+	workerQueue chan *bpInfo
+	bpTimeoutC  chan interface{}
+	quit        <-chan interface{}
+	ID          string
+	privKey     crypto.PrivKey
+	txOp        chain.TxOp
+	sdb         *state.ChainStateDB
 }
 
 // NewBlockFactory returns a new BlockFactory
 func NewBlockFactory(hub *component.ComponentHub, quitC <-chan interface{}) *BlockFactory {
 	bf := &BlockFactory{
-		ComponentHub:     hub,
-		jobQueue:         make(chan interface{}, slotQueueMax),
-		workerQueue:      make(chan *bpInfo),
-		bpTimeoutC:       make(chan interface{}, 1),
-		maxBlockBodySize: chain.MaxBlockBodySize(),
-		quit:             quitC,
-		ID:               p2p.NodeSID(),
-		privKey:          p2p.NodePrivKey(),
+		ComponentHub: hub,
+		jobQueue:     make(chan interface{}, slotQueueMax),
+		workerQueue:  make(chan *bpInfo),
+		bpTimeoutC:   make(chan interface{}, 1),
+		quit:         quitC,
+		ID:           p2p.NodeSID(),
+		privKey:      p2p.NodePrivKey(),
 	}
 
 	bf.txOp = chain.NewCompTxOp(
@@ -191,6 +189,8 @@ func (bf *BlockFactory) generateBlock(bpi *bpInfo, lpbNo types.BlockNo) (*types.
 	ts := bpi.slot.UnixNano()
 
 	blockState := bf.sdb.NewBlockState(bpi.bestBlock.GetHeader().GetBlocksRootHash())
+	// the voters of this BP share the reward of the block
+	blockState.SetBlockProducer([]byte(p2p.NodeID()))
 
 	txOp := chain.NewCompTxOp(
 		bf.txOp,
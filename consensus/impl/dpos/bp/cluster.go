@@ -62,6 +62,11 @@ func newBlockProducer(id peer.ID) *blockProducer {
 	return &blockProducer{id: id}
 }
 
+// Size returns the number of the block producers in c.
+func (c *Cluster) Size() uint16 {
+	return c.size
+}
+
 // BpIndex2ID returns the ID correspinding to idx.
 func (c *Cluster) BpIndex2ID(idx uint16) (peer.ID, bool) {
 	if bp, exist := c.member[idx]; exist {
@@ -6,15 +6,18 @@
 package dpos
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
-	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
 	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
-	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
@@ -26,6 +29,9 @@ import (
 var (
 	logger = log.NewLogger("dpos")
 
+	errNoStateDB       = errors.New("no state DB")
+	errNoBlockInterval = errors.New("no block interval")
+
 	// blockProducers is the number of block producers
 	blockProducers        uint16
 	defaultConsensusCount uint16
@@ -33,14 +39,21 @@ var (
 	lastJob *slot.Slot
 )
 
+// detectorSize is the number of the recent blocks checked for the double
+// signing.
+const detectorSize = 1000
+
 // DPoS is the main data structure of DPoS consensus
 type DPoS struct {
 	*Status
 	*component.ComponentHub
-	bpc  *bp.Cluster
+	bps  *bpSchedule
 	bf   *BlockFactory
 	quit chan interface{}
 	ca   types.ChainAccessor
+	sdb  *state.ChainStateDB
+
+	detector *evidence.Detector
 }
 
 // Status shows DPoS consensus's current status
@@ -65,19 +78,25 @@ func New(cfg *config.Config, cdb consensus.ChainDbReader, hub *component.Compone
 
 	Init(cfg.Consensus)
 
-	bpc, err := bp.NewCluster(cfg.Consensus.BpIds, blockProducers)
+	bps, err := newBpSchedule(cfg.Consensus.BpIds, cdb)
 	if err != nil {
 		return nil, err
 	}
 
+	status := NewStatus(defaultConsensusCount, cdb)
+	if bsLoader != nil {
+		bps.restore(bsLoader.bps)
+	}
+
 	quitC := make(chan interface{})
 
 	return &DPoS{
-		Status:       NewStatus(defaultConsensusCount, cdb),
+		Status:       status,
 		ComponentHub: hub,
-		bpc:          bpc,
+		bps:          bps,
 		bf:           NewBlockFactory(hub, quitC),
 		quit:         quitC,
+		detector:     evidence.NewDetector(detectorSize),
 	}, nil
 }
 
@@ -86,7 +105,8 @@ func Init(cfg *config.ConsensusConfig) {
 	consensus.InitBlockInterval(cfg.BlockInterval)
 
 	blockProducers = cfg.DposBpNumber
-	defaultConsensusCount = blockProducers*2/3 + 1
+	epochBlocks = cfg.DposEpoch
+	defaultConsensusCount = confirmsRequired(blockProducers)
 	slot.Init(cfg.BlockInterval, blockProducers)
 }
 
@@ -94,6 +114,33 @@ func consensusBlockCount() uint64 {
 	return uint64(defaultConsensusCount)
 }
 
+// confirmsRequired returns the number of the confirmations by the BPs
+// required for a block to be irreversible, when there are bps BPs.
+func confirmsRequired(bps uint16) uint16 {
+	return bps*2/3 + 1
+}
+
+// updateSlot reinitializes the slots of this BP by the block interval
+// effective at the block next to best, which the governance may change.
+func (dpos *DPoS) updateSlot(best *types.Block) error {
+	scs, err := dpos.systemState(best)
+	if err != nil {
+		return err
+	}
+	interval, err := system.GetParamOrDefault(scs, system.ParamBlockInterval, best.BlockNo()+1)
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		return errNoBlockInterval
+	}
+	if int64(interval) != slot.BlockIntervalSec() {
+		logger.Info().Uint64("interval", interval).Uint64("no", best.BlockNo()+1).Msg("block interval changed")
+		slot.Init(int64(interval), blockProducers)
+	}
+	return nil
+}
+
 // Ticker returns a time.Ticker for the main consensus loop.
 func (dpos *DPoS) Ticker() *time.Ticker {
 	return time.NewTicker(consensus.BlockInterval / 100)
@@ -122,6 +169,94 @@ func (dpos *DPoS) SetChainAccessor(chainAccessor types.ChainAccessor) {
 // called only once during the boot sequence.
 func (dpos *DPoS) SetStateDB(sdb *state.ChainStateDB) {
 	dpos.bf.sdb = sdb
+	dpos.sdb = sdb
+}
+
+// Update updates the LIB status by block. If block is an election block, the
+// BPs of the next epoch are elected by the vote result at block.
+func (dpos *DPoS) Update(block *types.Block) error {
+	if epoch, ok := electedEpoch(block.BlockNo()); ok {
+		if err := dpos.elect(block, epoch); err != nil {
+			logger.Error().Err(err).Uint64("no", block.BlockNo()).Uint64("epoch", epoch).
+				Msg("failed to elect BPs")
+			return err
+		}
+	}
+
+	dpos.Status.setConfirmsRequired(confirmsRequired(dpos.bps.cluster(block.BlockNo()).Size()))
+	dpos.Status.Update(block)
+
+	return nil
+}
+
+// Save saves the LIB status and the BPs elected.
+func (dpos *DPoS) Save(tx db.Transaction) error {
+	if err := dpos.Status.Save(tx); err != nil {
+		return err
+	}
+	return dpos.bps.save(tx)
+}
+
+// elect sets the BPs of epoch to the candidates with the most votes at block.
+// The number of the BPs is the BP count effective at the first block of
+// epoch. The current BPs remain only if the candidates are insufficient. An
+// error is returned if the vote result is unavailable, since the BPs would
+// differ from the other nodes.
+func (dpos *DPoS) elect(block *types.Block, epoch uint64) error {
+	scs, err := dpos.systemState(block)
+	if err != nil {
+		return err
+	}
+	votes, err := system.GetVoteResult(scs)
+	if err != nil {
+		return err
+	}
+	count, err := bpCount(scs, epoch)
+	if err != nil {
+		return err
+	}
+
+	ids := dpos.bps.ids(block.BlockNo())
+	if elected := electBPs(votes, count); elected != nil {
+		ids = elected
+	} else {
+		logger.Info().Uint64("no", block.BlockNo()).Msg("insufficient candidates. keep the current BPs")
+	}
+
+	if err := dpos.bps.set(epoch, ids); err != nil {
+		return err
+	}
+
+	logger.Info().Uint64("epoch", epoch).Uint64("election block", block.BlockNo()).
+		Strs("BPs", ids).Msg("BPs elected")
+
+	return nil
+}
+
+// bpCount returns the number of the BPs elected for epoch, which may be
+// changed by the governance from the first block of epoch.
+func bpCount(scs *state.ContractState, epoch uint64) (uint16, error) {
+	count, changed, err := system.GetParam(scs, system.ParamBpCount, epoch*epochBlocks)
+	if err != nil {
+		return 0, err
+	}
+	if !changed {
+		return blockProducers, nil
+	}
+	return uint16(count), nil
+}
+
+// systemState returns the state of the system contract at block.
+func (dpos *DPoS) systemState(block *types.Block) (*state.ContractState, error) {
+	if reader, ok := dpos.ca.(consensus.SystemStateReader); ok {
+		return reader.SystemState(block)
+	}
+	if dpos.sdb == nil {
+		return nil, errNoStateDB
+	}
+
+	states := dpos.sdb.OpenNewStateDB(block.GetHeader().GetBlocksRootHash())
+	return states.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 }
 
 // IsTransactionValid checks the DPoS consensus level validity of a transaction
@@ -140,22 +275,27 @@ func (dpos *DPoS) bpid() peer.ID {
 	return p2p.NodeID()
 }
 
-// IsBlockValid checks the DPoS consensus level validity of a block
-func (dpos *DPoS) IsBlockValid(block *types.Block, bestBlock *types.Block) error {
+// IsBlockValid checks the DPoS consensus level validity of a block. The slot
+// of block is that of the block interval in params.
+func (dpos *DPoS) IsBlockValid(block *types.Block, bestBlock *types.Block, params *consensus.ChainParams) error {
 	id, err := block.BPID()
 	if err != nil {
 		return &consensus.ErrorConsensus{Msg: "bad public key in block", Err: err}
 	}
+	if params.BlockIntervalSec <= 0 {
+		return &consensus.ErrorConsensus{Msg: "invalid chain parameters", Err: errNoBlockInterval}
+	}
 
 	ns := block.GetHeader().GetTimestamp()
-	idx, ok := dpos.bpc.BpID2Index(id)
-	s := slot.NewFromUnixNano(ns)
+	bpc := dpos.bps.cluster(block.BlockNo())
+	idx, ok := bpc.BpID2Index(id)
+	bpIdx := slot.IndexAt(ns, params.BlockIntervalSec) % int64(bpc.Size())
 	// Check whether the BP ID is one of the current BP members and its
 	// corresponding BP index is consistent with the block timestamp.
-	if !ok || !s.IsFor(idx) {
+	if !ok || bpIdx != int64(idx) {
 		return &consensus.ErrorConsensus{
 			Msg: fmt.Sprintf("BP %v (idx: %v) is not permitted for the time slot %v (%v)",
-				block.BPID2Str(), idx, time.Unix(0, ns), s.NextBpIndex()),
+				block.BPID2Str(), idx, time.Unix(0, ns), bpIdx),
 		}
 	}
 
@@ -164,40 +304,42 @@ func (dpos *DPoS) IsBlockValid(block *types.Block, bestBlock *types.Block) error
 		return &consensus.ErrorConsensus{Msg: "bad block signature", Err: err}
 	}
 
-	return nil
-}
-
-func (dpos *DPoS) bpIdx() uint16 {
-	idx, exist := dpos.bpc.BpID2Index(dpos.bpid())
-	if !exist {
-		logger.Fatal().Str("id", enc.ToString([]byte(dpos.bpid()))).Msg("BP has no correct BP membership")
+	// The block isn't rejected because of the double signing. Which one of
+	// the blocks survives is up to the fork choice; the evidence is kept
+	// to slash the BP.
+	if ev := dpos.detector.Check(block, params.BlockIntervalSec); ev != nil {
+		logger.Warn().Str("BP", block.BPID2Str()).Uint64("no", block.BlockNo()).
+			Msg("BP signed two different blocks for the same slot")
+		dpos.Tell(message.ChainSvc, &message.AddEvidence{Evidence: ev})
 	}
 
-	return idx
+	return nil
 }
 
 func (dpos *DPoS) getBpInfo(now time.Time, slotQueued *slot.Slot) *bpInfo {
-	s := slot.Time(now)
-
-	if !s.IsFor(dpos.bpIdx()) {
+	block, _ := dpos.ca.GetBestBlock()
+	if block == nil {
 		return nil
 	}
 
-	// already queued slot.
-	if slot.Equal(s, slotQueued) {
+	// No block is produced by a wrong block interval.
+	if err := dpos.updateSlot(block); err != nil {
+		logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to read the block interval")
 		return nil
 	}
 
-	block, _ := dpos.ca.GetBestBlock()
-	logger.Debug().Str("best", block.ID()).Uint64("no", block.GetHeader().GetBlockNo()).
-		Msg("GetBestBlock from BP")
-	if block == nil {
+	s := slot.Time(now)
+
+	// already queued slot.
+	if slot.Equal(s, slotQueued) {
 		return nil
 	}
 
-	if !isBpTiming(block, s) {
+	if !dpos.isBpTiming(block, s) {
 		return nil
 	}
+	logger.Debug().Str("best", block.ID()).Uint64("no", block.GetHeader().GetBlockNo()).
+		Msg("GetBestBlock from BP")
 
 	return &bpInfo{
 		bestBlock: block,
@@ -205,7 +347,16 @@ func (dpos *DPoS) getBpInfo(now time.Time, slotQueued *slot.Slot) *bpInfo {
 	}
 }
 
-func isBpTiming(block *types.Block, s *slot.Slot) bool {
+// isBpTiming reports whether this node produces the block next to block at
+// slot s. The BP of s is determined by the BPs active for the next block,
+// which may be changed at an epoch boundary.
+func (dpos *DPoS) isBpTiming(block *types.Block, s *slot.Slot) bool {
+	bpc := dpos.bps.cluster(block.BlockNo() + 1)
+	idx, ok := bpc.BpID2Index(dpos.bpid())
+	if !ok || !s.IsFor(idx, bpc.Size()) {
+		return false
+	}
+
 	blockSlot := slot.NewFromUnixNano(block.Header.Timestamp)
 	// The block corresponding to the current slot has already been generated.
 	if slot.LessEqual(s, blockSlot) {
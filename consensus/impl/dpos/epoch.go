@@ -0,0 +1,225 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package dpos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58"
+)
+
+const bpsKeyPrefix = "dpos.bps."
+
+// epochBlocks is the number of blocks in an election epoch. The BPs are never
+// elected if it is 0.
+var epochBlocks uint64
+
+func bpsKey(epoch uint64) []byte {
+	key := make([]byte, len(bpsKeyPrefix)+8)
+	copy(key, bpsKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(bpsKeyPrefix):], epoch)
+	return key
+}
+
+// epochOf returns the election epoch including the block of no.
+func epochOf(no types.BlockNo) uint64 {
+	if epochBlocks == 0 {
+		return 0
+	}
+	return no / epochBlocks
+}
+
+// electedEpoch returns the epoch whose BPs are elected by the vote result at
+// the block of no. The BPs of epoch e+1 are elected at the first block of epoch
+// e (e > 0), so that the election block is likely to be irreversible before
+// they start producing blocks. The epochs 0 and 1 are for the initial BPs.
+func electedEpoch(no types.BlockNo) (uint64, bool) {
+	if epochBlocks == 0 || no == 0 || no%epochBlocks != 0 {
+		return 0, false
+	}
+	return epochOf(no) + 1, true
+}
+
+// electBPs returns the IDs of the n candidates with the most votes, which are
+// ordered by the votes and then by the IDs. It returns nil if less than n
+// candidates are voted.
+func electBPs(votes *types.VoteList, n uint16) []string {
+	var candidates []*types.Vote
+	for _, v := range votes.GetVotes() {
+		if v.GetAmountBigInt().Sign() > 0 {
+			candidates = append(candidates, v)
+		}
+	}
+
+	if len(candidates) < int(n) {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if c := candidates[i].GetAmountBigInt().Cmp(candidates[j].GetAmountBigInt()); c != 0 {
+			return c > 0
+		}
+		return bytes.Compare(candidates[i].Candidate, candidates[j].Candidate) < 0
+	})
+
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = base58.Encode(candidates[i].Candidate)
+	}
+	return ids
+}
+
+type epochBPs struct {
+	ids []string
+	bpc *bp.Cluster
+}
+
+// bpSchedule keeps the BPs of each election epoch. The BPs elected are
+// persisted per epoch in the chain DB along with the other DPoS status.
+type bpSchedule struct {
+	sync.Mutex
+	initial *epochBPs
+	epochs  map[uint64]*epochBPs
+	unsaved map[uint64][]string
+	cdb     consensus.ChainDbReader
+}
+
+func newBpSchedule(ids []string, cdb consensus.ChainDbReader) (*bpSchedule, error) {
+	bpc, err := bp.NewCluster(ids, blockProducers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpSchedule{
+		initial: &epochBPs{ids: ids, bpc: bpc},
+		epochs:  make(map[uint64]*epochBPs),
+		unsaved: make(map[uint64][]string),
+		cdb:     cdb,
+	}, nil
+}
+
+// cluster returns the BP cluster active for the block of no.
+func (sc *bpSchedule) cluster(no types.BlockNo) *bp.Cluster {
+	sc.Lock()
+	defer sc.Unlock()
+
+	return sc.bps(epochOf(no)).bpc
+}
+
+// ids returns the IDs of the BPs active for the block of no.
+func (sc *bpSchedule) ids(no types.BlockNo) []string {
+	sc.Lock()
+	defer sc.Unlock()
+
+	return sc.bps(epochOf(no)).ids
+}
+
+// bps returns the BPs of epoch. The BPs of the nearest earlier epoch are used
+// if no BPs are elected for epoch, e.g. the election block is not connected
+// yet.
+func (sc *bpSchedule) bps(epoch uint64) *epochBPs {
+	for ; epoch > 1; epoch-- {
+		if e := sc.load(epoch); e != nil {
+			return e
+		}
+	}
+	return sc.initial
+}
+
+func (sc *bpSchedule) load(epoch uint64) *epochBPs {
+	if e, exist := sc.epochs[epoch]; exist {
+		return e
+	}
+
+	value := sc.cdb.Get(bpsKey(epoch))
+	if len(value) == 0 {
+		return nil
+	}
+
+	var ids []string
+	if err := common.GobDecode(value, &ids); err != nil {
+		logger.Error().Err(err).Uint64("epoch", epoch).Msg("failed to decode BPs")
+		return nil
+	}
+
+	e, err := sc.newEpochBPs(ids)
+	if err != nil {
+		logger.Error().Err(err).Uint64("epoch", epoch).Msg("invalid BPs in DB")
+		return nil
+	}
+	sc.epochs[epoch] = e
+
+	return e
+}
+
+// newEpochBPs returns the elected BPs, whose number may be changed by the
+// governance.
+func (sc *bpSchedule) newEpochBPs(ids []string) (*epochBPs, error) {
+	bpc, err := bp.NewCluster(ids, uint16(len(ids)))
+	if err != nil {
+		return nil, err
+	}
+	return &epochBPs{ids: ids, bpc: bpc}, nil
+}
+
+// set sets ids to the BPs of epoch, which are saved by save. The BPs set by
+// the election block of a reorganized branch are overwritten when the election
+// block of the new main chain is connected.
+func (sc *bpSchedule) set(epoch uint64, ids []string) error {
+	sc.Lock()
+	defer sc.Unlock()
+
+	e, err := sc.newEpochBPs(ids)
+	if err != nil {
+		return err
+	}
+	sc.epochs[epoch] = e
+	sc.unsaved[epoch] = ids
+
+	return nil
+}
+
+// save writes the BPs which are elected since the last save to tx.
+func (sc *bpSchedule) save(tx db.Transaction) error {
+	sc.Lock()
+	defer sc.Unlock()
+
+	for epoch, ids := range sc.unsaved {
+		b, err := common.GobEncode(ids)
+		if err != nil {
+			return err
+		}
+		tx.Set(bpsKey(epoch), b)
+
+		logger.Debug().Uint64("epoch", epoch).Strs("BPs", ids).Msg("elected BPs stored to DB")
+	}
+	sc.unsaved = make(map[uint64][]string)
+
+	return nil
+}
+
+// restore sets the BPs loaded by the boot loader to sc.
+func (sc *bpSchedule) restore(loaded map[uint64][]string) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	for epoch, ids := range loaded {
+		e, err := sc.newEpochBPs(ids)
+		if err != nil {
+			logger.Error().Err(err).Uint64("epoch", epoch).Msg("invalid BPs in DB")
+			continue
+		}
+		sc.epochs[epoch] = e
+	}
+}
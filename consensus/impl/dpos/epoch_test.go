@@ -0,0 +1,122 @@
+package dpos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCdb map[string][]byte
+
+func (cdb testCdb) GetBestBlock() (*types.Block, error)                      { return nil, nil }
+func (cdb testCdb) GetBlockByNo(blockNo types.BlockNo) (*types.Block, error) { return nil, nil }
+func (cdb testCdb) GetGenesisInfo() *types.Genesis                           { return nil }
+func (cdb testCdb) Get(key []byte) []byte                                    { return cdb[string(key)] }
+
+func (cdb testCdb) Set(key, value []byte) { cdb[string(key)] = value }
+func (cdb testCdb) Delete(key []byte)     { delete(cdb, string(key)) }
+func (cdb testCdb) Commit()               {}
+func (cdb testCdb) Discard()              {}
+
+func genBpIDs(t *testing.T, n int) []peer.ID {
+	ids := make([]peer.ID, n)
+	for i := range ids {
+		_, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		assert.NoError(t, err)
+		ids[i], err = peer.IDFromPublicKey(pubKey)
+		assert.NoError(t, err)
+	}
+	return ids
+}
+
+func voteList(ids []peer.ID, amounts ...int64) *types.VoteList {
+	votes := &types.VoteList{}
+	for i, amount := range amounts {
+		votes.Votes = append(votes.Votes, &types.Vote{
+			Candidate: []byte(ids[i]),
+			Amount:    big.NewInt(amount).Bytes(),
+		})
+	}
+	return votes
+}
+
+func TestElectBPs(t *testing.T) {
+	ids := genBpIDs(t, 4)
+
+	// not enough candidates with votes
+	assert.Nil(t, electBPs(voteList(ids, 10, 20, 0), 3))
+
+	elected := electBPs(voteList(ids, 10, 30, 20, 5), 3)
+	assert.Equal(t, []string{ids[1].Pretty(), ids[2].Pretty(), ids[0].Pretty()}, elected)
+
+	// ties are broken by the candidate IDs regardless of the order of votes
+	tie := electBPs(voteList(ids, 10, 10, 10, 10), 2)
+	reversed := electBPs(voteList([]peer.ID{ids[3], ids[2], ids[1], ids[0]}, 10, 10, 10, 10), 2)
+	assert.Equal(t, tie, reversed)
+}
+
+func TestBpSchedule(t *testing.T) {
+	savedBps, savedEpoch := blockProducers, epochBlocks
+	blockProducers, epochBlocks = 2, 10
+	defer func() { blockProducers, epochBlocks = savedBps, savedEpoch }()
+
+	_, ok := electedEpoch(0)
+	assert.False(t, ok)
+	_, ok = electedEpoch(15)
+	assert.False(t, ok)
+	epoch, ok := electedEpoch(20)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), epoch)
+
+	ids := genBpIDs(t, 4)
+	initial := []string{ids[0].Pretty(), ids[1].Pretty()}
+	elected := []string{ids[2].Pretty(), ids[3].Pretty()}
+
+	cdb := testCdb{}
+	sc, err := newBpSchedule(initial, cdb)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sc.set(2, elected))
+	assert.NoError(t, sc.save(cdb))
+
+	assert.Equal(t, initial, sc.ids(19))
+	assert.Equal(t, elected, sc.ids(20))
+	// the BPs remain until another election
+	assert.Equal(t, elected, sc.ids(55))
+	assert.True(t, sc.cluster(20).Has(ids[2]))
+	assert.False(t, sc.cluster(20).Has(ids[0]))
+
+	// the number of the BPs follows the election
+	assert.NoError(t, sc.set(4, []string{ids[0].Pretty(), ids[1].Pretty(), ids[2].Pretty()}))
+	assert.Equal(t, uint16(3), sc.cluster(40).Size())
+	assert.Equal(t, uint16(2), sc.cluster(35).Size())
+
+	// reloaded from the chain DB
+	loaded, err := newBpSchedule(initial, cdb)
+	assert.NoError(t, err)
+	assert.Equal(t, elected, loaded.ids(35))
+	assert.Equal(t, initial, loaded.ids(5))
+}
+
+func TestElectWithoutVoteResult(t *testing.T) {
+	savedBps, savedEpoch := blockProducers, epochBlocks
+	blockProducers, epochBlocks = 2, 10
+	defer func() { blockProducers, epochBlocks = savedBps, savedEpoch }()
+
+	ids := genBpIDs(t, 2)
+	initial := []string{ids[0].Pretty(), ids[1].Pretty()}
+	sc, err := newBpSchedule(initial, testCdb{})
+	assert.NoError(t, err)
+
+	// no state to read the vote result from
+	dpos := &DPoS{bps: sc}
+	block := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block.Header.BlockNo = 20
+	assert.Error(t, dpos.elect(block, 3))
+	assert.Empty(t, sc.unsaved)
+	assert.Equal(t, initial, sc.ids(35))
+}
@@ -0,0 +1,163 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package evidence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+var (
+	ErrNoSlot        = errors.New("evidence: slots are not available without the block interval")
+	ErrNoHeader      = errors.New("evidence: missing block header")
+	ErrSameBlock     = errors.New("evidence: same block")
+	ErrDifferentBP   = errors.New("evidence: blocks signed by different BPs")
+	ErrDifferentSlot = errors.New("evidence: blocks for different slots")
+	ErrBadSign       = errors.New("evidence: bad block signature")
+	ErrBadPubKey     = errors.New("evidence: BP public key not for an account")
+)
+
+// New returns the evidence that the BP of a and b signed both of them. The
+// headers are ordered by the block hashes, so that the evidence is the same
+// whichever block is received first.
+func New(a, b *types.Block) *types.DoubleSignEvidence {
+	h1, h2 := a.GetHeader(), b.GetHeader()
+	if bytes.Compare(headerHash(h1), headerHash(h2)) > 0 {
+		h1, h2 = h2, h1
+	}
+	return &types.DoubleSignEvidence{Header1: h1, Header2: h2}
+}
+
+// ID returns the identifier of ev, which is the hash of the block hashes.
+func ID(ev *types.DoubleSignEvidence) []byte {
+	digest := sha256.New()
+	digest.Write(headerHash(ev.GetHeader1()))
+	digest.Write(headerHash(ev.GetHeader2()))
+	return digest.Sum(nil)
+}
+
+func headerHash(h *types.BlockHeader) []byte {
+	return (&types.Block{Header: h}).BlockHash()
+}
+
+// Verify checks that ev proves a BP signed two different blocks for the same
+// slot, and returns the address of the account of the BP key. The slots are
+// those of blockIntervalSec, the block interval effective at the blocks.
+func Verify(ev *types.DoubleSignEvidence, blockIntervalSec int64) ([]byte, error) {
+	if blockIntervalSec <= 0 {
+		return nil, ErrNoSlot
+	}
+
+	h1, h2 := ev.GetHeader1(), ev.GetHeader2()
+	if h1 == nil || h2 == nil {
+		return nil, ErrNoHeader
+	}
+	if bytes.Equal(headerHash(h1), headerHash(h2)) {
+		return nil, ErrSameBlock
+	}
+	if !bytes.Equal(h1.GetPubKey(), h2.GetPubKey()) {
+		return nil, ErrDifferentBP
+	}
+	if slot.IndexAt(h1.GetTimestamp(), blockIntervalSec) != slot.IndexAt(h2.GetTimestamp(), blockIntervalSec) {
+		return nil, ErrDifferentSlot
+	}
+
+	for _, h := range []*types.BlockHeader{h1, h2} {
+		if valid, _ := (&types.Block{Header: h}).VerifySign(); !valid {
+			return nil, ErrBadSign
+		}
+	}
+
+	return Offender(h1)
+}
+
+// Offender returns the address of the account of the key which signed h.
+func Offender(h *types.BlockHeader) ([]byte, error) {
+	pubKey, err := crypto.UnmarshalPublicKey(h.GetPubKey())
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := pubKey.(*crypto.Secp256k1PublicKey)
+	if !ok {
+		return nil, ErrBadPubKey
+	}
+
+	return (*btcec.PublicKey)(key).SerializeCompressed(), nil
+}
+
+// OffenderID returns the ID of the BP which signed h, by which the BP is
+// voted for.
+func OffenderID(h *types.BlockHeader) (peer.ID, error) {
+	pubKey, err := crypto.UnmarshalPublicKey(h.GetPubKey())
+	if err != nil {
+		return peer.ID(""), err
+	}
+	return peer.IDFromPublicKey(pubKey)
+}
+
+// Detector detects the BPs signing two different blocks for the same slot
+// among the recent blocks.
+type Detector struct {
+	mu     sync.Mutex
+	blocks *lru.Cache
+}
+
+// NewDetector returns a Detector keeping the last size blocks.
+func NewDetector(size int) *Detector {
+	blocks, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &Detector{blocks: blocks}
+}
+
+// Check records block, and returns the evidence if its BP has signed another
+// block for the same slot of blockIntervalSec, the block interval effective at
+// block. The block with a bad signature is ignored.
+func (d *Detector) Check(block *types.Block, blockIntervalSec int64) *types.DoubleSignEvidence {
+	if blockIntervalSec <= 0 || block.GetHeader() == nil {
+		return nil
+	}
+
+	// The body isn't a part of the evidence.
+	header := &types.Block{Header: block.GetHeader()}
+	if valid, _ := header.VerifySign(); !valid {
+		return nil
+	}
+
+	key := slotKey(header.GetHeader(), blockIntervalSec)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if v, exist := d.blocks.Get(key); exist {
+		prev := v.(*types.Block)
+		if !bytes.Equal(prev.BlockHash(), header.BlockHash()) {
+			return New(prev, header)
+		}
+		return nil
+	}
+	d.blocks.Add(key, header)
+
+	return nil
+}
+
+func slotKey(h *types.BlockHeader, blockIntervalSec int64) string {
+	idx := make([]byte, 8)
+	binary.BigEndian.PutUint64(idx, uint64(slot.IndexAt(h.GetTimestamp(), blockIntervalSec)))
+	return string(h.GetPubKey()) + string(idx)
+}
@@ -0,0 +1,94 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package evidence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKey(t *testing.T) crypto.PrivKey {
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	return privKey
+}
+
+func newSignedBlock(t *testing.T, privKey crypto.PrivKey, root string, ts int64) *types.Block {
+	block := types.NewBlock(nil, []byte(root), nil, nil, nil, ts)
+	assert.NoError(t, block.Sign(privKey))
+	return block
+}
+
+func TestVerify(t *testing.T) {
+	key := newTestKey(t)
+	ts := time.Now().Truncate(time.Second).UnixNano()
+
+	b1 := newSignedBlock(t, key, "root1", ts)
+	b2 := newSignedBlock(t, key, "root2", ts+1)
+
+	ev := New(b1, b2)
+	assert.Equal(t, ev, New(b2, b1), "evidence must not depend on the order")
+	assert.Equal(t, ID(ev), ID(New(b2, b1)))
+
+	offender, err := Verify(ev, 1)
+	assert.NoError(t, err)
+	expected, err := Offender(b1.GetHeader())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, offender)
+	assert.Len(t, offender, types.AddressLength)
+
+	_, err = Verify(New(b1, b1), 1)
+	assert.Equal(t, ErrSameBlock, err)
+
+	_, err = Verify(New(b1, newSignedBlock(t, newTestKey(t), "root2", ts)), 1)
+	assert.Equal(t, ErrDifferentBP, err)
+
+	_, err = Verify(New(b1, newSignedBlock(t, key, "root2", ts+int64(time.Second))), 1)
+	assert.Equal(t, ErrDifferentSlot, err)
+
+	forged := newSignedBlock(t, key, "root2", ts)
+	forged.GetHeader().BlocksRootHash = []byte("root3")
+	_, err = Verify(New(b1, forged), 1)
+	assert.Equal(t, ErrBadSign, err)
+
+	_, err = Verify(&types.DoubleSignEvidence{Header1: b1.GetHeader()}, 1)
+	assert.Equal(t, ErrNoHeader, err)
+
+	_, err = Verify(ev, 0)
+	assert.Equal(t, ErrNoSlot, err)
+
+	id, err := OffenderID(b1.GetHeader())
+	assert.NoError(t, err)
+	expectedID, err := peer.IDFromPublicKey(key.GetPublic())
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, id)
+}
+
+func TestDetector(t *testing.T) {
+	key := newTestKey(t)
+	ts := time.Now().Truncate(time.Second).UnixNano()
+
+	d := NewDetector(10)
+	b1 := newSignedBlock(t, key, "root1", ts)
+	assert.Nil(t, d.Check(b1, 1))
+	assert.Nil(t, d.Check(b1, 1), "same block is not a double signing")
+	assert.Nil(t, d.Check(newSignedBlock(t, key, "root1", ts+int64(time.Second)), 1))
+	assert.Nil(t, d.Check(newSignedBlock(t, newTestKey(t), "root2", ts), 1))
+	assert.Nil(t, d.Check(newSignedBlock(t, key, "root3", ts), 0), "no slots without the block interval")
+
+	b2 := newSignedBlock(t, key, "root2", ts)
+	ev := d.Check(b2, 1)
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, New(b1, b2), ev)
+		_, err := Verify(ev, 1)
+		assert.NoError(t, err)
+	}
+}
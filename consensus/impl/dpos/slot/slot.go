@@ -31,11 +31,25 @@ func Init(blockIntervalSec int64, bps uint16) {
 	blockProducers = bps
 }
 
+// BlockIntervalSec returns the block interval in seconds, which the slots are
+// initialized with.
+func BlockIntervalSec() int64 {
+	return blockIntervalMs / 1000
+}
+
 // Now returns a Slot corresponding to the current local time.
 func Now() *Slot {
 	return Time(time.Now())
 }
 
+// IndexAt returns the absolute index of the slot at a UNIX time value (ns) for
+// the block interval blockIntervalSec. Unlike Index, it doesn't depend on the
+// slot parameters initialized.
+func IndexAt(ns int64, blockIntervalSec int64) int64 {
+	intervalMs := blockIntervalSec * 1000
+	return (nsToMs(ns) + intervalMs - 1) / intervalMs
+}
+
 // NewFromUnixNano returns a Slot corresponding to a UNIX time value (ns).
 func NewFromUnixNano(ns int64) *Slot {
 	return fromUnixNs(ns)
@@ -94,9 +108,10 @@ func IsNextTo(s1, s2 *Slot) bool {
 	return s1.prevIndex == s2.nextIndex
 }
 
-// IsFor reports whether s correponds to myBpIdx (block producer index).
-func (s *Slot) IsFor(bpIdx uint16) bool {
-	return s.NextBpIndex() == int64(bpIdx)
+// IsFor reports whether s correponds to myBpIdx (block producer index) among
+// bps block producers.
+func (s *Slot) IsFor(bpIdx uint16, bps uint16) bool {
+	return s.BpIndex(bps) == int64(bpIdx)
 }
 
 // GetBpTimeout returns the time available for block production.
@@ -121,11 +136,21 @@ func (s Slot) TimesUp() bool {
 	return s.RemainingTimeMS() <= bpMinTimeLimitMs
 }
 
+// Index returns the absolute index of s, which is unique to each slot.
+func (s *Slot) Index() int64 {
+	return s.nextIndex
+}
+
 // NextBpIndex returns BP index for s.nextIndex.
 func (s *Slot) NextBpIndex() int64 {
 	return absToBpIndex(s.nextIndex)
 }
 
+// BpIndex returns BP index for s.nextIndex among bps block producers.
+func (s *Slot) BpIndex(bps uint16) int64 {
+	return s.nextIndex % int64(bps)
+}
+
 func absToBpIndex(idx int64) int64 {
 	return idx % int64(blockProducers)
 }
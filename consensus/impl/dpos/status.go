@@ -52,6 +52,16 @@ func (s *Status) load() {
 	s.done = true
 }
 
+// setConfirmsRequired sets the number of the confirmations required for the
+// blocks added afterwards, which follows the number of the active BPs.
+func (s *Status) setConfirmsRequired(n uint16) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.load()
+	s.libState.confirmsRequired = n
+}
+
 // Update updates the last irreversible block (LIB).
 func (s *Status) Update(block *types.Block) {
 	s.Lock()
@@ -128,6 +138,17 @@ func (s *Status) NeedReorganization(rootNo types.BlockNo) bool {
 	return reorganizable
 }
 
+// LibNo returns the block number of the last irreversible block (LIB).
+func (s *Status) LibNo() (types.BlockNo, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.libState.Lib == nil {
+		return 0, false
+	}
+	return s.libState.Lib.BlockNo, true
+}
+
 // init recovers the last DPoS status including pre-LIB map and confirms
 // list between LIB and the best block.
 func (s *Status) init(cdb consensus.ChainDbReader) {
@@ -160,6 +181,7 @@ type bootLoader struct {
 	best    *types.Block
 	genesis *types.Block
 	bpIDs   []string
+	bps     map[uint64][]string
 	cdb     consensus.ChainDbReader
 }
 
@@ -178,6 +200,8 @@ func (bs *bootLoader) load() {
 		}
 	}
 
+	bs.bps = bs.loadBPs()
+
 	if gi := bs.cdb.GetGenesisInfo(); gi != nil {
 		bs.bpIDs = gi.BPs
 		for i, bp := range bs.bpIDs {
@@ -186,6 +210,26 @@ func (bs *bootLoader) load() {
 	}
 }
 
+// loadBPs loads the BPs elected for the current and the next epochs of the
+// best block. The BPs of the earlier epochs are loaded on demand.
+func (bs *bootLoader) loadBPs() map[uint64][]string {
+	bps := make(map[uint64][]string)
+
+	epoch := epochOf(bs.best.BlockNo())
+	for _, e := range []uint64{epoch, epoch + 1} {
+		var ids []string
+		if err := bs.decodeStatus(bpsKey(e), &ids); err != nil {
+			continue
+		}
+		bps[e] = ids
+		for i, bp := range ids {
+			logger.Info().Uint64("epoch", e).Int("index", i).Str("BPID", bp).Msg("elected BP")
+		}
+	}
+
+	return bps
+}
+
 // GetInitialBPs returns the initial BP IDs, which are loaded from Genesis
 // info in the chain DB.
 func GetInitialBPs() []string {
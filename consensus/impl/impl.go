@@ -9,7 +9,9 @@ import (
 	"github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/bft"
 	"github.com/aergoio/aergo/consensus/impl/dpos"
+	"github.com/aergoio/aergo/consensus/impl/raft"
 	"github.com/aergoio/aergo/consensus/impl/sbp"
 	"github.com/aergoio/aergo/pkg/component"
 )
@@ -21,6 +23,10 @@ func New(cfg *config.Config, cs *chain.ChainService, hub *component.ComponentHub
 
 	if cfg.Consensus.EnableDpos {
 		c, err = dpos.New(cfg, cs.CDBReader(), hub)
+	} else if cfg.Consensus.EnableBft {
+		c, err = bft.New(cfg, cs.CDBReader(), hub)
+	} else if cfg.Consensus.EnableRaft {
+		c, err = raft.New(cfg, cs.CDB(), hub)
 	} else {
 		c, err = sbp.New(cfg, hub)
 	}
@@ -0,0 +1,172 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package raft
+
+import (
+	"runtime"
+	"time"
+
+	bc "github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/p2p"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-crypto"
+)
+
+const (
+	slotQueueMax = 100
+
+	// proposalTimeoutTicks is the number of the ticks after which the block
+	// proposed by the leader is regarded as lost unless it is connected.
+	proposalTimeoutTicks = 5
+)
+
+type txExec struct {
+	execTx bc.TxExecFn
+}
+
+func newTxExec(blockNo types.BlockNo, ts int64) chain.TxOp {
+	// Block hash not determined yet
+	return &txExec{
+		execTx: bc.NewTxExecutor(blockNo, ts, contract.BlockFactory),
+	}
+}
+
+func (te *txExec) Apply(bState *state.BlockState, tx *types.Tx) error {
+	err := te.execTx(bState, tx)
+	return err
+}
+
+// BlockFactory produces a block every block interval while this node is the
+// leader of the Raft cluster, and proposes it to the cluster. The block is
+// connected when it is committed.
+type BlockFactory struct {
+	*component.ComponentHub
+	jobQueue chan interface{}
+	quit     <-chan interface{}
+	rn       *raftNode
+	privKey  crypto.PrivKey
+	txOp     chain.TxOp
+	sdb      *state.ChainStateDB
+	ca       types.ChainAccessor
+
+	prevBlock *types.Block
+	waited    int
+}
+
+// NewBlockFactory returns a new BlockFactory.
+func NewBlockFactory(hub *component.ComponentHub, rn *raftNode, quitC <-chan interface{}) *BlockFactory {
+	bf := &BlockFactory{
+		ComponentHub: hub,
+		jobQueue:     make(chan interface{}, slotQueueMax),
+		quit:         quitC,
+		rn:           rn,
+		privKey:      p2p.NodePrivKey(),
+	}
+
+	bf.txOp = chain.NewCompTxOp(
+		chain.TxOpFn(func(bState *state.BlockState, txIn *types.Tx) error {
+			select {
+			case <-bf.quit:
+				return chain.ErrQuit
+			default:
+				return nil
+			}
+		}),
+	)
+
+	return bf
+}
+
+// JobQueue returns the queue for block production triggering.
+func (bf *BlockFactory) JobQueue() chan<- interface{} {
+	return bf.jobQueue
+}
+
+// queueJob sends the best block to jq if this node is the leader and the
+// previous proposal is connected or timed out.
+func (bf *BlockFactory) queueJob(jq chan<- interface{}) {
+	if !bf.rn.isLeader() {
+		bf.prevBlock = nil
+		return
+	}
+
+	b, _ := bf.ca.GetBestBlock()
+	if b == nil {
+		return
+	}
+
+	if bf.prevBlock != nil && bf.prevBlock.BlockNo() == b.BlockNo() && bf.waited < proposalTimeoutTicks {
+		logger.Debug().Msg("previous block not committed. skip to generate block")
+		bf.waited++
+		return
+	}
+	bf.prevBlock = b
+	bf.waited = 0
+
+	select {
+	case jq <- b:
+	default:
+	}
+}
+
+// Start runs the Raft node and produces blocks on the leader.
+func (bf *BlockFactory) Start() {
+	defer logger.Info().Msg("shutdown initiated. stop the service")
+
+	runtime.LockOSThread()
+
+	if err := bf.rn.start(); err != nil {
+		logger.Fatal().Err(err).Msg("failed to start raft node")
+	}
+	go bf.rn.run(bf.quit)
+
+	for {
+		select {
+		case e := <-bf.jobQueue:
+			if prevBlock, ok := e.(*types.Block); ok {
+				bf.produce(prevBlock)
+			}
+		case <-bf.quit:
+			return
+		}
+	}
+}
+
+func (bf *BlockFactory) produce(prevBlock *types.Block) {
+	blockState := bf.sdb.NewBlockState(prevBlock.GetHeader().GetBlocksRootHash())
+	blockState.SetBlockProducer([]byte(p2p.NodeID()))
+
+	ts := time.Now().UnixNano()
+
+	txOp := chain.NewCompTxOp(
+		bf.txOp,
+		newTxExec(prevBlock.GetHeader().GetBlockNo()+1, ts),
+	)
+
+	block, err := chain.GenerateBlock(bf, prevBlock, blockState, txOp, ts)
+	if err != nil {
+		logger.Info().Err(err).Msg("failed to produce block")
+		return
+	}
+
+	if err := block.Sign(bf.privKey); err != nil {
+		logger.Error().Err(err).Msg("failed to sign block")
+		return
+	}
+
+	logger.Info().Uint64("no", block.GetHeader().GetBlockNo()).Str("hash", block.ID()).
+		Str("TrieRoot", enc.ToString(block.GetHeader().GetBlocksRootHash())).
+		Msg("block produced")
+
+	if err := bf.rn.propose(block, blockState); err != nil {
+		logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to propose block")
+	}
+}
@@ -0,0 +1,524 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package raft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	etcdraft "github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	tickInterval  = 100 * time.Millisecond
+	electionTick  = 10
+	heartbeatTick = 1
+
+	maxSizePerMsg   = 1024 * 1024
+	maxInflightMsgs = 256
+
+	// snapshotCount is the number of the applied entries which triggers a
+	// snapshot, and catchUpEntries is the number of the entries kept after
+	// the snapshot for the slow followers.
+	snapshotCount  = 1000
+	catchUpEntries = 100
+
+	proposeTimeout = 3 * time.Second
+
+	// catchUpInterval is the interval to check whether the blocks missing
+	// before a committed block are synced.
+	catchUpInterval = 3 * time.Second
+)
+
+var (
+	errNotRunning = errors.New("raft node is not running")
+)
+
+// errBlockGap indicates that a committed block doesn't follow the best block
+// since the blocks between are missing.
+type errBlockGap struct {
+	best  types.BlockNo
+	block *types.Block
+}
+
+func (e *errBlockGap) Error() string {
+	return fmt.Sprintf("committed block %d not following the best block %d", e.block.BlockNo(), e.best)
+}
+
+// membership is the members of the cluster kept in the snapshots. It follows
+// the Raft log, where the blocks and the membership changes are ordered.
+type membership struct {
+	// Members maps the Raft node IDs to the current members.
+	Members map[uint64]peer.ID
+	// Removed maps the former members to the number of the first block
+	// committed after they are removed. Their blocks before it remain valid.
+	Removed map[peer.ID]types.BlockNo
+	// BlockNo is the number of the last block committed.
+	BlockNo types.BlockNo
+}
+
+// raftNode runs a member of the Raft cluster. The committed entries, which
+// are blocks or membership changes, are applied to the chain and to the
+// members in order.
+type raftNode struct {
+	*component.ComponentHub
+	id      peer.ID
+	raftID  uint64
+	storage *raftStorage
+	ca      types.ChainAccessor
+
+	mu      sync.RWMutex
+	node    etcdraft.Node
+	members membership
+
+	// lead is the Raft node ID of the current leader, or 0 if unknown.
+	lead uint64
+
+	confState     raftpb.ConfState
+	snapshotIndex uint64
+	appliedIndex  uint64
+
+	// pending is the block proposed by this node and its block state, which
+	// is used on applying the block instead of executing it again.
+	pendingMu    sync.Mutex
+	pending      *types.Block
+	pendingState *state.BlockState
+}
+
+func newRaftNode(hub *component.ComponentHub, id peer.ID, cdb consensus.ChainDB, ids []peer.ID) *raftNode {
+	rn := &raftNode{
+		ComponentHub: hub,
+		id:           id,
+		raftID:       types.RaftID(id),
+		storage:      newRaftStorage(cdb),
+		members: membership{
+			Members: make(map[uint64]peer.ID),
+			Removed: make(map[peer.ID]types.BlockNo),
+		},
+	}
+	for _, id := range ids {
+		rn.members.Members[types.RaftID(id)] = id
+	}
+	return rn
+}
+
+// start starts the Raft node. A node persisting no Raft state starts a new
+// cluster if it is an initial member. Otherwise it restarts from the persisted
+// state, or joins the cluster after it is added as a member.
+func (rn *raftNode) start() error {
+	exist, err := rn.storage.load()
+	if err != nil {
+		return err
+	}
+
+	snap, err := rn.storage.Snapshot()
+	if err != nil {
+		return err
+	}
+	if !etcdraft.IsEmptySnap(snap) {
+		if err := rn.restoreMembers(snap); err != nil {
+			return err
+		}
+	}
+
+	cfg := &etcdraft.Config{
+		ID:              rn.raftID,
+		ElectionTick:    electionTick,
+		HeartbeatTick:   heartbeatTick,
+		Storage:         rn.storage,
+		Applied:         rn.appliedIndex,
+		MaxSizePerMsg:   maxSizePerMsg,
+		MaxInflightMsgs: maxInflightMsgs,
+		CheckQuorum:     true,
+		PreVote:         true,
+	}
+
+	var node etcdraft.Node
+	if _, initial := rn.members.Members[rn.raftID]; !exist && initial {
+		peers := make([]etcdraft.Peer, 0, len(rn.members.Members))
+		for raftID, id := range rn.members.Members {
+			peers = append(peers, etcdraft.Peer{ID: raftID, Context: []byte(id)})
+		}
+		logger.Info().Int("members", len(peers)).Msg("start a new raft cluster")
+		node = etcdraft.StartNode(cfg, peers)
+	} else {
+		logger.Info().Bool("restart", exist).Uint64("applied", rn.appliedIndex).Msg("restart raft node")
+		node = etcdraft.RestartNode(cfg)
+	}
+
+	rn.mu.Lock()
+	rn.node = node
+	rn.mu.Unlock()
+
+	return nil
+}
+
+func (rn *raftNode) getNode() etcdraft.Node {
+	rn.mu.RLock()
+	defer rn.mu.RUnlock()
+	return rn.node
+}
+
+// run handles the ticks and the Readys of the Raft node until quit is closed.
+func (rn *raftNode) run(quit <-chan interface{}) {
+	node := rn.getNode()
+	defer node.Stop()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			node.Tick()
+		case rd := <-node.Ready():
+			if rd.SoftState != nil {
+				atomic.StoreUint64(&rn.lead, rd.SoftState.Lead)
+			}
+
+			if err := rn.storage.save(rd.HardState, rd.Entries, rd.Snapshot); err != nil {
+				logger.Fatal().Err(err).Msg("failed to save raft state")
+			}
+			if !etcdraft.IsEmptySnap(rd.Snapshot) {
+				if err := rn.restoreMembers(rd.Snapshot); err != nil {
+					logger.Fatal().Err(err).Msg("failed to restore raft snapshot")
+				}
+			}
+
+			rn.send(rd.Messages)
+
+			for _, e := range rd.CommittedEntries {
+				if err := rn.apply(e, quit); err == errNotRunning {
+					return
+				} else if err != nil {
+					logger.Fatal().Err(err).Uint64("index", e.Index).Msg("failed to apply committed raft entry")
+				}
+			}
+			rn.maybeSnapshot()
+
+			node.Advance()
+		case <-quit:
+			return
+		}
+	}
+}
+
+// isLeader reports whether this node is the leader of the cluster.
+func (rn *raftNode) isLeader() bool {
+	return atomic.LoadUint64(&rn.lead) == rn.raftID
+}
+
+// isProducer reports whether the block of no may be produced by id, i.e. id
+// is a current member or was removed after the block.
+func (rn *raftNode) isProducer(id peer.ID, no types.BlockNo) bool {
+	rn.mu.RLock()
+	defer rn.mu.RUnlock()
+
+	if member, exist := rn.members.Members[types.RaftID(id)]; exist && member == id {
+		return true
+	}
+	removed, exist := rn.members.Removed[id]
+	return exist && no < removed
+}
+
+func (rn *raftNode) peerID(raftID uint64) (peer.ID, bool) {
+	rn.mu.RLock()
+	defer rn.mu.RUnlock()
+	id, exist := rn.members.Members[raftID]
+	return id, exist
+}
+
+// send passes msgs to the p2p service. The message to a member not connected
+// is lost, which Raft recovers from by retransmission.
+func (rn *raftNode) send(msgs []raftpb.Message) {
+	node := rn.getNode()
+	for _, msg := range msgs {
+		to, exist := rn.peerID(msg.To)
+		if !exist {
+			logger.Debug().Uint64("to", msg.To).Msg("drop raft message to unknown member")
+			continue
+		}
+
+		data, err := msg.Marshal()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to marshal raft message")
+			continue
+		}
+		rn.Tell(message.P2PSvc, &message.SendRaft{ToWhom: to, Body: &types.RaftMessage{Data: data}})
+
+		if msg.Type == raftpb.MsgSnap {
+			node.ReportSnapshot(msg.To, etcdraft.SnapshotFinish)
+		}
+	}
+}
+
+// step passes a message of a member to the Raft node.
+func (rn *raftNode) step(msg *types.RaftMessage) error {
+	node := rn.getNode()
+	if node == nil {
+		return errNotRunning
+	}
+
+	var m raftpb.Message
+	if err := m.Unmarshal(msg.GetData()); err != nil {
+		return err
+	}
+
+	return node.Step(context.Background(), m)
+}
+
+// propose proposes block, which is connected when it is committed. The block
+// state is used on connecting the block.
+func (rn *raftNode) propose(block *types.Block, blockState *state.BlockState) error {
+	node := rn.getNode()
+	if node == nil {
+		return errNotRunning
+	}
+
+	data, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+
+	rn.pendingMu.Lock()
+	rn.pending, rn.pendingState = block, blockState
+	rn.pendingMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), proposeTimeout)
+	defer cancel()
+
+	return node.Propose(ctx, data)
+}
+
+// changeMembership proposes to add or remove the member of id. The change
+// takes effect when it is committed.
+func (rn *raftNode) changeMembership(remove bool, id peer.ID) error {
+	node := rn.getNode()
+	if node == nil {
+		return errNotRunning
+	}
+
+	cc := raftpb.ConfChange{
+		Type:    raftpb.ConfChangeAddNode,
+		NodeID:  types.RaftID(id),
+		Context: []byte(id),
+	}
+	if remove {
+		cc.Type = raftpb.ConfChangeRemoveNode
+	}
+
+	_, exist := rn.peerID(cc.NodeID)
+	if exist != remove {
+		return &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("%v is already a member or not a member (remove: %v)", id.Pretty(), remove),
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), proposeTimeout)
+	defer cancel()
+
+	return node.ProposeConfChange(ctx, cc)
+}
+
+// apply applies the committed entry e. A block committed after the blocks
+// missing on this node, e.g. when it is restored from a snapshot, is applied
+// after they are synced.
+func (rn *raftNode) apply(e raftpb.Entry, quit <-chan interface{}) error {
+	switch e.Type {
+	case raftpb.EntryNormal:
+		if len(e.Data) != 0 {
+			err := rn.applyBlock(e.Data)
+			if gap, ok := err.(*errBlockGap); ok {
+				logger.Info().Err(err).Msg("sync the blocks missing before the committed block")
+				if err = rn.catchUp(gap, quit); err == nil {
+					err = rn.applyBlock(e.Data)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case raftpb.EntryConfChange:
+		var cc raftpb.ConfChange
+		if err := cc.Unmarshal(e.Data); err != nil {
+			return err
+		}
+		rn.applyConfChange(cc)
+	}
+	rn.appliedIndex = e.Index
+
+	return nil
+}
+
+// applyBlock connects the committed block unless it is already connected.
+// The block not following the best block is skipped, since it is produced by
+// a former leader on a chain which another committed block has extended
+// already. The block beyond the next of the best block results in
+// errBlockGap.
+func (rn *raftNode) applyBlock(data []byte) error {
+	block := &types.Block{}
+	if err := proto.Unmarshal(data, block); err != nil {
+		return err
+	}
+
+	if b, err := rn.ca.GetBlock(block.BlockHash()); err == nil && b != nil {
+		rn.setBlockNo(block.BlockNo())
+		return nil
+	}
+
+	best, err := rn.ca.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	if block.BlockNo() > best.BlockNo()+1 {
+		return &errBlockGap{best: best.BlockNo(), block: block}
+	}
+	if !bytes.Equal(block.GetHeader().GetPrevBlockHash(), best.BlockHash()) {
+		logger.Warn().Uint64("no", block.BlockNo()).Str("hash", block.ID()).Str("prev", block.PrevID()).
+			Msg("skip committed block not following the best block")
+		return nil
+	}
+
+	var blockState *state.BlockState
+	rn.pendingMu.Lock()
+	if rn.pending != nil && bytes.Equal(rn.pending.BlockHash(), block.BlockHash()) {
+		blockState = rn.pendingState
+	}
+	rn.pending, rn.pendingState = nil, nil
+	rn.pendingMu.Unlock()
+
+	// A block rejected by the chain service is rejected by every member
+	// alike, e.g. the block of a removed member, and it is skipped.
+	if err := chain.ConnectBlock(rn, block, blockState); err != nil {
+		logger.Error().Err(err).Msg("failed to connect the committed block")
+		return nil
+	}
+	rn.setBlockNo(block.BlockNo())
+
+	return nil
+}
+
+func (rn *raftNode) setBlockNo(no types.BlockNo) {
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	if no > rn.members.BlockNo {
+		rn.members.BlockNo = no
+	}
+}
+
+// catchUp requests the blocks missing before the committed block of gap to
+// its producer, and waits until they are connected. The Raft node doesn't
+// handle the ticks and the messages meanwhile.
+func (rn *raftNode) catchUp(gap *errBlockGap, quit <-chan interface{}) error {
+	producer, err := gap.block.BPID()
+	if err != nil {
+		return err
+	}
+	target := gap.block.BlockNo() - 1
+
+	for {
+		rn.Tell(message.SyncerSvc, &message.SyncStart{PeerID: producer, TargetNo: target})
+
+		select {
+		case <-time.After(catchUpInterval):
+		case <-quit:
+			return errNotRunning
+		}
+
+		best, err := rn.ca.GetBestBlock()
+		if err != nil {
+			return err
+		}
+		if best.BlockNo() >= target {
+			return nil
+		}
+		logger.Debug().Uint64("best", best.BlockNo()).Uint64("target", target).Msg("waiting for the missing blocks")
+	}
+}
+
+// applyConfChange changes the members. A removed member remains the valid
+// producer of the blocks committed before it is removed.
+func (rn *raftNode) applyConfChange(cc raftpb.ConfChange) {
+	rn.confState = *rn.getNode().ApplyConfChange(cc)
+
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+
+	switch cc.Type {
+	case raftpb.ConfChangeAddNode:
+		id := peer.ID(cc.Context)
+		rn.members.Members[cc.NodeID] = id
+		delete(rn.members.Removed, id)
+		logger.Info().Str("id", id.Pretty()).Msg("raft member added")
+	case raftpb.ConfChangeRemoveNode:
+		if cc.NodeID == rn.raftID {
+			logger.Warn().Msg("this node is removed from the raft cluster")
+		}
+		if id, exist := rn.members.Members[cc.NodeID]; exist {
+			rn.members.Removed[id] = rn.members.BlockNo + 1
+			delete(rn.members.Members, cc.NodeID)
+		}
+		logger.Info().Uint64("raftID", cc.NodeID).Msg("raft member removed")
+	}
+}
+
+// maybeSnapshot takes a snapshot of the members every snapshotCount entries.
+// The chain is not in the snapshot, and a member restored from a snapshot
+// catches up the blocks by the p2p sync.
+func (rn *raftNode) maybeSnapshot() {
+	if rn.appliedIndex-rn.snapshotIndex < snapshotCount {
+		return
+	}
+
+	rn.mu.RLock()
+	data, err := common.GobEncode(&rn.members)
+	rn.mu.RUnlock()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to encode raft members")
+		return
+	}
+
+	if err := rn.storage.compact(rn.appliedIndex, rn.appliedIndex-catchUpEntries, &rn.confState, data); err != nil {
+		logger.Error().Err(err).Msg("failed to take raft snapshot")
+		return
+	}
+	rn.snapshotIndex = rn.appliedIndex
+}
+
+func (rn *raftNode) restoreMembers(snap raftpb.Snapshot) error {
+	var members membership
+	if err := common.GobDecode(snap.Data, &members); err != nil {
+		return err
+	}
+	if members.Removed == nil {
+		members.Removed = make(map[peer.ID]types.BlockNo)
+	}
+
+	rn.mu.Lock()
+	rn.members = members
+	rn.mu.Unlock()
+
+	rn.confState = snap.Metadata.ConfState
+	rn.snapshotIndex = snap.Metadata.Index
+	rn.appliedIndex = snap.Metadata.Index
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package raft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type testChainAccessor struct {
+	best *types.Block
+}
+
+func (ca *testChainAccessor) GetBestBlock() (*types.Block, error) {
+	return ca.best, nil
+}
+
+func (ca *testChainAccessor) GetBlock(blockHash []byte) (*types.Block, error) {
+	return nil, errors.New("not found")
+}
+
+func (ca *testChainAccessor) GetHashByNo(blockNo types.BlockNo) ([]byte, error) {
+	return nil, errors.New("not found")
+}
+
+func newTestID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestIsProducer(t *testing.T) {
+	member, removed, outsider := newTestID(t), newTestID(t), newTestID(t)
+	rn := newRaftNode(nil, member, nil, []peer.ID{member, removed})
+
+	assert.True(t, rn.isProducer(member, 1))
+	assert.True(t, rn.isProducer(removed, 1))
+	assert.False(t, rn.isProducer(outsider, 1))
+
+	// the member is removed after block 10
+	rn.members.BlockNo = 10
+	rn.members.Removed[removed] = rn.members.BlockNo + 1
+	delete(rn.members.Members, types.RaftID(removed))
+
+	assert.True(t, rn.isProducer(removed, 10))
+	assert.False(t, rn.isProducer(removed, 11))
+	assert.True(t, rn.isProducer(member, 11))
+}
+
+func TestApplyBlockGap(t *testing.T) {
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	block1 := types.NewBlock(genesis, nil, nil, nil, nil, 1)
+	block2 := types.NewBlock(block1, nil, nil, nil, nil, 2)
+
+	rn := newRaftNode(nil, newTestID(t), nil, nil)
+	rn.ca = &testChainAccessor{best: genesis}
+
+	data, err := proto.Marshal(block2)
+	assert.NoError(t, err)
+
+	err = rn.applyBlock(data)
+	if gap, ok := err.(*errBlockGap); assert.True(t, ok, "error: %v", err) {
+		assert.Equal(t, block2.BlockHash(), gap.block.BlockHash())
+	}
+	assert.Equal(t, types.BlockNo(0), rn.members.BlockNo)
+
+	// a block not following the best block is skipped
+	fork := types.NewBlock(genesis, nil, nil, nil, nil, 3)
+	orphan := types.NewBlock(fork, nil, nil, nil, nil, 4)
+	rn.ca = &testChainAccessor{best: block1}
+	data, err = proto.Marshal(orphan)
+	assert.NoError(t, err)
+	assert.NoError(t, rn.applyBlock(data))
+	assert.Equal(t, types.BlockNo(0), rn.members.BlockNo)
+}
@@ -0,0 +1,192 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package raft
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+var logger = log.NewLogger("raft")
+
+// Raft is a crash fault tolerant consensus for private networks. The members
+// of the Raft cluster elect a leader, which produces the blocks and
+// replicates them through the Raft log. A block is connected by the members
+// when it is committed, i.e. replicated to the majority of them, and it is
+// never reverted. The members can be added or removed at runtime.
+//
+// Raft is also registered as the consensus service receiving the Raft
+// messages from peers and the membership changes.
+type Raft struct {
+	*component.BaseComponent
+	rn   *raftNode
+	bf   *BlockFactory
+	quit chan interface{}
+	ca   types.ChainAccessor
+}
+
+// New returns a new Raft consensus. The initial members are the BPs of the
+// genesis info if any, or cfg.Consensus.BpIds. They are used only when the
+// cluster starts; the members persisted in the chain DB are used afterwards.
+func New(cfg *config.Config, cdb consensus.ChainDB, hub *component.ComponentHub) (consensus.Consensus, error) {
+	if genesis := cdb.GetGenesisInfo(); genesis != nil && len(genesis.BPs) > 0 {
+		logger.Debug().Msg("use BPs from the genesis info")
+		cfg.Consensus.BpIds = genesis.BPs
+	}
+
+	consensus.InitBlockInterval(cfg.Consensus.BlockInterval)
+
+	ids := make([]peer.ID, 0, len(cfg.Consensus.BpIds))
+	for _, bpid := range cfg.Consensus.BpIds {
+		id, err := peer.IDB58Decode(bpid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bp id %v: %v", bpid, err)
+		}
+		ids = append(ids, id)
+	}
+
+	quitC := make(chan interface{})
+	rn := newRaftNode(hub, p2p.NodeID(), cdb, ids)
+	raft := &Raft{
+		rn:   rn,
+		bf:   NewBlockFactory(hub, rn, quitC),
+		quit: quitC,
+	}
+	raft.BaseComponent = component.NewBaseComponent(message.ConsensusSvc, raft, logger)
+	hub.Register(raft)
+
+	return raft, nil
+}
+
+// BeforeStart does nothing.
+func (raft *Raft) BeforeStart() {}
+
+// AfterStart does nothing.
+func (raft *Raft) AfterStart() {}
+
+// BeforeStop does nothing.
+func (raft *Raft) BeforeStop() {}
+
+// Statistics returns nil.
+func (raft *Raft) Statistics() *map[string]interface{} {
+	return nil
+}
+
+// Receive passes the Raft messages of peers to the Raft node, and proposes
+// the membership changes.
+func (raft *Raft) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *types.RaftMessage:
+		if err := raft.rn.step(msg); err != nil {
+			logger.Debug().Err(err).Msg("failed to step raft message")
+		}
+	case *message.ChangeMembership:
+		err := raft.rn.changeMembership(msg.Remove, msg.ID)
+		if err != nil {
+			logger.Error().Err(err).Str("id", msg.ID.Pretty()).Bool("remove", msg.Remove).
+				Msg("failed to change raft membership")
+		}
+		context.Respond(&message.ChangeMembershipRsp{Err: err})
+	}
+}
+
+// Ticker returns a time.Ticker for the main consensus loop.
+func (raft *Raft) Ticker() *time.Ticker {
+	return time.NewTicker(consensus.BlockInterval)
+}
+
+// QueueJob send a block triggering information to jq if this node is the
+// leader.
+func (raft *Raft) QueueJob(now time.Time, jq chan<- interface{}) {
+	raft.bf.queueJob(jq)
+}
+
+// BlockFactory returns the BlockFactory interface in Raft.
+func (raft *Raft) BlockFactory() consensus.BlockFactory {
+	return raft.bf
+}
+
+// QuitChan returns the channel from which consensus-related goroutines check
+// when shutdown is initiated.
+func (raft *Raft) QuitChan() chan interface{} {
+	return raft.quit
+}
+
+// SetChainAccessor sets raft.ca to chainAccessor.
+func (raft *Raft) SetChainAccessor(chainAccessor types.ChainAccessor) {
+	raft.ca = chainAccessor
+	raft.bf.ca = chainAccessor
+	raft.rn.ca = chainAccessor
+}
+
+// SetStateDB sets sdb to the block factory. This method is called only once
+// during the boot sequence.
+func (raft *Raft) SetStateDB(sdb *state.ChainStateDB) {
+	raft.bf.sdb = sdb
+}
+
+// IsTransactionValid checks the Raft consensus level validity of a
+// transaction.
+func (raft *Raft) IsTransactionValid(tx *types.Tx) bool {
+	return true
+}
+
+// IsBlockValid checks the signature of block and that it is produced by a
+// member of the Raft cluster at the block. The members follow the Raft log, so
+// a node which has never joined the cluster knows only the initial members.
+func (raft *Raft) IsBlockValid(block *types.Block, bestBlock *types.Block, params *consensus.ChainParams) error {
+	if valid, err := block.VerifySign(); !valid {
+		return &consensus.ErrorConsensus{Msg: "bad block signature", Err: err}
+	}
+
+	id, err := block.BPID()
+	if err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad public key in block", Err: err}
+	}
+	if !raft.rn.isProducer(id, block.BlockNo()) {
+		return &consensus.ErrorConsensus{
+			Msg: fmt.Sprintf("BP %v is not a member of the raft cluster", block.BPID2Str()),
+		}
+	}
+
+	return nil
+}
+
+// Update has nothing to do since the blocks are connected by the Raft node.
+func (raft *Raft) Update(block *types.Block) error {
+	return nil
+}
+
+// Save has nothing to do. The Raft state is saved by the Raft node.
+func (raft *Raft) Save(tx db.Transaction) error {
+	return nil
+}
+
+// NeedReorganization returns false since a committed block is never reverted.
+func (raft *Raft) NeedReorganization(rootNo types.BlockNo) bool {
+	return false
+}
+
+// LibNo returns the number of the best block, which is irreversible once it
+// is committed.
+func (raft *Raft) LibNo() (types.BlockNo, bool) {
+	if best, _ := raft.ca.GetBestBlock(); best != nil {
+		return best.BlockNo(), true
+	}
+	return 0, false
+}
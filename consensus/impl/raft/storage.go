@@ -0,0 +1,191 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package raft
+
+import (
+	"encoding/binary"
+
+	"github.com/aergoio/aergo/consensus"
+	etcdraft "github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+const entryKeyPrefix = "raft.entry."
+
+var (
+	hardStateKey = []byte("raft.hardState")
+	snapshotKey  = []byte("raft.snapshot")
+	lastIndexKey = []byte("raft.lastIndex")
+)
+
+func entryKey(index uint64) []byte {
+	key := make([]byte, len(entryKeyPrefix)+8)
+	copy(key, entryKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(entryKeyPrefix):], index)
+	return key
+}
+
+// raftStorage is the storage of the Raft log, which is kept in memory and
+// persisted in the chain DB. Everything is written to the chain DB before it
+// is added to the memory storage.
+type raftStorage struct {
+	*etcdraft.MemoryStorage
+	cdb consensus.ChainDB
+}
+
+func newRaftStorage(cdb consensus.ChainDB) *raftStorage {
+	return &raftStorage{
+		MemoryStorage: etcdraft.NewMemoryStorage(),
+		cdb:           cdb,
+	}
+}
+
+// load restores the snapshot, the hard state and the entries persisted in the
+// chain DB. It reports false if nothing is persisted, i.e. the node has never
+// run.
+func (rs *raftStorage) load() (bool, error) {
+	exist := false
+
+	if data := rs.cdb.Get(snapshotKey); len(data) != 0 {
+		var snap raftpb.Snapshot
+		if err := snap.Unmarshal(data); err != nil {
+			return false, err
+		}
+		if err := rs.ApplySnapshot(snap); err != nil {
+			return false, err
+		}
+		exist = true
+	}
+
+	if data := rs.cdb.Get(hardStateKey); len(data) != 0 {
+		var hs raftpb.HardState
+		if err := hs.Unmarshal(data); err != nil {
+			return false, err
+		}
+		if err := rs.SetHardState(hs); err != nil {
+			return false, err
+		}
+		exist = true
+	}
+
+	data := rs.cdb.Get(lastIndexKey)
+	if len(data) != 8 {
+		return exist, nil
+	}
+	lastIndex := binary.BigEndian.Uint64(data)
+
+	first, err := rs.FirstIndex()
+	if err != nil {
+		return false, err
+	}
+
+	var entries []raftpb.Entry
+	for i := first; i <= lastIndex; i++ {
+		var e raftpb.Entry
+		if err := e.Unmarshal(rs.cdb.Get(entryKey(i))); err != nil {
+			return false, err
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rs.Append(entries); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// save persists the state of a Ready and then adds it to the memory storage.
+// The entries replace the conflicting ones, so the last index is always that
+// of entries.
+func (rs *raftStorage) save(hs raftpb.HardState, entries []raftpb.Entry, snap raftpb.Snapshot) error {
+	tx := rs.cdb.NewTx()
+	defer tx.Discard()
+
+	if !etcdraft.IsEmptySnap(snap) {
+		data, err := snap.Marshal()
+		if err != nil {
+			return err
+		}
+		tx.Set(snapshotKey, data)
+		tx.Set(lastIndexKey, uint64ToBytes(snap.Metadata.Index))
+	}
+
+	if !etcdraft.IsEmptyHardState(hs) {
+		data, err := hs.Marshal()
+		if err != nil {
+			return err
+		}
+		tx.Set(hardStateKey, data)
+	}
+
+	for _, e := range entries {
+		data, err := e.Marshal()
+		if err != nil {
+			return err
+		}
+		tx.Set(entryKey(e.Index), data)
+	}
+	if len(entries) > 0 {
+		tx.Set(lastIndexKey, uint64ToBytes(entries[len(entries)-1].Index))
+	}
+
+	tx.Commit()
+
+	if !etcdraft.IsEmptySnap(snap) {
+		if err := rs.ApplySnapshot(snap); err != nil {
+			return err
+		}
+	}
+	if !etcdraft.IsEmptyHardState(hs) {
+		if err := rs.SetHardState(hs); err != nil {
+			return err
+		}
+	}
+	return rs.Append(entries)
+}
+
+// compact makes a snapshot at index with data and discards the entries up to
+// compactIndex, which is less than index. The entries after compactIndex are
+// kept in memory for the slow followers, but a restarted node loads only the
+// entries after the snapshot.
+func (rs *raftStorage) compact(index uint64, compactIndex uint64, cs *raftpb.ConfState, data []byte) error {
+	first, err := rs.FirstIndex()
+	if err != nil {
+		return err
+	}
+
+	snap, err := rs.CreateSnapshot(index, cs, data)
+	if err != nil {
+		return err
+	}
+	if compactIndex >= first {
+		if err := rs.Compact(compactIndex); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := snap.Marshal()
+	if err != nil {
+		return err
+	}
+
+	tx := rs.cdb.NewTx()
+	defer tx.Discard()
+	tx.Set(snapshotKey, encoded)
+	for i := first; i <= index; i++ {
+		tx.Delete(entryKey(i))
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func uint64ToBytes(n uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, n)
+	return buf
+}
@@ -0,0 +1,84 @@
+package raft
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testChainDB struct {
+	db.DB
+}
+
+func (cdb *testChainDB) GetBestBlock() (*types.Block, error) {
+	return nil, nil
+}
+
+func (cdb *testChainDB) GetBlockByNo(blockNo types.BlockNo) (*types.Block, error) {
+	return nil, nil
+}
+
+func (cdb *testChainDB) GetGenesisInfo() *types.Genesis {
+	return nil
+}
+
+func entries(from, to uint64, term uint64) []raftpb.Entry {
+	var ents []raftpb.Entry
+	for i := from; i <= to; i++ {
+		ents = append(ents, raftpb.Entry{Index: i, Term: term, Data: []byte{byte(i)}})
+	}
+	return ents
+}
+
+func TestStorageLoad(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("", "raft")
+	defer os.RemoveAll(tmpdir)
+
+	cdb := &testChainDB{DB: db.NewDB(db.BadgerImpl, tmpdir)}
+	defer cdb.Close()
+
+	rs := newRaftStorage(cdb)
+	exist, err := rs.load()
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	hs := raftpb.HardState{Term: 2, Vote: 1, Commit: 4}
+	assert.NoError(t, rs.save(hs, entries(1, 5, 1), raftpb.Snapshot{}))
+	// conflicting entries replace the uncommitted ones
+	assert.NoError(t, rs.save(raftpb.HardState{}, entries(5, 6, 2), raftpb.Snapshot{}))
+
+	cs := &raftpb.ConfState{Nodes: []uint64{1, 2, 3}}
+	assert.NoError(t, rs.compact(4, 3, cs, []byte("members")))
+
+	loaded := newRaftStorage(cdb)
+	exist, err = loaded.load()
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	first, _ := loaded.FirstIndex()
+	last, _ := loaded.LastIndex()
+	assert.Equal(t, uint64(5), first)
+	assert.Equal(t, uint64(6), last)
+
+	ents, err := loaded.Entries(first, last+1, 1024*1024)
+	assert.NoError(t, err)
+	if assert.Len(t, ents, 2) {
+		assert.Equal(t, uint64(2), ents[0].Term)
+		assert.Equal(t, []byte{6}, ents[1].Data)
+	}
+
+	loadedHs, loadedCs, err := loaded.InitialState()
+	assert.NoError(t, err)
+	assert.Equal(t, hs, loadedHs)
+	assert.Equal(t, cs.Nodes, loadedCs.Nodes)
+
+	snap, err := loaded.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), snap.Metadata.Index)
+	assert.Equal(t, []byte("members"), snap.Data)
+}
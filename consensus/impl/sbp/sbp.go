@@ -48,14 +48,13 @@ func (te *txExec) Apply(bState *state.BlockState, tx *types.Tx) error {
 // This can be used for testing purpose.
 type SimpleBlockFactory struct {
 	*component.ComponentHub
-	jobQueue         chan interface{}
-	blockInterval    time.Duration
-	maxBlockBodySize uint32
-	txOp             chain.TxOp
-	quit             chan interface{}
-	sdb              *state.ChainStateDB
-	ca               types.ChainAccessor
-	prevBlock        *types.Block
+	jobQueue      chan interface{}
+	blockInterval time.Duration
+	txOp          chain.TxOp
+	quit          chan interface{}
+	sdb           *state.ChainStateDB
+	ca            types.ChainAccessor
+	prevBlock     *types.Block
 }
 
 // New returns a SimpleBlockFactory.
@@ -63,11 +62,10 @@ func New(cfg *config.Config, hub *component.ComponentHub) (*SimpleBlockFactory,
 	consensus.InitBlockInterval(cfg.Consensus.BlockInterval)
 
 	s := &SimpleBlockFactory{
-		ComponentHub:     hub,
-		jobQueue:         make(chan interface{}, slotQueueMax),
-		blockInterval:    consensus.BlockInterval,
-		maxBlockBodySize: chain.MaxBlockBodySize(),
-		quit:             make(chan interface{}),
+		ComponentHub:  hub,
+		jobQueue:      make(chan interface{}, slotQueueMax),
+		blockInterval: consensus.BlockInterval,
+		quit:          make(chan interface{}),
 	}
 
 	s.txOp = chain.NewCompTxOp(
@@ -114,7 +112,7 @@ func (s *SimpleBlockFactory) IsTransactionValid(tx *types.Tx) bool {
 }
 
 // IsBlockValid checks the consensus level validity of a block.
-func (s *SimpleBlockFactory) IsBlockValid(*types.Block, *types.Block) error {
+func (s *SimpleBlockFactory) IsBlockValid(*types.Block, *types.Block, *consensus.ChainParams) error {
 	// SimpleBlockFactory has no block valid check.
 	return nil
 }
@@ -126,7 +124,8 @@ func (s *SimpleBlockFactory) QuitChan() chan interface{} {
 }
 
 // Update has nothging to do.
-func (s *SimpleBlockFactory) Update(block *types.Block) {
+func (s *SimpleBlockFactory) Update(block *types.Block) error {
+	return nil
 }
 
 // Save has nothging to do.
@@ -148,6 +147,11 @@ func (s *SimpleBlockFactory) NeedReorganization(rootNo types.BlockNo) bool {
 	return true
 }
 
+// LibNo returns false since SBP doesn't maintain the LIB.
+func (s *SimpleBlockFactory) LibNo() (types.BlockNo, bool) {
+	return 0, false
+}
+
 // Start run a simple block factory service.
 func (s *SimpleBlockFactory) Start() {
 	defer logger.Info().Msg("shutdown initiated. stop the service")
@@ -45,31 +45,35 @@ func SetPreloadTx(tx *types.Tx, service int) {
 	preLoadInfos[service].requestedTx = tx
 }
 
+// Execute runs the transaction on the receiver contract and returns the result
+// of the call, the events emitted by the contract and the gas used by the
+// execution. The gas used is returned even if the execution fails. The gas is
+// metered only if isV2 is set, i.e. from the v2 hardfork.
 func Execute(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
-	sender, receiver *state.V, preLoadService int) (string, error) {
+	sender, receiver *state.V, preLoadService int, isV2 bool) (string, []*types.Event, uint64, error) {
 
 	txBody := tx.GetBody()
 
 	// Transfer balance
 	if sender.AccountID() != receiver.AccountID() {
 		if sender.Balance().Cmp(txBody.GetAmountBigInt()) < 0 {
-			return "", types.ErrInsufficientBalance
+			return "", nil, 0, types.ErrInsufficientBalance
 		}
 		sender.SubBalance(txBody.GetAmountBigInt())
 		receiver.AddBalance(txBody.GetAmountBigInt())
 	}
 
 	if txBody.Payload == nil {
-		return "", nil
+		return "", nil, 0, nil
 	}
 
 	if !receiver.IsNew() && len(receiver.State().CodeHash) == 0 {
-		return "", errors.New("account is not a contract")
+		return "", nil, 0, errors.New("account is not a contract")
 	}
 
 	contractState, err := bs.OpenContractState(receiver.AccountID(), receiver.State())
 	if err != nil {
-		return "", err
+		return "", nil, 0, err
 	}
 
 	var rv string
@@ -87,37 +91,45 @@ func Execute(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
 			break
 		}
 		if err != nil {
-			return "", err
+			return "", nil, 0, err
 		}
 	}
+	var stateSet *StateSet
 	if ex != nil {
-		rv, err = PreCall(ex, bs, sender, contractState, blockNo, ts, receiver.RP())
+		stateSet = ex.stateSet
 	} else {
-		stateSet := NewContext(bs, sender, receiver, contractState, sender.ID(),
+		stateSet = NewContext(bs, sender, receiver, contractState, sender.ID(),
 			tx.GetHash(), blockNo, ts, "", true,
 			false, receiver.RP(), preLoadService, txBody.GetAmountBigInt())
+	}
+	stateSet.unmetered = !isV2
+	if isV2 {
+		stateSet.gasLimit = txBody.GetGasLimit() - types.TxBaseGas
+	}
+	stateSet.usedGas = 0
 
-		if receiver.IsCreate() {
-			rv, err = Create(contractState, txBody.Payload, receiver.ID(), stateSet)
-		} else {
-			rv, err = Call(contractState, txBody.Payload, receiver.ID(), stateSet)
-		}
+	if ex != nil {
+		rv, err = PreCall(ex, bs, sender, contractState, blockNo, ts, receiver.RP())
+	} else if receiver.IsCreate() {
+		rv, err = Create(contractState, txBody.Payload, receiver.ID(), stateSet)
+	} else {
+		rv, err = Call(contractState, txBody.Payload, receiver.ID(), stateSet)
 	}
 	if err != nil {
 		if err == types.ErrInsufficientBalance || err == types.ErrVmStart {
-			return "", err
+			return "", nil, stateSet.usedGas, err
 		} else if _, ok := err.(DbSystemError); ok {
-			return "", err
+			return "", nil, stateSet.usedGas, err
 		}
-		return "", VmError(err)
+		return "", nil, stateSet.usedGas, VmError(err)
 	}
 
 	err = bs.StageContractState(contractState)
 	if err != nil {
-		return "", err
+		return "", nil, stateSet.usedGas, err
 	}
 
-	return rv, nil
+	return rv, stateSet.events, stateSet.usedGas, nil
 }
 
 func PreLoadRequest(bs *state.BlockState, tx *types.Tx, preLoadService int) {
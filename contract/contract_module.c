@@ -228,6 +228,29 @@ static int modulePcall(lua_State *L)
 	return lua_gettop(L);
 }
 
+static int moduleEvent(lua_State *L)
+{
+	char *event_name;
+	char *json_args;
+	int *service = (int *)getLuaExecContext(L);
+
+	if (service == NULL) {
+		luaL_error(L, "cannot find execution context");
+	}
+
+	event_name = (char *)luaL_checkstring(L, 1);
+	json_args = lua_util_get_json_from_stack (L, 2, lua_gettop(L), false);
+	if (json_args == NULL) {
+		lua_error(L);
+	}
+	if (LuaEvent(L, service, event_name, json_args) < 0) {
+		free(json_args);
+		lua_error(L);
+	}
+	free(json_args);
+	return 0;
+}
+
 static const luaL_Reg call_methods[] = {
 	{"value", call_value},
 	{"gas", call_gas},
@@ -253,6 +276,7 @@ static const luaL_Reg contract_lib[] = {
 	{"balance", moduleBalance},
 	{"send", moduleSend},
 	{"pcall", modulePcall},
+	{"event", moduleEvent},
 	{NULL, NULL}
 };
 
@@ -305,6 +305,7 @@ static int db_pstmt_exec(lua_State *L)
         luaL_error(L, sqlite3_errmsg(pstmt->db));
     }
     n = sqlite3_changes(pstmt->db);
+    vm_use_sql_gas(L, n);
     lua_pushinteger(L, n);
     return 1;
 }
@@ -371,6 +372,7 @@ static int db_exec(lua_State *L)
     rc = sqlite3_exec(db, cmd, 0, 0, 0);
     LAST_ERROR(L, db, rc);
     n = sqlite3_changes(db);
+    vm_use_sql_gas(L, n);
     lua_pushinteger(L, n);
     return 1;
 }
@@ -0,0 +1,59 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+import "github.com/aergoio/aergo/types"
+
+// gas schedule of the contract execution
+const (
+	// instructionGas is charged per Lua VM instruction
+	instructionGas = 1
+	// stateSetGas and stateByteGas are charged per write to the contract
+	// state. stateByteGas is charged per byte of the key and the value
+	stateSetGas  = 100
+	stateByteGas = 1
+	stateDelGas  = 50
+	// sqlExecGas is charged per SQL execution and sqlRowGas per changed row
+	sqlExecGas = 100
+	sqlRowGas  = 10
+	// callGas is charged per contract.call, contract.delegatecall and
+	// contract.send
+	callGas = 1000
+	// eventGas and stateByteGas are charged per contract.event
+	eventGas = 100
+
+	queryGasLimit = types.DefaultTxGasLimit
+)
+
+// useGas charges gas to the executing tx. It returns false and uses up all
+// the remaining gas if the gas limit is exceeded. No gas is charged to an
+// unmetered tx.
+func (s *StateSet) useGas(gas uint64) bool {
+	if s.unmetered {
+		return true
+	}
+	if s.gasLimit-s.usedGas < gas {
+		s.usedGas = s.gasLimit
+		return false
+	}
+	s.usedGas += gas
+	return true
+}
+
+// limitGas restricts the remaining gas to at most gas and returns the
+// previous gas limit. It does nothing if gas is 0.
+func (s *StateSet) limitGas(gas uint64) uint64 {
+	prev := s.gasLimit
+	if gas > 0 && s.gasLimit-s.usedGas > gas {
+		s.gasLimit = s.usedGas + gas
+	}
+	return prev
+}
+
+// UsedGas returns the gas used during the execution.
+func (s *StateSet) UsedGas() uint64 {
+	return s.usedGas
+}
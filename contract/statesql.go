@@ -158,6 +158,21 @@ func conn(dbName string) (*DB, error) {
 	return openDB(dbName)
 }
 
+// DatabaseFile returns the path of the sql database file of a contract.
+func DatabaseFile(dbName string) string {
+	return filepath.Join(database.DataDir, dbName+".db")
+}
+
+// TruncateDatabase removes the commits of the sql database after the recovery
+// point rp, which is the one of the contract state.
+func TruncateDatabase(dbName string, rp uint64) error {
+	db, err := conn(dbName)
+	if err != nil {
+		return err
+	}
+	return db.restoreRecoveryPoint(rp)
+}
+
 func dataSrc(dbName string) string {
 	return fmt.Sprintf("file:%s/%s.db?branches=on", database.DataDir, dbName)
 }
@@ -27,6 +27,16 @@ func ExecuteSystemTx(txBody *types.TxBody, senderState *types.State,
 		err = voting(txBody, scs, blockNo)
 	case 'u':
 		err = unstaking(txBody, senderState, scs, blockNo)
+	case 'e':
+		err = slashing(txBody, scs, blockNo)
+	case 'c':
+		err = claiming(txBody, senderState, scs, blockNo)
+	case 'p':
+		err = proposing(txBody, scs, blockNo)
+	case 'a':
+		err = approving(txBody, scs, blockNo)
+	case 'x':
+		err = executing(txBody, scs, blockNo)
 	}
 	if err != nil {
 		return err
@@ -63,6 +73,16 @@ func ValidateSystemTx(txBody *types.TxBody, scs *state.ContractState, blockNo ui
 		}
 	case 'u':
 		_, err = validateForUnstaking(txBody, scs, blockNo)
+	case 'e':
+		_, _, _, err = validateForSlashing(txBody, scs)
+	case 'c':
+		err = validateForClaiming(txBody, scs)
+	case 'p':
+		_, _, err = validateForProposal(txBody, scs, blockNo)
+	case 'a':
+		_, _, err = validateForApproval(txBody, scs, blockNo)
+	case 'x':
+		_, _, err = validateForExecution(txBody, scs, blockNo)
 	}
 	if err != nil {
 		return err
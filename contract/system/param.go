@@ -0,0 +1,442 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"math/big"
+	"sort"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// The chain parameters which can be changed by the governance.
+const (
+	// ParamBlockInterval is the block interval in seconds.
+	ParamBlockInterval = "blockinterval"
+	// ParamMaxBlockSize is the maximum block size in bytes.
+	ParamMaxBlockSize = "maxblocksize"
+	// ParamBpRewardRate is the percentage of the block reward which goes to
+	// the BP. The rest is shared by the voters of the BP.
+	ParamBpRewardRate = "bprewardrate"
+	// ParamBpCount is the number of the BPs elected in DPoS.
+	ParamBpCount = "bpcount"
+)
+
+type paramRange struct {
+	min, max uint64
+}
+
+// params is the whitelist of the chain parameters with their valid ranges.
+var params = map[string]paramRange{
+	ParamBlockInterval: {1, 60},
+	ParamMaxBlockSize:  {1 << 12, 1 << 22},
+	ParamBpRewardRate:  {0, 100},
+	ParamBpCount:       {1, 100},
+}
+
+// defaultParams is the values of the chain parameters given by the config,
+// which are effective until the governance changes them.
+var defaultParams = map[string]uint64{
+	ParamBpRewardRate: BpRewardRate,
+}
+
+var proposalkey = []byte("proposal")
+var paramkey = []byte("param")
+var stakingtotalkey = []byte("stakingtotal")
+var stakingcountedkey = []byte("stakingcounted")
+var stakingseededkey = []byte("stakingseeded")
+
+// paramProposal is a proposal to change a chain parameter from a future
+// block. It is executed once the stakers approving it hold more than 2/3 of
+// the total staking.
+type paramProposal struct {
+	Name      string
+	Value     uint64
+	BlockNo   uint64
+	Approvers [][]byte
+	Executed  bool
+}
+
+// paramChange is the value of a chain parameter which is effective from
+// BlockNo.
+type paramChange struct {
+	BlockNo uint64
+	Value   uint64
+}
+
+// proposing creates a proposal and approves it by the sender. The payload is
+// 'p' followed by the encoded types.ChainParam.
+func proposing(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) error {
+	param, id, err := validateForProposal(txBody, scs, blockNo)
+	if err != nil {
+		return err
+	}
+	proposal, err := getProposal(scs, id)
+	if err != nil {
+		return err
+	}
+	if proposal == nil {
+		proposal = &paramProposal{Name: param.GetName(), Value: param.GetValue(), BlockNo: param.GetBlockNo()}
+	}
+	return approve(scs, id, proposal, txBody.Account)
+}
+
+// approving approves the proposal of the payload, which is 'a' followed by the
+// encoded types.ChainParam of the proposal.
+func approving(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) error {
+	id, proposal, err := validateForApproval(txBody, scs, blockNo)
+	if err != nil {
+		return err
+	}
+	return approve(scs, id, proposal, txBody.Account)
+}
+
+func approve(scs *state.ContractState, id []byte, proposal *paramProposal, approver []byte) error {
+	for _, a := range proposal.Approvers {
+		if bytes.Equal(a, approver) {
+			return types.ErrAlreadyApproved
+		}
+	}
+	// the staking total must include the staking of every approver
+	staked, err := getStaking(scs, approver)
+	if err != nil {
+		return err
+	}
+	if err := countStaking(scs, approver, staked); err != nil {
+		return err
+	}
+	proposal.Approvers = append(proposal.Approvers, approver)
+	return setProposal(scs, id, proposal)
+}
+
+// executing schedules the change of the proposal of the payload, which is 'x'
+// followed by the encoded types.ChainParam of the proposal. The approval is
+// counted by the current staking of the approvers, and it is refused until the
+// staking total is seeded at the v2 hardfork.
+func executing(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) error {
+	id, proposal, err := validateForExecution(txBody, scs, blockNo)
+	if err != nil {
+		return err
+	}
+
+	changes, err := getParamChanges(scs, proposal.Name)
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(changes), func(i int) bool { return changes[i].BlockNo >= proposal.BlockNo })
+	if i < len(changes) && changes[i].BlockNo == proposal.BlockNo {
+		changes[i].Value = proposal.Value
+	} else {
+		changes = append(changes, nil)
+		copy(changes[i+1:], changes[i:])
+		changes[i] = &paramChange{BlockNo: proposal.BlockNo, Value: proposal.Value}
+	}
+	if err := setParamChanges(scs, proposal.Name, changes); err != nil {
+		return err
+	}
+
+	proposal.Executed = true
+	return setProposal(scs, id, proposal)
+}
+
+func validateForProposal(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) (*types.ChainParam, []byte, error) {
+	var param types.ChainParam
+	if err := proto.Unmarshal(txBody.Payload[1:], &param); err != nil {
+		return nil, nil, types.ErrTxFormatInvalid
+	}
+	r, ok := params[param.GetName()]
+	if !ok {
+		return nil, nil, types.ErrUnknownParam
+	}
+	if param.GetValue() < r.min || param.GetValue() > r.max {
+		return nil, nil, types.ErrParamOutOfRange
+	}
+	if param.GetBlockNo() <= blockNo {
+		return nil, nil, types.ErrParamChangeTooLate
+	}
+	if err := validateStaked(scs, txBody.Account); err != nil {
+		return nil, nil, err
+	}
+	return &param, proposalID(&param), nil
+}
+
+func validateForApproval(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) ([]byte, *paramProposal, error) {
+	id, proposal, err := openProposal(txBody, scs, blockNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateStaked(scs, txBody.Account); err != nil {
+		return nil, nil, err
+	}
+	return id, proposal, nil
+}
+
+func validateForExecution(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) ([]byte, *paramProposal, error) {
+	id, proposal, err := openProposal(txBody, scs, blockNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the staking total misses the staking made before it was kept
+	seeded, err := scs.GetData(stakingseededkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(seeded) == 0 {
+		return nil, nil, types.ErrStakingTotalNotSeeded
+	}
+
+	approval := new(big.Int)
+	for _, a := range proposal.Approvers {
+		staked, err := getStaking(scs, a)
+		if err != nil {
+			return nil, nil, err
+		}
+		approval.Add(approval, staked.GetAmountBigInt())
+	}
+	total, err := getStakingTotal(scs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if new(big.Int).Mul(approval, big.NewInt(3)).Cmp(new(big.Int).Mul(total, big.NewInt(2))) <= 0 {
+		return nil, nil, types.ErrNotEnoughApproval
+	}
+	return id, proposal, nil
+}
+
+// openProposal returns the proposal of the payload, which is neither executed
+// nor expired.
+func openProposal(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) ([]byte, *paramProposal, error) {
+	var param types.ChainParam
+	if err := proto.Unmarshal(txBody.Payload[1:], &param); err != nil {
+		return nil, nil, types.ErrTxFormatInvalid
+	}
+	id := proposalID(&param)
+	proposal, err := getProposal(scs, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if proposal == nil {
+		return nil, nil, types.ErrNoProposal
+	}
+	if proposal.Executed {
+		return nil, nil, types.ErrProposalExecuted
+	}
+	if proposal.BlockNo <= blockNo {
+		return nil, nil, types.ErrParamChangeTooLate
+	}
+	return id, proposal, nil
+}
+
+func validateStaked(scs *state.ContractState, account []byte) error {
+	staked, err := getStaking(scs, account)
+	if err != nil {
+		return err
+	}
+	if staked.GetAmountBigInt().Cmp(new(big.Int).SetUint64(0)) == 0 {
+		return types.ErrMustStakeBeforeVote
+	}
+	return nil
+}
+
+func proposalID(param *types.ChainParam) []byte {
+	digest := sha256.New()
+	digest.Write([]byte(param.GetName()))
+	binary.Write(digest, binary.BigEndian, param.GetValue())
+	binary.Write(digest, binary.BigEndian, param.GetBlockNo())
+	return digest.Sum(nil)
+}
+
+// GetParam returns the value of the chain parameter name which is effective
+// at blockNo. It reports false if the parameter has never been changed by the
+// governance until blockNo.
+func GetParam(scs *state.ContractState, name string, blockNo types.BlockNo) (uint64, bool, error) {
+	changes, err := getParamChanges(scs, name)
+	if err != nil {
+		return 0, false, err
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		if changes[i].BlockNo <= blockNo {
+			return changes[i].Value, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// SetDefaultParams sets the values of the chain parameters effective until the
+// governance changes them. It is called once on boot.
+func SetDefaultParams(list []*types.ChainParam) {
+	for _, p := range list {
+		defaultParams[p.GetName()] = p.GetValue()
+	}
+}
+
+// GetParamOrDefault returns the value of the chain parameter name which is
+// effective at blockNo, or its default value if the governance has never
+// changed it until blockNo.
+func GetParamOrDefault(scs *state.ContractState, name string, blockNo types.BlockNo) (uint64, error) {
+	value, changed, err := GetParam(scs, name, blockNo)
+	if err != nil {
+		return 0, err
+	}
+	if !changed {
+		return defaultParams[name], nil
+	}
+	return value, nil
+}
+
+// GetParams returns the chain parameters changed by the governance, which are
+// effective at blockNo.
+func GetParams(scs *state.ContractState, blockNo types.BlockNo) ([]*types.ChainParam, error) {
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list []*types.ChainParam
+	for _, name := range names {
+		changes, err := getParamChanges(scs, name)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(changes) - 1; i >= 0; i-- {
+			if changes[i].BlockNo <= blockNo {
+				list = append(list, &types.ChainParam{Name: name, Value: changes[i].Value, BlockNo: changes[i].BlockNo})
+				break
+			}
+		}
+	}
+	return list, nil
+}
+
+// ConsensusKeys returns the keys of the storage read for the BP election and
+// the chain parameters, so that a light node, which keeps no state, can fetch
+// them from a peer with their proofs.
+func ConsensusKeys() [][]byte {
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := [][]byte{sortedlistkey, slashedbpskey}
+	for _, name := range names {
+		keys = append(keys, append(append([]byte{}, paramkey...), name...))
+	}
+	return keys
+}
+
+func getProposal(scs *state.ContractState, id []byte) (*paramProposal, error) {
+	data, err := scs.GetData(append(proposalkey, id...))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var proposal paramProposal
+	dec := gob.NewDecoder(bytes.NewBuffer(data))
+	if err := dec.Decode(&proposal); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func setProposal(scs *state.ContractState, id []byte, proposal *paramProposal) error {
+	var data bytes.Buffer
+	enc := gob.NewEncoder(&data)
+	if err := enc.Encode(proposal); err != nil {
+		return err
+	}
+	return scs.SetData(append(proposalkey, id...), data.Bytes())
+}
+
+func getParamChanges(scs *state.ContractState, name string) ([]*paramChange, error) {
+	data, err := scs.GetData(append(paramkey, name...))
+	if err != nil {
+		return nil, err
+	}
+	var changes []*paramChange
+	if len(data) != 0 {
+		dec := gob.NewDecoder(bytes.NewBuffer(data))
+		if err := dec.Decode(&changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func setParamChanges(scs *state.ContractState, name string, changes []*paramChange) error {
+	var data bytes.Buffer
+	enc := gob.NewEncoder(&data)
+	if err := enc.Encode(changes); err != nil {
+		return err
+	}
+	return scs.SetData(append(paramkey, name...), data.Bytes())
+}
+
+func getStakingTotal(scs *state.ContractState) (*big.Int, error) {
+	data, err := scs.GetData(stakingtotalkey)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// addStakingTotal adds amount, which is negative for unstaking, to the staking
+// total. Every staking is counted by countStaking before it changes, so the
+// total never goes below zero.
+func addStakingTotal(scs *state.ContractState, amount *big.Int) error {
+	total, err := getStakingTotal(scs)
+	if err != nil {
+		return err
+	}
+	if total.Add(total, amount).Sign() < 0 {
+		return types.ErrStakingTotalUnderflow
+	}
+	return scs.SetData(stakingtotalkey, total.Bytes())
+}
+
+// SeedStakingTotal counts the staking of stakers, which have staked before the
+// staking total was kept, into the total. The contract state can't enumerate
+// them, so they are given by the chain once at the v2 hardfork, from which a
+// proposal can be executed.
+func SeedStakingTotal(scs *state.ContractState, stakers [][]byte) error {
+	for _, account := range stakers {
+		staked, err := getStaking(scs, account)
+		if err != nil {
+			return err
+		}
+		if err := countStaking(scs, account, staked); err != nil {
+			return err
+		}
+	}
+	return scs.SetData(stakingseededkey, []byte{1})
+}
+
+// countStaking adds staked, the staking of account, to the staking total if
+// it has not been counted yet, so that a staking is counted once either by
+// SeedStakingTotal or when the staker changes it or approves a proposal.
+func countStaking(scs *state.ContractState, account []byte, staked *types.Staking) error {
+	key := append(stakingcountedkey, account...)
+	counted, err := scs.GetData(key)
+	if err != nil {
+		return err
+	}
+	if len(counted) != 0 {
+		return nil
+	}
+	if err := addStakingTotal(scs, staked.GetAmountBigInt()); err != nil {
+		return err
+	}
+	return scs.SetData(key, []byte{1})
+}
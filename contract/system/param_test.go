@@ -0,0 +1,138 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamGovernance(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	paramTx := func(cmd byte, account []byte, name string, value, blockNo uint64) *types.TxBody {
+		param, err := proto.Marshal(&types.ChainParam{Name: name, Value: value, BlockNo: blockNo})
+		assert.NoError(t, err, "could not encode param")
+		return &types.TxBody{Account: account, Payload: append([]byte{cmd}, param...)}
+	}
+
+	var stakers [][]byte
+	for i := 0; i < 3; i++ {
+		account := []byte(fmt.Sprintf("%33d", i))
+		stakeTx := &types.TxBody{Account: account, Amount: types.StakingMinimum.Bytes(), Payload: []byte{'s'}}
+		err = staking(stakeTx, &types.State{Balance: types.StakingMinimum.Bytes()}, scs, 0)
+		assert.NoError(t, err, "staking failed")
+		stakers = append(stakers, account)
+	}
+
+	err = ValidateSystemTx(paramTx('p', stakers[0], "unknown", 1, 100), scs, 10)
+	assert.Equal(t, types.ErrUnknownParam, err)
+	err = ValidateSystemTx(paramTx('p', stakers[0], ParamBpRewardRate, 101, 100), scs, 10)
+	assert.Equal(t, types.ErrParamOutOfRange, err)
+	err = ValidateSystemTx(paramTx('p', stakers[0], ParamBpCount, 5, 10), scs, 10)
+	assert.Equal(t, types.ErrParamChangeTooLate, err)
+	err = ValidateSystemTx(paramTx('p', []byte(fmt.Sprintf("%33d", 9)), ParamBpCount, 5, 100), scs, 10)
+	assert.Equal(t, types.ErrMustStakeBeforeVote, err, "proposer should stake")
+	err = ValidateSystemTx(paramTx('a', stakers[1], ParamBpCount, 5, 100), scs, 10)
+	assert.Equal(t, types.ErrNoProposal, err)
+
+	err = ExecuteSystemTx(paramTx('p', stakers[0], ParamBpCount, 5, 100), nil, scs, 10)
+	assert.NoError(t, err, "proposal failed")
+	err = ExecuteSystemTx(paramTx('a', stakers[0], ParamBpCount, 5, 100), nil, scs, 11)
+	assert.Equal(t, types.ErrAlreadyApproved, err, "proposer has approved")
+
+	// the approval should be more than 2/3 of the total staking
+	err = ExecuteSystemTx(paramTx('a', stakers[1], ParamBpCount, 5, 100), nil, scs, 11)
+	assert.NoError(t, err, "approval failed")
+	err = ValidateSystemTx(paramTx('x', stakers[1], ParamBpCount, 5, 100), scs, 12)
+	assert.Equal(t, types.ErrStakingTotalNotSeeded, err, "staking total should be seeded")
+	assert.NoError(t, SeedStakingTotal(scs, nil), "could not seed staking total")
+	err = ValidateSystemTx(paramTx('x', stakers[1], ParamBpCount, 5, 100), scs, 12)
+	assert.Equal(t, types.ErrNotEnoughApproval, err)
+	err = ExecuteSystemTx(paramTx('a', stakers[2], ParamBpCount, 5, 100), nil, scs, 12)
+	assert.NoError(t, err, "approval failed")
+	err = ExecuteSystemTx(paramTx('x', stakers[1], ParamBpCount, 5, 100), nil, scs, 13)
+	assert.NoError(t, err, "execution failed")
+	err = ValidateSystemTx(paramTx('x', stakers[1], ParamBpCount, 5, 100), scs, 14)
+	assert.Equal(t, types.ErrProposalExecuted, err)
+
+	value, changed, err := GetParam(scs, ParamBpCount, 99)
+	assert.NoError(t, err, "could not get param")
+	assert.False(t, changed, "should not be effective before the block")
+	value, changed, err = GetParam(scs, ParamBpCount, 100)
+	assert.NoError(t, err, "could not get param")
+	assert.True(t, changed, "should be effective from the block")
+	assert.Equal(t, uint64(5), value)
+
+	params, err := GetParams(scs, 100)
+	assert.NoError(t, err, "could not get params")
+	assert.Equal(t, []*types.ChainParam{{Name: ParamBpCount, Value: 5, BlockNo: 100}}, params)
+
+	SetDefaultParams([]*types.ChainParam{{Name: ParamBpCount, Value: 3}})
+	value, err = GetParamOrDefault(scs, ParamBpCount, 99)
+	assert.NoError(t, err, "could not get param")
+	assert.Equal(t, uint64(3), value, "default before the block")
+	value, err = GetParamOrDefault(scs, ParamBpCount, 100)
+	assert.NoError(t, err, "could not get param")
+	assert.Equal(t, uint64(5), value, "changed from the block")
+}
+
+func TestStakingTotalBackfill(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	// a staking made before the staking total was kept
+	old := []byte(fmt.Sprintf("%33d", 0))
+	legacy := new(big.Int).Mul(types.StakingMinimum, big.NewInt(2))
+	err = setStaking(scs, old, &types.Staking{Amount: legacy.Bytes()})
+	assert.NoError(t, err, "could not set staking")
+
+	staker := []byte(fmt.Sprintf("%33d", 1))
+	stakeTx := &types.TxBody{Account: staker, Amount: types.StakingMinimum.Bytes(), Payload: []byte{'s'}}
+	err = staking(stakeTx, &types.State{Balance: types.StakingMinimum.Bytes()}, scs, 0)
+	assert.NoError(t, err, "staking failed")
+
+	total, err := getStakingTotal(scs)
+	assert.NoError(t, err, "could not get staking total")
+	assert.Equal(t, types.StakingMinimum.String(), total.String(), "only the new staking is counted")
+
+	unstakeTx := &types.TxBody{Account: old, Amount: types.StakingMinimum.Bytes(), Payload: []byte{'u'}}
+	err = unstaking(unstakeTx, &types.State{}, scs, StakingDelay)
+	assert.NoError(t, err, "unstaking the old staking failed")
+
+	total, err = getStakingTotal(scs)
+	assert.NoError(t, err, "could not get staking total")
+	assert.Equal(t, legacy.String(), total.String(), "the old staking should be counted before unstaking")
+
+	err = unstaking(unstakeTx, &types.State{}, scs, StakingDelay*2)
+	assert.NoError(t, err, "unstaking the rest failed")
+	total, err = getStakingTotal(scs)
+	assert.NoError(t, err, "could not get staking total")
+	assert.Equal(t, types.StakingMinimum.String(), total.String(), "staking total")
+
+	err = addStakingTotal(scs, new(big.Int).Neg(legacy))
+	assert.Equal(t, types.ErrStakingTotalUnderflow, err, "staking total should not go below zero")
+
+	// the other old stakings are counted once by the seed
+	other := []byte(fmt.Sprintf("%33d", 2))
+	err = setStaking(scs, other, &types.Staking{Amount: legacy.Bytes()})
+	assert.NoError(t, err, "could not set staking")
+	err = SeedStakingTotal(scs, [][]byte{old, staker, other})
+	assert.NoError(t, err, "could not seed staking total")
+	total, err = getStakingTotal(scs)
+	assert.NoError(t, err, "could not get staking total")
+	assert.Equal(t, new(big.Int).Add(types.StakingMinimum, legacy).String(), total.String(), "seeded staking total")
+}
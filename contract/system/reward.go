@@ -0,0 +1,204 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/big"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58"
+)
+
+var rewardkey = []byte("reward")
+var rewardpervotekey = []byte("rewardpervote")
+
+// BpRewardRate is the default percentage of the block reward which goes to
+// the coinbase account of the BP, until ParamBpRewardRate is changed. The rest
+// is shared by the voters of the BP in proportion to their votes.
+const BpRewardRate = 30
+
+// rewardPrecision scales the reward per vote to keep its fraction. It is
+// large enough for the votes up to types.MaxAER.
+var rewardPrecision = new(big.Int).Exp(big.NewInt(10), big.NewInt(36), nil)
+
+// voterReward is the reward accounting of a voter. The reward earned by a
+// vote is its amount multiplied by the increase of the reward per vote of
+// each candidate since the last settlement.
+type voterReward struct {
+	Pending []byte
+	// RewardPerVote is that of each candidate of the vote at the last
+	// settlement, in the order of the candidates. A candidate without it, as
+	// of a vote cast before the reward sharing, starts from zero.
+	RewardPerVote [][]byte
+}
+
+// DistributeReward shares reward of the block blockNo produced by bpID with
+// the voters of the BP, and returns the rest of reward, which is for the BP.
+// The remainder of the division among the voters is burned.
+func DistributeReward(scs *state.ContractState, bpID []byte, reward *big.Int, blockNo types.BlockNo) (*big.Int, error) {
+	voteResult, err := loadVoteResult(scs)
+	if err != nil {
+		return nil, err
+	}
+	votes := (*voteResult)[base58.Encode(bpID)]
+	if votes == nil || votes.Sign() <= 0 {
+		return reward, nil
+	}
+
+	rate, err := GetParamOrDefault(scs, ParamBpRewardRate, blockNo)
+	if err != nil {
+		return nil, err
+	}
+
+	voterShare := new(big.Int).Mul(reward, new(big.Int).SetUint64(100-rate))
+	voterShare.Div(voterShare, big.NewInt(100))
+	if voterShare.Sign() == 0 {
+		return reward, nil
+	}
+
+	perVote, err := getRewardPerVote(scs, bpID)
+	if err != nil {
+		return nil, err
+	}
+	inc := new(big.Int).Mul(voterShare, rewardPrecision)
+	perVote.Add(perVote, inc.Div(inc, votes))
+	if err := scs.SetData(append(rewardpervotekey, bpID...), perVote.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Sub(reward, voterShare), nil
+}
+
+// claiming moves the whole pending reward of the sender to its balance.
+func claiming(txBody *types.TxBody, senderState *types.State, scs *state.ContractState, blockNo types.BlockNo) error {
+	vote, err := getVote(scs, txBody.Account)
+	if err != nil {
+		return err
+	}
+	reward, err := settleReward(scs, txBody.Account, vote, vote.Candidate)
+	if err != nil {
+		return err
+	}
+	pending := new(big.Int).SetBytes(reward.Pending)
+	if pending.Sign() == 0 {
+		return types.ErrNoReward
+	}
+
+	reward.Pending = nil
+	if err := setVoterReward(scs, txBody.Account, reward); err != nil {
+		return err
+	}
+
+	senderState.Balance = new(big.Int).Add(senderState.GetBalanceBigInt(), pending).Bytes()
+	return nil
+}
+
+func validateForClaiming(txBody *types.TxBody, scs *state.ContractState) error {
+	pending, err := pendingReward(scs, txBody.Account)
+	if err != nil {
+		return err
+	}
+	if pending.Sign() == 0 {
+		return types.ErrNoReward
+	}
+	return nil
+}
+
+// settleReward adds the reward earned by vote to the pending reward of voter,
+// and restarts the accounting for candidates, which are voted from now on.
+func settleReward(scs *state.ContractState, voter []byte, vote *types.Vote, candidates []byte) (*voterReward, error) {
+	reward, err := getVoterReward(scs, voter)
+	if err != nil {
+		return nil, err
+	}
+	earned, err := earnedReward(scs, vote, reward)
+	if err != nil {
+		return nil, err
+	}
+	reward.Pending = new(big.Int).Add(new(big.Int).SetBytes(reward.Pending), earned).Bytes()
+
+	reward.RewardPerVote = nil
+	for offset := 0; offset < len(candidates); offset += PeerIDLength {
+		perVote, err := getRewardPerVote(scs, candidates[offset:offset+PeerIDLength])
+		if err != nil {
+			return nil, err
+		}
+		reward.RewardPerVote = append(reward.RewardPerVote, perVote.Bytes())
+	}
+
+	return reward, setVoterReward(scs, voter, reward)
+}
+
+func earnedReward(scs *state.ContractState, vote *types.Vote, reward *voterReward) (*big.Int, error) {
+	earned := new(big.Int)
+	for i := 0; (i+1)*PeerIDLength <= len(vote.Candidate); i++ {
+		perVote, err := getRewardPerVote(scs, vote.Candidate[i*PeerIDLength:(i+1)*PeerIDLength])
+		if err != nil {
+			return nil, err
+		}
+		if i < len(reward.RewardPerVote) {
+			perVote.Sub(perVote, new(big.Int).SetBytes(reward.RewardPerVote[i]))
+		}
+		earned.Add(earned, perVote)
+	}
+	earned.Mul(earned, vote.GetAmountBigInt())
+	return earned.Div(earned, rewardPrecision), nil
+}
+
+func pendingReward(scs *state.ContractState, voter []byte) (*big.Int, error) {
+	vote, err := getVote(scs, voter)
+	if err != nil {
+		return nil, err
+	}
+	reward, err := getVoterReward(scs, voter)
+	if err != nil {
+		return nil, err
+	}
+	earned, err := earnedReward(scs, vote, reward)
+	if err != nil {
+		return nil, err
+	}
+	return earned.Add(earned, new(big.Int).SetBytes(reward.Pending)), nil
+}
+
+func getRewardPerVote(scs *state.ContractState, candidate []byte) (*big.Int, error) {
+	data, err := scs.GetData(append(rewardpervotekey, candidate...))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func getVoterReward(scs *state.ContractState, voter []byte) (*voterReward, error) {
+	key := append(rewardkey, voter...)
+	data, err := scs.GetData(key)
+	if err != nil {
+		return nil, err
+	}
+	var reward voterReward
+	if len(data) != 0 {
+		dec := gob.NewDecoder(bytes.NewBuffer(data))
+		err = dec.Decode(&reward)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &reward, nil
+}
+
+func setVoterReward(scs *state.ContractState, voter []byte, reward *voterReward) error {
+	key := append(rewardkey, voter...)
+	var data bytes.Buffer
+	enc := gob.NewEncoder(&data)
+	err := enc.Encode(reward)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(key, data.Bytes())
+}
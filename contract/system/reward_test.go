@@ -0,0 +1,123 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardDistribution(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	bp1 := []byte(fmt.Sprintf("%39d", 1)) //39:peer id length
+	bp2 := []byte(fmt.Sprintf("%39d", 2))
+
+	// a BP without votes takes the whole reward
+	reward := big.NewInt(1200)
+	bpShare, err := DistributeReward(scs, bp1, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+	assert.Equal(t, reward, bpShare, "whole reward should be for BP")
+
+	stakeAndVote := func(account []byte, amount *big.Int) []byte {
+		stakeTx := &types.TxBody{Account: account, Amount: amount.Bytes(), Payload: []byte{'s'}}
+		err = staking(stakeTx, &types.State{Balance: amount.Bytes()}, scs, 0)
+		assert.NoError(t, err, "staking failed")
+		voteTx := &types.TxBody{Account: account, Payload: append([]byte{'v'}, bp1...)}
+		err = voting(voteTx, scs, VotingDelay)
+		assert.NoError(t, err, "voting failed")
+		return account
+	}
+	voter1 := stakeAndVote([]byte(fmt.Sprintf("%33d", 1)), types.StakingMinimum)
+	voter2 := stakeAndVote([]byte(fmt.Sprintf("%33d", 2)), new(big.Int).Mul(types.StakingMinimum, big.NewInt(3)))
+
+	bpShare, err = DistributeReward(scs, bp1, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+	assert.Equal(t, big.NewInt(1200*BpRewardRate/100), bpShare, "BP share")
+	bpShare, err = DistributeReward(scs, bp2, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+	assert.Equal(t, reward, bpShare, "voters of the other BP should not share")
+
+	voterShare := int64(1200 * (100 - BpRewardRate) / 100)
+	staked, err := GetStaking(scs, voter1)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, voterShare/4, staked.GetRewardBigInt().Int64(), "reward of voter1")
+	staked, err = GetStaking(scs, voter2)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, voterShare*3/4, staked.GetRewardBigInt().Int64(), "reward of voter2")
+
+	// the reward earned is kept when the vote changes
+	voteTx := &types.TxBody{Account: voter1, Payload: append([]byte{'v'}, bp2...)}
+	err = voting(voteTx, scs, VotingDelay*2)
+	assert.NoError(t, err, "voting failed")
+	_, err = DistributeReward(scs, bp1, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+	staked, err = GetStaking(scs, voter1)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, voterShare/4, staked.GetRewardBigInt().Int64(), "reward of voter1 after changing vote")
+
+	claimTx := &types.TxBody{Account: voter1, Payload: []byte{'c'}}
+	assert.NoError(t, ValidateSystemTx(claimTx, scs, VotingDelay*3), "claim should be valid")
+	senderState := &types.State{}
+	err = ExecuteSystemTx(claimTx, senderState, scs, VotingDelay*3)
+	assert.NoError(t, err, "claim failed")
+	assert.Equal(t, voterShare/4, senderState.GetBalanceBigInt().Int64(), "reward should be claimed to balance")
+
+	assert.Equal(t, types.ErrNoReward, ValidateSystemTx(claimTx, scs, VotingDelay*4), "nothing left to claim")
+	staked, err = GetStaking(scs, voter2)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, voterShare*3/4+voterShare, staked.GetRewardBigInt().Int64(), "reward of voter2")
+}
+
+func TestRewardOfVoteBeforeFork(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	bp1 := []byte(fmt.Sprintf("%39d", 1)) //39:peer id length
+	bp2 := []byte(fmt.Sprintf("%39d", 2))
+	voter := []byte(fmt.Sprintf("%33d", 1))
+
+	// a vote cast before the fork has no reward accounting
+	amount := types.StakingMinimum
+	err = setStaking(scs, voter, &types.Staking{Amount: amount.Bytes()})
+	assert.NoError(t, err, "could not set staking")
+	err = setVote(scs, voter, &types.Vote{Candidate: append(append([]byte{}, bp1...), bp2...), Amount: amount.Bytes()})
+	assert.NoError(t, err, "could not set vote")
+	voteResult := map[string]*big.Int{
+		base58.Encode(bp1): amount,
+		base58.Encode(bp2): amount,
+	}
+	err = syncVoteResult(scs, &voteResult)
+	assert.NoError(t, err, "could not set vote result")
+
+	reward := big.NewInt(1000)
+	_, err = DistributeReward(scs, bp1, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+	_, err = DistributeReward(scs, bp2, reward, 0)
+	assert.NoError(t, err, "reward distribution failed")
+
+	voterShare := int64(1000 * (100 - BpRewardRate) / 100)
+	staked, err := GetStaking(scs, voter)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, voterShare*2, staked.GetRewardBigInt().Int64(), "reward from both candidates")
+
+	claimTx := &types.TxBody{Account: voter, Payload: []byte{'c'}}
+	senderState := &types.State{}
+	err = ExecuteSystemTx(claimTx, senderState, scs, 1)
+	assert.NoError(t, err, "claim failed")
+	assert.Equal(t, voterShare*2, senderState.GetBalanceBigInt().Int64(), "reward should be claimed to balance")
+	assert.Equal(t, types.ErrNoReward, ValidateSystemTx(claimTx, scs, 2), "nothing left to claim")
+}
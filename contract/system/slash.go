@@ -0,0 +1,180 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"math/big"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+var slashedkey = []byte("slashed")
+var slashedbpskey = []byte("slashedbps")
+var burnedkey = []byte("burned")
+
+// slashing punishes the BP which signed two different blocks for the same
+// slot. The BP is voted for by its peer ID, which is excluded from the vote
+// result for good, and the whole staking of the account of the BP key is
+// burned. The payload is 'e' followed by the encoded double-sign evidence.
+// Anyone may send it, and each evidence is applied only once.
+func slashing(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockNo) error {
+	offender, bpID, id, err := validateForSlashing(txBody, scs)
+	if err != nil {
+		return err
+	}
+
+	staked, err := getStaking(scs, offender)
+	if err != nil {
+		return err
+	}
+	if err := countStaking(scs, offender, staked); err != nil {
+		return err
+	}
+	if slashed := staked.GetAmountBigInt(); slashed.Sign() > 0 {
+		staked.Amount = nil
+		//blockNo will be updated in voting
+		staked.When = 0
+		err = setStaking(scs, offender, staked)
+		if err != nil {
+			return err
+		}
+		err = addStakingTotal(scs, new(big.Int).Neg(slashed))
+		if err != nil {
+			return err
+		}
+		// update the votes of the offender as unstaking does
+		err = voting(&types.TxBody{Account: offender, Payload: txBody.Payload[:1]}, scs, blockNo)
+		if err != nil {
+			return err
+		}
+		err = burn(scs, slashed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := slashBP(scs, bpID); err != nil {
+		return err
+	}
+
+	return scs.SetData(append(slashedkey, id...), []byte{1})
+}
+
+// validateForSlashing returns the account and the peer ID of the offender,
+// and the ID of the evidence. The slots of the blocks in the evidence are
+// those of the block interval effective at the blocks.
+func validateForSlashing(txBody *types.TxBody, scs *state.ContractState) ([]byte, peer.ID, []byte, error) {
+	var ev types.DoubleSignEvidence
+	if err := proto.Unmarshal(txBody.Payload[1:], &ev); err != nil {
+		return nil, "", nil, types.ErrTxFormatInvalid
+	}
+	interval, err := GetParamOrDefault(scs, ParamBlockInterval, ev.GetHeader1().GetBlockNo())
+	if err != nil {
+		return nil, "", nil, err
+	}
+	offender, err := evidence.Verify(&ev, int64(interval))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	bpID, err := evidence.OffenderID(ev.GetHeader1())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	id := evidence.ID(&ev)
+	slashed, err := scs.GetData(append(slashedkey, id...))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if len(slashed) != 0 {
+		return nil, "", nil, types.ErrAlreadySlashed
+	}
+
+	staked, err := getStaking(scs, offender)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	bps, err := getSlashedBPs(scs)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if staked.GetAmountBigInt().Sign() == 0 && bps[string(bpID)] {
+		return nil, "", nil, types.ErrNothingToSlash
+	}
+	return offender, bpID, id, nil
+}
+
+// slashBP excludes the BP of id from the vote result.
+func slashBP(scs *state.ContractState, id peer.ID) error {
+	bps, err := getSlashedBPs(scs)
+	if err != nil {
+		return err
+	}
+	if bps[string(id)] {
+		return nil
+	}
+
+	var list [][]byte
+	data, err := scs.GetData(slashedbpskey)
+	if err != nil {
+		return err
+	}
+	if len(data) != 0 {
+		if err := common.GobDecode(data, &list); err != nil {
+			return err
+		}
+	}
+	list = append(list, []byte(id))
+
+	data, err = common.GobEncode(list)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(slashedbpskey, data)
+}
+
+// getSlashedBPs returns the set of the peer IDs of the slashed BPs.
+func getSlashedBPs(scs *state.ContractState) (map[string]bool, error) {
+	data, err := scs.GetData(slashedbpskey)
+	if err != nil {
+		return nil, err
+	}
+	bps := make(map[string]bool)
+	if len(data) != 0 {
+		var list [][]byte
+		if err := common.GobDecode(data, &list); err != nil {
+			return nil, err
+		}
+		for _, id := range list {
+			bps[string(id)] = true
+		}
+	}
+	return bps, nil
+}
+
+// burn takes amount out of the supply. The staking has no balance behind it
+// while it is staked, so the slashed staking is burned by recording it here
+// instead of returning it to any balance.
+func burn(scs *state.ContractState, amount *big.Int) error {
+	burned, err := getBurned(scs)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(burnedkey, burned.Add(burned, amount).Bytes())
+}
+
+func getBurned(scs *state.ContractState) (*big.Int, error) {
+	data, err := scs.GetData(burnedkey)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,138 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashing(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	SetDefaultParams([]*types.ChainParam{{Name: ParamBlockInterval, Value: 1}})
+
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err, "could not generate key")
+	ts := time.Now().Truncate(time.Second).UnixNano()
+	b1 := types.NewBlock(nil, []byte("root1"), nil, nil, nil, ts)
+	b2 := types.NewBlock(nil, []byte("root2"), nil, nil, nil, ts)
+	b3 := types.NewBlock(nil, []byte("root3"), nil, nil, nil, ts)
+	assert.NoError(t, b1.Sign(privKey))
+	assert.NoError(t, b2.Sign(privKey))
+	assert.NoError(t, b3.Sign(privKey))
+	slashTx := func(b1, b2 *types.Block) *types.TxBody {
+		ev, err := proto.Marshal(evidence.New(b1, b2))
+		assert.NoError(t, err, "could not encode evidence")
+		return &types.TxBody{Payload: append([]byte{'e'}, ev...)}
+	}
+
+	offender, err := evidence.Offender(b1.GetHeader())
+	assert.NoError(t, err, "could not get offender")
+	bpID, err := evidence.OffenderID(b1.GetHeader())
+	assert.NoError(t, err, "could not get offender ID")
+
+	candidate := []byte(bpID)
+	other := []byte(fmt.Sprintf("%39d", 1)) //39:peer id length
+	err = staking(&types.TxBody{Account: []byte("voter"), Amount: types.StakingMinimum.Bytes(), Payload: []byte{'s'}},
+		&types.State{Balance: types.StakingMinimum.Bytes()}, scs, 0)
+	assert.NoError(t, err, "staking failed")
+	err = voting(&types.TxBody{Account: []byte("voter"), Payload: append([]byte{'v'}, other...)}, scs, VotingDelay)
+	assert.NoError(t, err, "voting failed")
+
+	stakeTx := &types.TxBody{
+		Account: offender,
+		Amount:  types.StakingMinimum.Bytes(),
+		Payload: []byte{'s'},
+	}
+	err = staking(stakeTx, &types.State{Balance: types.StakingMinimum.Bytes()}, scs, 0)
+	assert.NoError(t, err, "staking failed")
+	voteTx := &types.TxBody{Account: offender, Payload: append([]byte{'v'}, candidate...)}
+	err = voting(voteTx, scs, VotingDelay)
+	assert.NoError(t, err, "voting failed")
+
+	result, err := loadVoteResult(scs)
+	assert.NoError(t, err, "could not load vote result")
+	assert.Equal(t, 0, (*result)[base58.Encode(candidate)].Cmp(types.StakingMinimum), "vote result")
+
+	err = ValidateSystemTx(&types.TxBody{Payload: []byte{'e', 0xff}}, scs, VotingDelay*2)
+	assert.Equal(t, types.ErrTxFormatInvalid, err, "bad evidence encoding")
+
+	err = ExecuteSystemTx(slashTx(b1, b2), nil, scs, VotingDelay*2)
+	assert.NoError(t, err, "slashing failed")
+
+	staked, err := getStaking(scs, offender)
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, 0, staked.GetAmountBigInt().Sign(), "staking should be burned")
+
+	result, err = loadVoteResult(scs)
+	assert.NoError(t, err, "could not load vote result")
+	assert.Equal(t, 0, (*result)[base58.Encode(candidate)].Cmp(new(big.Int)), "votes should be taken away")
+
+	burned, err := getBurned(scs)
+	assert.NoError(t, err, "could not get burned")
+	assert.Equal(t, 0, burned.Cmp(types.StakingMinimum), "staking should be recorded as burned")
+
+	voteList, err := GetVoteResult(scs)
+	assert.NoError(t, err, "could not get vote result")
+	for _, v := range voteList.Votes {
+		assert.NotEqual(t, candidate, v.Candidate, "slashed BP should not be elected")
+	}
+	assert.Equal(t, other, voteList.Votes[0].Candidate, "other BP should remain")
+
+	err = ValidateSystemTx(slashTx(b1, b2), scs, VotingDelay*3)
+	assert.Equal(t, types.ErrAlreadySlashed, err, "evidence should be applied once")
+
+	// the BP is banned already and has nothing left to burn
+	err = ValidateSystemTx(slashTx(b1, b3), scs, VotingDelay*3)
+	assert.Equal(t, types.ErrNothingToSlash, err, "nothing to slash")
+}
+
+func TestSlashingWithoutStaking(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	SetDefaultParams([]*types.ChainParam{{Name: ParamBlockInterval, Value: 1}})
+
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err, "could not generate key")
+	ts := time.Now().Truncate(time.Second).UnixNano()
+	b1 := types.NewBlock(nil, []byte("root1"), nil, nil, nil, ts)
+	b2 := types.NewBlock(nil, []byte("root2"), nil, nil, nil, ts)
+	assert.NoError(t, b1.Sign(privKey))
+	assert.NoError(t, b2.Sign(privKey))
+	ev, err := proto.Marshal(evidence.New(b1, b2))
+	assert.NoError(t, err, "could not encode evidence")
+	bpID, err := evidence.OffenderID(b1.GetHeader())
+	assert.NoError(t, err, "could not get offender ID")
+
+	// the votes for the BP come from other accounts, so the BP is banned
+	// even if its own key has no staking
+	err = ExecuteSystemTx(&types.TxBody{Payload: append([]byte{'e'}, ev...)}, nil, scs, VotingDelay)
+	assert.NoError(t, err, "slashing failed")
+
+	bps, err := getSlashedBPs(scs)
+	assert.NoError(t, err, "could not get slashed BPs")
+	assert.True(t, bps[string(bpID)], "BP should be banned")
+
+	burned, err := getBurned(scs)
+	assert.NoError(t, err, "could not get burned")
+	assert.Equal(t, 0, burned.Sign(), "nothing should be burned")
+}
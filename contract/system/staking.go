@@ -30,6 +30,9 @@ func staking(txBody *types.TxBody, senderState *types.State,
 	if err != nil {
 		return err
 	}
+	if err := countStaking(scs, txBody.Account, staked); err != nil {
+		return err
+	}
 	beforeStaked := staked.GetAmountBigInt()
 	amount := txBody.GetAmountBigInt()
 	staked.Amount = new(big.Int).Add(beforeStaked, amount).Bytes()
@@ -38,6 +41,10 @@ func staking(txBody *types.TxBody, senderState *types.State,
 	if err != nil {
 		return err
 	}
+	err = addStakingTotal(scs, amount)
+	if err != nil {
+		return err
+	}
 
 	senderState.Balance = new(big.Int).Sub(senderState.GetBalanceBigInt(), amount).Bytes()
 	return nil
@@ -48,6 +55,9 @@ func unstaking(txBody *types.TxBody, senderState *types.State, scs *state.Contra
 	if err != nil {
 		return err
 	}
+	if err := countStaking(scs, txBody.Account, staked); err != nil {
+		return err
+	}
 	amount := txBody.GetAmountBigInt()
 	var backToBalance *big.Int
 	if staked.GetAmountBigInt().Cmp(amount) < 0 {
@@ -65,6 +75,10 @@ func unstaking(txBody *types.TxBody, senderState *types.State, scs *state.Contra
 	if err != nil {
 		return err
 	}
+	err = addStakingTotal(scs, new(big.Int).Neg(backToBalance))
+	if err != nil {
+		return err
+	}
 	err = voting(txBody, scs, blockNo)
 	if err != nil {
 		return err
@@ -104,7 +118,16 @@ func getStaking(scs *state.ContractState, who []byte) (*types.Staking, error) {
 
 func GetStaking(scs *state.ContractState, address []byte) (*types.Staking, error) {
 	if address != nil {
-		return getStaking(scs, address)
+		staking, err := getStaking(scs, address)
+		if err != nil {
+			return nil, err
+		}
+		reward, err := pendingReward(scs, address)
+		if err != nil {
+			return nil, err
+		}
+		staking.Reward = reward.Bytes()
+		return staking, nil
 	}
 	return nil, errors.New("invalid argument : address should not nil")
 }
@@ -43,6 +43,16 @@ func voting(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockN
 		return err
 	}
 
+	candidates := oldvote.Candidate
+	if txBody.Payload[0] == 'v' {
+		candidates = txBody.Payload[1:]
+	}
+	// the reward is earned by the old vote until now
+	_, err = settleReward(scs, txBody.Account, oldvote, candidates)
+	if err != nil {
+		return err
+	}
+
 	voteResult, err := loadVoteResult(scs)
 	if err != nil {
 		return err
@@ -175,11 +185,30 @@ func GetVoteResult(scs *state.ContractState) (*types.VoteList, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(data) == 0 {
+		// no one has voted yet
+		return &types.VoteList{}, nil
+	}
 
 	voteList := &types.VoteList{}
 	err = common.GobDecode(data, voteList)
 	if err != nil {
 		return nil, err
 	}
+
+	// the votes for the slashed BPs no longer count
+	slashed, err := getSlashedBPs(scs)
+	if err != nil {
+		return nil, err
+	}
+	if len(slashed) != 0 {
+		votes := voteList.Votes[:0]
+		for _, v := range voteList.Votes {
+			if !slashed[string(v.Candidate)] {
+				votes = append(votes, v)
+			}
+		}
+		voteList.Votes = votes
+	}
 	return voteList, nil
 }
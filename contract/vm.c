@@ -98,13 +98,37 @@ void count_hook(lua_State *L, lua_Debug *ar)
 	lua_error(L);
 }
 
-const char *vm_pcall(lua_State *L, int argc, int *nresult)
+void gas_hook(lua_State *L, lua_Debug *ar)
+{
+	int *service = (int *)getLuaExecContext(L);
+
+	if (LuaUseInstructionGas(L, service, VM_GAS_HOOK_COUNT) < 0) {
+		/* stop at the next instruction though the error is caught by pcall */
+		lua_sethook(L, gas_hook, LUA_MASKCOUNT, 1);
+		lua_error(L);
+	}
+}
+
+void vm_use_sql_gas(lua_State *L, int changes)
+{
+	int *service = (int *)getLuaExecContext(L);
+
+	if (LuaUseSqlGas(L, service, changes) < 0) {
+		lua_error(L);
+	}
+}
+
+const char *vm_pcall(lua_State *L, int argc, int *nresult, int metered)
 {
 	int err;
 	const char *errMsg = NULL;
 	int nr = lua_gettop(L) - argc - 1;
 
-	lua_sethook (L, count_hook, LUA_MASKCOUNT, 500000);
+	/* the instructions are not charged before the v2 hardfork */
+	if (metered)
+		lua_sethook(L, gas_hook, LUA_MASKCOUNT, VM_GAS_HOOK_COUNT);
+	else
+		lua_sethook(L, count_hook, LUA_MASKCOUNT, 500000);
 
 	err = lua_pcall(L, argc, LUA_MULTRET, 0);
 	if (err != 0) {
@@ -66,6 +66,12 @@ type StateSet struct {
 	dbSystemError     bool
 	callState         map[types.AccountID]*CallState
 	lastRecoveryEntry *recoveryEntry
+	events            []*types.Event
+	gasLimit          uint64
+	usedGas           uint64
+	// unmetered is set for the txs before the v2 hardfork, which are only
+	// limited by the instruction count and charged no gas.
+	unmetered bool
 }
 
 type recoveryEntry struct {
@@ -75,6 +81,7 @@ type recoveryEntry struct {
 	callState     *CallState
 	sqlSaveName   *string
 	stateRevision state.Snapshot
+	eventCount    int
 	prev          *recoveryEntry
 }
 
@@ -122,6 +129,7 @@ func NewContext(blockState *state.BlockState, sender, reciever *state.V,
 		blockHeight: blockHeight,
 		timestamp:   timestamp,
 		service:     C.int(service),
+		gasLimit:    types.DefaultTxGasLimit,
 	}
 	stateSet.callState = make(map[types.AccountID]*CallState)
 	stateSet.callState[reciever.AccountID()] = callState
@@ -145,6 +153,7 @@ func NewContextQuery(blockState *state.BlockState, receiverId []byte,
 		confirmed:   confirmed,
 		isQuery:     true,
 		service:     C.int(service),
+		gasLimit:    queryGasLimit,
 	}
 	stateSet.callState = make(map[types.AccountID]*CallState)
 	stateSet.callState[types.ToAccountID(receiverId)] = callState
@@ -266,7 +275,7 @@ func (ce *Executor) call(ci *types.CallInfo, target *LState) C.int {
 
 	ce.processArgs(ci)
 	nret := C.int(0)
-	if cErrMsg := C.vm_pcall(ce.L, C.int(len(ci.Args)+1), &nret); cErrMsg != nil {
+	if cErrMsg := C.vm_pcall(ce.L, C.int(len(ci.Args)+1), &nret, ce.metered()); cErrMsg != nil {
 		errMsg := C.GoString(cErrMsg)
 		C.free(unsafe.Pointer(cErrMsg))
 		ctrLog.Warn().Str("error", errMsg).Msgf("contract %s", types.EncodeAddress(ce.stateSet.curContract.contractId))
@@ -291,6 +300,14 @@ func (ce *Executor) call(ci *types.CallInfo, target *LState) C.int {
 	return nret
 }
 
+// metered reports to the VM whether the instructions are charged gas.
+func (ce *Executor) metered() C.int {
+	if ce.stateSet.unmetered {
+		return 0
+	}
+	return 1
+}
+
 func (ce *Executor) constructCall(ci *types.CallInfo) {
 	if ce.err != nil {
 		return
@@ -308,7 +325,7 @@ func (ce *Executor) constructCall(ci *types.CallInfo) {
 		return
 	}
 	nret := C.int(0)
-	if cErrMsg := C.vm_pcall(ce.L, C.int(len(ci.Args)), &nret); cErrMsg != nil {
+	if cErrMsg := C.vm_pcall(ce.L, C.int(len(ci.Args)), &nret, ce.metered()); cErrMsg != nil {
 		errMsg := C.GoString(cErrMsg)
 		C.free(unsafe.Pointer(cErrMsg))
 		ctrLog.Warn().Str("error", errMsg).Msgf("contract %s constructor call", types.EncodeAddress(ce.stateSet.curContract.contractId))
@@ -545,6 +562,9 @@ func Create(contractState *state.ContractState, code, contractAddress []byte,
 	if err != nil {
 		return "", err
 	}
+	if !stateSet.useGas(stateSetGas + uint64(codeLen)*stateByteGas) {
+		return "", types.ErrNotEnoughGas
+	}
 	contractState.SetData([]byte("Creator"), []byte(types.EncodeAddress(stateSet.curContract.sender)))
 	var ci types.CallInfo
 	if len(code) != int(codeLen) {
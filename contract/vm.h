@@ -6,6 +6,9 @@
 #include <luajit.h>
 #include "sqlite3-binding.h"
 
+/* the number of instructions charged at once by the gas hook */
+#define VM_GAS_HOOK_COUNT 1000
+
 typedef struct blockchain_ctx {
 	char *stateKey;
 	char *sender;
@@ -29,12 +32,13 @@ int vm_isnil(lua_State *L, int idx);
 void vm_getfield(lua_State *L, const char *name);
 void vm_remove_construct(lua_State *L, const char *constructName);
 const char *vm_loadbuff(lua_State *L, const char *code, size_t sz, int *service);
-const char *vm_pcall(lua_State *L, int argc, int* nresult);
+const char *vm_pcall(lua_State *L, int argc, int* nresult, int metered);
 const char *vm_get_json_ret(lua_State *L, int nresult);
 const char *vm_tostring(lua_State *L, int idx);
 const char *vm_copy_result(lua_State *L, lua_State *target, int cnt);
 void bc_ctx_delete(bc_ctx_t *bcctx);
 sqlite3 *vm_get_db(lua_State *L);
+void vm_use_sql_gas(lua_State *L, int changes);
 void vm_get_abi_function(lua_State *L, char *fname);
 
 #endif /* _VM_H */
@@ -20,6 +20,11 @@ import (
 	"github.com/aergoio/aergo/types"
 )
 
+const (
+	maxEventNameSize = 64
+	maxEventArgSize  = 4096
+)
+
 func luaPushStr(L *LState, str string) {
 	cStr := C.CString(str)
 	C.lua_pushstring(L, cStr)
@@ -37,7 +42,13 @@ func LuaSetDB(L *LState, service *C.int, key *C.char, value *C.char) C.int {
 		luaPushStr(L, "[System.LuaSetDB]set not permitted in query")
 		return -1
 	}
-	err := stateSet.curContract.callState.ctrState.SetData([]byte(C.GoString(key)), []byte(C.GoString(value)))
+	keyStr := C.GoString(key)
+	valueStr := C.GoString(value)
+	if !stateSet.useGas(stateSetGas + uint64(len(keyStr)+len(valueStr))*stateByteGas) {
+		luaPushStr(L, "[System.LuaSetDB]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
+	err := stateSet.curContract.callState.ctrState.SetData([]byte(keyStr), []byte(valueStr))
 	if err != nil {
 		luaPushStr(L, err.Error())
 		return -1
@@ -73,6 +84,10 @@ func LuaDelDB(L *LState, service *C.int, key *C.char) C.int {
 		luaPushStr(L, "[System.LuaGetDB]not found contract state")
 		return -1
 	}
+	if !stateSet.useGas(stateDelGas) {
+		luaPushStr(L, "[System.LuaDelDB]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
 	err := stateSet.curContract.callState.ctrState.DeleteData([]byte(C.GoString(key)))
 	if err != nil {
 		luaPushStr(L, err.Error())
@@ -102,6 +117,10 @@ func LuaCallContract(L *LState, service *C.int, contractId *C.char, fname *C.cha
 	if stateSet.isQuery == true {
 		luaPushStr(L, "[System.LuaCallContract]send not permitted in query")
 	}
+	if !stateSet.useGas(callGas) {
+		luaPushStr(L, "[System.LuaCallContract]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
 	callState := stateSet.callState[aid]
 	if callState == nil {
 		bs := stateSet.bs
@@ -165,7 +184,9 @@ func LuaCallContract(L *LState, service *C.int, contractId *C.char, fname *C.cha
 	}
 	stateSet.curContract = newContractInfo(callState, prevContractInfo.contractId, cid,
 		callState.curState.SqlRecoveryPoint, amountBig)
+	prevGasLimit := stateSet.limitGas(gas)
 	ret := ce.call(&ci, L)
+	stateSet.gasLimit = prevGasLimit
 	if ce.err != nil {
 		stateSet.curContract = prevContractInfo
 		luaPushStr(L, "[System.LuaCallContract] call err:"+ce.err.Error())
@@ -193,6 +214,10 @@ func LuaDelegateCallContract(L *LState, service *C.int, contractId *C.char,
 		luaPushStr(L, "[System.LuaDelegateCallContract]not found contract state")
 		return -1
 	}
+	if !stateSet.useGas(callGas) {
+		luaPushStr(L, "[System.LuaDelegateCallContract]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
 	bs := stateSet.bs
 	aid := types.ToAccountID(cid)
 	contractState, err := bs.OpenContractStateAccount(aid)
@@ -221,7 +246,9 @@ func LuaDelegateCallContract(L *LState, service *C.int, contractId *C.char,
 		callState := stateSet.curContract.callState
 		setRecoveryPoint(aid, stateSet, nil, callState, big.NewInt(0), callState.ctrState.Snapshot())
 	}
+	prevGasLimit := stateSet.limitGas(gas)
 	ret := ce.call(&ci, L)
+	stateSet.gasLimit = prevGasLimit
 	if ce.err != nil {
 		luaPushStr(L, "[System.LuaDelegateCallContract] call err:"+ce.err.Error())
 		return -1
@@ -246,6 +273,10 @@ func LuaSendAmount(L *LState, service *C.int, contractId *C.char, amount uint64)
 	if stateSet.isQuery == true {
 		luaPushStr(L, "[Contract.LuaSendAmount]send not permitted in query")
 	}
+	if !stateSet.useGas(callGas) {
+		luaPushStr(L, "[Contract.LuaSendAmount]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
 
 	aid := types.ToAccountID(cid)
 	callState := stateSet.callState[aid]
@@ -290,12 +321,69 @@ func sendBalance(L *LState, sender *types.State, receiver *types.State, amount *
 	return true
 }
 
+//export LuaUseInstructionGas
+func LuaUseInstructionGas(L *LState, service *C.int, count C.int) C.int {
+	return luaUseGas(L, service, uint64(count)*instructionGas)
+}
+
+//export LuaUseSqlGas
+func LuaUseSqlGas(L *LState, service *C.int, changes C.int) C.int {
+	return luaUseGas(L, service, sqlExecGas+uint64(changes)*sqlRowGas)
+}
+
+func luaUseGas(L *LState, service *C.int, gas uint64) C.int {
+	stateSet := curStateSet[*service]
+	if stateSet == nil {
+		luaPushStr(L, "[System.LuaUseGas]not found contract state")
+		return -1
+	}
+	if !stateSet.useGas(gas) {
+		luaPushStr(L, types.ErrNotEnoughGas.Error())
+		return -1
+	}
+	return 0
+}
+
 //export LuaPrint
 func LuaPrint(service *C.int, args *C.char) {
 	stateSet := curStateSet[*service]
 	logger.Info().Str("Contract SystemPrint", types.EncodeAddress(stateSet.curContract.contractId)).Msg(C.GoString(args))
 }
 
+//export LuaEvent
+func LuaEvent(L *LState, service *C.int, eventName *C.char, args *C.char) C.int {
+	stateSet := curStateSet[*service]
+	if stateSet == nil {
+		luaPushStr(L, "[Contract.Event]not found contract state")
+		return -1
+	}
+	if stateSet.isQuery == true {
+		luaPushStr(L, "[Contract.Event]event not permitted in query")
+		return -1
+	}
+	if len(C.GoString(eventName)) > maxEventNameSize {
+		luaPushStr(L, fmt.Sprintf("[Contract.Event]exceeded the maximum length of event name(%d)", maxEventNameSize))
+		return -1
+	}
+	if len(C.GoString(args)) > maxEventArgSize {
+		luaPushStr(L, fmt.Sprintf("[Contract.Event]exceeded the maximum size of event args(%d)", maxEventArgSize))
+		return -1
+	}
+	if !stateSet.useGas(eventGas + uint64(len(C.GoString(eventName))+len(C.GoString(args)))*stateByteGas) {
+		luaPushStr(L, "[Contract.Event]"+types.ErrNotEnoughGas.Error())
+		return -1
+	}
+	stateSet.events = append(stateSet.events,
+		&types.Event{
+			ContractAddress: stateSet.curContract.contractId,
+			EventIdx:        int32(len(stateSet.events)),
+			EventName:       C.GoString(eventName),
+			JsonArgs:        C.GoString(args),
+		},
+	)
+	return 0
+}
+
 func setRecoveryPoint(aid types.AccountID, stateSet *StateSet, senderState *types.State,
 	callState *CallState, amount *big.Int, snapshot state.Snapshot) {
 	var seq int
@@ -312,6 +400,7 @@ func setRecoveryPoint(aid types.AccountID, stateSet *StateSet, senderState *type
 		callState,
 		nil,
 		snapshot,
+		len(stateSet.events),
 		prev,
 	}
 	tx := callState.tx
@@ -352,6 +441,9 @@ func LuaClearRecovery(L *LState, service *C.int, start int, error bool) C.int {
 			item.recovery()
 		}
 		if item.seq == start {
+			if error {
+				stateSet.events = stateSet.events[:item.eventCount]
+			}
 			if error || item.prev == nil {
 				stateSet.lastRecoveryEntry = item.prev
 			}
@@ -349,6 +349,8 @@ func (l *luaTxCall) run(bs *state.BlockState, blockNo uint64, ts int64, receiptT
 				return err
 			}
 			r := types.NewReceipt(l.contract, "SUCCESS", rv)
+			r.Events = stateSet.events
+			r.GasUsed = stateSet.usedGas
 			b, _ := r.MarshalBinary()
 			receiptTx.Set(l.hash(), b)
 			return nil
@@ -513,7 +513,7 @@ abi.register(infiniteLoop)`
 			"loop",
 			1,
 			`{"Name":"infiniteLoop"}`,
-		).fail("exceeded the maximum instruction count"),
+		).fail(types.ErrNotEnoughGas.Error()),
 	)
 }
 
@@ -2605,4 +2605,118 @@ abi.register(GetVar1, Work)
 	}
 }
 
+func TestEvent(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	definition := `
+	function inc(v)
+		contract.event("inc", v, {a = "b"})
+		contract.pcall(function() contract.event("rollback") error("fail") end)
+		contract.event("done")
+	end
+	function query()
+		contract.event("query")
+	end
+	abi.register(inc, query)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "event", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	tx := NewLuaTxCall("ktlee", "event", 0, `{"Name": "inc", "Args":[3]}`)
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	receipt := bc.getReceipt(tx.hash())
+	events := receipt.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, but got: %d", len(events))
+	}
+	if events[0].GetEventName() != "inc" || events[0].GetJsonArgs() != `[3,{"a":"b"}]` {
+		t.Errorf("unexpected event: %s(%s)", events[0].GetEventName(), events[0].GetJsonArgs())
+	}
+	if events[1].GetEventName() != "done" || events[1].GetEventIdx() != 1 || events[1].GetJsonArgs() != "[]" {
+		t.Errorf("unexpected event: %s(%s) %d", events[1].GetEventName(), events[1].GetJsonArgs(), events[1].GetEventIdx())
+	}
+	if types.EncodeAddress(events[0].GetContractAddress()) != StrToAddress("event") {
+		t.Errorf("unexpected contract address: %s", types.EncodeAddress(events[0].GetContractAddress()))
+	}
+
+	err = bc.Query("event", `{"Name": "query", "Args":[]}`, "not permitted in query", "")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGas(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	definition := `
+	function set(v)
+		system.setItem("v", v)
+	end
+	function get(k)
+		return system.getItem(k)
+	end
+	function loop()
+		local i = 0
+		while true do
+			i = i + 1
+		end
+	end
+	function capped(addr)
+		local ok = contract.pcall(function() return contract.call.gas(5000)(addr, "loop") end)
+		system.setItem("capped", ok)
+		return ok
+	end
+	abi.register(set, get, loop, capped)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "gas", 0, definition),
+		NewLuaTxDef("ktlee", "gas2", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tx := NewLuaTxCall("ktlee", "gas", 0, `{"Name": "set", "Args":["value"]}`)
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if gas := bc.getReceipt(tx.hash()).GetGasUsed(); gas < stateSetGas {
+		t.Errorf("expected gas used >= %d, but got: %d", stateSetGas, gas)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "gas", 0, `{"Name": "loop"}`).fail(types.ErrNotEnoughGas.Error()),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tx = NewLuaTxCall("ktlee", "gas", 0, fmt.Sprintf(`{"Name": "capped", "Args":["%s"]}`, StrToAddress("gas2")))
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	gas := bc.getReceipt(tx.hash()).GetGasUsed()
+	if gas < 5000+callGas || gas >= types.DefaultTxGasLimit {
+		t.Errorf("unexpected gas used: %d", gas)
+	}
+	err = bc.Query("gas", `{"Name": "get", "Args":["capped"]}`, "", "false")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 // end of test-cases
@@ -1,8 +1,10 @@
 package merkle
 
 import (
-	"github.com/minio/sha256-simd"
+	"bytes"
 	"hash"
+
+	"github.com/minio/sha256-simd"
 )
 
 type MerkleEntry interface {
@@ -88,3 +90,53 @@ func CalculateMerkleTree(entries []MerkleEntry) [][]byte {
 
 	return merkles
 }
+
+// CalculateMerklePath returns the merkle path of the entry at index, which is
+// the hashes of its siblings from the leaf up to the root
+func CalculateMerklePath(entries []MerkleEntry, index int) [][]byte {
+	if index < 0 || index >= len(entries) {
+		return nil
+	}
+	return GetMerklePath(CalculateMerkleTree(entries), index)
+}
+
+// GetMerklePath returns the merkle path of the leaf at index in the tree made
+// by CalculateMerkleTree
+func GetMerklePath(merkles [][]byte, index int) [][]byte {
+	leafCount := (len(merkles) + 1) / 2
+	var path [][]byte
+	// children of branch node i are (i-leafCount)*2 and (i-leafCount)*2+1
+	for i := index; i < len(merkles)-1; i = leafCount + i/2 {
+		path = append(path, merkles[i^1])
+	}
+	return path
+}
+
+// VerifyMerklePath checks the hash of the entry at index is in the merkle
+// tree of the root with the merkle path. Since a missing right child is
+// padded with a copy of the left one, a right node equal to its sibling is a
+// padding beyond the last entry and the path through it is rejected. The
+// entries are never duplicated, so a valid path never has such a node.
+func VerifyMerklePath(root []byte, hash []byte, index int, path [][]byte) bool {
+	if index < 0 || index >= 1<<uint(len(path)) {
+		return false
+	}
+	hasher := sha256.New()
+	merkle := hash
+	for _, sibling := range path {
+		hasher.Reset()
+		if index%2 == 1 && bytes.Equal(sibling, merkle) {
+			return false
+		}
+		if index%2 == 0 {
+			hasher.Write(merkle)
+			hasher.Write(sibling)
+		} else {
+			hasher.Write(sibling)
+			hasher.Write(merkle)
+		}
+		merkle = hasher.Sum(nil)
+		index /= 2
+	}
+	return bytes.Equal(merkle, root)
+}
@@ -167,6 +167,51 @@ func TestMerkle32Tx(t *testing.T) {
 	assert.NotNil(t, merkleRoot)
 }
 
+func TestMerklePath(t *testing.T) {
+	h := sha256.New()
+	for count := 1; count <= 10; count++ {
+		tms = make([]MerkleEntry, count)
+		for i := 0; i < count; i++ {
+			h.Reset()
+			binary.Write(h, binary.LittleEndian, int64(i))
+			tms[i] = &testME{hash: h.Sum(nil)}
+		}
+
+		root := CalculateMerkleRoot(tms)
+		for i, tm := range tms {
+			path := CalculateMerklePath(tms, i)
+			assert.Truef(t, VerifyMerklePath(root, tm.GetHash(), i, path), "count=%d, idx=%d", count, i)
+
+			if count > 1 {
+				other := (i + 1) % count
+				assert.False(t, VerifyMerklePath(root, tms[other].GetHash(), i, path))
+				assert.False(t, VerifyMerklePath(root, tm.GetHash(), other, path))
+			}
+		}
+		assert.Nil(t, CalculateMerklePath(tms, count))
+	}
+}
+
+func TestMerklePathPadding(t *testing.T) {
+	h := sha256.New()
+	tms = make([]MerkleEntry, 5)
+	for i := range tms {
+		h.Reset()
+		binary.Write(h, binary.LittleEndian, int64(i))
+		tms[i] = &testME{hash: h.Sum(nil)}
+	}
+	merkles := CalculateMerkleTree(tms)
+	root := merkles[len(merkles)-1]
+	last := tms[4].GetHash()
+
+	// the last entry is copied to the padding at 5, 6 and 7
+	assert.True(t, VerifyMerklePath(root, last, 4, GetMerklePath(merkles, 4)))
+	assert.False(t, VerifyMerklePath(root, last, 5, GetMerklePath(merkles, 5)))
+	padded := [][]byte{last, merkles[10], merkles[12]}
+	assert.False(t, VerifyMerklePath(root, last, 6, padded))
+	assert.False(t, VerifyMerklePath(root, last, 7, padded))
+}
+
 func BenchmarkMerkle10000Tx(b *testing.B) {
 	b.Log("BenchmarkMerkle10000Tx")
 	beforeTest(10000)
@@ -0,0 +1,172 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo-lib/log"
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
+	"github.com/gorilla/websocket"
+)
+
+// JSONRPCService serves the api of AergoRPCService in JSON-RPC 2.0 over http
+// and websocket. websocket clients can subscribe new blocks and pending txs
+type JSONRPCService struct {
+	*component.BaseComponent
+
+	cfg *cfg.Config
+	rpc types.AergoRPCServiceServer
+
+	methods    map[string]methodFunc
+	httpServer *http.Server
+	upgrader   websocket.Upgrader
+
+	subLock sync.RWMutex
+	subs    map[string]*subscription
+}
+
+var (
+	logger = log.NewLogger("jsonrpc")
+)
+
+// maxBodySize limits the request body, it is same to the max message size of
+// grpc server
+const maxBodySize = 1024 * 1024 * 256
+
+func NewJSONRPCService(cfg *cfg.Config, rpc types.AergoRPCServiceServer) *JSONRPCService {
+	js := &JSONRPCService{
+		cfg:  cfg,
+		rpc:  rpc,
+		subs: map[string]*subscription{},
+	}
+	js.BaseComponent = component.NewBaseComponent(message.JSONRPCSvc, js, logger)
+	js.initMethods()
+
+	js.upgrader = websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			// same origin only unless CORS is allowed
+			return cfg.RPC.NSAllowCORS || isSameOrigin(r)
+		},
+	}
+	js.httpServer = &http.Server{
+		Addr:           fmt.Sprintf("%s:%d", cfg.RPC.NetServiceAddr, cfg.JSONRPC.JSONRPCPort),
+		Handler:        js,
+		ReadTimeout:    4 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
+
+	return js
+}
+
+func (js *JSONRPCService) BeforeStart() {}
+
+func (js *JSONRPCService) AfterStart() {
+	go js.serve()
+}
+
+func (js *JSONRPCService) serve() {
+	logger.Info().Str("addr", js.httpServer.Addr).Bool("tls", js.cfg.RPC.NSEnableTLS).Msg("JSON-RPC Service Started")
+	var err error
+	if js.cfg.RPC.NSEnableTLS {
+		err = js.httpServer.ListenAndServeTLS(js.cfg.RPC.NSCert, js.cfg.RPC.NSKey)
+	} else {
+		err = js.httpServer.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		logger.Error().Err(err).Msg("JSON-RPC server stopped")
+	}
+}
+
+func (js *JSONRPCService) BeforeStop() {
+	js.httpServer.Close()
+	js.subLock.Lock()
+	for _, sub := range js.subs {
+		sub.conn.close()
+	}
+	js.subLock.Unlock()
+}
+
+func (js *JSONRPCService) Statistics() *map[string]interface{} {
+	js.subLock.RLock()
+	defer js.subLock.RUnlock()
+	return &map[string]interface{}{
+		"subscriptions": len(js.subs),
+	}
+}
+
+func (js *JSONRPCService) Receive(context actor.Context) {
+}
+
+// ServeHTTP handles a request or a batch of them posted in http, or upgrades
+// the connection to websocket
+func (js *JSONRPCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if js.cfg.RPC.NSAllowCORS {
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", "*")
+		header.Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		header.Set("Access-Control-Allow-Headers", "Content-Type")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	if websocket.IsWebSocketUpgrade(r) {
+		js.serveWebsocket(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	if !isSameOrigin(r) {
+		ctx = withCrossOrigin(ctx)
+	}
+	out := js.handleMessage(ctx, body, nil)
+	w.Header().Set("Content-Type", "application/json")
+	if out == nil {
+		// only notifications are posted
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Write(out)
+}
+
+type crossOriginKey struct{}
+
+// withCrossOrigin marks that the request is from other origin. methods using
+// the accounts of the node are not served to it
+func withCrossOrigin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossOriginKey{}, true)
+}
+
+func isCrossOrigin(ctx context.Context) bool {
+	cross, _ := ctx.Value(crossOriginKey{}).(bool)
+	return cross
+}
+
+// isSameOrigin returns true if the request has no origin, that is it is not
+// from a browser, or it is from the page of this server
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || origin == "http://"+r.Host || origin == "https://"+r.Host
+}
@@ -0,0 +1,237 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/mr-tron/base58/base58"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRPC implements the part of AergoRPCService used in tests. calling
+// other methods panics
+type fakeRPC struct {
+	types.AergoRPCServiceServer
+
+	block *types.Block
+}
+
+func (f *fakeRPC) GetBlock(ctx context.Context, in *types.SingleBytes) (*types.Block, error) {
+	if len(in.Value) == 8 && binary.LittleEndian.Uint64(in.Value) == f.block.GetHeader().GetBlockNo() {
+		return f.block, nil
+	}
+	if bytes.Equal(in.Value, f.block.Hash) {
+		return f.block, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "Not found")
+}
+
+func newTestJSONRPCService() (*JSONRPCService, *fakeRPC) {
+	block := &types.Block{Header: &types.BlockHeader{BlockNo: 3}}
+	block.BlockHash()
+	rpc := &fakeRPC{block: block}
+	conf := &cfg.Config{
+		RPC:     &cfg.RPCConfig{NetServiceAddr: "127.0.0.1"},
+		JSONRPC: &cfg.JSONRPCConfig{JSONRPCPort: 8545},
+	}
+	return NewJSONRPCService(conf, rpc), rpc
+}
+
+type testResponse struct {
+	JSONRPC string
+	ID      json.RawMessage
+	Result  json.RawMessage
+	Error   *Error
+}
+
+func handle(t *testing.T, js *JSONRPCService, msg string, conn *wsConn) *testResponse {
+	out := js.handleMessage(context.Background(), []byte(msg), conn)
+	if out == nil {
+		t.Fatalf("no response for %s", msg)
+	}
+	var rsp testResponse
+	if err := json.Unmarshal(out, &rsp); err != nil {
+		t.Fatalf("invalid response %s : %s", string(out), err.Error())
+	}
+	if rsp.JSONRPC != version {
+		t.Errorf("invalid version of response : %s", string(out))
+	}
+	return &rsp
+}
+
+func checkErrorCode(t *testing.T, rsp *testResponse, code int) {
+	if rsp.Error == nil || rsp.Error.Code != code {
+		t.Errorf("expected error %d, but %v", code, rsp.Error)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	js, rpc := newTestJSONRPCService()
+	hash := base58.Encode(rpc.block.Hash)
+
+	for _, param := range []string{`3`, `"` + hash + `"`} {
+		rsp := handle(t, js, `{"jsonrpc":"2.0","id":"a","method":"aergo_getBlock","params":[`+param+`]}`, nil)
+		if rsp.Error != nil {
+			t.Fatalf("failed to get block %s : %s", param, rsp.Error.Message)
+		}
+		if string(rsp.ID) != `"a"` {
+			t.Errorf("id should be echoed, but %s", string(rsp.ID))
+		}
+		var block jsonconv.InOutBlock
+		if err := json.Unmarshal(rsp.Result, &block); err != nil {
+			t.Fatalf("invalid block : %s", err.Error())
+		}
+		if block.Hash != hash || block.Header.BlockNo != 3 {
+			t.Errorf("unexpected block : %s", string(rsp.Result))
+		}
+	}
+
+	if out := js.handleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"aergo_getBlock","params":[3]}`), nil); out != nil {
+		t.Errorf("notification should not be responded, but %s", string(out))
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	js, _ := newTestJSONRPCService()
+
+	rsp := handle(t, js, `{"jsonrpc":"2.0","id":1,"method":`, nil)
+	checkErrorCode(t, rsp, ErrCodeParse)
+	if string(rsp.ID) != "null" {
+		t.Errorf("id of parse error should be null, but %s", string(rsp.ID))
+	}
+	checkErrorCode(t, handle(t, js, `{"id":1,"method":"aergo_getBlock"}`, nil), ErrCodeInvalidRequest)
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_nothing"}`, nil), ErrCodeMethodNotFound)
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":["0OIl"]}`, nil), ErrCodeInvalidParams)
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":[3,4]}`, nil), ErrCodeInvalidParams)
+
+	rsp = handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":[4]}`, nil)
+	checkErrorCode(t, rsp, ErrCodeServer)
+	if rsp.Error != nil && rsp.Error.Data != codes.NotFound.String() {
+		t.Errorf("grpc code should be in data, but %v", rsp.Error.Data)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	js, _ := newTestJSONRPCService()
+
+	out := js.handleMessage(context.Background(), []byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":[3]},
+		{"jsonrpc":"2.0","method":"aergo_getBlock","params":[3]},
+		{"jsonrpc":"2.0","id":2,"method":"aergo_nothing"},
+		1
+	]`), nil)
+	var rsps []testResponse
+	if err := json.Unmarshal(out, &rsps); err != nil {
+		t.Fatalf("invalid batch response %s : %s", string(out), err.Error())
+	}
+	if len(rsps) != 3 {
+		t.Fatalf("notification should be excluded from batch response : %s", string(out))
+	}
+	if string(rsps[0].ID) != "1" || rsps[0].Error != nil {
+		t.Errorf("unexpected response : %s", string(out))
+	}
+	checkErrorCode(t, &rsps[1], ErrCodeMethodNotFound)
+	checkErrorCode(t, &rsps[2], ErrCodeInvalidRequest)
+
+	if out := js.handleMessage(context.Background(), []byte(`[{"jsonrpc":"2.0","method":"aergo_getBlock","params":[3]}]`), nil); out != nil {
+		t.Errorf("batch of notifications should not be responded, but %s", string(out))
+	}
+	checkErrorCode(t, handle(t, js, `[]`, nil), ErrCodeInvalidRequest)
+}
+
+func TestSubscription(t *testing.T) {
+	js, rpc := newTestJSONRPCService()
+
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, nil), ErrCodeMethodNotFound)
+
+	conn := &wsConn{send: make(chan []byte, wsSendQueueSize), done: make(chan struct{})}
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["unknown"]}`, conn), ErrCodeInvalidParams)
+
+	rsp := handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, conn)
+	var id string
+	if err := json.Unmarshal(rsp.Result, &id); err != nil || id == "" {
+		t.Fatalf("subscription id is expected, but %s", string(rsp.Result))
+	}
+
+	js.OnTxs([]*types.Tx{{Hash: []byte{1}, Body: &types.TxBody{}}})
+	js.OnBlock(rpc.block)
+	if len(conn.send) != 1 {
+		t.Fatalf("only new block should be notified, but %d", len(conn.send))
+	}
+	var noti struct {
+		Method string
+		Params struct {
+			Subscription string
+			Result       jsonconv.InOutBlock
+		}
+	}
+	if err := json.Unmarshal(<-conn.send, &noti); err != nil {
+		t.Fatalf("invalid notification : %s", err.Error())
+	}
+	if noti.Method != "aergo_subscription" || noti.Params.Subscription != id ||
+		noti.Params.Result.Hash != base58.Encode(rpc.block.Hash) {
+		t.Errorf("unexpected notification : %v", noti)
+	}
+
+	rsp = handle(t, js, `{"jsonrpc":"2.0","id":2,"method":"aergo_unsubscribe","params":["`+id+`"]}`, conn)
+	if string(rsp.Result) != "true" {
+		t.Errorf("unsubscribe should succeed, but %s", string(rsp.Result))
+	}
+	js.OnBlock(rpc.block)
+	if len(conn.send) != 0 {
+		t.Error("unsubscribed client should not be notified")
+	}
+}
+
+func TestLimits(t *testing.T) {
+	js, _ := newTestJSONRPCService()
+
+	batch := make([]string, maxBatchSize+1)
+	for i := range batch {
+		batch[i] = `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":[3]}`
+	}
+	checkErrorCode(t, handle(t, js, "["+strings.Join(batch, ",")+"]", nil), ErrCodeInvalidRequest)
+
+	conn := &wsConn{send: make(chan []byte, wsSendQueueSize), done: make(chan struct{})}
+	for i := 0; i < wsMaxSubscriptions; i++ {
+		if rsp := handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, conn); rsp.Error != nil {
+			t.Fatalf("subscription %d should succeed, but %v", i, rsp.Error)
+		}
+	}
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, conn), ErrCodeInvalidRequest)
+
+	js.removeSubscriptions(conn)
+	if rsp := handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, conn); rsp.Error != nil {
+		t.Errorf("subscription should succeed after removal, but %v", rsp.Error)
+	}
+}
+
+func TestSendTxOrigin(t *testing.T) {
+	js, _ := newTestJSONRPCService()
+	checkErrorCode(t, handle(t, js, `{"jsonrpc":"2.0","id":1,"method":"aergo_sendTx","params":[{}]}`, nil), ErrCodeMethodNotFound)
+
+	js.cfg.BaseConfig.Personal = true
+	js.initMethods()
+	out := js.handleMessage(withCrossOrigin(context.Background()), []byte(`{"jsonrpc":"2.0","id":1,"method":"aergo_sendTx","params":[{}]}`), nil)
+	var rsp testResponse
+	if err := json.Unmarshal(out, &rsp); err != nil {
+		t.Fatalf("invalid response %s : %s", string(out), err.Error())
+	}
+	checkErrorCode(t, &rsp, ErrCodeServer)
+	if rsp.Error != nil && rsp.Error.Data != codes.PermissionDenied.String() {
+		t.Errorf("cross origin request should be denied, but %v", rsp.Error.Data)
+	}
+}
@@ -0,0 +1,475 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	b58json "github.com/aergoio/aergo/types/jsonconv/encoding/json"
+	"github.com/mr-tron/base58/base58"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodFunc handles the params of request and returns the result, which is
+// marshaled in json with bytes in base58
+type methodFunc func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// ListParams is the param of aergo_listBlockHeaders
+type ListParams struct {
+	Hash   string
+	Height uint64
+	Size   uint32
+	Offset uint32
+	Asc    bool
+	From   uint64
+	To     uint64
+	Body   bool
+	Cursor string
+	// Range lists blocks in [From, To]
+	Range bool
+}
+
+type QueryResult struct {
+	Result b58json.RawMessage
+}
+
+func (js *JSONRPCService) initMethods() {
+	js.methods = map[string]methodFunc{
+		"aergo_blockchain":         js.blockchain,
+		"aergo_listBlockHeaders":   js.listBlockHeaders,
+		"aergo_getBlock":           js.getBlock,
+		"aergo_getTx":              js.getTx,
+		"aergo_getTxProof":         js.getTxProof,
+		"aergo_getReceipt":         js.getReceipt,
+		"aergo_commitTx":           js.commitTx,
+		"aergo_getState":           js.getState,
+		"aergo_getStateAndProof":   js.getStateAndProof,
+		"aergo_getStaking":         js.getStaking,
+		"aergo_getVotes":           js.getVotes,
+		"aergo_getAccountVotes":    js.getAccountVotes,
+		"aergo_listAccountTxs":     js.listAccountTxs,
+		"aergo_getABI":             js.getABI,
+		"aergo_queryContract":      js.queryContract,
+		"aergo_queryContractState": js.queryContractState,
+		"aergo_getPeers":           js.getPeers,
+	}
+	if js.cfg.Personal {
+		// sendTx uses the unlocked accounts of the node
+		js.methods["aergo_sendTx"] = js.sendTx
+	}
+}
+
+func decodeHash(s string) ([]byte, error) {
+	if s == "" {
+		return nil, invalidParams("hash is required")
+	}
+	hash, err := base58.Decode(s)
+	if err != nil {
+		return nil, invalidParams("invalid hash: " + err.Error())
+	}
+	return hash, nil
+}
+
+func decodeAddress(s string) ([]byte, error) {
+	addr, err := types.DecodeAddress(s)
+	if err != nil {
+		return nil, invalidParams("invalid address: " + err.Error())
+	}
+	return addr, nil
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, n)
+	return b
+}
+
+// hashParam parses the params which have a base58 encoded hash only
+func hashParam(params json.RawMessage) ([]byte, error) {
+	var s string
+	if err := parseParams(params, &s); err != nil {
+		return nil, err
+	}
+	return decodeHash(s)
+}
+
+// addressParam parses the params which have an address only
+func addressParam(params json.RawMessage) ([]byte, error) {
+	var s string
+	if err := parseParams(params, &s); err != nil {
+		return nil, err
+	}
+	return decodeAddress(s)
+}
+
+// blockParam parses the optional block param of the state queries, a number
+// or a base58 encoded hash. it is the latest block if not given
+func blockParam(id json.RawMessage) (uint64, []byte, error) {
+	if len(id) == 0 || string(id) == "null" {
+		return 0, nil, nil
+	}
+	var number uint64
+	if err := json.Unmarshal(id, &number); err == nil {
+		return number, nil, nil
+	}
+	var hash string
+	if err := json.Unmarshal(id, &hash); err != nil {
+		return 0, nil, invalidParams("block number or hash is required")
+	}
+	blockHash, err := decodeHash(hash)
+	if err != nil {
+		return 0, nil, err
+	}
+	return 0, blockHash, nil
+}
+
+// accountAtBlockParams parses the params which have an address and an
+// optional block
+func accountAtBlockParams(params json.RawMessage) (*types.AccountAtBlock, error) {
+	var address string
+	var block json.RawMessage
+	if err := parseParams(params, &address, &block); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	in := &types.AccountAtBlock{Account: addr}
+	if in.BlockNo, in.BlockHash, err = blockParam(block); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+func (js *JSONRPCService) blockchain(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	msg, err := js.rpc.Blockchain(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &jsonconv.InOutBlockchainStatus{
+		Hash:   base58.Encode(msg.GetBestBlockHash()),
+		Height: msg.GetBestHeight(),
+	}, nil
+}
+
+func (js *JSONRPCService) listBlockHeaders(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	// to the best block unless To is given, it is clamped by server
+	lp := ListParams{Size: 20, To: math.MaxUint64}
+	if err := parseParams(params, &lp); err != nil {
+		return nil, err
+	}
+	in := &types.ListParams{Height: lp.Height, Size: lp.Size, Offset: lp.Offset, Asc: lp.Asc,
+		From: lp.From, To: lp.To, WithBody: lp.Body, Range: lp.Range}
+	if lp.Hash != "" {
+		hash, err := decodeHash(lp.Hash)
+		if err != nil {
+			return nil, err
+		}
+		in.Hash = hash
+	}
+	if lp.Cursor != "" {
+		cursor, err := base58.Decode(lp.Cursor)
+		if err != nil {
+			return nil, invalidParams("invalid cursor: " + err.Error())
+		}
+		in.Cursor = cursor
+	}
+	msg, err := js.rpc.ListBlockHeaders(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvBlockHeaderList(msg), nil
+}
+
+// getBlock finds the block by number, or by base58 encoded hash
+func (js *JSONRPCService) getBlock(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var id json.RawMessage
+	if err := parseParams(params, &id); err != nil {
+		return nil, err
+	}
+	var value []byte
+	var number uint64
+	var hash string
+	if err := json.Unmarshal(id, &number); err == nil {
+		value = uint64Bytes(number)
+	} else if err := json.Unmarshal(id, &hash); err != nil {
+		return nil, invalidParams("block number or hash is required")
+	} else if value, err = decodeHash(hash); err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetBlock(ctx, &types.SingleBytes{Value: value})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvBlock(msg), nil
+}
+
+// getTx finds the tx in blocks first, and then in mempool. the block hash of
+// pending tx is empty
+func (js *JSONRPCService) getTx(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	in := &types.SingleBytes{Value: hash}
+	msg, err := js.rpc.GetBlockTX(ctx, in)
+	if err == nil && msg.GetTx() != nil {
+		return jsonconv.ConvTxInBlock(msg), nil
+	}
+	tx, err := js.rpc.GetTX(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvTxInBlock(&types.TxInBlock{Tx: tx}), nil
+}
+
+func (js *JSONRPCService) getTxProof(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetTXProof(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvTxProof(msg), nil
+}
+
+func (js *JSONRPCService) getReceipt(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	return js.rpc.GetReceipt(ctx, &types.SingleBytes{Value: hash})
+}
+
+// commitTx puts signed txs to mempool. the param is a tx or an array of them
+func (js *JSONRPCService) commitTx(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var txsParam json.RawMessage
+	if err := parseParams(params, &txsParam); err != nil {
+		return nil, err
+	}
+	if len(txsParam) > 0 && txsParam[0] == '{' {
+		txsParam = append(append([]byte{'['}, txsParam...), ']')
+	}
+	txs, err := jsonconv.ParseBase58Tx(txsParam)
+	if err != nil {
+		return nil, invalidParams("invalid tx: " + err.Error())
+	}
+	msg, err := js.rpc.CommitTX(ctx, &types.TxList{Txs: txs})
+	if err != nil {
+		return nil, err
+	}
+	out := []*jsonconv.InOutCommitResult{}
+	for _, result := range msg.GetResults() {
+		out = append(out, jsonconv.ConvCommitResult(result))
+	}
+	return out, nil
+}
+
+// sendTx signs the tx body with the unlocked account in the node and commits
+// it
+func (js *JSONRPCService) sendTx(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if isCrossOrigin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "cross origin request can not send tx")
+	}
+	var bodyParam json.RawMessage
+	if err := parseParams(params, &bodyParam); err != nil {
+		return nil, err
+	}
+	txBody, err := jsonconv.ParseBase58TxBody(bodyParam)
+	if err != nil {
+		return nil, invalidParams("invalid tx body: " + err.Error())
+	}
+	msg, err := js.rpc.SendTX(ctx, &types.Tx{Body: txBody})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvCommitResult(msg), nil
+}
+
+// getState returns the state of account at the block, or at the latest if no
+// block is given
+func (js *JSONRPCService) getState(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	in, err := accountAtBlockParams(params)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetState(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvState(msg), nil
+}
+
+// getStateAndProof returns the state of account with the merkle proof at the
+// root, or at the latest if no root is given
+func (js *JSONRPCService) getStateAndProof(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var address, root string
+	var compressed bool
+	if err := parseParams(params, &address, &root, &compressed); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	in := &types.AccountAndRoot{Account: addr, Compressed: compressed}
+	if root != "" {
+		if in.Root, err = decodeHash(root); err != nil {
+			return nil, err
+		}
+	}
+	return js.rpc.GetStateAndProof(ctx, in)
+}
+
+func (js *JSONRPCService) getStaking(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetStaking(ctx, &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvStaking(msg), nil
+}
+
+// listAccountTxs returns the txs sent or received by the address, latest
+// first
+func (js *JSONRPCService) listAccountTxs(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var s, c string
+	size := uint32(20)
+	if err := parseParams(params, &s, &size, &c); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(s)
+	if err != nil {
+		return nil, err
+	}
+	var cursor []byte
+	if c != "" {
+		if cursor, err = base58.Decode(c); err != nil {
+			return nil, invalidParams("invalid cursor: " + err.Error())
+		}
+	}
+	msg, err := js.rpc.ListAccountTxs(ctx, &types.AccountTxsParams{Account: addr, Size: size, Cursor: cursor})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvAccountTxList(msg), nil
+}
+
+// getVotes returns the top count of elected candidates
+func (js *JSONRPCService) getVotes(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	count := uint64(1)
+	if err := parseParams(params, &count); err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetVotes(ctx, &types.SingleBytes{Value: uint64Bytes(count)})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvVoteList(msg), nil
+}
+
+func (js *JSONRPCService) getAccountVotes(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.GetVotes(ctx, &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvVoteList(msg), nil
+}
+
+func (js *JSONRPCService) getABI(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	in, err := accountAtBlockParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return js.rpc.GetABI(ctx, in)
+}
+
+// queryContract calls the read only function of contract. params are the
+// address, types.CallInfo and the optional block
+func (js *JSONRPCService) queryContract(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var address string
+	var ci types.CallInfo
+	var block json.RawMessage
+	if err := parseParams(params, &address, &ci, &block); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if ci.Name == "" {
+		return nil, invalidParams("function name is required")
+	}
+	queryinfo, err := json.Marshal(ci)
+	if err != nil {
+		return nil, err
+	}
+	in := &types.Query{ContractAddress: addr, Queryinfo: queryinfo}
+	if in.BlockNo, in.BlockHash, err = blockParam(block); err != nil {
+		return nil, err
+	}
+	msg, err := js.rpc.QueryContract(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := msg.GetValue()
+	if !json.Valid(result) {
+		result, _ = json.Marshal(string(result))
+	}
+	return &QueryResult{Result: result}, nil
+}
+
+// queryContractState returns the state variable of contract with the merkle
+// proof of it. params are address, var name, var index, root and compressed
+func (js *JSONRPCService) queryContractState(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var address, root string
+	in := &types.StateQuery{}
+	if err := parseParams(params, &address, &in.VarName, &in.VarIndex, &root, &in.Compressed); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if in.VarName == "" {
+		return nil, invalidParams("var name is required")
+	}
+	in.ContractAddress = addr
+	if root != "" {
+		if in.Root, err = decodeHash(root); err != nil {
+			return nil, err
+		}
+	}
+	return js.rpc.QueryContractState(ctx, in)
+}
+
+func (js *JSONRPCService) getPeers(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	msg, err := js.rpc.GetPeers(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	out := []*jsonconv.InOutPeer{}
+	for _, p := range msg.GetPeers() {
+		out = append(out, jsonconv.ConvPeer(p))
+	}
+	return out, nil
+}
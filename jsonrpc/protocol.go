@@ -0,0 +1,190 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	b58json "github.com/aergoio/aergo/types/jsonconv/encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const version = "2.0"
+
+// maxBatchSize limits the number of requests in a batch
+const maxBatchSize = 100
+
+// error codes defined in JSON-RPC 2.0
+const (
+	ErrCodeParse          = -32700
+	ErrCodeInvalidRequest = -32600
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeInternal       = -32603
+	// ErrCodeServer is for errors from the rpc service. the grpc status code
+	// is in data
+	ErrCodeServer = -32000
+)
+
+// Error is the error object of JSON-RPC response
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalidParams(msg string) *Error {
+	return &Error{Code: ErrCodeInvalidParams, Message: msg}
+}
+
+// toError converts errors of rpc service into JSON-RPC error
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	if s, ok := status.FromError(err); ok {
+		if s.Code() == codes.InvalidArgument {
+			return invalidParams(s.Message())
+		}
+		return &Error{Code: ErrCodeServer, Message: s.Message(), Data: s.Code().String()}
+	}
+	return &Error{Code: ErrCodeServer, Message: err.Error()}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+func errorResponse(id json.RawMessage, err *Error) *response {
+	return &response{JSONRPC: version, ID: id, Error: err}
+}
+
+// handleMessage handles a request or a batch of them, and returns the
+// marshaled response. it returns nil if there is nothing to respond, that is
+// all requests are notifications
+func (js *JSONRPCService) handleMessage(ctx context.Context, msg []byte, conn *wsConn) []byte {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) > 0 && msg[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(msg, &batch); err != nil {
+			return marshalResponse(errorResponse(nil, &Error{Code: ErrCodeParse, Message: err.Error()}))
+		}
+		if len(batch) == 0 {
+			return marshalResponse(errorResponse(nil, &Error{Code: ErrCodeInvalidRequest, Message: "empty batch"}))
+		}
+		if len(batch) > maxBatchSize {
+			return marshalResponse(errorResponse(nil, &Error{Code: ErrCodeInvalidRequest, Message: "too many requests in batch"}))
+		}
+		var rsps []*response
+		for _, raw := range batch {
+			if rsp := js.handleRequest(ctx, raw, conn); rsp != nil {
+				rsps = append(rsps, rsp)
+			}
+		}
+		if len(rsps) == 0 {
+			return nil
+		}
+		return marshalResponse(rsps)
+	}
+	if !json.Valid(msg) {
+		return marshalResponse(errorResponse(nil, &Error{Code: ErrCodeParse, Message: "invalid json"}))
+	}
+	if rsp := js.handleRequest(ctx, msg, conn); rsp != nil {
+		return marshalResponse(rsp)
+	}
+	return nil
+}
+
+// handleRequest calls the method of the request. it returns nil for
+// notification, the request without id
+func (js *JSONRPCService) handleRequest(ctx context.Context, raw json.RawMessage, conn *wsConn) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nil, &Error{Code: ErrCodeInvalidRequest, Message: err.Error()})
+	}
+	if req.JSONRPC != version || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: ErrCodeInvalidRequest, Message: "invalid request"})
+	}
+	result, err := js.call(ctx, &req, conn)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, toError(err))
+	}
+	out, err := b58json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, &Error{Code: ErrCodeInternal, Message: err.Error()})
+	}
+	return &response{JSONRPC: version, ID: req.ID, Result: out}
+}
+
+func (js *JSONRPCService) call(ctx context.Context, req *request, conn *wsConn) (interface{}, error) {
+	switch req.Method {
+	case "aergo_subscribe":
+		return js.subscribe(conn, req.Params)
+	case "aergo_unsubscribe":
+		return js.unsubscribe(conn, req.Params)
+	}
+	method, exist := js.methods[req.Method]
+	if !exist {
+		return nil, &Error{Code: ErrCodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	return method(ctx, req.Params)
+}
+
+// parseParams unmarshals positional params into args in order. missing
+// trailing params are left as they are, so args can have default values
+func parseParams(params json.RawMessage, args ...interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(params, &list); err != nil {
+		return invalidParams("params should be an array")
+	}
+	if len(list) > len(args) {
+		return invalidParams("too many params")
+	}
+	for i, p := range list {
+		if err := b58json.Unmarshal(p, args[i]); err != nil {
+			return invalidParams(err.Error())
+		}
+	}
+	return nil
+}
+
+func marshalResponse(v interface{}) []byte {
+	out, err := json.Marshal(v)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to marshal response")
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,257 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package jsonrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	b58json "github.com/aergoio/aergo/types/jsonconv/encoding/json"
+	"github.com/gorilla/websocket"
+)
+
+// topics of subscription
+const (
+	TopicNewBlocks  = "newBlocks"
+	TopicPendingTxs = "pendingTxs"
+)
+
+const (
+	// a connection which can not keep up with the notifications is closed
+	wsSendQueueSize = 256
+	wsWriteTimeout  = 10 * time.Second
+	wsMaxMessage    = maxBodySize
+	// a connection which sends nothing, not even a pong, for wsPongWait is
+	// closed. pings are sent before the deadline
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxSubscriptions limits the subscriptions of a connection
+	wsMaxSubscriptions = 32
+)
+
+// wsConn is a websocket client. requests are handled in order, and responses
+// and notifications are written by a writer goroutine
+type wsConn struct {
+	conn *websocket.Conn
+	send chan []byte
+	// subs is the number of subscriptions of the connection. it is guarded
+	// by subLock of the service
+	subs int
+	// crossOrigin is set if the connection is upgraded from a request of
+	// other origin
+	crossOrigin bool
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+type subscription struct {
+	id    string
+	topic string
+	conn  *wsConn
+}
+
+// SubscriptionResult is the params of the notification of a subscription
+type SubscriptionResult struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+func (js *JSONRPCService) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := js.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug().Err(err).Msg("failed to upgrade to websocket")
+		return
+	}
+	conn.SetReadLimit(wsMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	c := &wsConn{
+		conn:        conn,
+		send:        make(chan []byte, wsSendQueueSize),
+		crossOrigin: !isSameOrigin(r),
+		done:        make(chan struct{}),
+	}
+	go c.writeLoop()
+	defer js.removeSubscriptions(c)
+	defer c.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if c.crossOrigin {
+		ctx = withCrossOrigin(ctx)
+	}
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if out := js.handleMessage(ctx, msg, c); out != nil && !c.write(out) {
+			return
+		}
+	}
+}
+
+func (c *wsConn) writeLoop() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// write queues the message without blocking. the connection is closed if
+// the queue is full
+func (c *wsConn) write(msg []byte) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		logger.Debug().Msg("websocket client can not keep up, closing connection")
+		c.close()
+		return false
+	}
+}
+
+func (c *wsConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func newSubscriptionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(b), nil
+}
+
+// subscribe adds a subscription of the topic for the websocket connection and
+// returns the id of it
+func (js *JSONRPCService) subscribe(conn *wsConn, params json.RawMessage) (interface{}, error) {
+	if conn == nil {
+		return nil, &Error{Code: ErrCodeMethodNotFound, Message: "subscription is only available on websocket"}
+	}
+	var topic string
+	if err := parseParams(params, &topic); err != nil {
+		return nil, err
+	}
+	if topic != TopicNewBlocks && topic != TopicPendingTxs {
+		return nil, invalidParams("unknown topic: " + topic)
+	}
+	id, err := newSubscriptionID()
+	if err != nil {
+		return nil, err
+	}
+	js.subLock.Lock()
+	defer js.subLock.Unlock()
+	if conn.subs >= wsMaxSubscriptions {
+		return nil, &Error{Code: ErrCodeInvalidRequest, Message: "too many subscriptions"}
+	}
+	conn.subs++
+	js.subs[id] = &subscription{id: id, topic: topic, conn: conn}
+	return id, nil
+}
+
+// unsubscribe removes the subscription of id, and returns whether it existed
+func (js *JSONRPCService) unsubscribe(conn *wsConn, params json.RawMessage) (interface{}, error) {
+	if conn == nil {
+		return nil, &Error{Code: ErrCodeMethodNotFound, Message: "subscription is only available on websocket"}
+	}
+	var id string
+	if err := parseParams(params, &id); err != nil {
+		return nil, err
+	}
+	js.subLock.Lock()
+	defer js.subLock.Unlock()
+	sub, exist := js.subs[id]
+	if !exist || sub.conn != conn {
+		return false, nil
+	}
+	delete(js.subs, id)
+	conn.subs--
+	return true, nil
+}
+
+func (js *JSONRPCService) removeSubscriptions(conn *wsConn) {
+	js.subLock.Lock()
+	defer js.subLock.Unlock()
+	for id, sub := range js.subs {
+		if sub.conn == conn {
+			delete(js.subs, id)
+		}
+	}
+	conn.subs = 0
+}
+
+// OnBlock notifies the header of new block to subscribers
+func (js *JSONRPCService) OnBlock(block *types.Block) {
+	header := &types.Block{Hash: block.BlockHash(), Header: block.GetHeader()}
+	js.publish(TopicNewBlocks, jsonconv.ConvBlock(header))
+}
+
+// OnTxs notifies new txs in mempool to subscribers
+func (js *JSONRPCService) OnTxs(txs []*types.Tx) {
+	for _, tx := range txs {
+		js.publish(TopicPendingTxs, jsonconv.ConvTx(tx))
+	}
+}
+
+func (js *JSONRPCService) publish(topic string, result interface{}) {
+	js.subLock.RLock()
+	defer js.subLock.RUnlock()
+	if len(js.subs) == 0 {
+		return
+	}
+	out, err := b58json.Marshal(result)
+	if err != nil {
+		logger.Error().Err(err).Str("topic", topic).Msg("failed to marshal notification")
+		return
+	}
+	for _, sub := range js.subs {
+		if sub.topic != topic {
+			continue
+		}
+		msg := marshalResponse(&notification{
+			JSONRPC: version,
+			Method:  "aergo_subscription",
+			Params:  &SubscriptionResult{Subscription: sub.id, Result: out},
+		})
+		sub.conn.write(msg)
+	}
+}
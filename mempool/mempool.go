@@ -8,10 +8,12 @@ package mempool
 import (
 	"bufio"
 	"bytes"
+	"container/heap"
 	"encoding/csv"
 	"io"
 	"math/big"
 	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/aergoio/aergo-actor/router"
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/chain"
 	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/internal/enc"
@@ -46,13 +49,17 @@ type MemPool struct {
 	//curBestBlockHash
 	sdb         *state.ChainStateDB
 	bestBlockID types.BlockID
+	bestBlockNo types.BlockNo
 	stateDB     *state.StateDB
 	verifier    *actor.PID
 	orphan      int
 	cache       map[types.TxID]*types.Tx
+	added       map[types.TxID]time.Time
 	pool        map[types.AccountID]*TxList
+	lasts       evictHeap
 	dumpPath    string
 	status      int32
+	evicted     evictStats
 	// followings are for test
 	testConfig bool
 	deadtx     int
@@ -64,6 +71,7 @@ func NewMemPoolService(cfg *cfg.Config, sdb *state.ChainStateDB) *MemPool {
 		cfg:      cfg,
 		sdb:      sdb,
 		cache:    map[types.TxID]*types.Tx{},
+		added:    map[types.TxID]time.Time{},
 		pool:     map[types.AccountID]*TxList{},
 		dumpPath: cfg.Mempool.DumpFilePath,
 		status:   initial,
@@ -153,11 +161,26 @@ func (mp *MemPool) Receive(context actor.Context) {
 	}
 }
 
+// evictStats counts transactions removed from mempool before they are
+// included in a block, by the reason
+type evictStats struct {
+	full     int // pool size limit
+	account  int // per account limit
+	expired  int // orphan ttl
+	replaced int // replaced by higher price
+}
+
 func (mp *MemPool) Statistics() *map[string]interface{} {
 	return &map[string]interface{}{
 		"total":  len(mp.cache),
 		"orphan": mp.orphan,
 		"dead":   mp.deadtx,
+		"evicted": map[string]int{
+			"full":     mp.evicted.full,
+			"account":  mp.evicted.account,
+			"expired":  mp.evicted.expired,
+			"replaced": mp.evicted.replaced,
+		},
 	}
 }
 
@@ -168,14 +191,26 @@ func (mp *MemPool) get(maxBlockBodySize uint32) ([]*types.Tx, error) {
 	count := 0
 	size := 0
 	txs := make([]*types.Tx, 0)
-Gather:
+
+	heads := make(txHeap, 0, len(mp.pool))
 	for _, list := range mp.pool {
-		for _, tx := range list.Get() {
-			if size += proto.Size(tx); uint32(size) > maxBlockBodySize {
-				break Gather
-			}
-			txs = append(txs, tx)
-			count++
+		if ready := list.Get(); len(ready) > 0 {
+			heads = append(heads, newTxHead(ready))
+		}
+	}
+	heap.Init(&heads)
+	for heads.Len() > 0 {
+		head := heads[0]
+		tx := head.txs[0]
+		if size += proto.Size(tx); uint32(size) > maxBlockBodySize {
+			break
+		}
+		txs = append(txs, tx)
+		count++
+		if head.next() {
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
 		}
 	}
 	elapsed := time.Since(start)
@@ -213,6 +248,15 @@ func (mp *MemPool) put(tx *types.Tx) error {
 	}
 	defer mp.releaseMemPoolList(list)
 	diff, err := list.Put(tx)
+	if err == types.ErrSameNonceAlreadyInMempool {
+		var old *types.Tx
+		if old, err = list.Replace(tx, mp.cfg.Mempool.PriceBump); err == nil {
+			mp.uncache(old)
+			mp.evicted.replaced++
+			mp.Debug().Str("old", enc.ToString(old.GetHash())).
+				Str("new", enc.ToString(tx.GetHash())).Msg("tx replaced by higher price")
+		}
+	}
 	if err != nil {
 		mp.Debug().Err(err).Msg("fail to put at a mempool list")
 		return err
@@ -220,8 +264,21 @@ func (mp *MemPool) put(tx *types.Tx) error {
 
 	mp.orphan -= diff
 	mp.cache[id] = tx
+	mp.added[id] = time.Now()
+	mp.updateEvictHeap(list)
 	//mp.Debugf("tx add-ed size(%d, %d)[%s]", len(mp.cache), mp.orphan, tx.GetBody().String())
 
+	if limit := mp.cfg.Mempool.AccountLimit; limit > 0 && list.orphans() > limit {
+		if evicted := mp.evictLast(list); evicted == tx {
+			return types.ErrTxAccountLimit
+		}
+	}
+	if limit := mp.cfg.Mempool.SizeLimit; limit > 0 && len(mp.cache) > limit {
+		if evicted := mp.evictLowestPrice(tx); evicted == tx {
+			return types.ErrTxPoolFull
+		}
+	}
+
 	if !mp.testConfig {
 		mp.notifyNewTx(*tx)
 	}
@@ -235,6 +292,75 @@ func (mp *MemPool) puts(txs ...*types.Tx) []error {
 	return errs
 }
 
+// evictLowestPrice removes the lowest priced transaction among the last ones
+// of each account, so nonce continuity of remaining transactions is kept.
+// incoming is evicted first on the same price
+func (mp *MemPool) evictLowestPrice(incoming *types.Tx) *types.Tx {
+	if mp.lasts.Len() == 0 {
+		return nil
+	}
+	victim := mp.lasts[0]
+	if list := mp.getMemPoolList(incoming.GetBody().GetAccount()); list != nil &&
+		list.Last() == incoming && list.lastPrice.Cmp(victim.lastPrice) == 0 {
+		victim = list
+	}
+	minPrice := victim.lastPrice
+	diff, evicted := victim.RemoveLast()
+	mp.orphan -= diff
+	mp.uncache(evicted)
+	mp.releaseMemPoolList(victim)
+	mp.evicted.full++
+	mp.Debug().Str("hash", enc.ToString(evicted.GetHash())).
+		Str("price", minPrice.String()).Msg("tx evicted from full mempool")
+	return evicted
+}
+
+// evictLast removes the transaction which has the highest nonce of the
+// account exceeding the per account limit
+func (mp *MemPool) evictLast(list *TxList) *types.Tx {
+	diff, evicted := list.RemoveLast()
+	if evicted == nil {
+		return nil
+	}
+	mp.orphan -= diff
+	mp.uncache(evicted)
+	mp.updateEvictHeap(list)
+	mp.evicted.account++
+	mp.Debug().Str("hash", enc.ToString(evicted.GetHash())).
+		Uint64("nonce", evicted.GetBody().GetNonce()).Msg("tx evicted by account limit")
+	return evicted
+}
+
+// expireOrphans removes orphan transactions staying in mempool longer than
+// the orphan ttl. it is checked whenever a block arrives
+func (mp *MemPool) expireOrphans(now time.Time) {
+	ttl := time.Duration(mp.cfg.Mempool.OrphanTTL) * time.Second
+	if ttl <= 0 || mp.orphan == 0 {
+		return
+	}
+	expired := func(tx *types.Tx) bool {
+		return now.Sub(mp.added[types.ToTxID(tx.GetHash())]) > ttl
+	}
+	for _, list := range mp.pool {
+		removed := list.RemoveOrphans(expired)
+		if len(removed) == 0 {
+			continue
+		}
+		mp.orphan -= len(removed)
+		for _, tx := range removed {
+			mp.uncache(tx)
+		}
+		mp.evicted.expired += len(removed)
+		mp.releaseMemPoolList(list)
+	}
+}
+
+func (mp *MemPool) uncache(tx *types.Tx) {
+	id := types.ToTxID(tx.GetHash())
+	delete(mp.cache, id)
+	delete(mp.added, id)
+}
+
 func (mp *MemPool) setStateDB(block *types.Block) bool {
 	if mp.testConfig {
 		return true
@@ -249,6 +375,7 @@ func (mp *MemPool) setStateDB(block *types.Block) bool {
 			normal = false
 		}
 		mp.bestBlockID = newBlockID
+		mp.bestBlockNo = block.BlockNo()
 
 		stateRoot := block.GetHeader().GetBlocksRootHash()
 		if mp.stateDB == nil {
@@ -296,14 +423,15 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 			// TODO : ????
 			continue
 		}
-		diff, delTxs := list.FilterByState(ns)
+		diff, delTxs := list.FilterByState(ns, mp.nextBlockNo())
 		mp.orphan -= diff
 		for _, tx := range delTxs {
-			delete(mp.cache, types.ToTxID(tx.GetHash())) // need lock
+			mp.uncache(tx) // need lock
 		}
 		mp.releaseMemPoolList(list)
 		check++
 	}
+	mp.expireOrphans(start)
 
 	//FOR TEST
 	for _, tx := range block.GetBody().GetTxs() {
@@ -323,6 +451,11 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 	return nil
 }
 
+// nextBlockNo returns the number of the block following the best block.
+func (mp *MemPool) nextBlockNo() types.BlockNo {
+	return mp.bestBlockNo + 1
+}
+
 // signiture verification
 func (mp *MemPool) verifyTx(tx *types.Tx) error {
 	err := tx.Validate()
@@ -345,7 +478,18 @@ func (mp *MemPool) validateTx(tx *types.Tx) error {
 	if err != nil {
 		return err
 	}
-	err = tx.ValidateWithSenderState(ns)
+	// the txs are checked by the rules of the block they are going to be
+	// included in
+	no := mp.nextBlockNo()
+	err = chain.CheckMultisig(tx.GetBody(), no)
+	if err != nil {
+		return err
+	}
+	err = chain.CheckGasLimit(tx.GetBody(), no)
+	if err != nil {
+		return err
+	}
+	err = tx.ValidateWithSenderStateAndFee(ns, chain.MaxTxFee(tx.GetBody(), no))
 	if err != nil {
 		return err
 	}
@@ -392,7 +536,26 @@ func (mp *MemPool) acquireMemPoolList(acc []byte) (*TxList, error) {
 	return mp.pool[id], nil
 }
 
+// updateEvictHeap reorders the list in the eviction heap after its last
+// transaction is changed. an empty list is removed from the heap
+func (mp *MemPool) updateEvictHeap(list *TxList) {
+	last := list.Last()
+	switch {
+	case last == nil:
+		if list.heapIdx >= 0 {
+			heap.Remove(&mp.lasts, list.heapIdx)
+		}
+	case list.heapIdx < 0:
+		list.lastPrice = last.GetBody().GetPriceBigInt()
+		heap.Push(&mp.lasts, list)
+	default:
+		list.lastPrice = last.GetBody().GetPriceBigInt()
+		heap.Fix(&mp.lasts, list.heapIdx)
+	}
+}
+
 func (mp *MemPool) releaseMemPoolList(list *TxList) {
+	mp.updateEvictHeap(list)
 	if list.Empty() {
 		id := types.ToAccountID(list.account)
 		delete(mp.pool, id)
@@ -437,6 +600,10 @@ func (mp *MemPool) notifyNewTx(tx types.Tx) {
 	mp.RequestTo(message.P2PSvc, &message.NotifyNewTransactions{
 		Txs: []*types.Tx{&tx},
 	})
+	// for subscribers of pending txs
+	mp.TellTo(message.RPCSvc, &message.NotifyNewTransactions{
+		Txs: []*types.Tx{&tx},
+	})
 }
 
 func (mp *MemPool) loadTxs() {
@@ -475,7 +642,16 @@ func (mp *MemPool) loadTxs() {
 			mp.Error().Err(err).Msg("errr on unmarshalling tx during loading")
 			continue
 		}
-		mp.put(&buf) // nolint: errcheck
+		if err = mp.put(&buf); err != nil || len(rc) < 2 {
+			continue
+		}
+		// the orphan ttl counts from when the tx was first added
+		added, err := strconv.ParseInt(rc[1], 10, 64)
+		if err != nil {
+			mp.Error().Err(err).Msg("err on decoding added time during loading")
+			continue
+		}
+		mp.setAdded(&buf, time.Unix(0, added))
 	}
 
 	mp.Info().Int("try", count).
@@ -485,6 +661,16 @@ func (mp *MemPool) loadTxs() {
 		Msg("loading mempool done")
 }
 
+// setAdded restores the time when tx was added to the mempool
+func (mp *MemPool) setAdded(tx *types.Tx, added time.Time) {
+	mp.Lock()
+	defer mp.Unlock()
+	id := types.ToTxID(tx.GetHash())
+	if _, ok := mp.added[id]; ok {
+		mp.added[id] = added
+	}
+}
+
 func (mp *MemPool) isRunning() bool {
 	if atomic.LoadInt32(&mp.status) != running {
 		mp.Info().Msg("skip to dump txs because mempool is not running yet")
@@ -523,7 +709,8 @@ func (mp *MemPool) dumpTxsToFile() {
 			}
 
 			strData := enc.ToString(data)
-			err = writer.Write([]string{strData})
+			added := mp.added[types.ToTxID(v.GetHash())].UnixNano()
+			err = writer.Write([]string{strData, strconv.FormatInt(added, 10)})
 			if err != nil {
 				mp.Info().Err(err).Msg("writing encoded tx fail")
 				break
@@ -11,6 +11,7 @@ import (
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aergoio/aergo/account/key"
 	"github.com/aergoio/aergo/config"
@@ -124,6 +125,13 @@ func genTx(acc int, rec int, nonce uint64, amount uint64) *types.Tx {
 	//key.SignTx(&tx, sign[acc])
 	return &tx
 }
+func genTxWithPrice(acc int, rec int, nonce uint64, amount uint64, price uint64) *types.Tx {
+	tx := genTx(acc, rec, nonce, amount)
+	tx.Body.Limit = types.TxBaseGas
+	tx.Body.Price = new(big.Int).SetUint64(price).Bytes()
+	tx.Hash = tx.CalculateTxHash()
+	return tx
+}
 
 func TestInvalidTransaction(t *testing.T) {
 
@@ -528,6 +536,32 @@ func TestDumpAndLoad(t *testing.T) {
 	os.Remove(pool.dumpPath) // nolint: errcheck
 }
 
+func TestDumpAndLoadAdded(t *testing.T) {
+	initTest(t)
+	pool.dumpPath = "./mempool_dump_test"
+	defer os.Remove(pool.dumpPath) // nolint: errcheck
+	pool.cfg.Mempool.OrphanTTL = 10
+
+	orphan := genTx(0, 0, 2, 1)
+	assert.NoError(t, pool.put(orphan))
+	added := time.Now().Add(-5 * time.Second)
+	pool.added[types.ToTxID(orphan.GetHash())] = added
+	atomic.StoreInt32(&pool.status, running)
+	pool.dumpTxsToFile()
+	deinitTest()
+
+	initTest(t)
+	defer deinitTest()
+	pool.dumpPath = "./mempool_dump_test"
+	pool.cfg.Mempool.OrphanTTL = 10
+	pool.loadTxs()
+	assert.Equal(t, added.UnixNano(), pool.added[types.ToTxID(orphan.GetHash())].UnixNano())
+
+	// the loaded orphan expires by the time it was first added
+	pool.expireOrphans(time.Now().Add(6 * time.Second))
+	assert.Nil(t, pool.exists(orphan.GetHash()))
+}
+
 func TestEvitOnProfit(t *testing.T) {
 	initTest(t)
 	defer deinitTest()
@@ -576,3 +610,159 @@ func TestDeleteInvokePriceFilterOut(t *testing.T) {
 	simulateBlockGen(txs[1:2]...)
 	checkRemainder(0, 0)
 }
+
+func TestGetOrderedByPrice(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	txs := []*types.Tx{
+		genTxWithPrice(0, 0, 1, 1, 1),
+		genTxWithPrice(0, 0, 2, 1, 9),
+		genTxWithPrice(1, 0, 1, 1, 5),
+		genTxWithPrice(2, 0, 1, 1, 3),
+		genTxWithPrice(2, 0, 2, 1, 7),
+	}
+	errs := pool.puts(txs...)
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	ret, err := pool.get(maxBlockBodySize)
+	assert.NoError(t, err)
+	// nonce order of each account is kept over price
+	expected := []*types.Tx{txs[2], txs[3], txs[4], txs[0], txs[1]}
+	if assert.Equal(t, len(expected), len(ret)) {
+		for i := range expected {
+			assert.True(t, sameTx(expected[i], ret[i]), "wrong order at %d", i)
+		}
+	}
+}
+
+func TestReplaceByPrice(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.PriceBump = 10
+
+	old := genTxWithPrice(0, 0, 1, 1, 10)
+	assert.NoError(t, pool.put(old))
+	assert.NoError(t, pool.put(genTxWithPrice(0, 0, 2, 1, 10)))
+
+	err := pool.put(genTxWithPrice(0, 0, 1, 2, 10))
+	assert.EqualError(t, err, types.ErrTxPriceTooLowToReplace.Error())
+	err = pool.put(genTxWithPrice(0, 0, 1, 2, 10000))
+	assert.EqualError(t, err, types.ErrInsufficientBalance.Error())
+
+	replacing := genTxWithPrice(0, 0, 1, 2, 11)
+	assert.NoError(t, pool.put(replacing))
+
+	total, orphan := pool.Size()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 0, orphan)
+	assert.Nil(t, pool.exists(old.GetHash()))
+	assert.NotNil(t, pool.exists(replacing.GetHash()))
+}
+
+func TestEvictOnFull(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.SizeLimit = 3
+
+	txs := []*types.Tx{
+		genTxWithPrice(0, 0, 1, 1, 5),
+		genTxWithPrice(0, 0, 2, 1, 2),
+		genTxWithPrice(1, 0, 1, 1, 1),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx))
+	}
+
+	// not more profitable than any evictable tx
+	err := pool.put(genTxWithPrice(2, 0, 1, 1, 1))
+	assert.EqualError(t, err, types.ErrTxPoolFull.Error())
+
+	assert.NoError(t, pool.put(genTxWithPrice(2, 0, 1, 1, 3)))
+	assert.Nil(t, pool.exists(txs[2].GetHash()))
+
+	// the last tx of an account is evicted to keep nonce continuity
+	assert.NoError(t, pool.put(genTxWithPrice(3, 0, 1, 1, 4)))
+	assert.Nil(t, pool.exists(txs[1].GetHash()))
+	assert.NotNil(t, pool.exists(txs[0].GetHash()))
+
+	total, orphan := pool.Size()
+	assert.Equal(t, 3, total)
+	assert.Equal(t, 0, orphan)
+
+	// the eviction heap keeps an entry per account ordered by the last price
+	assert.Equal(t, len(pool.pool), pool.lasts.Len())
+	assert.Equal(t, int64(3), pool.lasts[0].lastPrice.Int64())
+}
+
+func TestAccountLimit(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.AccountLimit = 2
+
+	assert.NoError(t, pool.put(genTx(0, 0, 1, 1)))
+	assert.NoError(t, pool.put(genTx(0, 0, 3, 1)))
+	assert.NoError(t, pool.put(genTx(0, 0, 4, 1)))
+
+	err := pool.put(genTx(0, 0, 5, 1))
+	assert.EqualError(t, err, types.ErrTxAccountLimit.Error())
+
+	// only the orphans count against the limit
+	assert.NoError(t, pool.put(genTx(0, 0, 2, 1)))
+	assert.NoError(t, pool.put(genTx(0, 0, 7, 1)))
+	assert.NoError(t, pool.put(genTx(0, 0, 8, 1)))
+
+	// the orphan with the highest nonce is evicted for a lower one
+	orphan := genTx(0, 0, 8, 1)
+	assert.NoError(t, pool.put(genTx(0, 0, 6, 1)))
+	assert.Nil(t, pool.exists(orphan.GetHash()))
+	assert.NoError(t, pool.put(genTx(1, 0, 1, 1)))
+
+	total, o := pool.Size()
+	assert.Equal(t, 7, total)
+	assert.Equal(t, 2, o)
+	evicted := (*pool.Statistics())["evicted"].(map[string]int)
+	assert.Equal(t, 2, evicted["account"])
+}
+
+func TestExpireOrphans(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.OrphanTTL = 10
+
+	txs := []*types.Tx{
+		genTx(0, 0, 1, 1),
+		genTx(0, 0, 3, 1),
+		genTx(1, 0, 2, 1),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx))
+	}
+	orphan := genTx(0, 0, 4, 1)
+	assert.NoError(t, pool.put(orphan))
+	pool.added[types.ToTxID(orphan.GetHash())] = time.Now().Add(10 * time.Second)
+
+	pool.expireOrphans(time.Now().Add(5 * time.Second))
+	total, o := pool.Size()
+	assert.Equal(t, 4, total)
+	assert.Equal(t, 3, o)
+
+	pool.expireOrphans(time.Now().Add(15 * time.Second))
+	total, o = pool.Size()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, o)
+	assert.NotNil(t, pool.exists(txs[0].GetHash()))
+	assert.NotNil(t, pool.exists(orphan.GetHash()))
+
+	// a continuous tx makes the left orphan processible
+	assert.NoError(t, pool.put(genTx(0, 0, 2, 1)))
+	assert.NoError(t, pool.put(genTx(0, 0, 3, 1)))
+	total, o = pool.Size()
+	assert.Equal(t, 4, total)
+	assert.Equal(t, 0, o)
+
+	evicted := (*pool.Statistics())["evicted"].(map[string]int)
+	assert.Equal(t, 2, evicted["expired"])
+}
@@ -0,0 +1,89 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package mempool
+
+import (
+	"math/big"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// txHead is the first not-yet-selected processible transaction of an account
+type txHead struct {
+	txs   []*types.Tx
+	price *big.Int
+}
+
+func newTxHead(txs []*types.Tx) *txHead {
+	return &txHead{
+		txs:   txs,
+		price: txs[0].GetBody().GetPriceBigInt(),
+	}
+}
+
+// next moves to the next transaction of the account
+// it returns false if there is no more transaction
+func (h *txHead) next() bool {
+	h.txs = h.txs[1:]
+	if len(h.txs) == 0 {
+		return false
+	}
+	h.price = h.txs[0].GetBody().GetPriceBigInt()
+	return true
+}
+
+// txHeap is a max-heap of accounts ordered by the price of their first
+// transaction. picking from it keeps nonce order of each account while
+// higher priced transactions are selected first
+type txHeap []*txHead
+
+func (h txHeap) Len() int { return len(h) }
+func (h txHeap) Less(i, j int) bool {
+	return h[i].price.Cmp(h[j].price) > 0
+}
+func (h txHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *txHeap) Push(x interface{}) {
+	*h = append(*h, x.(*txHead))
+}
+
+func (h *txHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// evictHeap is a min-heap of accounts ordered by the price of their last
+// transaction. the top is the account whose last transaction is evicted
+// first from a full mempool
+type evictHeap []*TxList
+
+func (h evictHeap) Len() int { return len(h) }
+func (h evictHeap) Less(i, j int) bool {
+	return h[i].lastPrice.Cmp(h[j].lastPrice) < 0
+}
+func (h evictHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIdx = i
+	h[j].heapIdx = j
+}
+
+func (h *evictHeap) Push(x interface{}) {
+	tl := x.(*TxList)
+	tl.heapIdx = len(*h)
+	*h = append(*h, tl)
+}
+
+func (h *evictHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	x.heapIdx = -1
+	*h = old[:n-1]
+	return x
+}
@@ -6,9 +6,11 @@
 package mempool
 
 import (
+	"math/big"
 	"sort"
 	"sync"
 
+	"github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/types"
 )
 
@@ -19,6 +21,11 @@ type TxList struct {
 	account []byte
 	ready   int
 	list    []*types.Tx // nonce-ordered tx list
+
+	// position in the eviction heap of mempool and the price of the last
+	// transaction it is ordered by
+	heapIdx   int
+	lastPrice *big.Int
 }
 
 // NewTxList creates new TxList with given State
@@ -26,6 +33,7 @@ func NewTxList(acc []byte, st *types.State) *TxList {
 	return &TxList{
 		base:    st,
 		account: acc,
+		heapIdx: -1,
 	}
 }
 
@@ -115,9 +123,89 @@ func (tl *TxList) Put(tx *types.Tx) (int, error) {
 	return oldCnt - newCnt, nil
 }
 
+// Replace replaces the transaction which has same nonce with tx
+// tx must pay more than bump percent of the price of the old one
+// it returns the replaced transaction
+func (tl *TxList) Replace(tx *types.Tx, bump int) (*types.Tx, error) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	index, found := tl.search(tx)
+	if !found {
+		return nil, types.ErrTxNotFound
+	}
+	old := tl.list[index]
+	if !enoughPriceBump(old, tx, bump) {
+		return nil, types.ErrTxPriceTooLowToReplace
+	}
+	tl.list[index] = tx
+	return old, nil
+}
+
+// enoughPriceBump checks price of newTx is higher than price of oldTx at
+// least by bump percent
+func enoughPriceBump(oldTx *types.Tx, newTx *types.Tx, bump int) bool {
+	oldPrice := oldTx.GetBody().GetPriceBigInt()
+	newPrice := newTx.GetBody().GetPriceBigInt()
+	if newPrice.Cmp(oldPrice) <= 0 {
+		return false
+	}
+	threshold := new(big.Int).Mul(oldPrice, big.NewInt(int64(100+bump)))
+	return new(big.Int).Mul(newPrice, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// Last returns the transaction which has the highest nonce
+// removing it does not break nonce continuity of others
+func (tl *TxList) Last() *types.Tx {
+	tl.RLock()
+	defer tl.RUnlock()
+	if len(tl.list) == 0 {
+		return nil
+	}
+	return tl.list[len(tl.list)-1]
+}
+
+// RemoveLast removes the transaction which has the highest nonce
+// it returns the change of orphan count like Put and removed transaction
+func (tl *TxList) RemoveLast() (int, *types.Tx) {
+	tl.Lock()
+	defer tl.Unlock()
+	if len(tl.list) == 0 {
+		return 0, nil
+	}
+	last := len(tl.list) - 1
+	tx := tl.list[last]
+	tl.list = tl.list[:last]
+	if tl.ready > last {
+		tl.ready = last
+		return 0, tx
+	}
+	return 1, tx
+}
+
+// RemoveOrphans removes orphan transactions which expired returns true
+// it returns removed transactions, all of them are counted as orphan
+func (tl *TxList) RemoveOrphans(expired func(*types.Tx) bool) []*types.Tx {
+	tl.Lock()
+	defer tl.Unlock()
+
+	var removed []*types.Tx
+	left := tl.list[:tl.ready]
+	for _, x := range tl.list[tl.ready:] {
+		if expired(x) {
+			removed = append(removed, x)
+		} else {
+			left = append(left, x)
+		}
+	}
+	tl.list = left
+	return removed
+}
+
 // SetMinNonce sets new minimum nonce for TxList
 // evict on some transactions is possible due to minimum nonce
-func (tl *TxList) FilterByState(st *types.State) (int, []*types.Tx) {
+// the balance is checked by the fee of the block of no
+func (tl *TxList) FilterByState(st *types.State, no types.BlockNo) (int, []*types.Tx) {
 	tl.Lock()
 	defer tl.Unlock()
 
@@ -137,7 +225,7 @@ func (tl *TxList) FilterByState(st *types.State) (int, []*types.Tx) {
 	var left []*types.Tx
 	removed := tl.list[:0]
 	for i, x := range tl.list {
-		err := x.ValidateWithSenderState(st)
+		err := x.ValidateWithSenderStateAndFee(st, chain.MaxTxFee(x.GetBody(), no))
 		if err == nil || err == types.ErrTxNonceToohigh {
 			if err != nil && !balCheck {
 				left = append(left, tl.list[i:]...)
@@ -190,6 +278,11 @@ func (tl *TxList) len() int {
 	return len(tl.list)
 }
 
+// orphans returns the number of transactions waiting for a lower nonce
+func (tl *TxList) orphans() int {
+	return len(tl.list) - tl.ready
+}
+
 /*
 
 func (tl *TxList) printList() {
@@ -73,6 +73,25 @@ type ImportAccountRsp struct {
 	Err     error
 }
 
+type CreateMnemonic struct {
+	Passphrase string
+}
+
+type CreateMnemonicRsp struct {
+	Mnemonic string
+	Account  *types.Account
+	Err      error
+}
+
+type ImportMnemonic struct {
+	Mnemonic   string
+	Passphrase string
+}
+
+type DeriveAccount struct {
+	Passphrase string
+}
+
 type ExportAccount struct {
 	Account *types.Account
 	Pass    string
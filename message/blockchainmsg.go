@@ -6,6 +6,8 @@
 package message
 
 import (
+	"math"
+
 	"github.com/aergoio/aergo/types"
 	"github.com/libp2p/go-libp2p-peer"
 )
@@ -56,20 +58,34 @@ type AddBlockRsp struct {
 	BlockHash []byte
 	Err       error
 }
+
+// LatestBlockNo is given as BlockNo of the state queries, GetState, GetABI and
+// GetQuery, to get the state at the latest block.
+const LatestBlockNo = types.BlockNo(math.MaxUint64)
+
+// GetState gets the state of an account at the block of BlockHash, or BlockNo
+// if no hash is given.
 type GetState struct {
-	Account []byte
+	Account   []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetStateRsp struct {
 	State *types.State
 	Err   error
 }
+// GetStateAndProof gets the state of Account with its proof. The variables of
+// the contract at StorageKeys, which are the keys of its storage trie, are
+// proven as well.
 type GetStateAndProof struct {
-	Account    []byte
-	Root       []byte
-	Compressed bool
+	Account     []byte
+	Root        []byte
+	StorageKeys [][]byte
+	Compressed  bool
 }
 type GetStateAndProofRsp struct {
 	StateProof *types.StateProof
+	VarProofs  []*types.ContractVarProof
 	Err        error
 }
 type GetTx struct {
@@ -81,6 +97,14 @@ type GetTxRsp struct {
 	Err   error
 }
 
+type GetTxProof struct {
+	TxHash []byte
+}
+type GetTxProofRsp struct {
+	Proof *types.TxProof
+	Err   error
+}
+
 type GetReceipt struct {
 	TxHash []byte
 }
@@ -89,8 +113,45 @@ type GetReceiptRsp struct {
 	Err     error
 }
 
+// GetReceipts returns all receipts of the block in the main chain.
+type GetReceipts struct {
+	BlockHash []byte
+}
+type GetReceiptsRsp struct {
+	Receipts types.Receipts
+	Err      error
+}
+
+// GetAccountTxs returns txs sent or received by the account, latest first.
+// it is available only if the account index is enabled. the txs are listed
+// from the cursor returned with the previous page.
+type GetAccountTxs struct {
+	Account []byte
+	Cursor  []byte
+	Size    uint32
+}
+
+// GetAccountTxsRsp has the txs of the blocks from IndexedFrom, where the
+// account index started.
+type GetAccountTxsRsp struct {
+	Txs         []*types.AccountTx
+	NextCursor  []byte
+	IndexedFrom types.BlockNo
+	Err         error
+}
+
+// NotifyBlockReceipts is sent to the RPC service after a block is connected
+// to the main chain. Removed is set if the block is rolled back by a reorg.
+type NotifyBlockReceipts struct {
+	Block    *types.Block
+	Receipts types.Receipts
+	Removed  bool
+}
+
 type GetABI struct {
-	Contract []byte
+	Contract  []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetABIRsp struct {
 	ABI *types.ABI
@@ -100,6 +161,8 @@ type GetABIRsp struct {
 type GetQuery struct {
 	Contract  []byte
 	Queryinfo []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetQueryRsp struct {
 	Result []byte
@@ -166,3 +229,87 @@ type GetAncestorRsp struct {
 	Ancestor *types.BlockInfo
 	Err      error
 }
+
+// GetLibBlock is sent from p2p to get the last irreversible block, whose
+// state is served to the syncers of other peers.
+type GetLibBlock struct{}
+
+type GetLibBlockRsp struct {
+	Block *types.Block
+	Err   error
+}
+
+// GetStateData is sent from p2p to get the trie nodes, the account states or
+// the contract codes stored at the hashes, for the state at root.
+type GetStateData struct {
+	Root   []byte
+	Hashes [][]byte
+}
+
+type GetStateDataRsp struct {
+	Data [][]byte
+	Err  error
+}
+
+// GetStorageChunk is sent from p2p to get the ordered leaves of the storage
+// trie at root from the key start.
+type GetStorageChunk struct {
+	Root  []byte
+	Start []byte
+	Size  int
+}
+
+type GetStorageChunkRsp struct {
+	Keys    [][]byte
+	Values  [][]byte
+	Proofs  []*types.ContractVarProof
+	HasNext bool
+	Err     error
+}
+
+// SyncState is sent from syncer to rebuild the state of Block with the data
+// fetched from a peer. Block is given only to start the sync. Data is fetched
+// for Hashes, and Keys and Values are a chunk of the storage requested with
+// the Proofs of them.
+type SyncState struct {
+	Block   *types.Block
+	Hashes  [][]byte
+	Data    [][]byte
+	Keys    [][]byte
+	Values  [][]byte
+	Proofs  []*types.ContractVarProof
+	HasNext bool
+}
+
+// SyncStateRsp tells what to fetch next: the data of Hashes, or the chunk of
+// the storage trie at StorageRoot from StorageStart. Done is true when the
+// state is rebuilt and the block is connected to the chain.
+type SyncStateRsp struct {
+	Hashes       [][]byte
+	StorageRoot  []byte
+	StorageStart []byte
+	Done         bool
+	Err          error
+}
+
+// AddEvidence stores the evidence of a BP signing two different blocks for
+// the same slot. It is sent without response by the consensus and p2p.
+type AddEvidence struct {
+	Evidence *types.DoubleSignEvidence
+}
+
+// GetEvidence requests all the evidence stored.
+type GetEvidence struct{}
+
+type GetEvidenceRsp struct {
+	Evidences []*types.DoubleSignEvidence
+	Err       error
+}
+
+// GetChainParams requests the chain parameters active for the next block.
+type GetChainParams struct{}
+
+type GetChainParamsRsp struct {
+	Params *types.ChainParamList
+	Err    error
+}
@@ -0,0 +1,26 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package message
+
+import "github.com/libp2p/go-libp2p-peer"
+
+// ConsensusSvc is the name of the consensus service receiving the consensus
+// messages of peers. It is registered only by the consensus implementations
+// exchanging messages, such as BFT and Raft.
+const ConsensusSvc = "ConsensusSvc"
+
+// ChangeMembership requests the consensus service to add or remove the member
+// of ID. Only the consensus with a dynamic membership, such as Raft, handles
+// it.
+type ChangeMembership struct {
+	Remove bool
+	ID     peer.ID
+}
+
+// ChangeMembershipRsp is the response of ChangeMembership.
+type ChangeMembershipRsp struct {
+	Err error
+}
@@ -0,0 +1,8 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package message
+
+const JSONRPCSvc = "JSONRPCSvc"
@@ -91,9 +91,13 @@ type GetBlockInfos struct {
 	Hashes []BlockHash
 }
 
+// GetBlockChunks is sent from Syncer, send types.GetBlockRequest to dest
+// peer. Only the headers are fetched with types.GetBlockHeadersRequest if
+// HeaderOnly is set, and the blocks in the response have no body.
 type GetBlockChunks struct {
 	GetBlockInfos
-	TTL time.Duration
+	TTL        time.Duration
+	HeaderOnly bool
 }
 
 // GetMissingBlocks send types.GetMissingRequest to dest peer.
@@ -167,3 +171,76 @@ type GetHashByNoRsp struct {
 	BlockHash   BlockHash
 	Err      error
 }
+
+// GetSyncLibBlock is sent from Syncer, send types.GetLibBlockRequest to dest peer.
+type GetSyncLibBlock struct {
+	ToWhom peer.ID
+}
+
+type GetSyncLibBlockRsp struct {
+	Block *types.Block
+	Err   error
+}
+
+// GetSyncTrieNodes is sent from Syncer, send types.GetTrieNodesRequest to dest peer.
+type GetSyncTrieNodes struct {
+	ToWhom peer.ID
+	Root   []byte
+	Hashes [][]byte
+}
+
+type GetSyncTrieNodesRsp struct {
+	Nodes [][]byte
+	Err   error
+}
+
+// GetSyncStorageChunk is sent from Syncer, send types.GetStorageChunkRequest to dest peer.
+type GetSyncStorageChunk struct {
+	ToWhom peer.ID
+	Root   []byte
+	Start  []byte
+	Size   uint32
+}
+
+type GetSyncStorageChunkRsp struct {
+	Keys    [][]byte
+	Values  [][]byte
+	Proofs  []*types.ContractVarProof
+	HasNext bool
+	Err     error
+}
+
+// GetStateProof is sent from ChainService of a light node, send
+// types.GetStateProofRequest to a peer keeping the state. The actor responds
+// with GetStateProofRsp when the peer responds.
+type GetStateProof struct {
+	Root        []byte
+	Account     []byte
+	StorageKeys [][]byte
+	Compressed  bool
+}
+
+type GetStateProofRsp struct {
+	StateProof *types.StateProof
+	VarProofs  []*types.ContractVarProof
+	Err        error
+}
+
+// NotifyBftProposal send types.BftProposal to other peers, which pass it to
+// their consensus service.
+type NotifyBftProposal struct {
+	Proposal *types.BftProposal
+}
+
+// NotifyBftVote send types.BftVote to other peers, which pass it to their
+// consensus service.
+type NotifyBftVote struct {
+	Vote *types.BftVote
+}
+
+// SendRaft send types.RaftMessage to the peer of ToWhom, which passes it to
+// its consensus service.
+type SendRaft struct {
+	ToWhom peer.ID
+	Body   *types.RaftMessage
+}
@@ -18,6 +18,13 @@ type FinderResult struct {
 	Err      error
 }
 
+// StateFetcherResult is sent when the state of Block is synced. Block is nil
+// if the state sync isn't available, then all the blocks are synced instead.
+type StateFetcherResult struct {
+	Block *types.Block
+	Err   error
+}
+
 //HashDownloader
 type StartFetch struct{}
 
@@ -83,6 +83,11 @@ func (p2ps *P2P) GetBlocksChunk(context actor.Context, msg *message.GetBlockChun
 		context.Respond(&message.GetBlockChunksRsp{ToWhom:peerID, Err:fmt.Errorf("invalid peer")})
 		return
 	}
+	if msg.HeaderOnly {
+		receiver := NewBlockHeadersReceiver(p2ps, remotePeer, blockHashes, msg.TTL)
+		receiver.StartGet()
+		return
+	}
 	receiver := NewBlockReceiver(p2ps, remotePeer, blockHashes, msg.TTL)
 	receiver.StartGet()
 }
@@ -116,6 +121,45 @@ func (p2ps *P2P) GetBlockHashByNo(context actor.Context, msg *message.GetHashByN
 	receiver.StartGet()
 }
 
+// GetSyncLibBlock send request message to peer and make response message for the LIB block
+func (p2ps *P2P) GetSyncLibBlock(context actor.Context, msg *message.GetSyncLibBlock) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetLibBlockRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetSyncLibBlockRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewLibBlockReceiver(p2ps, remotePeer, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// GetSyncTrieNodes send request message to peer and make response message for the state data
+func (p2ps *P2P) GetSyncTrieNodes(context actor.Context, msg *message.GetSyncTrieNodes) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetTrieNodesRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetSyncTrieNodesRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewTrieNodesReceiver(p2ps, remotePeer, msg.Root, msg.Hashes, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// GetSyncStorageChunk send request message to peer and make response message for the contract storage
+func (p2ps *P2P) GetSyncStorageChunk(context actor.Context, msg *message.GetSyncStorageChunk) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetStorageChunkRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetSyncStorageChunkRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewStorageChunkReceiver(p2ps, remotePeer, msg.Root, msg.Start, msg.Size, fetchTimeOut)
+	receiver.StartGet()
+}
+
 // NotifyNewBlock send notice message of new block to a peer
 func (p2ps *P2P) NotifyNewBlock(newBlock message.NotifyNewBlock) bool {
 	req := &types.NewBlockNotice{
@@ -137,6 +181,39 @@ func (p2ps *P2P) NotifyNewBlock(newBlock message.NotifyNewBlock) bool {
 	return true
 }
 
+// NotifyBftMessage send a proposal or a vote of the BFT consensus to all the
+// running peers. The validators are expected to be connected each other, since
+// the messages are not relayed.
+func (p2ps *P2P) NotifyBftMessage(protocolID SubProtocol, msg pbMessage) bool {
+	mo := p2ps.mf.newMsgRequestOrder(false, protocolID, msg)
+
+	skipped, sent := 0, 0
+	for _, neighbor := range p2ps.pm.GetPeers() {
+		if neighbor != nil && neighbor.State() == types.RUNNING {
+			sent++
+			neighbor.sendMessage(mo)
+		} else {
+			skipped++
+		}
+	}
+	p2ps.Debug().Int("skipped_cnt", skipped).Int("sent_cnt", sent).Str(LogProtoID, protocolID.String()).Msg("Notifying bft message")
+	return true
+}
+
+// SendRaft send a message of the Raft consensus to the peer of peerID. The
+// message is dropped if the peer is not connected, which Raft recovers from by
+// retransmission.
+func (p2ps *P2P) SendRaft(peerID peer.ID, msg *types.RaftMessage) bool {
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Debug().Str(LogPeerID, peerID.Pretty()).Msg("drop raft message to unconnected peer")
+		return false
+	}
+
+	remotePeer.sendMessage(p2ps.mf.newMsgRequestOrder(false, RaftMessage, msg))
+	return true
+}
+
 // GetMissingBlocks send request message to peer about blocks which my local peer doesn't have
 func (p2ps *P2P) GetMissingBlocks(peerID peer.ID, hashes []message.BlockHash) bool {
 	remotePeer, exists := p2ps.pm.GetPeer(peerID)
@@ -228,3 +305,31 @@ func (p2ps *P2P) GetSyncAncestor(peerID peer.ID, hashes [][]byte) bool {
 	remotePeer.sendMessage(p2ps.mf.newMsgRequestOrder(true, GetAncestorRequest, req))
 	return true
 }
+
+// GetStateProof send request message to a peer keeping the state and make response message for the state proof
+func (p2ps *P2P) GetStateProof(context actor.Context, msg *message.GetStateProof) {
+	remotePeer := p2ps.selectStatePeer()
+	if remotePeer == nil {
+		p2ps.Warn().Str(LogProtoID, GetStateProofRequest.String()).Msg("No peer to get the state proof")
+		context.Respond(&message.GetStateProofRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewStateProofReceiver(p2ps, remotePeer, context.Sender(), msg.Root, msg.Account, msg.StorageKeys, msg.Compressed, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// selectStatePeer returns the running peer which noticed the highest block.
+// A light node, which keeps no state, doesn't notice new blocks.
+func (p2ps *P2P) selectStatePeer() RemotePeer {
+	var selected RemotePeer
+	for _, remotePeer := range p2ps.pm.GetPeers() {
+		notice := remotePeer.LastNotice()
+		if remotePeer.State() != types.RUNNING || notice == nil {
+			continue
+		}
+		if selected == nil || notice.BlockNo > selected.LastNotice().BlockNo {
+			selected = remotePeer
+		}
+	}
+	return selected
+}
@@ -0,0 +1,79 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// BlockHeadersReceiver sends p2p getBlockHeadersRequest to target peer and receives the headers of the requested blocks.
+// The headers are sent to syncer as blocks without body, in the same response as BlocksChunkReceiver.
+// It will not send response if timeout expired.
+type BlockHeadersReceiver struct {
+	requestID MsgID
+
+	peer  RemotePeer
+	actor ActorService
+
+	blockHashes []message.BlockHash
+	timeout     time.Time
+	finished    bool
+}
+
+func NewBlockHeadersReceiver(actor ActorService, peer RemotePeer, blockHashes []message.BlockHash, ttl time.Duration) *BlockHeadersReceiver {
+	timeout := time.Now().Add(ttl)
+	return &BlockHeadersReceiver{actor: actor, peer: peer, blockHashes: blockHashes, timeout: timeout}
+}
+
+func (br *BlockHeadersReceiver) StartGet() {
+	// the headers are listed from the last block to the ancestors
+	req := &types.GetBlockHeadersRequest{Hash: br.blockHashes[len(br.blockHashes)-1], Size: uint32(len(br.blockHashes))}
+	mo := br.peer.MF().newMsgBlockRequestOrder(br.ReceiveResp, GetBlockHeadersRequest, req)
+	br.peer.sendMessage(mo)
+	br.requestID = mo.GetMsgID()
+}
+
+// ReceiveResp must be called just in read go routine
+func (br *BlockHeadersReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	// timeout
+	if br.finished || br.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		br.finished = true
+		br.peer.consumeRequest(br.requestID)
+		return
+	}
+	br.actor.TellRequest(message.SyncerSvc, br.toRsp(msgBody.(*types.GetBlockHeadersResponse)))
+	br.finished = true
+	br.peer.consumeRequest(br.requestID)
+	return
+}
+
+func (br *BlockHeadersReceiver) toRsp(body *types.GetBlockHeadersResponse) *message.GetBlockChunksRsp {
+	// remote peer response failure
+	if body.Status != types.ResultStatus_OK || len(body.Hashes) != len(body.Headers) {
+		return &message.GetBlockChunksRsp{ToWhom: br.peer.ID(), Err: message.RemotePeerFailError}
+	}
+	if len(body.Headers) < len(br.blockHashes) {
+		return &message.GetBlockChunksRsp{ToWhom: br.peer.ID(), Err: message.MissingHashError}
+	}
+	count := len(br.blockHashes)
+	blocks := make([]*types.Block, count)
+	for i := 0; i < count; i++ {
+		hash := body.Hashes[count-1-i]
+		// unexpected block
+		if !bytes.Equal(br.blockHashes[i], hash) {
+			return &message.GetBlockChunksRsp{ToWhom: br.peer.ID(), Err: message.UnexpectedBlockError}
+		}
+		blocks[i] = &types.Block{Hash: hash, Header: body.Headers[count-1-i]}
+	}
+	return &message.GetBlockChunksRsp{ToWhom: br.peer.ID(), Blocks: blocks}
+}
@@ -0,0 +1,91 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBlockHeadersReceiver_ReceiveResp(t *testing.T) {
+	inputHashes := make([]message.BlockHash, len(sampleBlks))
+	for i, hash := range sampleBlks {
+		inputHashes[i] = hash
+	}
+	// the headers are responded in descending order
+	descHashes := make([][]byte, len(sampleBlks))
+	headers := make([]*types.BlockHeader, len(sampleBlks))
+	for i := range sampleBlks {
+		descHashes[i] = sampleBlks[len(sampleBlks)-1-i]
+		headers[i] = &types.BlockHeader{BlockNo: uint64(100 + len(sampleBlks) - 1 - i)}
+	}
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		rspInterval time.Duration
+		hashes      [][]byte
+		headers     []*types.BlockHeader
+		rspStatus   types.ResultStatus
+
+		// to verify
+		sentResp  int
+		respError bool
+	}{
+		{"TSingleResp", time.Minute, 0, descHashes, headers, types.ResultStatus_OK, 1, false},
+		// Fail1 remote err
+		{"TRemoteFail", time.Minute, 0, nil, nil, types.ResultStatus_INTERNAL, 1, true},
+		// Fail2 fewer headers
+		{"TMissing", time.Minute, 0, descHashes[1:], headers[1:], types.ResultStatus_OK, 1, true},
+		// Fail3 unexpected order
+		{"TUnexpected", time.Minute, 0, sampleBlks, headers, types.ResultStatus_OK, 1, true},
+		// Fail4 response sent after timeout
+		{"TTimeout", time.Millisecond * 10, time.Millisecond * 20, descHashes, headers, types.ResultStatus_OK, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActor := new(MockActorService)
+			mockActor.On("TellRequest", message.SyncerSvc, mock.AnythingOfType("*message.GetBlockChunksRsp"))
+			mockMF := new(MockMoFactory)
+			mockPeer := new(MockRemotePeer)
+			mockPeer.On("ID").Return(dummyPeerID)
+			mockPeer.On("MF").Return(mockMF)
+			mockPeer.On("sendMessage", mock.Anything)
+			mockPeer.On("consumeRequest", mock.AnythingOfType("p2p.MsgID"))
+			mockMF.On("newMsgBlockRequestOrder", mock.Anything, GetBlockHeadersRequest, mock.AnythingOfType("*types.GetBlockHeadersRequest")).Return(dummyMo)
+
+			br := NewBlockHeadersReceiver(mockActor, mockPeer, inputHashes, test.ttl)
+			br.StartGet()
+			mockPeer.AssertCalled(t, "sendMessage", dummyMo)
+
+			msg := &V030Message{subProtocol: GetBlockHeadersResponse, id: sampleMsgID}
+			body := &types.GetBlockHeadersResponse{Hashes: test.hashes, Headers: test.headers, Status: test.rspStatus}
+			if test.rspInterval > 0 {
+				time.Sleep(test.rspInterval)
+			}
+			br.ReceiveResp(msg, body)
+
+			mockPeer.AssertNumberOfCalls(t, "consumeRequest", 1)
+			mockActor.AssertNumberOfCalls(t, "TellRequest", test.sentResp)
+			if test.sentResp > 0 {
+				mockActor.AssertCalled(t, "TellRequest", message.SyncerSvc, mock.MatchedBy(func(arg *message.GetBlockChunksRsp) bool {
+					if test.respError {
+						return arg.Err != nil && len(arg.Blocks) == 0
+					}
+					for i, block := range arg.Blocks {
+						if block.GetBody() != nil || block.BlockNo() != uint64(100+i) {
+							return false
+						}
+					}
+					return arg.Err == nil && len(arg.Blocks) == len(inputHashes)
+				}))
+			}
+		})
+	}
+}
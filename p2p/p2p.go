@@ -14,6 +14,7 @@ import (
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
@@ -40,6 +41,11 @@ type P2P struct {
 	mf     moFactory
 	signer msgSigner
 	ca     types.ChainAccessor
+
+	// bft is whether the BFT consensus messages of peers are passed to the consensus service
+	bft bool
+	// raft is whether the Raft consensus messages of peers are passed to the consensus service
+	raft bool
 }
 
 type HandlerFactory interface {
@@ -144,6 +150,8 @@ func (p2ps *P2P) Statistics() *map[string]interface{} {
 
 func (p2ps *P2P) init(cfg *config.Config, chainsvc *chain.ChainService) {
 	p2ps.ca = chainsvc
+	p2ps.bft = cfg.Consensus.EnableBft
+	p2ps.raft = cfg.Consensus.EnableRaft
 
 	signer := newDefaultMsgSigner(ni.privKey, ni.pubKey, ni.id)
 	mf := &pbMOFactory{signer: signer}
@@ -151,6 +159,10 @@ func (p2ps *P2P) init(cfg *config.Config, chainsvc *chain.ChainService) {
 	metricMan := metric.NewMetricManager(10)
 	peerMan := NewPeerManager(p2ps, p2ps, cfg, signer, reconMan, metricMan, p2ps.Logger, mf)
 	syncMan := newSyncManager(p2ps, peerMan, p2ps.Logger)
+	if cfg.Consensus.EnableDpos {
+		// check the blocks received for the double signing of the BPs
+		syncMan.(*syncManager).detector = evidence.NewDetector(DefaultGlobalBlockCacheSize)
+	}
 
 	// connect managers each other
 	reconMan.pm = peerMan
@@ -197,6 +209,20 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		context.Respond(&message.GetPeersRsp{Peers: peers, LastBlks: lastBlks, States: states})
 	case *message.GetSyncAncestor:
 		p2ps.GetSyncAncestor(msg.ToWhom, msg.Hashes)
+	case *message.GetSyncLibBlock:
+		p2ps.GetSyncLibBlock(context, msg)
+	case *message.GetSyncTrieNodes:
+		p2ps.GetSyncTrieNodes(context, msg)
+	case *message.GetSyncStorageChunk:
+		p2ps.GetSyncStorageChunk(context, msg)
+	case *message.GetStateProof:
+		p2ps.GetStateProof(context, msg)
+	case *message.NotifyBftProposal:
+		p2ps.NotifyBftMessage(BftProposalNotice, msg.Proposal)
+	case *message.NotifyBftVote:
+		p2ps.NotifyBftMessage(BftVoteNotice, msg.Vote)
+	case *message.SendRaft:
+		p2ps.SendRaft(msg.ToWhom, msg.Body)
 	}
 }
 
@@ -261,6 +287,23 @@ func (p2ps *P2P) insertHandlers(peer *remotePeerImpl) {
 	peer.handlers[GetHashByNoRequest] = newGetHashByNoReqHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetHashByNoResponse] = newGetHashByNoRespHandler(p2ps.pm, peer, logger, p2ps)
 
+	// StateHandlers
+	peer.handlers[GetLibBlockRequest] = newGetLibBlockReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetLibBlockResponse] = newGetLibBlockRespHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetTrieNodesRequest] = newGetTrieNodesReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetTrieNodesResponse] = newGetTrieNodesRespHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetStorageChunkRequest] = newGetStorageChunkReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetStorageChunkResponse] = newGetStorageChunkRespHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetStateProofRequest] = newGetStateProofReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetStateProofResponse] = newGetStateProofRespHandler(p2ps.pm, peer, logger, p2ps)
+
+	// BftHandlers
+	peer.handlers[BftProposalNotice] = newBftProposalNoticeHandler(p2ps.pm, peer, logger, p2ps, p2ps.bft)
+	peer.handlers[BftVoteNotice] = newBftVoteNoticeHandler(p2ps.pm, peer, logger, p2ps, p2ps.bft)
+
+	// RaftHandlers
+	peer.handlers[RaftMessage] = newRaftMessageHandler(p2ps.pm, peer, logger, p2ps, p2ps.raft)
+
 	// TxHandlers
 	peer.handlers[GetTXsRequest] = newTxReqHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetTxsResponse] = newTxRespHandler(p2ps.pm, peer, logger, p2ps)
@@ -28,6 +28,10 @@ const (
 	MaxBlockResponseCount       = 2000
 	MaxResponseSplitCount = 5
 
+	MaxTrieNodeResponseCount     = 1000
+	MaxStorageChunkResponseCount = 10000
+	MaxStorageProofRequestCount  = 100
+
 	SyncWorkTTL = time.Second * 30
 	AddBlockCheckpoint = 100
 	AddBlockWaitTime = time.Second * 10
@@ -67,6 +71,23 @@ const (
 	GetTxsResponse
 	NewTxNotice
 )
+const (
+	GetLibBlockRequest SubProtocol = 0x030 + iota
+	GetLibBlockResponse
+	GetTrieNodesRequest
+	GetTrieNodesResponse
+	GetStorageChunkRequest
+	GetStorageChunkResponse
+	GetStateProofRequest
+	GetStateProofResponse
+)
+const (
+	BftProposalNotice SubProtocol = 0x040 + iota
+	BftVoteNotice
+)
+const (
+	RaftMessage SubProtocol = 0x050 + iota
+)
 
 //go:generate stringer -type=SubProtocol
 
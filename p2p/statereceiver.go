@@ -0,0 +1,132 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// StateReceiver sends a request of the state to the target peer and tells
+// the syncer, or respondTo if it is set, the response made by toRsp, or
+// failRsp if the peer failed. It will not send response if timeout expired.
+type StateReceiver struct {
+	requestID MsgID
+
+	peer      RemotePeer
+	actor     ActorService
+	respondTo *actor.PID
+
+	protocol SubProtocol
+	req      proto.Message
+	toRsp    func(body proto.Message) interface{}
+	failRsp  interface{}
+	timeout  time.Time
+	finished bool
+}
+
+// NewLibBlockReceiver creates a receiver of the LIB block of the peer.
+func NewLibBlockReceiver(actor ActorService, peer RemotePeer, ttl time.Duration) *StateReceiver {
+	return &StateReceiver{actor: actor, peer: peer, timeout: time.Now().Add(ttl),
+		protocol: GetLibBlockRequest, req: &types.GetLibBlockRequest{},
+		toRsp: func(body proto.Message) interface{} {
+			resp := body.(*types.GetLibBlockResponse)
+			if resp.Status != types.ResultStatus_OK || resp.Block == nil {
+				return nil
+			}
+			return &message.GetSyncLibBlockRsp{Block: resp.Block}
+		},
+		failRsp: &message.GetSyncLibBlockRsp{Err: message.RemotePeerFailError},
+	}
+}
+
+// NewTrieNodesReceiver creates a receiver of the trie nodes, the account
+// states or the contract codes of hashes.
+func NewTrieNodesReceiver(actor ActorService, peer RemotePeer, root []byte, hashes [][]byte, ttl time.Duration) *StateReceiver {
+	return &StateReceiver{actor: actor, peer: peer, timeout: time.Now().Add(ttl),
+		protocol: GetTrieNodesRequest, req: &types.GetTrieNodesRequest{Root: root, Hashes: hashes},
+		toRsp: func(body proto.Message) interface{} {
+			resp := body.(*types.GetTrieNodesResponse)
+			if resp.Status != types.ResultStatus_OK {
+				return nil
+			}
+			return &message.GetSyncTrieNodesRsp{Nodes: resp.Nodes}
+		},
+		failRsp: &message.GetSyncTrieNodesRsp{Err: message.RemotePeerFailError},
+	}
+}
+
+// NewStorageChunkReceiver creates a receiver of the leaves of the storage
+// trie at root from start.
+func NewStorageChunkReceiver(actor ActorService, peer RemotePeer, root, start []byte, size uint32, ttl time.Duration) *StateReceiver {
+	return &StateReceiver{actor: actor, peer: peer, timeout: time.Now().Add(ttl),
+		protocol: GetStorageChunkRequest, req: &types.GetStorageChunkRequest{Root: root, Start: start, Size: size},
+		toRsp: func(body proto.Message) interface{} {
+			resp := body.(*types.GetStorageChunkResponse)
+			if resp.Status != types.ResultStatus_OK {
+				return nil
+			}
+			return &message.GetSyncStorageChunkRsp{Keys: resp.Keys, Values: resp.Values, Proofs: resp.Proofs, HasNext: resp.HasNext}
+		},
+		failRsp: &message.GetSyncStorageChunkRsp{Err: message.RemotePeerFailError},
+	}
+}
+
+// NewStateProofReceiver creates a receiver of the proof of the account state
+// at root and the proofs of its storage at storageKeys, which are responded to
+// the actor of respondTo.
+func NewStateProofReceiver(actor ActorService, peer RemotePeer, respondTo *actor.PID, root, account []byte, storageKeys [][]byte, compressed bool, ttl time.Duration) *StateReceiver {
+	return &StateReceiver{actor: actor, peer: peer, respondTo: respondTo, timeout: time.Now().Add(ttl),
+		protocol: GetStateProofRequest, req: &types.GetStateProofRequest{Root: root, Account: account, StorageKeys: storageKeys, Compressed: compressed},
+		toRsp: func(body proto.Message) interface{} {
+			resp := body.(*types.GetStateProofResponse)
+			if resp.Status != types.ResultStatus_OK || resp.Proof == nil {
+				return nil
+			}
+			return &message.GetStateProofRsp{StateProof: resp.Proof, VarProofs: resp.VarProofs}
+		},
+		failRsp: &message.GetStateProofRsp{Err: message.RemotePeerFailError},
+	}
+}
+
+func (sr *StateReceiver) StartGet() {
+	mo := sr.peer.MF().newMsgBlockRequestOrder(sr.ReceiveResp, sr.protocol, sr.req)
+	sr.requestID = mo.GetMsgID()
+	sr.peer.sendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (sr *StateReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	// timeout
+	if sr.finished || sr.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		sr.finished = true
+		sr.peer.consumeRequest(sr.requestID)
+		return
+	}
+	// remote peer response failure
+	if rsp := sr.toRsp(msgBody); rsp != nil {
+		sr.tell(rsp)
+	} else {
+		sr.tell(sr.failRsp)
+	}
+	sr.finished = true
+	sr.peer.consumeRequest(sr.requestID)
+	return
+}
+
+func (sr *StateReceiver) tell(rsp interface{}) {
+	if sr.respondTo != nil {
+		sr.respondTo.Tell(rsp)
+		return
+	}
+	sr.actor.TellRequest(message.SyncerSvc, rsp)
+}
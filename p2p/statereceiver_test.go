@@ -0,0 +1,72 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrieNodesReceiver_ReceiveResp(t *testing.T) {
+	root := dummyBlockHash
+	hashes := [][]byte{dummyBlockHash}
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		rspInterval time.Duration
+		nodes       [][]byte
+		rspStatus   types.ResultStatus
+
+		// to verify
+		consumed  int
+		sentResp  int
+		respError bool
+	}{
+		{"TSingleResp", time.Minute, 0, [][]byte{[]byte("node")}, types.ResultStatus_OK, 1, 1, false},
+		// Fail1 remote err
+		{"TRemoteFail", time.Minute, 0, nil, types.ResultStatus_INTERNAL, 1, 1, true},
+		// Fail2 unknown root
+		{"TMissingRoot", time.Minute, 0, nil, types.ResultStatus_NOT_FOUND, 1, 1, true},
+		// Fail3 response sent after timeout
+		{"TTimeout", time.Millisecond * 10, time.Millisecond * 20, [][]byte{[]byte("node")}, types.ResultStatus_OK, 1, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActor := new(MockActorService)
+			mockActor.On("TellRequest", message.SyncerSvc, mock.AnythingOfType("*message.GetSyncTrieNodesRsp"))
+			mockMF := new(MockMoFactory)
+			mockPeer := new(MockRemotePeer)
+			mockPeer.On("ID").Return(dummyPeerID)
+			mockPeer.On("MF").Return(mockMF)
+			mockPeer.On("sendMessage", mock.Anything)
+			mockPeer.On("consumeRequest", mock.AnythingOfType("p2p.MsgID"))
+			mockMF.On("newMsgBlockRequestOrder", mock.Anything, GetTrieNodesRequest, mock.AnythingOfType("*types.GetTrieNodesRequest")).Return(dummyMo)
+
+			sr := NewTrieNodesReceiver(mockActor, mockPeer, root, hashes, test.ttl)
+			sr.StartGet()
+			mockPeer.AssertCalled(t, "sendMessage", dummyMo)
+
+			msg := &V030Message{subProtocol: GetTrieNodesResponse, id: sampleMsgID}
+			body := &types.GetTrieNodesResponse{Nodes: test.nodes, Status: test.rspStatus}
+			if test.rspInterval > 0 {
+				time.Sleep(test.rspInterval)
+			}
+			sr.ReceiveResp(msg, body)
+
+			mockPeer.AssertNumberOfCalls(t, "consumeRequest", test.consumed)
+			mockActor.AssertNumberOfCalls(t, "TellRequest", test.sentResp)
+			if test.sentResp > 0 {
+				mockActor.AssertCalled(t, "TellRequest", message.SyncerSvc, mock.MatchedBy(func(arg *message.GetSyncTrieNodesRsp) bool {
+					return (arg.Err != nil) == test.respError && len(arg.Nodes) == len(test.nodes)
+				}))
+			}
+		})
+	}
+}
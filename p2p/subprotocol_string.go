@@ -8,12 +8,17 @@ const (
 	_SubProtocol_name_0 = "StatusRequestPingRequestPingResponseGoAwayAddressesRequestAddressesResponse"
 	_SubProtocol_name_1 = "GetBlocksRequestGetBlocksResponseGetBlockHeadersRequestGetBlockHeadersResponseGetMissingRequestGetMissingResponseNewBlockNoticeGetAncestorRequestGetAncestorResponseGetHashesRequestGetHashesResponseGetHashByNoRequestGetHashByNoResponse"
 	_SubProtocol_name_2 = "GetTXsRequestGetTxsResponseNewTxNotice"
+	_SubProtocol_name_3 = "GetLibBlockRequestGetLibBlockResponseGetTrieNodesRequestGetTrieNodesResponseGetStorageChunkRequestGetStorageChunkResponseGetStateProofRequestGetStateProofResponse"
+	_SubProtocol_name_4 = "BftProposalNoticeBftVoteNotice"
+	_SubProtocol_name_5 = "RaftMessage"
 )
 
 var (
 	_SubProtocol_index_0 = [...]uint8{0, 13, 24, 36, 42, 58, 75}
 	_SubProtocol_index_1 = [...]uint8{0, 16, 33, 55, 78, 95, 113, 127, 145, 164, 180, 197, 215, 234}
 	_SubProtocol_index_2 = [...]uint8{0, 13, 27, 38}
+	_SubProtocol_index_3 = [...]uint8{0, 18, 37, 56, 76, 98, 121, 141, 162}
+	_SubProtocol_index_4 = [...]uint8{0, 17, 30}
 )
 
 func (i SubProtocol) String() string {
@@ -27,6 +32,14 @@ func (i SubProtocol) String() string {
 	case 32 <= i && i <= 34:
 		i -= 32
 		return _SubProtocol_name_2[_SubProtocol_index_2[i]:_SubProtocol_index_2[i+1]]
+	case 48 <= i && i <= 55:
+		i -= 48
+		return _SubProtocol_name_3[_SubProtocol_index_3[i]:_SubProtocol_index_3[i+1]]
+	case 64 <= i && i <= 65:
+		i -= 64
+		return _SubProtocol_name_4[_SubProtocol_index_4[i]:_SubProtocol_index_4[i+1]]
+	case i == 80:
+		return _SubProtocol_name_5
 	default:
 		return "SubProtocol(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,68 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+type bftProposalNoticeHandler struct {
+	BaseMsgHandler
+	enabled bool
+}
+
+var _ MessageHandler = (*bftProposalNoticeHandler)(nil)
+
+type bftVoteNoticeHandler struct {
+	BaseMsgHandler
+	enabled bool
+}
+
+var _ MessageHandler = (*bftVoteNoticeHandler)(nil)
+
+// newBftProposalNoticeHandler creates handler for BftProposalNotice. The
+// proposals are passed to the consensus service only if enabled, i.e. the BFT
+// consensus is used.
+func newBftProposalNoticeHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService, enabled bool) *bftProposalNoticeHandler {
+	bh := &bftProposalNoticeHandler{BaseMsgHandler: BaseMsgHandler{protocol: BftProposalNotice, pm: pm, peer: peer, actor: actor, logger: logger}, enabled: enabled}
+	return bh
+}
+
+func (bh *bftProposalNoticeHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.BftProposal{})
+}
+
+func (bh *bftProposalNoticeHandler) handle(msg Message, msgBody proto.Message) {
+	data := msgBody.(*types.BftProposal)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), bh.peer.ID(), data.GetRound())
+
+	if bh.enabled && data.GetBlock().GetHeader() != nil {
+		bh.actor.TellRequest(message.ConsensusSvc, data)
+	}
+}
+
+// newBftVoteNoticeHandler creates handler for BftVoteNotice. The votes are
+// passed to the consensus service only if enabled.
+func newBftVoteNoticeHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService, enabled bool) *bftVoteNoticeHandler {
+	bh := &bftVoteNoticeHandler{BaseMsgHandler: BaseMsgHandler{protocol: BftVoteNotice, pm: pm, peer: peer, actor: actor, logger: logger}, enabled: enabled}
+	return bh
+}
+
+func (bh *bftVoteNoticeHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.BftVote{})
+}
+
+func (bh *bftVoteNoticeHandler) handle(msg Message, msgBody proto.Message) {
+	data := msgBody.(*types.BftVote)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), bh.peer.ID(), data.GetHeight())
+
+	if bh.enabled {
+		bh.actor.TellRequest(message.ConsensusSvc, data)
+	}
+}
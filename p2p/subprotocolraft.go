@@ -0,0 +1,55 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/golang/protobuf/proto"
+)
+
+type raftMessageHandler struct {
+	BaseMsgHandler
+	enabled bool
+}
+
+var _ MessageHandler = (*raftMessageHandler)(nil)
+
+// newRaftMessageHandler creates handler for RaftMessage. The messages are
+// passed to the consensus service only if enabled, i.e. the Raft consensus is
+// used, and only if they are sent by the Raft member of the remote peer.
+func newRaftMessageHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService, enabled bool) *raftMessageHandler {
+	rh := &raftMessageHandler{BaseMsgHandler: BaseMsgHandler{protocol: RaftMessage, pm: pm, peer: peer, actor: actor, logger: logger}, enabled: enabled}
+	return rh
+}
+
+func (rh *raftMessageHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.RaftMessage{})
+}
+
+func (rh *raftMessageHandler) handle(msg Message, msgBody proto.Message) {
+	data := msgBody.(*types.RaftMessage)
+	debugLogReceiveMsg(rh.logger, rh.protocol, msg.ID().String(), rh.peer.ID(), len(data.GetData()))
+
+	if !rh.enabled {
+		return
+	}
+
+	var m raftpb.Message
+	if err := m.Unmarshal(data.GetData()); err != nil {
+		rh.logger.Debug().Err(err).Str(LogPeerID, rh.peer.ID().Pretty()).Msg("invalid raft message")
+		return
+	}
+	if m.From != types.RaftID(rh.peer.ID()) {
+		rh.logger.Info().Str(LogPeerID, rh.peer.ID().Pretty()).Uint64("from", m.From).
+			Msg("drop raft message from another member")
+		return
+	}
+
+	rh.actor.TellRequest(message.ConsensusSvc, data)
+}
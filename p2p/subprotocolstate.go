@@ -0,0 +1,268 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+type getLibBlockRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getLibBlockResponseHandler struct {
+	BaseMsgHandler
+}
+
+type getTrieNodesRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getTrieNodesResponseHandler struct {
+	BaseMsgHandler
+}
+
+type getStorageChunkRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getStorageChunkResponseHandler struct {
+	BaseMsgHandler
+}
+
+type getStateProofRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getStateProofResponseHandler struct {
+	BaseMsgHandler
+}
+
+// newGetLibBlockReqHandler creates handler for GetLibBlockRequest
+func newGetLibBlockReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getLibBlockRequestHandler {
+	bh := &getLibBlockRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetLibBlockRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getLibBlockRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetLibBlockRequest{})
+}
+
+func (bh *getLibBlockRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetLibBlockRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, data)
+
+	resp := &types.GetLibBlockResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc, &message.GetLibBlock{})
+	if err != nil {
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if result := rawResponse.(message.GetLibBlockRsp); result.Err != nil {
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Block = result.Block
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetLibBlockResponse, resp))
+}
+
+// newGetLibBlockRespHandler creates handler for GetLibBlockResponse
+func newGetLibBlockRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getLibBlockResponseHandler {
+	bh := &getLibBlockResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetLibBlockResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getLibBlockResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetLibBlockResponse{})
+}
+
+func (bh *getLibBlockResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetLibBlockResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s", data.Status))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
+
+// newGetTrieNodesReqHandler creates handler for GetTrieNodesRequest
+func newGetTrieNodesReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getTrieNodesRequestHandler {
+	bh := &getTrieNodesRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetTrieNodesRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getTrieNodesRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetTrieNodesRequest{})
+}
+
+func (bh *getTrieNodesRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetTrieNodesRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, fmt.Sprintf("node_cnt=%d", len(data.Hashes)))
+
+	// check if requested too many nodes
+	if len(data.Hashes) > MaxTrieNodeResponseCount {
+		resp := &types.GetTrieNodesResponse{Status: types.ResultStatus_INVALID_ARGUMENT}
+		remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetTrieNodesResponse, resp))
+		return
+	}
+	resp := &types.GetTrieNodesResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc,
+		&message.GetStateData{Root: data.Root, Hashes: data.Hashes})
+	if err != nil {
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if result := rawResponse.(message.GetStateDataRsp); result.Err != nil {
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Nodes = result.Data
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetTrieNodesResponse, resp))
+}
+
+// newGetTrieNodesRespHandler creates handler for GetTrieNodesResponse
+func newGetTrieNodesRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getTrieNodesResponseHandler {
+	bh := &getTrieNodesResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetTrieNodesResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getTrieNodesResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetTrieNodesResponse{})
+}
+
+func (bh *getTrieNodesResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetTrieNodesResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s,node_cnt=%d", data.Status, len(data.Nodes)))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
+
+// newGetStorageChunkReqHandler creates handler for GetStorageChunkRequest
+func newGetStorageChunkReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStorageChunkRequestHandler {
+	bh := &getStorageChunkRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStorageChunkRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getStorageChunkRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStorageChunkRequest{})
+}
+
+func (bh *getStorageChunkRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStorageChunkRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, data)
+
+	// check if requested too many leaves
+	if data.Size == 0 || data.Size > MaxStorageChunkResponseCount {
+		resp := &types.GetStorageChunkResponse{Status: types.ResultStatus_INVALID_ARGUMENT}
+		remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetStorageChunkResponse, resp))
+		return
+	}
+	resp := &types.GetStorageChunkResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc,
+		&message.GetStorageChunk{Root: data.Root, Start: data.Start, Size: int(data.Size)})
+	if err != nil {
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if result := rawResponse.(message.GetStorageChunkRsp); result.Err != nil {
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Keys, resp.Values, resp.Proofs, resp.HasNext = result.Keys, result.Values, result.Proofs, result.HasNext
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetStorageChunkResponse, resp))
+}
+
+// newGetStorageChunkRespHandler creates handler for GetStorageChunkResponse
+func newGetStorageChunkRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStorageChunkResponseHandler {
+	bh := &getStorageChunkResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStorageChunkResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getStorageChunkResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStorageChunkResponse{})
+}
+
+func (bh *getStorageChunkResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStorageChunkResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s,leaf_cnt=%d,hasNext=%t", data.Status, len(data.Keys), data.HasNext))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
+
+// newGetStateProofReqHandler creates handler for GetStateProofRequest
+func newGetStateProofReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStateProofRequestHandler {
+	bh := &getStateProofRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStateProofRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getStateProofRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStateProofRequest{})
+}
+
+func (bh *getStateProofRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStateProofRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, data)
+
+	// check if requested too many variables
+	if len(data.StorageKeys) > MaxStorageProofRequestCount {
+		resp := &types.GetStateProofResponse{Status: types.ResultStatus_INVALID_ARGUMENT}
+		remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetStateProofResponse, resp))
+		return
+	}
+	resp := &types.GetStateProofResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc,
+		&message.GetStateAndProof{Account: data.Account, Root: data.Root, StorageKeys: data.StorageKeys, Compressed: data.Compressed})
+	if err != nil {
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if result := rawResponse.(message.GetStateAndProofRsp); result.Err != nil {
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Proof, resp.VarProofs = result.StateProof, result.VarProofs
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetStateProofResponse, resp))
+}
+
+// newGetStateProofRespHandler creates handler for GetStateProofResponse
+func newGetStateProofRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStateProofResponseHandler {
+	bh := &getStateProofResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStateProofResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+
+	return bh
+}
+
+func (bh *getStateProofResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStateProofResponse{})
+}
+
+func (bh *getStateProofResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStateProofResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s", data.Status))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
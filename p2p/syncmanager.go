@@ -9,6 +9,8 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/consensus/impl/dpos/evidence"
+	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/types"
@@ -37,6 +39,9 @@ type syncManager struct {
 	syncLock *sync.Mutex
 	syncing  bool
 	sw       *syncWorker
+
+	// detector is nil unless the consensus is DPoS.
+	detector *evidence.Detector
 }
 
 func newSyncManager(actor ActorService, pm PeerManager, logger *log.Logger) SyncManager {
@@ -111,6 +116,7 @@ func (sm *syncManager) HandleNewBlockNotice(peer RemotePeer, hashArr BlkHash, da
 func (sm *syncManager) HandleGetBlockResponse(peer RemotePeer, msg Message, resp *types.GetBlockResponse) {
 	blocks := resp.Blocks
 	peerID := peer.ID()
+	sm.checkDoubleSign(peerID, blocks)
 	worker, found := sm.getWorker(peerID)
 	if found {
 		worker.putAddBlock(msg, blocks, resp.HasNext)
@@ -141,6 +147,22 @@ func (sm *syncManager) HandleGetBlockResponse(peer RemotePeer, msg Message, resp
 	}
 }
 
+// checkDoubleSign sends the evidence to chainservice if any BP of blocks has
+// signed another block for the same slot. The slots are those of the local BP
+// loop, and chainservice verifies the evidence by the chain state.
+func (sm *syncManager) checkDoubleSign(peerID peer.ID, blocks []*types.Block) {
+	if sm.detector == nil {
+		return
+	}
+	for _, block := range blocks {
+		if ev := sm.detector.Check(block, slot.BlockIntervalSec()); ev != nil {
+			sm.logger.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogBlkHash, enc.ToString(block.Hash)).
+				Str("bp", block.BPID2Str()).Msg("Got block signed by BP which signed another block for same slot")
+			sm.actor.TellRequest(message.ChainSvc, &message.AddEvidence{Evidence: ev})
+		}
+	}
+}
+
 func (sm *syncManager) HandleNewTxNotice(peer RemotePeer, hashArrs []TxHash, data *types.NewTransactionsNotice) {
 	peerID := peer.ID()
 
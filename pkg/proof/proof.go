@@ -0,0 +1,172 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package proof verifies merkle proofs of the state trie returned by aergo
+// nodes. it needs only the state root of a trusted block header, so light
+// clients can check account states and contract variables without any
+// database.
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+const varIDPrefix = "_sv_"
+
+var (
+	ErrNoProof       = errors.New("no proof")
+	ErrInvalidProof  = errors.New("malformed proof")
+	ErrValueNotMatch = errors.New("proven value does not match to the returned data")
+	ErrRootNotMatch  = errors.New("proof does not match to the state root")
+)
+
+// VerifyState checks the state of the account in sp is proven against the
+// state root, which is the BlocksRootHash of a block header. if the account
+// does not exist, sp should be a proof of non-inclusion.
+func VerifyState(root []byte, account []byte, sp *types.StateProof) error {
+	if sp == nil {
+		return ErrNoProof
+	}
+	var value []byte
+	if sp.GetInclusion() {
+		if sp.GetState() == nil {
+			return ErrValueNotMatch
+		}
+		raw, err := proto.Marshal(sp.GetState())
+		if err != nil {
+			return err
+		}
+		value = common.Hasher(raw)
+	}
+	id := types.ToAccountID(account)
+	return verify(root, id[:], value, sp.GetInclusion(), sp.GetProofKey(), sp.GetProofVal(),
+		sp.GetBitmap(), int(sp.GetHeight()), sp.GetAuditPath())
+}
+
+// VerifyContractVar checks the value of the contract variable in vp is proven
+// against the storage root of the contract
+func VerifyContractVar(storageRoot []byte, varName, varIndex string, vp *types.ContractVarProof) error {
+	key := common.Hasher([]byte(varIDPrefix + varName + varIndex))
+	return VerifyStorageVar(storageRoot, key, vp)
+}
+
+// VerifyStorageVar checks the value in vp is proven against the storage root
+// of the contract at key, which is the key of the storage trie, e.g. the hash
+// of the key given to the system contract storage.
+func VerifyStorageVar(storageRoot, key []byte, vp *types.ContractVarProof) error {
+	if vp == nil {
+		return ErrNoProof
+	}
+	var value []byte
+	if vp.GetInclusion() {
+		value = common.Hasher(vp.GetValue())
+	}
+	return verify(storageRoot, key, value, vp.GetInclusion(), vp.GetProofKey(), vp.GetProofVal(),
+		vp.GetBitmap(), int(vp.GetHeight()), vp.GetAuditPath())
+}
+
+// VerifyStorageLeaf checks the leaf at key, whose raw value is value, is
+// included in the storage trie of the contract. the value of vp is not used,
+// as the leaves of a storage chunk are sent apart from their proofs.
+func VerifyStorageLeaf(storageRoot, key, value []byte, vp *types.ContractVarProof) error {
+	if vp == nil {
+		return ErrNoProof
+	}
+	return verify(storageRoot, key, common.Hasher(value), true, vp.GetProofKey(), vp.GetProofVal(),
+		vp.GetBitmap(), int(vp.GetHeight()), vp.GetAuditPath())
+}
+
+// VerifyStateQuery checks the contract state and the variable in the result
+// of QueryContractState against the state root
+func VerifyStateQuery(root []byte, contract []byte, varName, varIndex string, sqp *types.StateQueryProof) error {
+	if sqp == nil {
+		return ErrNoProof
+	}
+	if err := VerifyState(root, contract, sqp.GetContractProof()); err != nil {
+		return fmt.Errorf("contract state: %s", err.Error())
+	}
+	if !sqp.GetContractProof().GetInclusion() {
+		// no contract, so no variable
+		return nil
+	}
+	storageRoot := sqp.GetContractProof().GetState().GetStorageRoot()
+	if err := VerifyContractVar(storageRoot, varName, varIndex, sqp.GetVarProof()); err != nil {
+		return fmt.Errorf("contract variable: %s", err.Error())
+	}
+	return nil
+}
+
+// verify checks the inclusion or the non-inclusion proof of key in the trie
+// of root. the proof is compressed if it has the bitmap
+func verify(root, key, value []byte, inclusion bool, proofKey, proofVal, bitmap []byte,
+	height int, ap [][]byte) error {
+	if len(root) == 0 {
+		// nothing can be included in an empty trie
+		if inclusion || len(ap) != 0 || len(proofKey) != 0 {
+			return ErrRootNotMatch
+		}
+		return nil
+	}
+	compressed := len(bitmap) != 0
+	if !validShape(key, proofKey, bitmap, height, ap, compressed) {
+		return ErrInvalidProof
+	}
+	// the trie only uses the root and the hash function for verification
+	smt := trie.NewTrie(root, common.Hasher, nil)
+	var ok bool
+	if inclusion {
+		if !bytes.Equal(value, proofVal) {
+			return ErrValueNotMatch
+		}
+		if compressed {
+			ok = smt.VerifyInclusionC(bitmap, key, value, ap, height)
+		} else {
+			ok = smt.VerifyInclusion(ap, key, value)
+		}
+	} else {
+		if bytes.Equal(key, proofKey) {
+			// a leaf of the key itself can not prove its absence
+			return ErrRootNotMatch
+		}
+		if compressed {
+			ok = smt.VerifyNonInclusionC(ap, height, bitmap, key, proofVal, proofKey)
+		} else {
+			ok = smt.VerifyNonInclusion(ap, key, proofVal, proofKey)
+		}
+	}
+	if !ok {
+		return ErrRootNotMatch
+	}
+	return nil
+}
+
+// validShape checks the lengths of the proof so that a malformed one from
+// an untrusted node can not make the verification panic
+func validShape(key, proofKey, bitmap []byte, height int, ap [][]byte, compressed bool) bool {
+	trieHeight := trie.HashLength * 8
+	if len(key) != trie.HashLength || (len(proofKey) != 0 && len(proofKey) != trie.HashLength) {
+		return false
+	}
+	if !compressed {
+		return len(ap) <= trieHeight
+	}
+	if height < 0 || height > trieHeight || len(bitmap)*8 < height {
+		return false
+	}
+	var n int
+	for i := 0; i < height; i++ {
+		if bitmap[i/8]&(1<<uint(7-i%8)) != 0 {
+			n++
+		}
+	}
+	return n == len(ap)
+}
@@ -0,0 +1,225 @@
+package proof
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+type testAccount struct {
+	address []byte
+	state   *types.State
+}
+
+// newTestTrie makes the state trie of accounts in memory
+func newTestTrie(t *testing.T, accounts []testAccount) *trie.Trie {
+	type kv struct{ key, value []byte }
+	var kvs []kv
+	for _, acc := range accounts {
+		raw, err := proto.Marshal(acc.state)
+		if err != nil {
+			t.Fatalf("could not marshal state : %s", err.Error())
+		}
+		id := types.ToAccountID(acc.address)
+		kvs = append(kvs, kv{id[:], common.Hasher(raw)})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].key, kvs[j].key) < 0 })
+	var keys, values [][]byte
+	for _, e := range kvs {
+		keys = append(keys, e.key)
+		values = append(values, e.value)
+	}
+	smt := trie.NewTrie(nil, common.Hasher, nil)
+	if _, err := smt.Update(keys, values); err != nil {
+		t.Fatalf("could not update trie : %s", err.Error())
+	}
+	return smt
+}
+
+func getStateProof(smt *trie.Trie, account testAccount, compressed bool) *types.StateProof {
+	id := types.ToAccountID(account.address)
+	sp := &types.StateProof{}
+	if compressed {
+		var height int
+		sp.Bitmap, sp.AuditPath, height, sp.Inclusion, sp.ProofKey, sp.ProofVal, _ = smt.MerkleProofCompressed(id[:])
+		sp.Height = uint32(height)
+	} else {
+		sp.AuditPath, sp.Inclusion, sp.ProofKey, sp.ProofVal, _ = smt.MerkleProof(id[:])
+	}
+	if sp.Inclusion {
+		sp.State = account.state
+	}
+	return sp
+}
+
+func TestVerifyState(t *testing.T) {
+	var accounts []testAccount
+	for i := 0; i < 10; i++ {
+		accounts = append(accounts, testAccount{
+			address: []byte(fmt.Sprintf("account%d", i)),
+			state:   &types.State{Nonce: uint64(i), Balance: []byte{byte(i)}},
+		})
+	}
+	smt := newTestTrie(t, accounts)
+	absent := testAccount{address: []byte("absent")}
+
+	for _, compressed := range []bool{false, true} {
+		for _, acc := range accounts {
+			sp := getStateProof(smt, acc, compressed)
+			if err := VerifyState(smt.Root, acc.address, sp); err != nil {
+				t.Errorf("inclusion should be verified (compressed=%t) : %s", compressed, err.Error())
+			}
+		}
+		sp := getStateProof(smt, absent, compressed)
+		if err := VerifyState(smt.Root, absent.address, sp); err != nil {
+			t.Errorf("non-inclusion should be verified (compressed=%t) : %s", compressed, err.Error())
+		}
+
+		// forged state
+		sp = getStateProof(smt, accounts[0], compressed)
+		sp.State = &types.State{Nonce: 100, Balance: []byte{100}}
+		if err := VerifyState(smt.Root, accounts[0].address, sp); err != ErrValueNotMatch {
+			t.Errorf("forged state should fail, but %v", err)
+		}
+		raw, _ := proto.Marshal(sp.State)
+		sp.ProofVal = common.Hasher(raw)
+		if err := VerifyState(smt.Root, accounts[0].address, sp); err != ErrRootNotMatch {
+			t.Errorf("forged state and value should fail, but %v", err)
+		}
+
+		// proof of other account
+		sp = getStateProof(smt, accounts[1], compressed)
+		if err := VerifyState(smt.Root, accounts[0].address, sp); err != ErrRootNotMatch {
+			t.Errorf("proof of other account should fail, but %v", err)
+		}
+
+		// hidden account
+		sp = getStateProof(smt, accounts[2], compressed)
+		sp.Inclusion = false
+		sp.State = nil
+		if err := VerifyState(smt.Root, accounts[2].address, sp); err == nil {
+			t.Error("hiding existing account should fail")
+		}
+
+		// other root
+		sp = getStateProof(smt, accounts[3], compressed)
+		if err := VerifyState(common.Hasher([]byte("root")), accounts[3].address, sp); err != ErrRootNotMatch {
+			t.Errorf("other root should fail, but %v", err)
+		}
+
+		// malformed
+		sp = getStateProof(smt, accounts[4], compressed)
+		sp.AuditPath = append(sp.AuditPath, sp.AuditPath...)
+		if compressed {
+			if err := VerifyState(smt.Root, accounts[4].address, sp); err != ErrInvalidProof {
+				t.Errorf("malformed proof should fail, but %v", err)
+			}
+		} else if err := VerifyState(smt.Root, accounts[4].address, sp); err == nil {
+			t.Error("malformed proof should fail")
+		}
+	}
+}
+
+func TestVerifyContractVar(t *testing.T) {
+	value := []byte("value")
+	key := common.Hasher([]byte("_sv_" + "name" + "index"))
+	other := common.Hasher([]byte("_sv_" + "other"))
+	keys := [][]byte{key, other}
+	values := [][]byte{common.Hasher(value), common.Hasher([]byte("other"))}
+	if bytes.Compare(key, other) > 0 {
+		keys[0], keys[1] = keys[1], keys[0]
+		values[0], values[1] = values[1], values[0]
+	}
+	smt := trie.NewTrie(nil, common.Hasher, nil)
+	smt.Update(keys, values)
+
+	vp := &types.ContractVarProof{Value: value}
+	vp.AuditPath, vp.Inclusion, vp.ProofKey, vp.ProofVal, _ = smt.MerkleProof(key)
+	if err := VerifyContractVar(smt.Root, "name", "index", vp); err != nil {
+		t.Errorf("contract variable should be verified : %s", err.Error())
+	}
+	if err := VerifyContractVar(smt.Root, "name", "other", vp); err != ErrRootNotMatch {
+		t.Errorf("other variable should fail, but %v", err)
+	}
+	vp.Value = []byte("forged")
+	if err := VerifyContractVar(smt.Root, "name", "index", vp); err != ErrValueNotMatch {
+		t.Errorf("forged value should fail, but %v", err)
+	}
+
+	absent := &types.ContractVarProof{}
+	absentKey := common.Hasher([]byte("_sv_" + "absent"))
+	absent.AuditPath, absent.Inclusion, absent.ProofKey, absent.ProofVal, _ = smt.MerkleProof(absentKey)
+	if err := VerifyContractVar(smt.Root, "absent", "", absent); err != nil {
+		t.Errorf("non-inclusion should be verified : %s", err.Error())
+	}
+	if err := VerifyContractVar(nil, "absent", "", &types.ContractVarProof{}); err != nil {
+		t.Errorf("nothing should be in empty trie : %s", err.Error())
+	}
+}
+
+func TestVerifyStorageLeaf(t *testing.T) {
+	value := []byte("value")
+	key := common.Hasher([]byte("_sv_" + "name"))
+	other := common.Hasher([]byte("_sv_" + "other"))
+	keys := [][]byte{key, other}
+	values := [][]byte{common.Hasher(value), common.Hasher([]byte("other"))}
+	if bytes.Compare(key, other) > 0 {
+		keys[0], keys[1] = keys[1], keys[0]
+		values[0], values[1] = values[1], values[0]
+	}
+	smt := trie.NewTrie(nil, common.Hasher, nil)
+	smt.Update(keys, values)
+
+	vp := &types.ContractVarProof{}
+	var height int
+	vp.Bitmap, vp.AuditPath, height, vp.Inclusion, vp.ProofKey, vp.ProofVal, _ = smt.MerkleProofCompressed(key)
+	vp.Height = uint32(height)
+	if err := VerifyStorageLeaf(smt.Root, key, value, vp); err != nil {
+		t.Errorf("storage leaf should be verified : %s", err.Error())
+	}
+	if err := VerifyStorageLeaf(smt.Root, key, []byte("forged"), vp); err != ErrValueNotMatch {
+		t.Errorf("forged value should fail, but %v", err)
+	}
+	if err := VerifyStorageLeaf(smt.Root, other, value, vp); err == nil {
+		t.Errorf("other key should fail")
+	}
+	if err := VerifyStorageLeaf(smt.Root, key, value, nil); err != ErrNoProof {
+		t.Errorf("missing proof should fail, but %v", err)
+	}
+}
+
+func TestVerifyStorageVar(t *testing.T) {
+	value := []byte("value")
+	key := common.Hasher([]byte("sortedlist"))
+	other := common.Hasher([]byte("param"))
+	keys := [][]byte{key, other}
+	values := [][]byte{common.Hasher(value), common.Hasher([]byte("other"))}
+	if bytes.Compare(key, other) > 0 {
+		keys[0], keys[1] = keys[1], keys[0]
+		values[0], values[1] = values[1], values[0]
+	}
+	smt := trie.NewTrie(nil, common.Hasher, nil)
+	smt.Update(keys, values)
+
+	vp := &types.ContractVarProof{Value: value}
+	vp.AuditPath, vp.Inclusion, vp.ProofKey, vp.ProofVal, _ = smt.MerkleProof(key)
+	if err := VerifyStorageVar(smt.Root, key, vp); err != nil {
+		t.Errorf("storage variable should be verified : %s", err.Error())
+	}
+	if err := VerifyStorageVar(smt.Root, other, vp); err == nil {
+		t.Errorf("other key should fail")
+	}
+
+	absent := &types.ContractVarProof{}
+	absentKey := common.Hasher([]byte("absent"))
+	absent.AuditPath, absent.Inclusion, absent.ProofKey, absent.ProofVal, _ = smt.MerkleProof(absentKey)
+	if err := VerifyStorageVar(smt.Root, absentKey, absent); err != nil {
+		t.Errorf("non-inclusion should be verified : %s", err.Error())
+	}
+}
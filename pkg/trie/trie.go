@@ -45,6 +45,8 @@ type Trie struct {
 	pastTries [][]byte
 	// atomicUpdate, commit all the changes made by intermediate update calls
 	atomicUpdate bool
+	// journal records the committed and obsoleted nodes if it is set
+	journal *NodeJournal
 }
 
 // NewSMT creates a new SMT given a keySize and a hash function.
@@ -57,6 +59,7 @@ func NewTrie(root []byte, hash func(data ...[]byte) []byte, store db.DB) *Trie {
 	s.db = &CacheDB{
 		liveCache:    make(map[Hash][][]byte),
 		updatedNodes: make(map[Hash][][]byte),
+		obsoleted:    make(map[Hash]struct{}),
 		Store:        store,
 	}
 	// don't store any cache by default (contracts state don't use cache)
@@ -266,13 +269,16 @@ func (s *Trie) updateParallel(lnode, rnode, root []byte, lkeys, rkeys, lvalues,
 func (s *Trie) deleteOldNode(root []byte, height int, movingUp bool) {
 	var node Hash
 	copy(node[:], root)
+	s.db.updatedMux.Lock()
+	if len(root) != 0 {
+		s.obsolete(node, height, movingUp)
+	}
 	if !s.atomicUpdate || movingUp {
 		// dont delete old nodes with atomic updated except when
 		// moving up a shortcut, we dont record every single move
-		s.db.updatedMux.Lock()
 		delete(s.db.updatedNodes, node)
-		s.db.updatedMux.Unlock()
 	}
+	s.db.updatedMux.Unlock()
 	if height >= s.CacheHeightLimit {
 		s.db.liveMux.Lock()
 		delete(s.db.liveCache, node)
@@ -550,6 +556,14 @@ func (s *Trie) storeNode(batch [][]byte, h, oldRoot []byte, height int) {
 		}
 		s.deleteOldNode(oldRoot, height, false)
 	}
+	if s.journal != nil && len(oldRoot) >= HashLength && bytes.Equal(h[:HashLength], oldRoot[:HashLength]) {
+		// the old node is stored again at the same position
+		var node Hash
+		copy(node[:], h)
+		s.db.updatedMux.Lock()
+		delete(s.db.obsoleted, node)
+		s.db.updatedMux.Unlock()
+	}
 }
 
 // interiorHash hashes 2 children to get the parent hash and stores it in the updatedNodes and maybe in liveCache.
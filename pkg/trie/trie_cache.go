@@ -20,6 +20,8 @@ type CacheDB struct {
 	updatedNodes map[Hash][][]byte
 	// updatedMux is a lock for updatedNodes
 	updatedMux sync.RWMutex
+	// obsoleted are the committed nodes replaced by updates (guarded by updatedMux)
+	obsoleted map[Hash]struct{}
 	// nodesToRevert will be deleted from db
 	nodesToRevert [][]byte
 	// revertMux is a lock for updatedNodes
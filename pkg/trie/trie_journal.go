@@ -0,0 +1,100 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package trie
+
+import (
+	"sync"
+)
+
+// JournalNode is a batch node written to the database by StageUpdates.
+type JournalNode struct {
+	Key   []byte
+	Value []byte
+}
+
+// NodeJournal records the batch nodes committed and replaced by the tries
+// it is attached to. It is used to reference count the trie nodes so that
+// the nodes of old tries can be deleted (state pruning).
+// The same journal can be shared by several tries.
+type NodeJournal struct {
+	lock sync.Mutex
+	// Created are the nodes written by StageUpdates
+	Created []JournalNode
+	// Obsoleted are the nodes, loaded from the database, that are no longer
+	// part of the trie after the update
+	Obsoleted [][]byte
+}
+
+// NewNodeJournal returns an empty journal.
+func NewNodeJournal() *NodeJournal {
+	return &NodeJournal{}
+}
+
+// Reset clears the recorded nodes.
+func (j *NodeJournal) Reset() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.Created = nil
+	j.Obsoleted = nil
+}
+
+// LeafValues returns the values of the leaves in the serialized batch node.
+func LeafValues(node []byte) [][]byte {
+	var values [][]byte
+	batch := (&Trie{}).parseBatch(node)
+	// the value of a leaf is the right child of a shortcut, flagged by 2
+	for i := 2; i < len(batch); i += 2 {
+		if n := len(batch[i]); n == HashLength+1 && batch[i][HashLength] == 2 {
+			values = append(values, batch[i][:HashLength])
+		}
+	}
+	return values
+}
+
+// SetJournal attaches a journal to the trie.
+// When a journal is attached, Revert doesn't delete nodes from the database:
+// the owner of the journal is responsible for it.
+func (s *Trie) SetJournal(j *NodeJournal) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.journal = j
+}
+
+// obsolete records a committed node which was replaced by an update.
+// It must be called with updatedMux locked.
+func (s *Trie) obsolete(node Hash, height int, movingUp bool) {
+	// only batch roots are stored in the db. a shortcut moving up is
+	// deleted with the height of its parent.
+	if s.journal == nil || (height%4 != 0 && !movingUp) {
+		return
+	}
+	if _, exists := s.db.updatedNodes[node]; exists {
+		// the node was never committed
+		return
+	}
+	s.db.obsoleted[node] = struct{}{}
+}
+
+// record adds the updated and obsoleted nodes to the journal and clears the
+// obsoleted nodes.
+func (c *CacheDB) record(j *NodeJournal) {
+	c.updatedMux.Lock()
+	defer c.updatedMux.Unlock()
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for key, batch := range c.updatedNodes {
+		var node []byte
+		j.Created = append(j.Created, JournalNode{
+			Key:   append(node, key[:]...),
+			Value: c.serializeBatch(batch),
+		})
+	}
+	for key := range c.obsoleted {
+		var node []byte
+		j.Obsoleted = append(j.Obsoleted, append(node, key[:]...))
+	}
+	c.obsoleted = make(map[Hash]struct{})
+}
@@ -11,7 +11,8 @@ import (
 )
 
 // Revert rewinds the state tree to a previous version
-// All the nodes (subtree roots and values) reverted are deleted from the database.
+// All the nodes (subtree roots and values) reverted are deleted from the database
+// unless a journal is attached to the trie.
 func (s *Trie) Revert(toOldRoot []byte) error {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -37,27 +38,32 @@ func (s *Trie) Revert(toOldRoot []byte) error {
 		return fmt.Errorf("The root cannot be reverted, because already latest of not in pastTries : current : %x, target : %x", s.Root, toOldRoot)
 	}
 
-	// For every node of toOldRoot, compare it to the equivalent node in other pasttries between toOldRoot and current s.Root. If a node is different, delete the one from pasttries
-	s.db.nodesToRevert = make([][]byte, 0)
-	for i := toIndex + 1; i < len(s.pastTries); i++ {
-		ch := make(chan error, 1)
-		s.maybeDeleteSubTree(toOldRoot, s.pastTries[i], s.TrieHeight, 0, nil, nil, ch)
-		err := <-ch
-		if err != nil {
-			return err
+	// The nodes of a journaled trie may be shared with other tries: leave
+	// their deletion to the owner of the journal.
+	if s.journal == nil {
+		// For every node of toOldRoot, compare it to the equivalent node in other pasttries between toOldRoot and current s.Root. If a node is different, delete the one from pasttries
+		s.db.nodesToRevert = make([][]byte, 0)
+		for i := toIndex + 1; i < len(s.pastTries); i++ {
+			ch := make(chan error, 1)
+			s.maybeDeleteSubTree(toOldRoot, s.pastTries[i], s.TrieHeight, 0, nil, nil, ch)
+			err := <-ch
+			if err != nil {
+				return err
+			}
 		}
+		// NOTE The tx interface doesnt handle ErrTxnTooBig
+		txn := s.db.Store.NewTx()
+		for _, key := range s.db.nodesToRevert {
+			txn.Delete(key[:HashLength])
+		}
+		txn.Commit()
 	}
-	// NOTE The tx interface doesnt handle ErrTxnTooBig
-	txn := s.db.Store.NewTx()
-	for _, key := range s.db.nodesToRevert {
-		txn.Delete(key[:HashLength])
-	}
-	txn.Commit()
 
 	s.pastTries = s.pastTries[:toIndex+1]
 	s.Root = toOldRoot
 	s.db.liveCache = make(map[Hash][][]byte)
 	s.db.updatedNodes = make(map[Hash][][]byte)
+	s.db.obsoleted = make(map[Hash]struct{})
 	if isShortcut {
 		// If toOldRoot is a shortcut batch, it is possible that
 		// revert has deleted it if the key was ever stored at height0
@@ -636,6 +636,201 @@ func TestStash(t *testing.T) {
 	os.RemoveAll(".aergo")
 }
 
+func TestTrieJournal(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+	smt := NewTrie(nil, common.Hasher, st)
+	journal := NewNodeJournal()
+	smt.SetJournal(journal)
+
+	keys := getFreshData(10, 32)
+	values := getFreshData(10, 32)
+	root, _ := smt.Update(keys, values)
+	nUpdated := len(smt.db.updatedNodes)
+	smt.Commit()
+	if len(journal.Created) != nUpdated || len(journal.Obsoleted) != 0 {
+		t.Fatal("committed nodes not recorded")
+	}
+	for _, n := range journal.Created {
+		if !bytes.Equal(st.Get(n.Key), n.Value) {
+			t.Fatal("recorded node differs from the stored one")
+		}
+	}
+	journal.Reset()
+
+	// the old root is replaced
+	smt.Update(keys[:1], getFreshData(1, 32))
+	smt.Commit()
+	found := false
+	for _, node := range journal.Obsoleted {
+		if bytes.Equal(node, root[:HashLength]) {
+			found = true
+		}
+		if bytes.Equal(node, smt.Root[:HashLength]) {
+			t.Fatal("live root recorded as obsoleted")
+		}
+	}
+	if !found {
+		t.Fatal("replaced root not recorded")
+	}
+	created := journal.Created
+
+	// revert doesn't delete the nodes of a journaled trie
+	if err := smt.Revert(root); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range created {
+		if len(st.Get(n.Key)) == 0 {
+			t.Fatal("node deleted by revert")
+		}
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
+func TestLeafValues(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+	smt := NewTrie(nil, common.Hasher, st)
+	journal := NewNodeJournal()
+	smt.SetJournal(journal)
+
+	keys := getFreshData(10, 32)
+	values := getFreshData(10, 32)
+	smt.Update(keys, values)
+	smt.Commit()
+
+	// every value is in a leaf of the committed nodes
+	found := make(map[string]int)
+	for _, n := range journal.Created {
+		for _, v := range LeafValues(n.Value) {
+			found[string(v)]++
+		}
+	}
+	if len(found) != len(values) {
+		t.Fatalf("%d leaf values found, expected %d", len(found), len(values))
+	}
+	for _, v := range values {
+		if found[string(v)] == 0 {
+			t.Fatalf("value %x not found in the leaves", v)
+		}
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
+func TestTrieWalk(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+	smt := NewTrie(nil, common.Hasher, st)
+
+	keys := getFreshData(100, 32)
+	values := getFreshData(100, 32)
+	root, _ := smt.Update(keys, values)
+	smt.Commit()
+
+	// walk the trie loaded from the db
+	smt = NewTrie(root, common.Hasher, st)
+	i := 0
+	err := smt.Walk(root, func(key, value []byte) error {
+		if i >= len(keys) || !bytes.Equal(key, keys[i]) || !bytes.Equal(value, values[i]) {
+			t.Fatalf("unexpected leaf %d", i)
+		}
+		i++
+		return nil
+	})
+	if err != nil || i != len(keys) {
+		t.Fatal("failed to walk all the leaves", err)
+	}
+
+	stop := fmt.Errorf("stop")
+	i = 0
+	err = smt.Walk(root, func(key, value []byte) error {
+		i++
+		return stop
+	})
+	if err != stop || i != 1 {
+		t.Fatal("walk not stopped by error")
+	}
+
+	i = 50
+	err = smt.WalkFrom(root, keys[i], func(key, value []byte) error {
+		if i >= len(keys) || !bytes.Equal(key, keys[i]) {
+			t.Fatalf("unexpected leaf %d", i)
+		}
+		i++
+		return nil
+	})
+	if err != nil || i != len(keys) {
+		t.Fatal("failed to walk the leaves from a key", err)
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
+func TestTrieVerifyNode(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+	smt := NewTrie(nil, common.Hasher, st)
+
+	keys := getFreshData(1000, 32)
+	values := getFreshData(1000, 32)
+	root, _ := smt.Update(keys, values)
+	smt.Commit()
+
+	// collect the leaves by verifying every node from the root
+	leaves := make(map[string][]byte)
+	hashes, heights := [][]byte{root}, []int{smt.TrieHeight}
+	for len(hashes) > 0 {
+		hash, height := hashes[0], heights[0]
+		hashes, heights = hashes[1:], heights[1:]
+		children, lkeys, lvalues, err := smt.VerifyNode(hash, st.Get(hash), height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, child := range children {
+			hashes = append(hashes, child)
+			heights = append(heights, height-4)
+		}
+		for i, key := range lkeys {
+			leaves[string(key)] = lvalues[i]
+		}
+	}
+	if len(leaves) != len(keys) {
+		t.Fatal("failed to collect all the leaves", len(leaves))
+	}
+	for i, key := range keys {
+		if !bytes.Equal(leaves[string(key)], values[i]) {
+			t.Fatal("unexpected leaf", i)
+		}
+	}
+
+	node := st.Get(root)
+	modified := make([]byte, len(node))
+	copy(modified, node)
+	modified[len(modified)-2] ^= 1
+	if _, _, _, err := smt.VerifyNode(root, modified, smt.TrieHeight); err == nil {
+		t.Fatal("modified node is verified")
+	}
+	if _, _, _, err := smt.VerifyNode(root, node[:len(node)-1], smt.TrieHeight); err == nil {
+		t.Fatal("truncated node is verified")
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
 func benchmark10MAccounts10Ktps(smt *Trie, b *testing.B) {
 	//b.ReportAllocs()
 	keys := getFreshData(100, 32)
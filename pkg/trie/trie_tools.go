@@ -110,6 +110,147 @@ func (s *Trie) get(root, key []byte, batch [][]byte, iBatch, height int) ([]byte
 	return s.get(lnode, key, batch, 2*iBatch+1, height-1)
 }
 
+// Walk calls fn with the key and value of every leaf of the trie at root, in
+// the order of keys. It stops at the first error returned by fn.
+func (s *Trie) Walk(root []byte, fn func(key, value []byte) error) error {
+	return s.WalkFrom(root, nil, fn)
+}
+
+// WalkFrom is like Walk but skips the leaves whose keys are less than start.
+// The nodes are only read, so atomicUpdate is left as it is.
+func (s *Trie) WalkFrom(root, start []byte, fn func(key, value []byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.walk(root, nil, 0, s.TrieHeight, start, fn)
+}
+
+// walk calls fn with the leaves of the sub tree at root from start. start is
+// nil when all the leaves of the sub tree come after it.
+func (s *Trie) walk(root []byte, batch [][]byte, iBatch, height int, start []byte, fn func(key, value []byte) error) error {
+	if len(root) == 0 {
+		return nil
+	}
+	batch, iBatch, lnode, rnode, isShortcut, err := s.loadChildren(root, height, iBatch, batch)
+	if err != nil {
+		return err
+	}
+	if isShortcut {
+		if start != nil && bytes.Compare(lnode[:HashLength], start) < 0 {
+			return nil
+		}
+		return fn(lnode[:HashLength], rnode[:HashLength])
+	}
+	if start != nil && bitIsSet(start, s.TrieHeight-height) {
+		return s.walk(rnode, batch, 2*iBatch+2, height-1, start, fn)
+	}
+	if err := s.walk(lnode, batch, 2*iBatch+1, height-1, start, fn); err != nil {
+		return err
+	}
+	return s.walk(rnode, batch, 2*iBatch+2, height-1, nil, fn)
+}
+
+// VerifyNode checks that node is the serialized trie node of hash at height,
+// so that a node received from a peer can be stored. height is a multiple of
+// 4 as only those nodes are stored. It returns the hashes of the child nodes
+// at height-4 and the keys and values of the leaves in the node.
+func (s *Trie) VerifyNode(hash, node []byte, height int) (children, keys, values [][]byte, err error) {
+	if len(hash) != HashLength || height <= 0 || height%4 != 0 || len(node) < 4 {
+		return nil, nil, nil, fmt.Errorf("invalid trie node %x", hash)
+	}
+	n := 0
+	for i := 0; i < 30; i++ {
+		if bitIsSet(node, i) {
+			n++
+		}
+	}
+	if len(node) != 4+33*n || (bitIsSet(node, 31) && !(bitIsSet(node, 0) && bitIsSet(node, 1))) {
+		return nil, nil, nil, fmt.Errorf("invalid trie node %x", hash)
+	}
+	v := &nodeVerifier{trie: s, batch: s.parseBatch(node)}
+	h, err := v.verify(0, height)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid trie node %x: %s", hash, err.Error())
+	}
+	if !bytes.Equal(h, hash) {
+		return nil, nil, nil, fmt.Errorf("trie node doesn't match the hash %x", hash)
+	}
+	return v.children, v.keys, v.values, nil
+}
+
+// nodeVerifier computes the hashes in a batch from its leaves and children.
+type nodeVerifier struct {
+	trie     *Trie
+	batch    [][]byte
+	children [][]byte
+	keys     [][]byte
+	values   [][]byte
+}
+
+// verify returns the hash of the node at iBatch and checks that the hash
+// stored for it in the batch is the same.
+func (v *nodeVerifier) verify(iBatch, height int) ([]byte, error) {
+	batch := v.batch
+	if iBatch == 0 {
+		if batch[0][0] == 1 {
+			return v.leaf(iBatch, height)
+		}
+	} else {
+		node := batch[iBatch]
+		if node[HashLength] > 1 {
+			return nil, fmt.Errorf("invalid flag of node %d", iBatch)
+		}
+		if height%4 == 0 {
+			// the child node is stored as another batch
+			v.children = append(v.children, node[:HashLength])
+			return node[:HashLength], nil
+		}
+		var h []byte
+		var err error
+		if node[HashLength] == 1 {
+			h, err = v.leaf(iBatch, height)
+		} else {
+			h, err = v.interior(iBatch, height)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(h, node[:HashLength]) {
+			return nil, fmt.Errorf("hash of node %d doesn't match", iBatch)
+		}
+		return h, nil
+	}
+	return v.interior(iBatch, height)
+}
+
+func (v *nodeVerifier) leaf(iBatch, height int) ([]byte, error) {
+	key, value := v.batch[2*iBatch+1], v.batch[2*iBatch+2]
+	if len(key) == 0 || len(value) == 0 || key[HashLength] != 2 || value[HashLength] != 2 {
+		return nil, fmt.Errorf("invalid leaf of node %d", iBatch)
+	}
+	v.keys = append(v.keys, key[:HashLength])
+	v.values = append(v.values, value[:HashLength])
+	return v.trie.hash(key[:HashLength], value[:HashLength], []byte{byte(height)}), nil
+}
+
+func (v *nodeVerifier) interior(iBatch, height int) ([]byte, error) {
+	left, right := DefaultLeaf, DefaultLeaf
+	var err error
+	if len(v.batch[2*iBatch+1]) == 0 && len(v.batch[2*iBatch+2]) == 0 {
+		return nil, fmt.Errorf("empty children of node %d", iBatch)
+	}
+	if len(v.batch[2*iBatch+1]) != 0 {
+		if left, err = v.verify(2*iBatch+1, height-1); err != nil {
+			return nil, err
+		}
+	}
+	if len(v.batch[2*iBatch+2]) != 0 {
+		if right, err = v.verify(2*iBatch+2, height-1); err != nil {
+			return nil, err
+		}
+	}
+	return v.trie.hash(left, right), nil
+}
+
 // TrieRootExists returns true if the root exists in Database.
 func (s *Trie) TrieRootExists(root []byte) bool {
 	s.db.lock.RLock()
@@ -149,6 +290,9 @@ func (s *Trie) StageUpdates(txn *db.Transaction) {
 		s.updatePastTries()
 	}
 	s.db.commit(txn)
+	if s.journal != nil {
+		s.db.record(s.journal)
+	}
 
 	s.db.updatedNodes = make(map[Hash][][]byte)
 	s.prevRoot = s.Root
@@ -174,6 +318,7 @@ func (s *Trie) Stash(rollbackCache bool) error {
 		s.db.liveCache = make(map[Hash][][]byte)
 	}
 	s.db.updatedNodes = make(map[Hash][][]byte)
+	s.db.obsoleted = make(map[Hash]struct{})
 	// also stash past tries created by Atomic update
 	for i := len(s.pastTries) - 1; i >= 0; i-- {
 		if bytes.Equal(s.pastTries[i], s.Root) {
@@ -0,0 +1,500 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package restservice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/aergoio/aergo/types/jsonconv/encoding/json"
+	"github.com/mr-tron/base58/base58"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBodySize limits the request body, it is same to the max message size of
+// grpc server
+const maxBodySize = 1024 * 1024 * 256
+
+type InOutQueryResult struct {
+	Result json.RawMessage
+}
+
+func (cs *RestService) initRoutes() {
+	cs.handle(http.MethodGet, "/chaintree", cs.getChainTree)
+
+	cs.handle(http.MethodGet, "/v1/blockchain", cs.getBlockchain)
+	cs.handle(http.MethodGet, "/v1/blocks", cs.listBlockHeaders)
+	cs.handle(http.MethodGet, "/v1/blocks/{}", cs.getBlock)
+
+	cs.handle(http.MethodGet, "/v1/txs/{}", cs.getTx)
+	cs.handle(http.MethodGet, "/v1/txs/{}/proof", cs.getTxProof)
+	cs.handle(http.MethodPost, "/v1/txs", cs.commitTx)
+	if cs.cfg.Personal {
+		cs.handle(http.MethodPost, "/v1/txs/send", cs.sendTx)
+	}
+	cs.handle(http.MethodGet, "/v1/receipts/{}", cs.getReceipt)
+
+	cs.handle(http.MethodGet, "/v1/accounts/{}/state", cs.getState)
+	cs.handle(http.MethodGet, "/v1/accounts/{}/staking", cs.getStaking)
+	cs.handle(http.MethodGet, "/v1/accounts/{}/votes", cs.getAccountVotes)
+	cs.handle(http.MethodGet, "/v1/accounts/{}/txs", cs.listAccountTxs)
+	cs.handle(http.MethodGet, "/v1/votes", cs.getVotes)
+
+	cs.handle(http.MethodGet, "/v1/contracts/{}/abi", cs.getABI)
+	cs.handle(http.MethodPost, "/v1/contracts/{}/query", cs.queryContract)
+	cs.handle(http.MethodGet, "/v1/contracts/{}/state", cs.queryContractState)
+
+	cs.handle(http.MethodGet, "/v1/peers", cs.getPeers)
+}
+
+// isSameOrigin reports whether the request is not from a page of other
+// origin. a request without Origin header is not from a browser
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || origin == "http://"+r.Host || origin == "https://"+r.Host
+}
+
+func invalidParam(name string, err error) error {
+	return status.Errorf(codes.InvalidArgument, "invalid %s: %s", name, err.Error())
+}
+
+func decodeHash(name string, s string) ([]byte, error) {
+	hash, err := base58.Decode(s)
+	if err != nil {
+		return nil, invalidParam(name, err)
+	}
+	return hash, nil
+}
+
+func decodeAddress(s string) ([]byte, error) {
+	addr, err := types.DecodeAddress(s)
+	if err != nil {
+		return nil, invalidParam("address", err)
+	}
+	return addr, nil
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, n)
+	return b
+}
+
+func queryUint(r *http.Request, name string, bitSize int) (uint64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(v, 10, bitSize)
+	if err != nil {
+		return 0, invalidParam(name, err)
+	}
+	return n, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, invalidParam(name, err)
+	}
+	return b, nil
+}
+
+func queryHash(r *http.Request, name string) ([]byte, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	return decodeHash(name, v)
+}
+
+// queryBlock parses the block of the state queries. the latest block is used
+// if neither blockno nor blockhash is given
+func queryBlock(r *http.Request) (uint64, []byte, error) {
+	blockNo, err := queryUint(r, "blockno", 64)
+	if err != nil {
+		return 0, nil, err
+	}
+	blockHash, err := queryHash(r, "blockhash")
+	if err != nil {
+		return 0, nil, err
+	}
+	return blockNo, blockHash, nil
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return nil, invalidParam("body", err)
+	}
+	return body, nil
+}
+
+func (cs *RestService) getChainTree(r *http.Request, params []string) (interface{}, error) {
+	tree, err := cs.bc.GetChainTree()
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(tree), nil
+}
+
+func (cs *RestService) getBlockchain(r *http.Request, params []string) (interface{}, error) {
+	msg, err := cs.rpc.Blockchain(r.Context(), &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &jsonconv.InOutBlockchainStatus{
+		Hash:   base58.Encode(msg.GetBestBlockHash()),
+		Height: msg.GetBestHeight(),
+	}, nil
+}
+
+// listBlockHeaders lists headers from the block of hash or height backward,
+// or forward with asc
+func (cs *RestService) listBlockHeaders(r *http.Request, params []string) (interface{}, error) {
+	var err error
+	in := &types.ListParams{}
+	if in.Hash, err = queryHash(r, "hash"); err != nil {
+		return nil, err
+	}
+	if in.Height, err = queryUint(r, "height", 64); err != nil {
+		return nil, err
+	}
+	size, err := queryUint(r, "size", 32)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryUint(r, "offset", 32)
+	if err != nil {
+		return nil, err
+	}
+	in.Size, in.Offset = uint32(size), uint32(offset)
+	if in.Size == 0 {
+		in.Size = 20
+	}
+	if in.Asc, err = queryBool(r, "asc"); err != nil {
+		return nil, err
+	}
+	if in.From, err = queryUint(r, "from", 64); err != nil {
+		return nil, err
+	}
+	if in.To, err = queryUint(r, "to", 64); err != nil {
+		return nil, err
+	}
+	query := r.URL.Query()
+	_, hasFrom := query["from"]
+	_, hasTo := query["to"]
+	in.Range = hasFrom || hasTo
+	if in.Range && !hasTo {
+		// to the best block, it is clamped by server
+		in.To = math.MaxUint64
+	}
+	if in.WithBody, err = queryBool(r, "body"); err != nil {
+		return nil, err
+	}
+	if in.Cursor, err = queryHash(r, "cursor"); err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.ListBlockHeaders(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvBlockHeaderList(msg), nil
+}
+
+// getBlock finds the block by number if the parameter is decimal, otherwise
+// by base58 encoded hash
+func (cs *RestService) getBlock(r *http.Request, params []string) (interface{}, error) {
+	var value []byte
+	if number, err := strconv.ParseUint(params[0], 10, 64); err == nil {
+		value = uint64Bytes(number)
+	} else if value, err = decodeHash("block hash", params[0]); err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.GetBlock(r.Context(), &types.SingleBytes{Value: value})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvBlock(msg), nil
+}
+
+// getTx finds the tx in blocks first, and then in mempool. the block hash of
+// pending tx is empty
+func (cs *RestService) getTx(r *http.Request, params []string) (interface{}, error) {
+	hash, err := decodeHash("tx hash", params[0])
+	if err != nil {
+		return nil, err
+	}
+	in := &types.SingleBytes{Value: hash}
+	msg, err := cs.rpc.GetBlockTX(r.Context(), in)
+	if err == nil && msg.GetTx() != nil {
+		return jsonconv.ConvTxInBlock(msg), nil
+	}
+	tx, err := cs.rpc.GetTX(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvTxInBlock(&types.TxInBlock{Tx: tx}), nil
+}
+
+func (cs *RestService) getTxProof(r *http.Request, params []string) (interface{}, error) {
+	hash, err := decodeHash("tx hash", params[0])
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.GetTXProof(r.Context(), &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvTxProof(msg), nil
+}
+
+// commitTx puts signed txs, a json object or an array of them, to mempool
+func (cs *RestService) commitTx(r *http.Request, params []string) (interface{}, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		body = append(append([]byte{'['}, body...), ']')
+	}
+	txs, err := jsonconv.ParseBase58Tx(body)
+	if err != nil {
+		return nil, invalidParam("tx", err)
+	}
+	msg, err := cs.rpc.CommitTX(r.Context(), &types.TxList{Txs: txs})
+	if err != nil {
+		return nil, err
+	}
+	out := []*jsonconv.InOutCommitResult{}
+	for _, result := range msg.GetResults() {
+		out = append(out, jsonconv.ConvCommitResult(result))
+	}
+	return out, nil
+}
+
+// sendTx signs the tx body with the unlocked account in the node and commits
+// it. it needs personal feature of the node. a page of other origin can not
+// use it even if CORS is allowed, since it spends the unlocked account
+func (cs *RestService) sendTx(r *http.Request, params []string) (interface{}, error) {
+	if !isSameOrigin(r) {
+		return nil, status.Errorf(codes.PermissionDenied, "cross origin request can not send tx")
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	txBody, err := jsonconv.ParseBase58TxBody(body)
+	if err != nil {
+		return nil, invalidParam("tx body", err)
+	}
+	msg, err := cs.rpc.SendTX(r.Context(), &types.Tx{Body: txBody})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvCommitResult(msg), nil
+}
+
+func (cs *RestService) getReceipt(r *http.Request, params []string) (interface{}, error) {
+	hash, err := decodeHash("tx hash", params[0])
+	if err != nil {
+		return nil, err
+	}
+	return cs.rpc.GetReceipt(r.Context(), &types.SingleBytes{Value: hash})
+}
+
+// getState returns the state of account at the block of blockno or
+// blockhash. with proof=true, it returns the merkle proof of the state at the
+// root, or at the latest if no root is given
+func (cs *RestService) getState(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	withProof, err := queryBool(r, "proof")
+	if err != nil {
+		return nil, err
+	}
+	if withProof {
+		in := &types.AccountAndRoot{Account: addr}
+		if in.Root, err = queryHash(r, "root"); err != nil {
+			return nil, err
+		}
+		if in.Compressed, err = queryBool(r, "compressed"); err != nil {
+			return nil, err
+		}
+		return cs.rpc.GetStateAndProof(r.Context(), in)
+	}
+	in := &types.AccountAtBlock{Account: addr}
+	if in.BlockNo, in.BlockHash, err = queryBlock(r); err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.GetState(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvState(msg), nil
+}
+
+func (cs *RestService) getStaking(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.GetStaking(r.Context(), &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvStaking(msg), nil
+}
+
+func (cs *RestService) getAccountVotes(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.GetVotes(r.Context(), &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvVoteList(msg), nil
+}
+
+func (cs *RestService) listAccountTxs(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	size, err := queryUint(r, "size", 32)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := queryHash(r, "cursor")
+	if err != nil {
+		return nil, err
+	}
+	in := &types.AccountTxsParams{Account: addr, Size: uint32(size), Cursor: cursor}
+	if in.Size == 0 {
+		in.Size = 20
+	}
+	msg, err := cs.rpc.ListAccountTxs(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvAccountTxList(msg), nil
+}
+
+// getVotes returns the top count of elected candidates
+func (cs *RestService) getVotes(r *http.Request, params []string) (interface{}, error) {
+	count, err := queryUint(r, "count", 64)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		count = 1
+	}
+	msg, err := cs.rpc.GetVotes(r.Context(), &types.SingleBytes{Value: uint64Bytes(count)})
+	if err != nil {
+		return nil, err
+	}
+	return jsonconv.ConvVoteList(msg), nil
+}
+
+func (cs *RestService) getABI(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	in := &types.AccountAtBlock{Account: addr}
+	if in.BlockNo, in.BlockHash, err = queryBlock(r); err != nil {
+		return nil, err
+	}
+	return cs.rpc.GetABI(r.Context(), in)
+}
+
+// queryContract calls the read only function of contract at the block of
+// blockno or blockhash. the body is the json of types.CallInfo
+func (cs *RestService) queryContract(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	var ci types.CallInfo
+	if err := json.Unmarshal(body, &ci); err != nil {
+		return nil, invalidParam("call info", err)
+	}
+	queryinfo, err := json.Marshal(ci)
+	if err != nil {
+		return nil, err
+	}
+	in := &types.Query{ContractAddress: addr, Queryinfo: queryinfo}
+	if in.BlockNo, in.BlockHash, err = queryBlock(r); err != nil {
+		return nil, err
+	}
+	msg, err := cs.rpc.QueryContract(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	result := msg.GetValue()
+	if !json.Valid(result) {
+		result, _ = json.Marshal(string(result))
+	}
+	return &InOutQueryResult{Result: result}, nil
+}
+
+// queryContractState returns the state variable of contract with the merkle
+// proof of it
+func (cs *RestService) queryContractState(r *http.Request, params []string) (interface{}, error) {
+	addr, err := decodeAddress(params[0])
+	if err != nil {
+		return nil, err
+	}
+	query := r.URL.Query()
+	in := &types.StateQuery{
+		ContractAddress: addr,
+		VarName:         query.Get("var"),
+		VarIndex:        query.Get("index"),
+	}
+	if in.VarName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "var is required")
+	}
+	if in.Root, err = queryHash(r, "root"); err != nil {
+		return nil, err
+	}
+	if in.Compressed, err = queryBool(r, "compressed"); err != nil {
+		return nil, err
+	}
+	return cs.rpc.QueryContractState(r.Context(), in)
+}
+
+func (cs *RestService) getPeers(r *http.Request, params []string) (interface{}, error) {
+	msg, err := cs.rpc.GetPeers(r.Context(), &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	out := []*jsonconv.InOutPeer{}
+	for _, p := range msg.GetPeers() {
+		out = append(out, jsonconv.ConvPeer(p))
+	}
+	return out, nil
+}
@@ -6,13 +6,9 @@
 package restservice
 
 import (
-	//"html"
-	//	"encoding/hex"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-
-	//"sync"
+	"time"
 
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-lib/log"
@@ -20,27 +16,41 @@ import (
 	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
 )
 
+// RestService serves the api of AergoRPCService in http/json
 type RestService struct {
 	*component.BaseComponent
 
 	cfg *cfg.Config
 	bc  *bc.ChainService
-}
+	rpc types.AergoRPCServiceServer
 
-//var wait sync.WaitGroup
+	routes     []*route
+	httpServer *http.Server
+}
 
 var (
 	logger = log.NewLogger("rest")
 )
 
-func NewRestService(cfg *cfg.Config, bc *bc.ChainService) *RestService {
+func NewRestService(cfg *cfg.Config, bc *bc.ChainService, rpc types.AergoRPCServiceServer) *RestService {
 	cs := &RestService{
 		cfg: cfg,
 		bc:  bc,
+		rpc: rpc,
 	}
 	cs.BaseComponent = component.NewBaseComponent(message.RestSvc, cs, logger)
+	cs.initRoutes()
+
+	cs.httpServer = &http.Server{
+		Addr:           fmt.Sprintf("%s:%d", cfg.RPC.NetServiceAddr, cfg.REST.RestPort),
+		Handler:        cs,
+		ReadTimeout:    4 * time.Second,
+		WriteTimeout:   4 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
 
 	return cs
 }
@@ -48,28 +58,24 @@ func NewRestService(cfg *cfg.Config, bc *bc.ChainService) *RestService {
 func (cs *RestService) BeforeStart() {}
 
 func (cs *RestService) AfterStart() {
-	go func() {
-		http.HandleFunc("/chaintree", func(w http.ResponseWriter, r *http.Request) {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				logger.Error().Err(err).Msg("Error reading body")
-				http.Error(w, "can't read body", http.StatusBadRequest)
-				return
-			}
-			logger.Debug().Str("body", string(body)).Msg("Received")
-			// Sorry, Just for ChainTree lookup now
-			i, _ := cs.bc.GetChainTree()
-			w.Write(i)
-		})
-		logger.Info().Int("port", cs.cfg.REST.RestPort).Msg("Rest Service Started")
-		portNo := fmt.Sprintf(":%v", cs.cfg.REST.RestPort)
-		err := http.ListenAndServe(portNo, nil)
-		logger.Info().Err(err).Msg("Start rest server")
-	}()
+	go cs.serve()
 }
 
-func (cs *RestService) BeforeStop() {
+func (cs *RestService) serve() {
+	logger.Info().Str("addr", cs.httpServer.Addr).Bool("tls", cs.cfg.RPC.NSEnableTLS).Msg("Rest Service Started")
+	var err error
+	if cs.cfg.RPC.NSEnableTLS {
+		err = cs.httpServer.ListenAndServeTLS(cs.cfg.RPC.NSCert, cs.cfg.RPC.NSKey)
+	} else {
+		err = cs.httpServer.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		logger.Error().Err(err).Msg("Rest server stopped")
+	}
+}
 
+func (cs *RestService) BeforeStop() {
+	cs.httpServer.Close()
 }
 
 func (cs *RestService) Statistics() *map[string]interface{} {
@@ -78,3 +84,41 @@ func (cs *RestService) Statistics() *map[string]interface{} {
 
 func (cs *RestService) Receive(context actor.Context) {
 }
+
+// ServeHTTP dispatches the request to the handler of matched route
+func (cs *RestService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if cs.cfg.RPC.NSAllowCORS {
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", "*")
+		header.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		header.Set("Access-Control-Allow-Headers", "Content-Type")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	pathMatched := false
+	for _, rt := range cs.routes {
+		params, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+		out, err := rt.handler(r, params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+	if pathMatched {
+		writeErrorStatus(w, http.StatusMethodNotAllowed, "method not allowed")
+	} else {
+		writeErrorStatus(w, http.StatusNotFound, "no such api")
+	}
+}
@@ -0,0 +1,194 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package restservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonconv"
+	"github.com/mr-tron/base58/base58"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRPC implements the part of AergoRPCService used in tests. calling
+// other methods panics
+type fakeRPC struct {
+	types.AergoRPCServiceServer
+
+	block   *types.Block
+	commits []*types.Tx
+}
+
+func (f *fakeRPC) GetBlock(ctx context.Context, in *types.SingleBytes) (*types.Block, error) {
+	if len(in.Value) == 8 && binary.LittleEndian.Uint64(in.Value) == f.block.GetHeader().GetBlockNo() {
+		return f.block, nil
+	}
+	if bytes.Equal(in.Value, f.block.Hash) {
+		return f.block, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "Not found")
+}
+
+func (f *fakeRPC) CommitTX(ctx context.Context, in *types.TxList) (*types.CommitResultList, error) {
+	f.commits = append(f.commits, in.Txs...)
+	results := &types.CommitResultList{}
+	for _, tx := range in.Txs {
+		results.Results = append(results.Results, &types.CommitResult{Hash: tx.Hash})
+	}
+	return results, nil
+}
+
+func (f *fakeRPC) SendTX(ctx context.Context, in *types.Tx) (*types.CommitResult, error) {
+	f.commits = append(f.commits, in)
+	return &types.CommitResult{Hash: []byte{0x01}}, nil
+}
+
+func newTestRestService(allowCORS bool) (*RestService, *fakeRPC) {
+	block := &types.Block{Header: &types.BlockHeader{BlockNo: 3}}
+	block.BlockHash()
+	rpc := &fakeRPC{block: block}
+	conf := &cfg.Config{
+		RPC:  &cfg.RPCConfig{NetServiceAddr: "127.0.0.1", NSAllowCORS: allowCORS},
+		REST: &cfg.RESTConfig{RestPort: 8080},
+	}
+	return NewRestService(conf, nil, rpc), rpc
+}
+
+func doRequest(cs *RestService, method, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	cs.ServeHTTP(w, req)
+	return w
+}
+
+func checkError(t *testing.T, w *httptest.ResponseRecorder, code int) {
+	if w.Code != code {
+		t.Errorf("expected status %d, but %d : %s", code, w.Code, w.Body.String())
+	}
+	var out errorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("error response is not json : %s", w.Body.String())
+	}
+	if out.Error.Code != code || out.Error.Message == "" {
+		t.Errorf("invalid error response : %s", w.Body.String())
+	}
+}
+
+func TestGetBlock(t *testing.T) {
+	cs, rpc := newTestRestService(false)
+	hash := base58.Encode(rpc.block.Hash)
+
+	for _, id := range []string{"3", hash} {
+		w := doRequest(cs, http.MethodGet, "/v1/blocks/"+id, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("failed to get block %s : %d %s", id, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type : %s", ct)
+		}
+		var out jsonconv.InOutBlock
+		if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+			t.Fatalf("block response is not json : %s", err.Error())
+		}
+		if out.Hash != hash || out.Header.BlockNo != 3 {
+			t.Errorf("unexpected block : %s", w.Body.String())
+		}
+	}
+
+	checkError(t, doRequest(cs, http.MethodGet, "/v1/blocks/4", ""), http.StatusNotFound)
+	checkError(t, doRequest(cs, http.MethodGet, "/v1/blocks/0OIl", ""), http.StatusBadRequest)
+}
+
+func TestRouting(t *testing.T) {
+	cs, _ := newTestRestService(false)
+
+	checkError(t, doRequest(cs, http.MethodGet, "/v1/nothing", ""), http.StatusNotFound)
+	checkError(t, doRequest(cs, http.MethodGet, "/v1/blocks/3/extra", ""), http.StatusNotFound)
+	checkError(t, doRequest(cs, http.MethodPost, "/v1/blocks/3", ""), http.StatusMethodNotAllowed)
+	checkError(t, doRequest(cs, http.MethodGet, "/v1/accounts/invalid/state", ""), http.StatusBadRequest)
+
+	w := doRequest(cs, http.MethodOptions, "/v1/blocks/3", "")
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("CORS should not be allowed by default")
+	}
+}
+
+func TestCORS(t *testing.T) {
+	cs, _ := newTestRestService(true)
+
+	w := doRequest(cs, http.MethodOptions, "/v1/blocks/3", "")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("preflight should succeed, but %d", w.Code)
+	}
+	w = doRequest(cs, http.MethodGet, "/v1/blocks/3", "")
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Error("CORS header is missing")
+	}
+}
+
+func TestCommitTx(t *testing.T) {
+	cs, rpc := newTestRestService(false)
+
+	tx := &types.Tx{Body: &types.TxBody{Nonce: 1, Amount: []byte{10}}}
+	tx.Hash = tx.CalculateTxHash()
+	body := jsonconv.TxConvBase58Addr(tx)
+
+	for _, b := range []string{body, "[" + body + "]"} {
+		w := doRequest(cs, http.MethodPost, "/v1/txs", b)
+		if w.Code != http.StatusOK {
+			t.Fatalf("failed to commit : %d %s", w.Code, w.Body.String())
+		}
+		var out []jsonconv.InOutCommitResult
+		if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+			t.Fatalf("commit response is not json : %s", err.Error())
+		}
+		if len(out) != 1 || out[0].Hash != base58.Encode(tx.Hash) || out[0].Error != types.CommitStatus_TX_OK.String() {
+			t.Errorf("unexpected commit result : %s", w.Body.String())
+		}
+	}
+	if len(rpc.commits) != 2 || !bytes.Equal(rpc.commits[0].Hash, tx.Hash) {
+		t.Errorf("tx should be committed to rpc service")
+	}
+
+	checkError(t, doRequest(cs, http.MethodPost, "/v1/txs", "{invalid"), http.StatusBadRequest)
+}
+
+func TestSendTx(t *testing.T) {
+	cs, _ := newTestRestService(true)
+	checkError(t, doRequest(cs, http.MethodPost, "/v1/txs/send", `{"Nonce":1}`), http.StatusNotFound)
+
+	rpc := &fakeRPC{}
+	conf := &cfg.Config{
+		BaseConfig: cfg.BaseConfig{Personal: true},
+		RPC:        &cfg.RPCConfig{NetServiceAddr: "127.0.0.1", NSAllowCORS: true},
+		REST:       &cfg.RESTConfig{RestPort: 8080},
+	}
+	cs = NewRestService(conf, nil, rpc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/txs/send", strings.NewReader(`{"Nonce":1}`))
+	req.Header.Set("Origin", "http://other.example.com")
+	w := httptest.NewRecorder()
+	cs.ServeHTTP(w, req)
+	checkError(t, w, http.StatusForbidden)
+	if len(rpc.commits) != 0 {
+		t.Error("cross origin request should not send tx")
+	}
+
+	w = doRequest(cs, http.MethodPost, "/v1/txs/send", `{"Nonce":1}`)
+	if w.Code != http.StatusOK || len(rpc.commits) != 1 {
+		t.Errorf("failed to send tx : %d %s", w.Code, w.Body.String())
+	}
+}
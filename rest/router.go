@@ -0,0 +1,109 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package restservice
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aergoio/aergo/types/jsonconv/encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handlerFunc returns the object to be written in json. params are the
+// path segments matched to {} of the route pattern
+type handlerFunc func(r *http.Request, params []string) (interface{}, error)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+const paramSegment = "{}"
+
+func (cs *RestService) handle(method string, pattern string, handler handlerFunc) {
+	cs.routes = append(cs.routes, &route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (rt *route) match(path string) ([]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	var params []string
+	for i, s := range rt.segments {
+		if s == paramSegment {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params = append(params, segments[i])
+		} else if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// ErrorBody is the json object of the error response
+type ErrorBody struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+var httpStatusOfCode = map[codes.Code]int{
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.NotFound:          http.StatusNotFound,
+	codes.AlreadyExists:     http.StatusConflict,
+	codes.PermissionDenied:  http.StatusForbidden,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Unimplemented:     http.StatusNotImplemented,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+}
+
+// writeError writes err in the form of ErrorBody. grpc status errors of rpc
+// service are converted to corresponding http status
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if s, ok := status.FromError(err); ok {
+		if c, exist := httpStatusOfCode[s.Code()]; exist {
+			code = c
+		}
+		writeErrorStatus(w, code, s.Message())
+		return
+	}
+	writeErrorStatus(w, code, err.Error())
+}
+
+func writeErrorStatus(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, &errorResponse{
+		Error: ErrorBody{Code: code, Status: http.StatusText(code), Message: msg},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, out interface{}) {
+	body, err := json.Marshal(out)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to marshal response")
+		code = http.StatusInternalServerError
+		body = []byte(`{"error":{"code":500,"status":"Internal Server Error","message":"failed to marshal response"}}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/types"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/libp2p/go-libp2p-peer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -39,8 +40,26 @@ type AergoRPCService struct {
 
 	streamLock  sync.RWMutex
 	blockstream []types.AergoRPCService_ListBlockStreamServer
+
+	eventStreamLock sync.Mutex
+	eventstream     map[*eventStream]struct{}
+}
+
+// eventStream is a subscriber of ListEventStream. Connected and removed
+// blocks are queued to ch, which is closed if the subscriber cannot keep up.
+type eventStream struct {
+	filter *types.FilterInfo
+	ch     chan *message.NotifyBlockReceipts
 }
 
+const eventStreamQueueSize = 128
+
+// max number of blocks in a page of ListBlockHeaders
+const (
+	maxListBlocks         = 1000
+	maxListBlocksWithBody = 100
+)
+
 // FIXME remove redundant constants
 const halfMinute = time.Second * 30
 const defaultActorTimeout = time.Second * 3
@@ -116,13 +135,11 @@ func (rpc *AergoRPCService) Blockchain(ctx context.Context, in *types.Empty) (*t
 
 // ListBlockHeaders handle rpc request listblocks
 func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.ListParams) (*types.BlockHeaderList, error) {
-	var maxFetchSize uint32
-	// TODO refactor with almost same code is in p2pcmdblock.go
-	if in.Size > uint32(1000) {
-		maxFetchSize = uint32(1000)
-	} else {
-		maxFetchSize = in.Size
+	if in.Range || len(in.Cursor) != 0 {
+		return rpc.listBlocksInRange(in)
 	}
+	// TODO refactor with almost same code is in p2pcmdblock.go
+	maxFetchSize := listSizeLimit(in)
 	idx := uint32(0)
 	hashes := make([][]byte, 0, maxFetchSize)
 	headers := make([]*types.Block, 0, maxFetchSize)
@@ -136,7 +153,9 @@ func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.List
 				break
 			}
 			hashes = append(hashes, foundBlock.BlockHash())
-			foundBlock.Body = nil
+			if !in.WithBody {
+				foundBlock.Body = nil
+			}
 			headers = append(headers, foundBlock)
 			idx++
 			hash = foundBlock.Header.PrevBlockHash
@@ -161,7 +180,9 @@ func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.List
 					break
 				}
 				hashes = append(hashes, foundBlock.BlockHash())
-				foundBlock.Body = nil
+				if !in.WithBody {
+					foundBlock.Body = nil
+				}
 				headers = append(headers, foundBlock)
 				idx++
 			}
@@ -173,7 +194,9 @@ func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.List
 					break
 				}
 				hashes = append(hashes, foundBlock.BlockHash())
-				foundBlock.Body = nil
+				if !in.WithBody {
+					foundBlock.Body = nil
+				}
 				headers = append(headers, foundBlock)
 				idx++
 			}
@@ -183,6 +206,105 @@ func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.List
 	return &types.BlockHeaderList{Blocks: headers}, err
 
 }
+
+// listSizeLimit returns the size of list, which is limited less if blocks are
+// listed with their bodies
+func listSizeLimit(in *types.ListParams) uint32 {
+	size := in.Size
+	if in.WithBody && size > maxListBlocksWithBody {
+		size = maxListBlocksWithBody
+	} else if size > maxListBlocks {
+		size = maxListBlocks
+	}
+	return size
+}
+
+// listBlocksInRange lists blocks of the main chain whose numbers are in
+// [in.From, in.To]. in.To is clamped to the best block. the next page starts
+// at in.Cursor, which was returned with the previous page, so pages are not
+// shifted by new blocks.
+func (rpc *AergoRPCService) listBlocksInRange(in *types.ListParams) (*types.BlockHeaderList, error) {
+	size := listSizeLimit(in)
+
+	best, err := rpc.actorHelper.GetChainAccessor().GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	from, to := in.From, in.To
+	if bestNo := best.GetHeader().GetBlockNo(); to > bestNo {
+		to = bestNo
+	}
+	if from > to {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid range: from %d is greater than to %d", from, to)
+	}
+
+	next := to
+	if in.Asc {
+		next = from
+	}
+	if len(in.Cursor) != 0 {
+		var lastHash []byte
+		if next, lastHash, err = decodeListCursor(in.Cursor); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		// the cursor is valid only if the last listed block is still in the
+		// main chain
+		lastNo := next + 1
+		if in.Asc {
+			lastNo = next - 1
+		}
+		last, ok := extractBlockFromFuture(rpc.hub.RequestFuture(message.ChainSvc,
+			&message.GetBlockByNo{BlockNo: lastNo}, defaultActorTimeout, "rpc.(*AergoRPCService).ListBlockHeaders#3"))
+		if !ok || last == nil || !bytes.Equal(last.BlockHash(), lastHash) {
+			return nil, status.Errorf(codes.Aborted, "stale cursor: block %d is not in the main chain any more", lastNo)
+		}
+	}
+
+	blocks := make([]*types.Block, 0, size)
+	var lastHash []byte
+	more := next >= from && next <= to
+	for more && uint32(len(blocks)) < size {
+		block, ok := extractBlockFromFuture(rpc.hub.RequestFuture(message.ChainSvc,
+			&message.GetBlockByNo{BlockNo: next}, defaultActorTimeout, "rpc.(*AergoRPCService).ListBlockHeaders#4"))
+		if !ok || block == nil {
+			return nil, status.Errorf(codes.NotFound, "block %d not found", next)
+		}
+		lastHash = block.BlockHash()
+		if !in.WithBody {
+			block.Body = nil
+		}
+		blocks = append(blocks, block)
+
+		if in.Asc {
+			more = next < to
+			next++
+		} else {
+			more = next > from
+			next--
+		}
+	}
+
+	list := &types.BlockHeaderList{Blocks: blocks}
+	if more && len(blocks) > 0 {
+		list.NextCursor = encodeListCursor(next, lastHash)
+	}
+	return list, nil
+}
+
+// encodeListCursor returns the cursor of the page which starts at the block
+// number next. lastHash is the hash of the last block of the previous page.
+func encodeListCursor(next types.BlockNo, lastHash []byte) []byte {
+	cursor := make([]byte, 8, 8+len(lastHash))
+	binary.BigEndian.PutUint64(cursor, next)
+	return append(cursor, lastHash...)
+}
+
+func decodeListCursor(cursor []byte) (types.BlockNo, []byte, error) {
+	if len(cursor) <= 8 {
+		return 0, nil, errors.New("invalid cursor")
+	}
+	return binary.BigEndian.Uint64(cursor[:8]), cursor[8:], nil
+}
 func (rpc *AergoRPCService) BroadcastToListBlockStream(block *types.Block) error {
 	var err error
 	rpc.streamLock.RLock()
@@ -225,6 +347,236 @@ func (rpc *AergoRPCService) ListBlockStream(in *types.Empty, stream types.AergoR
 	}
 }
 
+// BroadcastToEventStream queues receipts of a connected or removed block to
+// every event stream subscriber. A subscriber whose queue is full is dropped
+// and its stream ends with ResourceExhausted.
+func (rpc *AergoRPCService) BroadcastToEventStream(msg *message.NotifyBlockReceipts) {
+	rpc.eventStreamLock.Lock()
+	defer rpc.eventStreamLock.Unlock()
+	for es := range rpc.eventstream {
+		select {
+		case es.ch <- msg:
+		default:
+			logger.Warn().Uint64("blockNo", msg.Block.BlockNo()).Msg("event stream queue is full; dropping subscriber")
+			close(es.ch)
+			delete(rpc.eventstream, es)
+		}
+	}
+}
+
+func (rpc *AergoRPCService) subscribeEvents(filter *types.FilterInfo) *eventStream {
+	es := &eventStream{
+		filter: filter,
+		ch:     make(chan *message.NotifyBlockReceipts, eventStreamQueueSize),
+	}
+	rpc.eventStreamLock.Lock()
+	rpc.eventstream[es] = struct{}{}
+	rpc.eventStreamLock.Unlock()
+	return es
+}
+
+// isSubscribed returns false if the subscriber is dropped by overflow.
+func (rpc *AergoRPCService) isSubscribed(es *eventStream) bool {
+	rpc.eventStreamLock.Lock()
+	defer rpc.eventStreamLock.Unlock()
+	_, exist := rpc.eventstream[es]
+	return exist
+}
+
+func errEventStreamOverflow(lastNo types.BlockNo) error {
+	return status.Errorf(codes.ResourceExhausted,
+		"event stream is too slow; reconnect from block %d", lastNo+1)
+}
+
+func (rpc *AergoRPCService) unsubscribeEvents(es *eventStream) {
+	rpc.eventStreamLock.Lock()
+	if _, exist := rpc.eventstream[es]; exist {
+		close(es.ch)
+		delete(rpc.eventstream, es)
+	}
+	rpc.eventStreamLock.Unlock()
+}
+
+// ListEventStream streams receipts and contract events of the blocks matching
+// the filter. If blockfrom is given, the receipts of the blocks already in
+// the chain are sent first, so that a reconnecting client can catch up. The
+// stream ends after blockto if it is given. If a block already sent is rolled
+// back by a reorg, its receipts are sent again with removed set.
+func (rpc *AergoRPCService) ListEventStream(in *types.FilterInfo, stream types.AergoRPCService_ListEventStreamServer) error {
+	if in.Blockto != 0 && in.Blockfrom > in.Blockto {
+		return status.Errorf(codes.InvalidArgument, "blockfrom is greater than blockto")
+	}
+	if len(in.ContractAddress) != 0 && len(in.ContractAddress) != types.AddressLength {
+		return status.Errorf(codes.InvalidArgument, "invalid contract address")
+	}
+
+	// subscribe first not to miss blocks executed while catching up
+	es := rpc.subscribeEvents(in)
+	defer rpc.unsubscribeEvents(es)
+
+	var lastNo types.BlockNo
+	catchingUp := in.Blockfrom != 0
+	if catchingUp {
+		var err error
+		if lastNo, err = rpc.catchUpEvents(in, stream, es); err != nil {
+			return err
+		}
+		if in.Blockto != 0 && lastNo >= in.Blockto {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case msg, ok := <-es.ch:
+			if !ok {
+				return errEventStreamOverflow(lastNo)
+			}
+			blockNo := msg.Block.BlockNo()
+			if msg.Removed {
+				// only the blocks already sent need to be removed
+				if blockNo < in.Blockfrom || blockNo > lastNo {
+					continue
+				}
+				if err := sendFilteredReceipts(stream, in, msg.Block, msg.Receipts, true); err != nil {
+					return err
+				}
+				lastNo = blockNo - 1
+				continue
+			}
+			if blockNo < in.Blockfrom || (catchingUp && blockNo <= lastNo) {
+				continue
+			}
+			catchingUp = false
+			if in.Blockto != 0 && blockNo > in.Blockto {
+				return nil
+			}
+			if err := sendFilteredReceipts(stream, in, msg.Block, msg.Receipts, false); err != nil {
+				return err
+			}
+			lastNo = blockNo
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// catchUpEvents sends the receipts of the main chain blocks from blockfrom
+// to the best block (or blockto) and returns the number of the last block
+// sent. It fails if the subscriber is dropped while catching up.
+func (rpc *AergoRPCService) catchUpEvents(in *types.FilterInfo, stream types.AergoRPCService_ListEventStreamServer,
+	es *eventStream) (types.BlockNo, error) {
+	ca := rpc.actorHelper.GetChainAccessor()
+	blockNo := in.Blockfrom
+	for {
+		best, err := ca.GetBestBlock()
+		if err != nil {
+			return 0, err
+		}
+		bestNo := best.BlockNo()
+		if in.Blockto != 0 && in.Blockto < bestNo {
+			bestNo = in.Blockto
+		}
+		if blockNo > bestNo {
+			return blockNo - 1, nil
+		}
+		for ; blockNo <= bestNo; blockNo++ {
+			select {
+			case <-stream.Context().Done():
+				return 0, stream.Context().Err()
+			default:
+			}
+			if !rpc.isSubscribed(es) {
+				return 0, errEventStreamOverflow(blockNo - 1)
+			}
+			hash, err := ca.GetHashByNo(blockNo)
+			if err != nil {
+				return 0, err
+			}
+			block, err := ca.GetBlock(hash)
+			if err != nil {
+				return 0, err
+			}
+			result, err := rpc.hub.RequestFuture(message.ChainSvc, &message.GetReceipts{BlockHash: hash},
+				defaultActorTimeout, "rpc.(*AergoRPCService).ListEventStream").Result()
+			if err != nil {
+				return 0, err
+			}
+			rsp, ok := result.(message.GetReceiptsRsp)
+			if !ok {
+				return 0, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+			}
+			if rsp.Err != nil {
+				return 0, rsp.Err
+			}
+			if err := sendFilteredReceipts(stream, in, block, rsp.Receipts, false); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+func sendFilteredReceipts(stream types.AergoRPCService_ListEventStreamServer, filter *types.FilterInfo,
+	block *types.Block, receipts types.Receipts, removed bool) error {
+	txs := block.GetBody().GetTxs()
+	for i, r := range receipts {
+		r = filterReceipt(filter, r)
+		if r == nil {
+			continue
+		}
+		var txHash []byte
+		if i < len(txs) {
+			txHash = txs[i].GetHash()
+		}
+		err := stream.Send(&types.ReceiptInBlock{
+			TxHash:    txHash,
+			BlockHash: block.BlockHash(),
+			BlockNo:   block.BlockNo(),
+			TxIndex:   int32(i),
+			Receipt:   r,
+			Removed:   removed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterReceipt returns the receipt to be sent to the subscriber or nil if it
+// does not match. A receipt matches the contract address if it is the receipt
+// of a call to that contract or the contract emitted an event during the tx.
+// If the event name or the contract address is given, only the matching
+// events are left in the returned receipt.
+func filterReceipt(filter *types.FilterInfo, r *types.Receipt) *types.Receipt {
+	if len(filter.ContractAddress) == 0 && len(filter.EventName) == 0 {
+		return r
+	}
+	var events []*types.Event
+	for _, ev := range r.Events {
+		if len(filter.ContractAddress) != 0 && !bytes.Equal(filter.ContractAddress, ev.ContractAddress) {
+			continue
+		}
+		if len(filter.EventName) != 0 && filter.EventName != ev.EventName {
+			continue
+		}
+		events = append(events, ev)
+	}
+	if len(events) == 0 {
+		if len(filter.EventName) != 0 || !bytes.Equal(filter.ContractAddress, r.ContractAddress) {
+			return nil
+		}
+	}
+	return &types.Receipt{
+		ContractAddress: r.ContractAddress,
+		Status:          r.Status,
+		Ret:             r.Ret,
+		Events:          events,
+		FeeUsed:         r.FeeUsed,
+		GasUsed:         r.GasUsed,
+	}
+}
+
 func extractBlockFromFuture(future *actor.Future) (*types.Block, bool) {
 	rawResponse, err := future.Result()
 	if err != nil {
@@ -315,12 +667,26 @@ func (rpc *AergoRPCService) GetBlockTX(ctx context.Context, in *types.SingleByte
 	return &types.TxInBlock{Tx: rsp.Tx, TxIdx: rsp.TxIds}, rsp.Err
 }
 
+// GetTXProof handle rpc request to get merkle path of the tx in block
+func (rpc *AergoRPCService) GetTXProof(ctx context.Context, in *types.SingleBytes) (*types.TxProof, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetTxProof{TxHash: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetTXProof").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetTxProofRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Proof, rsp.Err
+}
+
 var emptyBytes = make([]byte, 0)
 
 // SendTX try to fill the nonce, sign, hash in the transaction automatically and commit it
 func (rpc *AergoRPCService) SendTX(ctx context.Context, tx *types.Tx) (*types.CommitResult, error) {
 	getStateResult, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetState{Account: tx.Body.Account}, defaultActorTimeout, "rpc.(*AergoRPCService).SendTx").Result()
+		&message.GetState{Account: tx.Body.Account, BlockNo: message.LatestBlockNo}, defaultActorTimeout, "rpc.(*AergoRPCService).SendTx").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -418,10 +784,21 @@ func (rpc *AergoRPCService) CommitTX(ctx context.Context, in *types.TxList) (*ty
 	return results, nil
 }
 
-// GetState handle rpc request getstate
-func (rpc *AergoRPCService) GetState(ctx context.Context, in *types.SingleBytes) (*types.State, error) {
+// stateBlockNo converts the block number of the state queries in rpc, where 0
+// without hash means the latest block, since the genesis block can be given by
+// its hash.
+func stateBlockNo(blockNo types.BlockNo, blockHash []byte) types.BlockNo {
+	if blockNo == 0 && len(blockHash) == 0 {
+		return message.LatestBlockNo
+	}
+	return blockNo
+}
+
+// GetState handle rpc request getstate. The state at the block of the hash or
+// number is returned if it is given.
+func (rpc *AergoRPCService) GetState(ctx context.Context, in *types.AccountAtBlock) (*types.State, error) {
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetState{Account: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetState").Result()
+		&message.GetState{Account: in.Account, BlockNo: stateBlockNo(in.BlockNo, in.BlockHash), BlockHash: in.BlockHash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetState").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -483,6 +860,27 @@ func (rpc *AergoRPCService) CreateAccount(ctx context.Context, in *types.Persona
 	*/
 }
 
+// CreateMnemonicAccount handle rpc request to create hd seed with new mnemonic
+func (rpc *AergoRPCService) CreateMnemonicAccount(ctx context.Context, in *types.Personal) (*types.MnemonicAccount, error) {
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
+		&message.CreateMnemonic{Passphrase: in.Passphrase}, defaultActorTimeout, "rpc.(*AergoRPCService).CreateMnemonicAccount")
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.CreateMnemonicRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, rsp.Err
+	}
+	return &types.MnemonicAccount{Mnemonic: rsp.Mnemonic, Account: rsp.Account}, nil
+}
+
 // GetAccounts handle rpc request getaccounts
 func (rpc *AergoRPCService) GetAccounts(ctx context.Context, in *types.Empty) (*types.AccountList, error) {
 	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
@@ -557,6 +955,44 @@ func (rpc *AergoRPCService) ImportAccount(ctx context.Context, in *types.ImportF
 	return rsp.Account, rsp.Err
 }
 
+// ImportMnemonic handle rpc request to import hd seed of mnemonic
+func (rpc *AergoRPCService) ImportMnemonic(ctx context.Context, in *types.MnemonicFormat) (*types.Account, error) {
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
+		&message.ImportMnemonic{Mnemonic: in.Mnemonic, Passphrase: in.Passphrase},
+		defaultActorTimeout, "rpc.(*AergoRPCService).ImportMnemonic")
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.ImportAccountRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Account, rsp.Err
+}
+
+// DeriveAccount handle rpc request to derive next account from hd seed
+func (rpc *AergoRPCService) DeriveAccount(ctx context.Context, in *types.Personal) (*types.Account, error) {
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
+		&message.DeriveAccount{Passphrase: in.Passphrase},
+		defaultActorTimeout, "rpc.(*AergoRPCService).DeriveAccount")
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.AccountRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Account, rsp.Err
+}
+
 func (rpc *AergoRPCService) ExportAccount(ctx context.Context, in *types.Personal) (*types.SingleBytes, error) {
 	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
 		&message.ExportAccount{Account: in.Account, Pass: in.Passphrase},
@@ -639,6 +1075,68 @@ func (rpc *AergoRPCService) GetPeers(ctx context.Context, in *types.Empty) (*typ
 	return ret, nil
 }
 
+// ChangeMembership handle rpc request changemembership
+func (rpc *AergoRPCService) ChangeMembership(ctx context.Context, in *types.MembershipChange) (*types.Empty, error) {
+	id, err := peer.IDB58Decode(in.NodeID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid node id: %s", err.Error())
+	}
+
+	result, err := rpc.hub.RequestFutureResult(message.ConsensusSvc,
+		&message.ChangeMembership{Remove: in.Remove, ID: id}, defaultActorTimeout, "rpc.(*AergoRPCService).ChangeMembership")
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable membership change")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.ChangeMembershipRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, rsp.Err.Error())
+	}
+	return &types.Empty{}, nil
+}
+
+// ListEvidence handle rpc request listevidence
+func (rpc *AergoRPCService) ListEvidence(ctx context.Context, in *types.Empty) (*types.EvidenceList, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetEvidence{}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEvidence").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetEvidenceRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.Internal, rsp.Err.Error())
+	}
+	return &types.EvidenceList{Evidences: rsp.Evidences}, nil
+}
+
+// GetChainParams handle rpc request getchainparams. It returns the chain
+// parameters active for the next block, including those changed by the
+// governance.
+func (rpc *AergoRPCService) GetChainParams(ctx context.Context, in *types.Empty) (*types.ChainParamList, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetChainParams{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetChainParams").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetChainParamsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.Internal, rsp.Err.Error())
+	}
+	return rsp.Params, nil
+}
+
 // NodeState handle rpc request nodestate
 func (rpc *AergoRPCService) NodeState(ctx context.Context, in *types.NodeReq) (*types.SingleBytes, error) {
 	timeout := int64(binary.LittleEndian.Uint64(in.Timeout))
@@ -718,9 +1216,37 @@ func (rpc *AergoRPCService) GetReceipt(ctx context.Context, in *types.SingleByte
 	return rsp.Receipt, rsp.Err
 }
 
-func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (*types.ABI, error) {
+// ListAccountTxs handle rpc request listaccounttxs. it returns txs sent or
+// received by the account, latest first. the txs before IndexedFrom, where
+// the account index started, are not listed.
+func (rpc *AergoRPCService) ListAccountTxs(ctx context.Context, in *types.AccountTxsParams) (*types.AccountTxList, error) {
+	if len(in.Account) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "account is required")
+	}
+	size := in.Size
+	if size > uint32(1000) {
+		size = uint32(1000)
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetAccountTxs{Account: in.Account, Cursor: in.Cursor, Size: size},
+		defaultActorTimeout, "rpc.(*AergoRPCService).ListAccountTxs").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetAccountTxsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Error(codes.Unavailable, rsp.Err.Error())
+	}
+	return &types.AccountTxList{Account: in.Account, Txs: rsp.Txs,
+		NextCursor: rsp.NextCursor, IndexedFrom: rsp.IndexedFrom}, nil
+}
+
+func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.AccountAtBlock) (*types.ABI, error) {
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetABI{Contract: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetABI").Result()
+		&message.GetABI{Contract: in.Account, BlockNo: stateBlockNo(in.BlockNo, in.BlockHash), BlockHash: in.BlockHash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetABI").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -733,7 +1259,7 @@ func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (
 
 func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query) (*types.SingleBytes, error) {
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
+		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo, BlockNo: stateBlockNo(in.BlockNo, in.BlockHash), BlockHash: in.BlockHash}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -113,6 +114,93 @@ func TestAergoRPCService_GetTX(t *testing.T) {
 	}
 }
 
+func Test_filterReceipt(t *testing.T) {
+	contract1 := append([]byte{0x02}, bytes.Repeat([]byte{0x01}, types.AddressLength-1)...)
+	contract2 := append([]byte{0x02}, bytes.Repeat([]byte{0x02}, types.AddressLength-1)...)
+	inc1 := &types.Event{ContractAddress: contract1, EventName: "inc"}
+	done1 := &types.Event{ContractAddress: contract1, EventName: "done"}
+	inc2 := &types.Event{ContractAddress: contract2, EventName: "inc"}
+	receipt := &types.Receipt{ContractAddress: contract1, Status: "SUCCESS",
+		Events: []*types.Event{inc1, done1, inc2}, GasUsed: 100, FeeUsed: []byte{0x01}}
+	noEvent := &types.Receipt{ContractAddress: contract1, Status: "SUCCESS", GasUsed: 10}
+
+	tests := []struct {
+		name    string
+		filter  *types.FilterInfo
+		receipt *types.Receipt
+		want    []*types.Event
+		wantNil bool
+	}{
+		{"all", &types.FilterInfo{}, receipt, []*types.Event{inc1, done1, inc2}, false},
+		{"contract", &types.FilterInfo{ContractAddress: contract1}, receipt, []*types.Event{inc1, done1}, false},
+		{"calleeContract", &types.FilterInfo{ContractAddress: contract2}, receipt, []*types.Event{inc2}, false},
+		{"eventName", &types.FilterInfo{EventName: "inc"}, receipt, []*types.Event{inc1, inc2}, false},
+		{"both", &types.FilterInfo{ContractAddress: contract2, EventName: "inc"}, receipt, []*types.Event{inc2}, false},
+		{"noMatchedEvent", &types.FilterInfo{ContractAddress: contract2, EventName: "done"}, receipt, nil, true},
+		{"receiptOnly", &types.FilterInfo{ContractAddress: contract1}, noEvent, nil, false},
+		{"otherContract", &types.FilterInfo{ContractAddress: contract2}, noEvent, nil, true},
+		{"eventNameNoEvent", &types.FilterInfo{EventName: "inc"}, noEvent, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterReceipt(tt.filter, tt.receipt)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("filterReceipt() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("filterReceipt() = nil")
+			}
+			if !reflect.DeepEqual(got.Events, tt.want) {
+				t.Errorf("filterReceipt() events = %v, want %v", got.Events, tt.want)
+			}
+			if got.Status != tt.receipt.Status {
+				t.Errorf("filterReceipt() status = %v, want %v", got.Status, tt.receipt.Status)
+			}
+			if got.GasUsed != tt.receipt.GasUsed || !bytes.Equal(got.FeeUsed, tt.receipt.FeeUsed) {
+				t.Errorf("filterReceipt() gas = %v, fee = %v, want %v, %v", got.GasUsed, got.FeeUsed,
+					tt.receipt.GasUsed, tt.receipt.FeeUsed)
+			}
+		})
+	}
+}
+
+func Test_listCursor(t *testing.T) {
+	cursor := encodeListCursor(100215, dummyBlockHash)
+	next, lastHash, err := decodeListCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodeListCursor() error = %v", err)
+	}
+	if next != 100215 || !bytes.Equal(lastHash, dummyBlockHash) {
+		t.Errorf("decodeListCursor() = %v, %v, want %v, %v", next, lastHash, 100215, dummyBlockHash)
+	}
+	if _, _, err := decodeListCursor(cursor[:8]); err == nil {
+		t.Error("decodeListCursor() should fail without block hash")
+	}
+}
+
+func Test_listSizeLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *types.ListParams
+		want uint32
+	}{
+		{"small", &types.ListParams{Size: 20}, 20},
+		{"headers", &types.ListParams{Size: 5000}, maxListBlocks},
+		{"bodies", &types.ListParams{Size: 5000, WithBody: true}, maxListBlocksWithBody},
+		{"range bodies", &types.ListParams{Size: 500, WithBody: true, Range: true}, maxListBlocksWithBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listSizeLimit(tt.in); got != tt.want {
+				t.Errorf("listSizeLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 type FutureStub struct {
 	actor.Future
 	dumbResult interface{}
@@ -39,6 +39,16 @@ type RPC struct {
 	httpServer    *http.Server
 
 	ca types.ChainAccessor
+
+	listeners []BroadcastListener
+}
+
+// BroadcastListener is notified of new blocks and pending txs which rpc
+// service receives. it is called in the actor of rpc service, so it must not
+// block
+type BroadcastListener interface {
+	OnBlock(block *types.Block)
+	OnTxs(txs []*types.Tx)
 }
 
 //var _ component.IComponent = (*RPCComponent)(nil)
@@ -48,6 +58,7 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor) *RPC {
 	actualServer := &AergoRPCService{
 		msgHelper:   message.GetHelper(),
 		blockstream: []types.AergoRPCService_ListBlockStreamServer{},
+		eventstream: map[*eventStream]struct{}{},
 	}
 
 	tracer := opentracing.GlobalTracer()
@@ -90,6 +101,18 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor) *RPC {
 	return rpcsvc
 }
 
+// ActualServer returns the implementation of AergoRPCService, which is
+// shared with other api gateways
+func (ns *RPC) ActualServer() *AergoRPCService {
+	return ns.actualServer
+}
+
+// AddListener adds the listener of broadcasts. it must be called before the
+// service starts
+func (ns *RPC) AddListener(listener BroadcastListener) {
+	ns.listeners = append(ns.listeners, listener)
+}
+
 func (ns *RPC) SetHub(hub *component.ComponentHub) {
 	ns.actualServer.hub = hub
 	ns.BaseComponent.SetHub(hub)
@@ -119,6 +142,15 @@ func (ns *RPC) Receive(context actor.Context) {
 	case *types.Block:
 		server := ns.actualServer
 		server.BroadcastToListBlockStream(msg)
+		for _, l := range ns.listeners {
+			l.OnBlock(msg)
+		}
+	case *message.NotifyNewTransactions:
+		for _, l := range ns.listeners {
+			l.OnTxs(msg.Txs)
+		}
+	case *message.NotifyBlockReceipts:
+		ns.actualServer.BroadcastToEventStream(msg)
 	case *actor.Started:
 	case *actor.Stopping:
 	case *actor.Stopped:
@@ -14,6 +14,7 @@ type BlockInfo struct {
 type BlockState struct {
 	StateDB
 	BpReward []byte //final bp reward, increment when tx executes
+	bpID     []byte
 	receipts types.Receipts
 	CodeMap  map[types.AccountID][]byte
 }
@@ -42,6 +43,34 @@ func NewBlockState(states *StateDB) *BlockState {
 	}
 }
 
+// SetBlockNo sets the number of the block whose state is committed by bs.
+// It is used to journal the trie nodes for state pruning, and to find the
+// chain parameters effective for the block.
+func (bs *BlockState) SetBlockNo(blockNo types.BlockNo) {
+	bs.blockNo = &blockNo
+}
+
+// BlockNo returns the number of the block whose state is committed by bs, or
+// 0 if it isn't set.
+func (bs *BlockState) BlockNo() types.BlockNo {
+	if bs.blockNo == nil {
+		return 0
+	}
+	return *bs.blockNo
+}
+
+// SetBlockProducer sets the ID of the BP which produces the block. The block
+// reward is shared with the voters of the BP.
+func (bs *BlockState) SetBlockProducer(bpID []byte) {
+	bs.bpID = bpID
+}
+
+// BlockProducer returns the ID of the BP which produces the block, or nil if
+// the block isn't signed.
+func (bs *BlockState) BlockProducer() []byte {
+	return bs.bpID
+}
+
 func (bs *BlockState) AddReceipt(r *types.Receipt) {
 	bs.receipts = append(bs.receipts, r)
 }
@@ -17,6 +17,7 @@ type ChainStateDB struct {
 	states   *StateDB
 	store    db.DB
 	testmode bool
+	pruner   *statePruner
 }
 
 // NewChainStateDB creates instance of ChainStateDB
@@ -32,6 +33,7 @@ func (sdb *ChainStateDB) Clone() *ChainStateDB {
 	newSdb := &ChainStateDB{
 		store:  sdb.store,
 		states: sdb.GetStateDB().Clone(),
+		pruner: sdb.pruner,
 	}
 	return newSdb
 }
@@ -60,6 +62,54 @@ func (sdb *ChainStateDB) Init(dbType string, dataDir string, bestBlock *types.Bl
 	return nil
 }
 
+// SetPruning enables the state pruning which keeps only the state tries of
+// the last keep blocks (and the blocks after the LIB). It is disabled if keep
+// is 0. Since the trie nodes committed without pruning aren't reference
+// counted, pruning can be enabled only on an empty state db.
+func (sdb *ChainStateDB) SetPruning(keep int) {
+	sdb.Lock()
+	defer sdb.Unlock()
+
+	enabled := len(sdb.store.Get([]byte(pruneName))) != 0
+	if keep <= 0 {
+		if enabled {
+			sdb.store.Delete([]byte(pruneName))
+			logger.Warn().Msg("state pruning is disabled. it can't be enabled again on this state db")
+		}
+		return
+	}
+	if !enabled {
+		if len(sdb.states.GetRoot()) != 0 {
+			logger.Warn().Msg("state pruning is available only on an empty state db. disabled")
+			return
+		}
+		sdb.store.Set([]byte(pruneName), []byte{1})
+	}
+	sdb.pruner = newStatePruner(sdb.store, uint64(keep))
+
+	logger.Info().Int("keep", keep).Msg("state pruning enabled")
+}
+
+// IsPruning reports whether the state pruning is enabled.
+func (sdb *ChainStateDB) IsPruning() bool {
+	return sdb.pruner != nil
+}
+
+// Prune deletes the trie nodes reachable only from the states older than both
+// the last blocks and the LIB (if hasLib).
+func (sdb *ChainStateDB) Prune(bestNo types.BlockNo, libNo types.BlockNo, hasLib bool) error {
+	if sdb.pruner == nil {
+		return nil
+	}
+	return sdb.pruner.prune(bestNo, libNo, hasLib)
+}
+
+// IsStatePruned reports whether the state of the block was deleted by the
+// state pruning. It may be enabled before, even if it is disabled now.
+func (sdb *ChainStateDB) IsStatePruned(blockNo types.BlockNo) bool {
+	return blockNo < loadPrunedNo(sdb.store)
+}
+
 // Close saves latest block information of the chain
 func (sdb *ChainStateDB) Close() error {
 	sdb.Lock()
@@ -79,7 +129,9 @@ func (sdb *ChainStateDB) GetStateDB() *StateDB {
 
 // OpenNewStateDB returns new instance of statedb given state root hash
 func (sdb *ChainStateDB) OpenNewStateDB(root []byte) *StateDB {
-	return NewStateDB(&sdb.store, root, sdb.testmode)
+	states := NewStateDB(&sdb.store, root, sdb.testmode)
+	states.setPruner(sdb.pruner)
+	return states
 }
 
 func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB, []string) error) error {
@@ -88,6 +140,12 @@ func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB
 
 	// create state of genesis block
 	gbState := sdb.NewBlockState(stateDB.GetRoot())
+	gbState.SetBlockNo(block.BlockNo())
+	if gbState.journal != nil {
+		// the contract storages updated by bpInit are committed along with
+		// the genesis block state
+		stateDB.journal = gbState.journal
+	}
 
 	if len(genesis.BPs) > 0 && bpInit != nil {
 		// To avoid cyclic dedendency, BP initilization is called via function
@@ -21,6 +21,9 @@ func (states *StateDB) OpenContractState(aid types.AccountID, st *types.State) (
 	if storage == nil {
 		root := common.Compactz(st.StorageRoot)
 		storage = newBufferedStorage(root, *states.store)
+		if states.journal != nil {
+			storage.trie.SetJournal(states.journal)
+		}
 	}
 	res := &ContractState{
 		State:   st,
@@ -0,0 +1,251 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+)
+
+const (
+	pruneName = stateName + ".prune"
+	// prunedNo is the key of the last block whose journal is pruned
+	prunedNo = pruneName + ".last"
+	// pruneRefPrefix is the key prefix of the reference count of trie nodes
+	// and state values
+	pruneRefPrefix = pruneName + ".r"
+	// pruneJournalPrefix is the key prefix of the journal of blocks
+	pruneJournalPrefix = pruneName + ".j"
+)
+
+// pruneJournal is the trie nodes created and obsoleted by a block.
+type pruneJournal struct {
+	Created   [][]byte
+	Obsoleted [][]byte
+}
+
+// statePruner deletes the trie nodes which are not reachable from the
+// retained state roots.
+//
+// Every trie node has a reference count, which is increased when the node is
+// committed and decreased when a block replacing it leaves the retained
+// window. The journal of a block is kept until then so that the block can be
+// replaced by a reorganization: the nodes created by the replaced block are
+// released and its obsoleted nodes are ignored.
+//
+// The state values, which are stored by their hashes, are reference counted
+// by the trie nodes having the leaves of them. A value gets a reference when
+// a node having its leaf is first counted, and loses it when the node is
+// deleted.
+type statePruner struct {
+	lock   sync.Mutex
+	store  db.DB
+	keep   uint64
+	pruned types.BlockNo
+}
+
+func newStatePruner(store db.DB, keep uint64) *statePruner {
+	return &statePruner{
+		store:  store,
+		keep:   keep,
+		pruned: loadPrunedNo(store),
+	}
+}
+
+// loadPrunedNo returns the number of the last block whose journal is pruned.
+// The states of the blocks before it are no longer available.
+func loadPrunedNo(store db.DB) types.BlockNo {
+	if raw := store.Get([]byte(prunedNo)); len(raw) == 8 {
+		return types.BlockNo(binary.BigEndian.Uint64(raw))
+	}
+	return 0
+}
+
+func pruneRefKey(node []byte) []byte {
+	return append([]byte(pruneRefPrefix), node...)
+}
+
+func pruneJournalKey(blockNo types.BlockNo) []byte {
+	key := make([]byte, len(pruneJournalPrefix)+8)
+	copy(key, pruneJournalPrefix)
+	binary.BigEndian.PutUint64(key[len(pruneJournalPrefix):], blockNo)
+	return key
+}
+
+func (p *statePruner) loadJournal(blockNo types.BlockNo) (*pruneJournal, error) {
+	raw := p.store.Get(pruneJournalKey(blockNo))
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	j := &pruneJournal{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// record stages the reference counts of the nodes committed by a block
+// and its journal. If the state is committed without a block number, only
+// the reference counts are updated: the nodes obsoleted by the commit are
+// never deleted.
+func (p *statePruner) record(dbtx *db.Transaction, blockNo *types.BlockNo, journal *trie.NodeJournal) error {
+	deltas := make(map[string]int64)
+	values := make(map[string][]byte)
+
+	if blockNo != nil {
+		// the block replaces a block of the same number
+		// (reorganization): release the nodes it created.
+		old, err := p.loadJournal(*blockNo)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			for _, node := range old.Created {
+				deltas[string(node)]--
+			}
+		}
+	}
+
+	j := &pruneJournal{Obsoleted: journal.Obsoleted}
+	for _, node := range journal.Created {
+		deltas[string(node.Key)]++
+		values[string(node.Key)] = node.Value
+		j.Created = append(j.Created, node.Key)
+	}
+	p.apply(dbtx, deltas, values)
+
+	if blockNo != nil {
+		buf := &bytes.Buffer{}
+		if err := gob.NewEncoder(buf).Encode(j); err != nil {
+			return err
+		}
+		(*dbtx).Set(pruneJournalKey(*blockNo), buf.Bytes())
+	}
+	return nil
+}
+
+// apply stages the reference count changes. Nodes no longer referenced are
+// deleted, and so are the state values of their leaves.
+func (p *statePruner) apply(dbtx *db.Transaction, deltas map[string]int64, values map[string][]byte) {
+	leaves := make(map[string]int64)
+	for node, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		refKey := pruneRefKey([]byte(node))
+		raw := p.store.Get(refKey)
+		if len(raw) == 0 {
+			if delta < 0 {
+				// not counted (committed before pruning was enabled)
+				continue
+			}
+			// the node may have been deleted after it was staged by
+			// another transaction: write it again
+			if value, ok := values[node]; ok {
+				(*dbtx).Set([]byte(node), value)
+				for _, leaf := range trie.LeafValues(value) {
+					leaves[string(leaf)]++
+				}
+			}
+		}
+		var count int64
+		if len(raw) == 8 {
+			count = int64(binary.BigEndian.Uint64(raw))
+		}
+		count += delta
+		if count <= 0 {
+			value, ok := values[node]
+			if !ok {
+				value = p.store.Get([]byte(node))
+			}
+			for _, leaf := range trie.LeafValues(value) {
+				leaves[string(leaf)]--
+			}
+			(*dbtx).Delete([]byte(node))
+			(*dbtx).Delete(refKey)
+			continue
+		}
+		setRefCount(dbtx, refKey, count)
+	}
+
+	for leaf, delta := range leaves {
+		if delta == 0 {
+			continue
+		}
+		refKey := pruneRefKey([]byte(leaf))
+		raw := p.store.Get(refKey)
+		if len(raw) == 0 && delta < 0 {
+			// not counted (stored before pruning was enabled)
+			continue
+		}
+		var count int64
+		if len(raw) == 8 {
+			count = int64(binary.BigEndian.Uint64(raw))
+		}
+		count += delta
+		if count <= 0 {
+			(*dbtx).Delete([]byte(leaf))
+			(*dbtx).Delete(refKey)
+			continue
+		}
+		setRefCount(dbtx, refKey, count)
+	}
+}
+
+func setRefCount(dbtx *db.Transaction, refKey []byte, count int64) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(count))
+	(*dbtx).Set(refKey, buf)
+}
+
+// prune releases the nodes obsoleted by the blocks up to the first retained
+// block, which is the oldest of the last keep blocks and the LIB.
+func (p *statePruner) prune(bestNo types.BlockNo, libNo types.BlockNo, hasLib bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if bestNo < p.keep {
+		return nil
+	}
+	keepFrom := bestNo - p.keep + 1
+	if hasLib && libNo < keepFrom {
+		keepFrom = libNo
+	}
+
+	from := p.pruned
+	for no := p.pruned + 1; no <= keepFrom; no++ {
+		j, err := p.loadJournal(no)
+		if err != nil {
+			return err
+		}
+
+		dbtx := p.store.NewTx()
+		if j != nil {
+			deltas := make(map[string]int64)
+			for _, node := range j.Obsoleted {
+				deltas[string(node)]--
+			}
+			p.apply(&dbtx, deltas, nil)
+			dbtx.Delete(pruneJournalKey(no))
+		}
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, no)
+		dbtx.Set([]byte(prunedNo), buf)
+		dbtx.Commit()
+
+		p.pruned = no
+	}
+	if p.pruned != from {
+		logger.Debug().Uint64("best", bestNo).Uint64("pruned", p.pruned).Msg("state pruned")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package state
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatePruning(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+
+	sdb.SetPruning(2)
+	assert.True(t, sdb.IsPruning())
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis(), nil))
+
+	applyBlock := func(no types.BlockNo, st *types.State) []byte {
+		bs := sdb.NewBlockState(sdb.GetRoot())
+		bs.SetBlockNo(no)
+		assert.NoError(t, bs.PutState(testAccount, st))
+		assert.NoError(t, sdb.Apply(bs))
+		return sdb.GetRoot()
+	}
+	checkState := func(root []byte, expected *types.State) {
+		st, err := sdb.OpenNewStateDB(root).GetState(testAccount)
+		if expected == nil {
+			assert.Error(t, err, "state must be pruned")
+			return
+		}
+		assert.NoError(t, err)
+		assert.True(t, stateEquals(expected, st))
+	}
+
+	var roots [][]byte
+	for i := range testStates {
+		no := types.BlockNo(i + 1)
+		roots = append(roots, applyBlock(no, &testStates[i]))
+		assert.NoError(t, sdb.Prune(no, 0, false))
+	}
+	// only the states of the last 2 blocks are kept
+	for i, root := range roots {
+		if i < len(roots)-2 {
+			checkState(root, nil)
+		} else {
+			checkState(root, &testStates[i])
+		}
+	}
+	last := types.BlockNo(len(roots))
+	assert.True(t, sdb.IsStatePruned(last-2))
+	assert.False(t, sdb.IsStatePruned(last-1))
+
+	// reorganization: block 5 is replaced
+	assert.NoError(t, sdb.Rollback(roots[3]))
+	newRoot := applyBlock(5, &testSecondStates[0])
+	assert.NoError(t, sdb.Prune(5, 0, false))
+	checkState(roots[3], &testStates[3])
+	checkState(roots[4], nil)
+	checkState(newRoot, &testSecondStates[0])
+
+	// the states after the LIB are kept
+	roots = [][]byte{newRoot}
+	for i := 1; i < len(testSecondStates); i++ {
+		no := types.BlockNo(5 + i)
+		roots = append(roots, applyBlock(no, &testSecondStates[i]))
+		assert.NoError(t, sdb.Prune(no, 5, true))
+	}
+	for i, root := range roots {
+		checkState(root, &testSecondStates[i])
+	}
+}
+
+func TestStatePruningBounded(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+
+	sdb.SetPruning(2)
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis(), nil))
+
+	countKeys := func() int {
+		n := 0
+		for it := sdb.store.Iterator(nil, bytes.Repeat([]byte{0xff}, 64)); it.Valid(); it.Next() {
+			n++
+		}
+		return n
+	}
+
+	// the state values replaced are deleted along with the trie nodes
+	var counted int
+	for no := types.BlockNo(1); no <= 50; no++ {
+		bs := sdb.NewBlockState(sdb.GetRoot())
+		bs.SetBlockNo(no)
+		assert.NoError(t, bs.PutState(testAccount, &types.State{Nonce: no, Balance: []byte{byte(no)}}))
+		assert.NoError(t, sdb.Apply(bs))
+		assert.NoError(t, sdb.Prune(no, 0, false))
+		if no == 10 {
+			counted = countKeys()
+		}
+	}
+	assert.Equal(t, counted, countKeys())
+}
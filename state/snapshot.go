@@ -0,0 +1,228 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// snapshotBatchSize is the number of trie leaves updated at once while a
+// snapshot is imported.
+const snapshotBatchSize = 10000
+
+var (
+	errSnapshotOrder = errors.New("snapshot entries are not in the order of keys")
+	errSnapshotEntry = errors.New("snapshot entry is out of an account")
+)
+
+// SnapshotWriter receives the entries of a state snapshot. Account states are
+// given in the order of their ids. Each account is followed by its contract
+// code and its storage entries in the order of their keys.
+type SnapshotWriter interface {
+	Account(id []byte, state []byte) error
+	Code(code []byte) error
+	Storage(key []byte, value []byte) error
+}
+
+// ExportSnapshot writes the state at root, including the code and the
+// storage of contracts, to w.
+func (sdb *ChainStateDB) ExportSnapshot(root []byte, w SnapshotWriter) error {
+	store := sdb.store
+	return trie.NewTrie(root, common.Hasher, store).Walk(root, func(id, hash []byte) error {
+		raw := store.Get(hash)
+		if err := w.Account(id, raw); err != nil {
+			return err
+		}
+		st := &types.State{}
+		if err := proto.Unmarshal(raw, st); err != nil {
+			return err
+		}
+		if len(st.CodeHash) != 0 {
+			if err := w.Code(store.Get(st.CodeHash)); err != nil {
+				return err
+			}
+		}
+		storageRoot := common.Compactz(st.StorageRoot)
+		if len(storageRoot) == 0 {
+			return nil
+		}
+		return trie.NewTrie(storageRoot, common.Hasher, store).Walk(storageRoot, func(key, hash []byte) error {
+			return w.Storage(key, store.Get(hash))
+		})
+	})
+}
+
+// snapshotTrie builds a trie from the ordered leaves of a snapshot.
+type snapshotTrie struct {
+	trie   *trie.Trie
+	last   []byte
+	keys   [][]byte
+	hashes [][]byte
+	values [][]byte
+}
+
+func newSnapshotTrie(store db.DB) *snapshotTrie {
+	return &snapshotTrie{trie: trie.NewTrie(nil, common.Hasher, store)}
+}
+
+func (t *snapshotTrie) put(store db.DB, key, value []byte) error {
+	if len(key) != trie.HashLength {
+		return fmt.Errorf("invalid key of snapshot entry: %s", enc.ToString(key))
+	}
+	if t.last != nil && bytes.Compare(t.last, key) >= 0 {
+		return errSnapshotOrder
+	}
+	t.last = key
+	t.keys = append(t.keys, key)
+	t.hashes = append(t.hashes, common.Hasher(value))
+	t.values = append(t.values, value)
+	if len(t.keys) >= snapshotBatchSize {
+		return t.flush(store)
+	}
+	return nil
+}
+
+// flush updates the trie with the pending leaves and writes them with the
+// trie nodes.
+func (t *snapshotTrie) flush(store db.DB) error {
+	if len(t.keys) == 0 {
+		return nil
+	}
+	if _, err := t.trie.Update(t.keys, t.hashes); err != nil {
+		return err
+	}
+	dbtx := store.NewTx()
+	for i, hash := range t.hashes {
+		dbtx.Set(hash, t.values[i])
+	}
+	t.trie.StageUpdates(&dbtx)
+	dbtx.Commit()
+
+	t.keys, t.hashes, t.values = nil, nil, nil
+	return nil
+}
+
+// SnapshotImporter rebuilds the state from the entries of a snapshot. The
+// entries are verified against the state and storage roots they make.
+type SnapshotImporter struct {
+	sdb      *ChainStateDB
+	store    db.DB
+	accounts *snapshotTrie
+	storage  *snapshotTrie
+	id       []byte
+	state    *types.State
+	code     bool
+}
+
+// NewSnapshotImporter returns an importer of a snapshot. The state db must be
+// empty.
+func (sdb *ChainStateDB) NewSnapshotImporter() (*SnapshotImporter, error) {
+	if len(sdb.GetRoot()) != 0 {
+		return nil, errors.New("snapshot can be imported only to an empty state db")
+	}
+	return &SnapshotImporter{
+		sdb:      sdb,
+		store:    sdb.store,
+		accounts: newSnapshotTrie(sdb.store),
+	}, nil
+}
+
+// Account implements SnapshotWriter.
+func (imp *SnapshotImporter) Account(id []byte, state []byte) error {
+	if err := imp.finishAccount(); err != nil {
+		return err
+	}
+	st := &types.State{}
+	if err := proto.Unmarshal(state, st); err != nil {
+		return err
+	}
+	if err := imp.accounts.put(imp.store, id, state); err != nil {
+		return err
+	}
+	imp.id, imp.state, imp.code = id, st, false
+	if len(common.Compactz(st.StorageRoot)) != 0 {
+		imp.storage = newSnapshotTrie(imp.store)
+	}
+	return nil
+}
+
+// Code implements SnapshotWriter.
+func (imp *SnapshotImporter) Code(code []byte) error {
+	if imp.state == nil || len(imp.state.CodeHash) == 0 || imp.code {
+		return errSnapshotEntry
+	}
+	if !bytes.Equal(common.Hasher(code), imp.state.CodeHash) {
+		return fmt.Errorf("code of account %s doesn't match its hash", enc.ToString(imp.id))
+	}
+	imp.store.Set(imp.state.CodeHash, code)
+	imp.code = true
+	return nil
+}
+
+// Storage implements SnapshotWriter.
+func (imp *SnapshotImporter) Storage(key []byte, value []byte) error {
+	if imp.storage == nil {
+		return errSnapshotEntry
+	}
+	return imp.storage.put(imp.store, key, value)
+}
+
+// finishAccount verifies the code and the storage root of the last account.
+func (imp *SnapshotImporter) finishAccount() error {
+	if imp.state == nil {
+		return nil
+	}
+	if len(imp.state.CodeHash) != 0 && !imp.code {
+		return fmt.Errorf("code of account %s is missing", enc.ToString(imp.id))
+	}
+	if imp.storage != nil {
+		if err := imp.storage.flush(imp.store); err != nil {
+			return err
+		}
+		if !bytes.Equal(imp.storage.trie.Root, imp.state.StorageRoot) {
+			return fmt.Errorf("storage root of account %s doesn't match", enc.ToString(imp.id))
+		}
+	}
+	imp.id, imp.state, imp.storage = nil, nil, nil
+	return nil
+}
+
+// Finish verifies the imported state against the state root of the block of
+// the snapshot and makes it the latest state. The states of the blocks before
+// it are not available, as if they were pruned.
+func (imp *SnapshotImporter) Finish(blockNo types.BlockNo, root []byte) error {
+	if err := imp.finishAccount(); err != nil {
+		return err
+	}
+	if err := imp.accounts.flush(imp.store); err != nil {
+		return err
+	}
+	if !bytes.Equal(imp.accounts.trie.Root, root) {
+		return fmt.Errorf("state root %s doesn't match the block (%s)",
+			enc.ToString(imp.accounts.trie.Root), enc.ToString(root))
+	}
+	return imp.sdb.setSnapshotRoot(blockNo, root)
+}
+
+// setSnapshotRoot makes the state at root, which is of the block blockNo, the
+// latest state without the states of the blocks before it.
+func (sdb *ChainStateDB) setSnapshotRoot(blockNo types.BlockNo, root []byte) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, blockNo)
+	sdb.store.Set([]byte(prunedNo), buf)
+
+	return sdb.states.SetRoot(root)
+}
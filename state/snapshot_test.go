@@ -0,0 +1,69 @@
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSnapshot(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	testAddress := []byte("test_contract")
+	testCode := []byte("test_code")
+	testKey := []byte("test_key")
+	testBytes := []byte("test_bytes")
+
+	// make a state with an account and a contract
+	assert.NoError(t, stateDB.PutState(testAccount, &testStates[0]))
+	contractState, err := stateDB.OpenContractStateAccount(types.ToAccountID(testAddress))
+	assert.NoError(t, err, "could not open contract state")
+	assert.NoError(t, contractState.SetCode(testCode))
+	assert.NoError(t, contractState.SetData(testKey, testBytes))
+	assert.NoError(t, stateDB.StageContractState(contractState))
+	assert.NoError(t, stateDB.Update())
+	assert.NoError(t, stateDB.Commit())
+	root := stateDB.GetRoot()
+
+	importSnapshot := func(root []byte) (*ChainStateDB, error) {
+		sdb := NewChainStateDB()
+		_ = sdb.Init(string(db.BadgerImpl), "test_snapshot", nil, false)
+		imp, err := sdb.NewSnapshotImporter()
+		assert.NoError(t, err)
+		assert.NoError(t, chainStateDB.ExportSnapshot(stateDB.GetRoot(), imp))
+		return sdb, imp.Finish(5, root)
+	}
+
+	// the snapshot doesn't match the block
+	sdb, err := importSnapshot(testRoot)
+	assert.Error(t, err)
+	_ = sdb.Close()
+	_ = os.RemoveAll("test_snapshot")
+
+	sdb, err = importSnapshot(root)
+	assert.NoError(t, err)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test_snapshot")
+	}()
+	assert.Equal(t, root, sdb.GetRoot())
+	assert.True(t, sdb.IsStatePruned(4))
+	assert.False(t, sdb.IsStatePruned(5))
+
+	states := sdb.GetStateDB()
+	st, err := states.GetState(testAccount)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[0], st))
+
+	contractState, err = states.OpenContractStateAccount(types.ToAccountID(testAddress))
+	assert.NoError(t, err, "could not open contract state")
+	res, err := contractState.GetCode()
+	assert.NoError(t, err)
+	assert.Equal(t, testCode, res)
+	res, err = contractState.GetData(testKey)
+	assert.NoError(t, err)
+	assert.Equal(t, testBytes, res)
+}
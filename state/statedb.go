@@ -59,6 +59,9 @@ type StateDB struct {
 	store    *db.DB
 	batchtx  db.Transaction
 	testmode bool
+	pruner   *statePruner
+	journal  *trie.NodeJournal
+	blockNo  *types.BlockNo
 }
 
 // NewStateDB craete StateDB instance
@@ -73,6 +76,17 @@ func NewStateDB(dbstore *db.DB, root []byte, test bool) *StateDB {
 	return &sdb
 }
 
+// setPruner attaches a node journal to the tries of states so that the nodes
+// committed are reference counted by the pruner.
+func (states *StateDB) setPruner(pruner *statePruner) {
+	if pruner == nil {
+		return
+	}
+	states.pruner = pruner
+	states.journal = trie.NewNodeJournal()
+	states.trie.SetJournal(states.journal)
+}
+
 // Clone returns a new StateDB which has same store and Root
 func (states *StateDB) Clone() *StateDB {
 	states.lock.RLock()
@@ -366,6 +380,28 @@ func (states *StateDB) GetVarAndProof(id []byte, root []byte, compressed bool) (
 
 }
 
+// GetVarsAndProofs gets the values of the variables at the keys of the
+// contract trie root with their proofs. Every key is absent from an empty
+// trie.
+func (states *StateDB) GetVarsAndProofs(keys [][]byte, root []byte, compressed bool) ([]*types.ContractVarProof, error) {
+	var proofs []*types.ContractVarProof
+	for _, key := range keys {
+		if len(key) != trie.HashLength {
+			return nil, fmt.Errorf("invalid key of contract trie: %s", enc.ToString(key))
+		}
+		if len(root) == 0 {
+			proofs = append(proofs, &types.ContractVarProof{})
+			continue
+		}
+		vp, err := states.GetVarAndProof(key, root, compressed)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, vp)
+	}
+	return proofs, nil
+}
+
 // GetStateAndProof gets the state and associated proof of an account
 // in the given trie root. If the account doesnt exist, a proof of
 // non existence is returned.
@@ -473,6 +509,12 @@ func (states *StateDB) Commit() error {
 	states.lock.Lock()
 	defer states.lock.Unlock()
 
+	if states.pruner != nil {
+		// reference counts must not be changed until dbtx is committed
+		states.pruner.lock.Lock()
+		defer states.pruner.lock.Unlock()
+	}
+
 	dbtx := (*states.store).NewTx()
 	if err := states.stage(&dbtx); err != nil {
 		dbtx.Discard()
@@ -488,6 +530,12 @@ func (states *StateDB) stage(dbtx *db.Transaction) error {
 	if err := states.buffer.stage(dbtx); err != nil {
 		return err
 	}
+	if states.pruner != nil {
+		if err := states.pruner.record(dbtx, states.blockNo, states.journal); err != nil {
+			return err
+		}
+		states.journal.Reset()
+	}
 	// reset buffer
 	if err := states.buffer.reset(); err != nil {
 		return err
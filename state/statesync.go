@@ -0,0 +1,308 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/pkg/proof"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	// ErrStateSyncSQL is returned when the state has a contract using the sql
+	// database, which can't be synced from peers.
+	ErrStateSyncSQL = errors.New("state sync doesn't support contracts using the sql database")
+
+	errStateSyncData    = errors.New("state data is not requested")
+	errStateSyncMissing = errors.New("state data is not available from the peer")
+)
+
+// syncItem is the kind of data fetched by its hash.
+type syncItem int
+
+const (
+	syncNode syncItem = iota
+	syncState
+	syncCode
+)
+
+// syncEntry is the data to fetch by its hash.
+type syncEntry struct {
+	item   syncItem
+	height int
+}
+
+// StateSync rebuilds the state of a block from the data downloaded from
+// peers. The nodes of the account trie, the account states and the contract
+// codes are fetched by their hashes, and the storage of each contract is
+// fetched in chunks of ordered leaves. Everything is verified against the
+// state root before it is stored.
+type StateSync struct {
+	sdb          *ChainStateDB
+	store        db.DB
+	root         []byte
+	trie         *trie.Trie
+	pending      map[types.HashID]syncEntry
+	storageRoots [][]byte
+	storage      *snapshotTrie
+	storageRoot  []byte
+	storageNext  []byte
+}
+
+// NewStateSync returns a sync of the state at root. The trie nodes stored
+// without pruning aren't reference counted, so it is not available when the
+// state pruning is enabled.
+func (sdb *ChainStateDB) NewStateSync(root []byte) (*StateSync, error) {
+	if sdb.IsPruning() {
+		return nil, errors.New("state sync is not available with the state pruning")
+	}
+	if len(root) != trie.HashLength {
+		return nil, fmt.Errorf("invalid state root %s", enc.ToString(root))
+	}
+	ss := &StateSync{
+		sdb:     sdb,
+		store:   sdb.store,
+		root:    root,
+		trie:    trie.NewTrie(nil, common.Hasher, sdb.store),
+		pending: make(map[types.HashID]syncEntry),
+	}
+	ss.pending[types.ToHashID(root)] = syncEntry{item: syncNode, height: ss.trie.TrieHeight}
+	return ss, nil
+}
+
+// Root returns the state root to sync.
+func (ss *StateSync) Root() []byte {
+	return ss.root
+}
+
+// Missing returns the hashes of at most max trie nodes, account states or
+// contract codes to fetch.
+func (ss *StateSync) Missing(max int) [][]byte {
+	var hashes [][]byte
+	for id := range ss.pending {
+		if len(hashes) >= max {
+			break
+		}
+		hashes = append(hashes, id.Bytes())
+	}
+	return hashes
+}
+
+// AddData verifies and stores the data fetched for the hashes. The data may
+// be fewer than the hashes, then the rest of them are still missing.
+func (ss *StateSync) AddData(hashes, data [][]byte) error {
+	if len(data) == 0 || len(data) > len(hashes) {
+		return errStateSyncMissing
+	}
+	dbtx := ss.store.NewTx()
+	defer dbtx.Discard()
+	for i, value := range data {
+		hash := hashes[i]
+		id := types.ToHashID(hash)
+		entry, ok := ss.pending[id]
+		if !ok {
+			return errStateSyncData
+		}
+		if len(value) == 0 {
+			return errStateSyncMissing
+		}
+		if entry.item == syncNode {
+			if err := ss.addNode(hash, value, entry.height); err != nil {
+				return err
+			}
+		} else {
+			if !bytes.Equal(common.Hasher(value), hash) {
+				return fmt.Errorf("state data doesn't match the hash %s", enc.ToString(hash))
+			}
+			if entry.item == syncState {
+				if err := ss.addState(value); err != nil {
+					return err
+				}
+			}
+		}
+		dbtx.Set(hash, value)
+		delete(ss.pending, id)
+	}
+	dbtx.Commit()
+	return nil
+}
+
+func (ss *StateSync) addNode(hash, node []byte, height int) error {
+	children, _, values, err := ss.trie.VerifyNode(hash, node, height)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		ss.pending[types.ToHashID(child)] = syncEntry{item: syncNode, height: height - 4}
+	}
+	for _, value := range values {
+		ss.pending[types.ToHashID(value)] = syncEntry{item: syncState}
+	}
+	return nil
+}
+
+func (ss *StateSync) addState(value []byte) error {
+	st := &types.State{}
+	if err := proto.Unmarshal(value, st); err != nil {
+		return err
+	}
+	if st.SqlRecoveryPoint > 0 {
+		return ErrStateSyncSQL
+	}
+	if len(st.CodeHash) != 0 {
+		ss.pending[types.ToHashID(st.CodeHash)] = syncEntry{item: syncCode}
+	}
+	if storageRoot := common.Compactz(st.StorageRoot); len(storageRoot) != 0 {
+		ss.storageRoots = append(ss.storageRoots, storageRoot)
+	}
+	return nil
+}
+
+// StorageRequest returns the root of the storage trie to fetch and the key
+// where the next chunk of it starts. root is nil if no storage is left.
+func (ss *StateSync) StorageRequest() (root []byte, start []byte) {
+	if ss.storage == nil {
+		if len(ss.storageRoots) == 0 {
+			return nil, nil
+		}
+		ss.storageRoot, ss.storageRoots = ss.storageRoots[0], ss.storageRoots[1:]
+		ss.storage = newSnapshotTrie(ss.store)
+		ss.storageNext = nil
+	}
+	return ss.storageRoot, ss.storageNext
+}
+
+// AddStorage stores a chunk of the storage trie requested by StorageRequest.
+// Each leaf is verified by its proof before it is stored, and the storage
+// root is verified when the last chunk is added, so that no leaf is missing.
+func (ss *StateSync) AddStorage(keys, values [][]byte, proofs []*types.ContractVarProof, hasNext bool) error {
+	if ss.storage == nil {
+		return errStateSyncData
+	}
+	if len(keys) != len(values) || len(keys) != len(proofs) || (len(keys) == 0 && hasNext) {
+		return errStateSyncMissing
+	}
+	for i, key := range keys {
+		if err := proof.VerifyStorageLeaf(ss.storageRoot, key, values[i], proofs[i]); err != nil {
+			return fmt.Errorf("storage leaf %s doesn't match the root %s: %s", enc.ToString(key), enc.ToString(ss.storageRoot), err.Error())
+		}
+		if err := ss.storage.put(ss.store, key, values[i]); err != nil {
+			return err
+		}
+	}
+	if hasNext {
+		ss.storageNext = nextKey(keys[len(keys)-1])
+		if ss.storageNext != nil {
+			return nil
+		}
+	}
+	if err := ss.storage.flush(ss.store); err != nil {
+		return err
+	}
+	if !bytes.Equal(ss.storage.trie.Root, ss.storageRoot) {
+		return fmt.Errorf("storage doesn't match the root %s", enc.ToString(ss.storageRoot))
+	}
+	ss.storage, ss.storageRoot, ss.storageNext = nil, nil, nil
+	return nil
+}
+
+// Done reports whether the whole state is fetched.
+func (ss *StateSync) Done() bool {
+	return len(ss.pending) == 0 && ss.storage == nil && len(ss.storageRoots) == 0
+}
+
+// Finish makes the fetched state, which is of the block blockNo, the latest
+// state. The states of the blocks before it are not available, as if they
+// were pruned.
+func (ss *StateSync) Finish(blockNo types.BlockNo) error {
+	if !ss.Done() {
+		return errors.New("state sync is not done")
+	}
+	return ss.sdb.setSnapshotRoot(blockNo, ss.root)
+}
+
+// GetStateData returns the trie nodes, the account states or the contract
+// codes stored at the hashes for the state at root, as many as they fit in
+// maxSize. The data of an unknown hash is empty.
+func (sdb *ChainStateDB) GetStateData(root []byte, hashes [][]byte, maxSize int) ([][]byte, error) {
+	if len(root) != trie.HashLength || len(sdb.store.Get(root)) == 0 {
+		return nil, fmt.Errorf("state root %s is not available", enc.ToString(root))
+	}
+	var data [][]byte
+	size := 0
+	for _, hash := range hashes {
+		var value []byte
+		// other keys than hashes are not state data
+		if len(hash) == trie.HashLength {
+			value = sdb.store.Get(hash)
+		}
+		if size += len(value); size > maxSize && len(data) > 0 {
+			break
+		}
+		data = append(data, value)
+	}
+	return data, nil
+}
+
+// GetStorageChunk returns the leaves of the storage trie at root from start,
+// with the raw values and the proofs of them, at most n of them and as many
+// as they fit in maxSize.
+func (sdb *ChainStateDB) GetStorageChunk(root, start []byte, n, maxSize int) (keys, values [][]byte, proofs []*types.ContractVarProof, hasNext bool, err error) {
+	if len(root) != trie.HashLength || len(sdb.store.Get(root)) == 0 {
+		return nil, nil, nil, false, fmt.Errorf("storage root %s is not available", enc.ToString(root))
+	}
+	store := sdb.store
+	size := 0
+	errFull := errors.New("storage chunk is full")
+	// the proofs are made by another trie, which is not locked by the walk
+	proofTrie := trie.NewTrie(root, common.Hasher, store)
+	err = trie.NewTrie(root, common.Hasher, store).WalkFrom(root, start, func(key, hash []byte) error {
+		if len(keys) >= n || size >= maxSize {
+			return errFull
+		}
+		bitmap, ap, height, _, proofKey, proofVal, err := proofTrie.MerkleProofCompressedCustomized(key, root)
+		if err != nil {
+			return err
+		}
+		value := store.Get(hash)
+		size += len(key) + len(value) + len(bitmap) + len(proofKey) + len(proofVal) + len(ap)*trie.HashLength
+		keys = append(keys, key)
+		values = append(values, value)
+		proofs = append(proofs, &types.ContractVarProof{
+			Inclusion: true,
+			ProofKey:  proofKey,
+			ProofVal:  proofVal,
+			Bitmap:    bitmap,
+			Height:    uint32(height),
+			AuditPath: ap,
+		})
+		return nil
+	})
+	if err == errFull {
+		return keys, values, proofs, true, nil
+	}
+	return keys, values, proofs, false, err
+}
+
+// nextKey returns the key right after key, or nil if key is the last one.
+func nextKey(key []byte) []byte {
+	next := make([]byte, len(key))
+	copy(next, key)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i]++; next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSync(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	testAddress := []byte("test_contract")
+	testCode := []byte("test_code")
+	testKeys := [][]byte{[]byte("test_key1"), []byte("test_key2"), []byte("test_key3")}
+	testBytes := []byte("test_bytes")
+
+	// make a state with accounts and a contract
+	assert.NoError(t, stateDB.PutState(testAccount, &testStates[0]))
+	assert.NoError(t, stateDB.PutState(types.ToAccountID([]byte("test_address")), &testStates[1]))
+	contractState, err := stateDB.OpenContractStateAccount(types.ToAccountID(testAddress))
+	assert.NoError(t, err, "could not open contract state")
+	assert.NoError(t, contractState.SetCode(testCode))
+	for _, key := range testKeys {
+		assert.NoError(t, contractState.SetData(key, testBytes))
+	}
+	assert.NoError(t, stateDB.StageContractState(contractState))
+	assert.NoError(t, stateDB.Update())
+	assert.NoError(t, stateDB.Commit())
+	root := stateDB.GetRoot()
+
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test_statesync", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test_statesync")
+	}()
+	ss, err := sdb.NewStateSync(root)
+	assert.NoError(t, err)
+
+	// data not matching the hashes
+	hashes := ss.Missing(1)
+	assert.Error(t, ss.AddData(hashes, [][]byte{testBytes}))
+
+	for !ss.Done() {
+		if hashes := ss.Missing(2); len(hashes) > 0 {
+			data, err := chainStateDB.GetStateData(root, hashes, 1<<20)
+			assert.NoError(t, err)
+			assert.NoError(t, ss.AddData(hashes, data))
+			continue
+		}
+		storageRoot, start := ss.StorageRequest()
+		keys, values, proofs, hasNext, err := chainStateDB.GetStorageChunk(storageRoot, start, 2, 1<<20)
+		assert.NoError(t, err)
+		// a leaf not proven by the storage root is rejected as it arrives
+		tampered := append([][]byte{[]byte("tampered")}, values[1:]...)
+		assert.Error(t, ss.AddStorage(keys, tampered, proofs, hasNext))
+		assert.Error(t, ss.AddStorage(keys, values, nil, hasNext))
+		assert.NoError(t, ss.AddStorage(keys, values, proofs, hasNext))
+	}
+	assert.NoError(t, ss.Finish(5))
+	assert.Equal(t, root, sdb.GetRoot())
+	assert.True(t, sdb.IsStatePruned(4))
+
+	states := sdb.GetStateDB()
+	st, err := states.GetState(testAccount)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[0], st))
+
+	contractState, err = states.OpenContractStateAccount(types.ToAccountID(testAddress))
+	assert.NoError(t, err, "could not open contract state")
+	res, err := contractState.GetCode()
+	assert.NoError(t, err)
+	assert.Equal(t, testCode, res)
+	for _, key := range testKeys {
+		res, err = contractState.GetData(key)
+		assert.NoError(t, err)
+		assert.Equal(t, testBytes, res)
+	}
+}
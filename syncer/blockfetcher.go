@@ -42,6 +42,9 @@ type BlockFetcher struct {
 	maxFetchTasks  int
 	maxPendingConn int
 
+	// headerOnly fetches the blocks without body for a light node
+	headerOnly bool
+
 	debug bool
 
 	stat BlockFetcherStat
@@ -511,7 +514,7 @@ func (bf *BlockFetcher) runTask(task *FetchTask, peer *SyncPeer) {
 
 	logger.Debug().Int("peerno", task.syncPeer.No).Int("count", task.count).Uint64("StartNo", task.startNo).Str("start", enc.ToString(task.hashes[0])).Int("runqueue", bf.runningQueue.Len()).Msg("send block fetch request")
 
-	bf.compRequester.TellTo(message.P2PSvc, &message.GetBlockChunks{GetBlockInfos: message.GetBlockInfos{ToWhom: peer.ID, Hashes: task.hashes}, TTL: DfltFetchTimeOut})
+	bf.compRequester.TellTo(message.P2PSvc, &message.GetBlockChunks{GetBlockInfos: message.GetBlockInfos{ToWhom: peer.ID, Hashes: task.hashes}, TTL: DfltFetchTimeOut, HeaderOnly: bf.headerOnly})
 }
 
 //TODO refactoring matchFunc
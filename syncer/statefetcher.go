@@ -0,0 +1,339 @@
+package syncer
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
+	"github.com/pkg/errors"
+)
+
+// StateFetcher fetches the state at the LIB block of the remote peer, so that
+// only the blocks after it are fetched and executed. The LIB block isn't
+// trusted until the headers from the genesis block to it are fetched and
+// verified. The state is requested by the hashes of the trie nodes and in
+// chunks of the contract storages, and it is verified by the chain service.
+type StateFetcher struct {
+	compRequester component.IComponentRequester //for communicate with other service
+
+	ctx     *types.SyncContext
+	genesis *types.Block
+
+	libCh     chan *message.GetSyncLibBlockRsp
+	hashesCh  chan *message.GetHashesRsp
+	headersCh chan *message.GetBlockChunksRsp
+	nodesCh   chan *message.GetSyncTrieNodesRsp
+	storageCh chan *message.GetSyncStorageChunkRsp
+
+	quitCh chan interface{}
+
+	dfltTimeout time.Duration
+
+	cfg *SyncerConfig
+
+	fetchedNodes  int
+	fetchedLeaves int
+
+	isRunning bool
+	waitGroup *sync.WaitGroup
+}
+
+var (
+	ErrStateFetcherQuit    = errors.New("state fetcher quit")
+	ErrStateFetcherTimeout = errors.New("state fetcher timeout")
+	ErrStateFetcherLib     = errors.New("lib block of the peer is not linked to the genesis block")
+)
+
+var (
+	NameStateFetcher = "StateFetcher"
+
+	DfltStorageChunkSize = uint32(1000)
+	DfltStateHeaderCount = uint64(1000)
+)
+
+func newStateFetcher(ctx *types.SyncContext, compRequester component.IComponentRequester, genesis *types.Block, cfg *SyncerConfig) *StateFetcher {
+	sf := &StateFetcher{ctx: ctx, compRequester: compRequester, genesis: genesis, cfg: cfg}
+
+	sf.dfltTimeout = cfg.fetchTimeOut
+	sf.quitCh = make(chan interface{})
+	sf.libCh = make(chan *message.GetSyncLibBlockRsp, 1)
+	sf.hashesCh = make(chan *message.GetHashesRsp, 1)
+	sf.headersCh = make(chan *message.GetBlockChunksRsp, 1)
+	sf.nodesCh = make(chan *message.GetSyncTrieNodesRsp, 1)
+	sf.storageCh = make(chan *message.GetSyncStorageChunkRsp, 1)
+
+	return sf
+}
+
+func (sf *StateFetcher) start() {
+	sf.waitGroup = &sync.WaitGroup{}
+	sf.waitGroup.Add(1)
+	sf.isRunning = true
+
+	run := func() {
+		defer sf.waitGroup.Done()
+
+		logger.Debug().Msg("start to fetch state")
+
+		block, err := sf.fetchState()
+		if err == ErrStateFetcherQuit {
+			logger.Debug().Msg("quit state fetcher")
+			return
+		}
+
+		sf.compRequester.TellTo(message.SyncerSvc, &message.StateFetcherResult{Block: block, Err: err})
+		logger.Debug().Msg("stopped state fetcher successfully")
+	}
+
+	go run()
+}
+
+func (sf *StateFetcher) stop() {
+	if sf == nil {
+		return
+	}
+
+	logger.Info().Msg("state fetcher stop#1")
+
+	if sf.isRunning {
+		close(sf.quitCh)
+		sf.isRunning = false
+	}
+
+	sf.waitGroup.Wait()
+
+	logger.Info().Msg("state fetcher stop#2")
+}
+
+// fetchState returns the block whose state is fetched, or nil if the remote
+// peer has no LIB block to sync the state.
+func (sf *StateFetcher) fetchState() (*types.Block, error) {
+	block, err := sf.getLibBlock()
+	if err != nil || block == nil || block.BlockNo() <= sf.ctx.BestNo || block.BlockNo() >= sf.ctx.TargetNo {
+		return nil, err
+	}
+	if err = sf.verifyLibBlock(block); err != nil {
+		logger.Error().Err(err).Uint64("no", block.BlockNo()).Str("hash", block.ID()).Msg("failed to verify the lib block of the peer")
+		return nil, err
+	}
+	root := block.GetHeader().GetBlocksRootHash()
+
+	logger.Info().Uint64("no", block.BlockNo()).Str("hash", block.ID()).Msg("start to sync the state of the lib block")
+
+	rsp, err := sf.syncState(&message.SyncState{Block: block})
+	for err == nil && !rsp.Done {
+		if len(rsp.Hashes) > 0 {
+			var nodes [][]byte
+			if nodes, err = sf.getTrieNodes(root, rsp.Hashes); err != nil {
+				break
+			}
+			sf.fetchedNodes += len(nodes)
+			rsp, err = sf.syncState(&message.SyncState{Hashes: rsp.Hashes, Data: nodes})
+		} else {
+			var chunk *message.GetSyncStorageChunkRsp
+			if chunk, err = sf.getStorageChunk(rsp.StorageRoot, rsp.StorageStart); err != nil {
+				break
+			}
+			sf.fetchedLeaves += len(chunk.Keys)
+			rsp, err = sf.syncState(&message.SyncState{Keys: chunk.Keys, Values: chunk.Values, Proofs: chunk.Proofs, HasNext: chunk.HasNext})
+		}
+	}
+	if err != nil {
+		logger.Error().Err(err).Int("nodes", sf.fetchedNodes).Int("leaves", sf.fetchedLeaves).Msg("failed to fetch state")
+		return nil, err
+	}
+
+	logger.Info().Uint64("no", block.BlockNo()).Int("nodes", sf.fetchedNodes).Int("leaves", sf.fetchedLeaves).Msg("state of the lib block is synced")
+	return block, nil
+}
+
+func (sf *StateFetcher) syncState(msg *message.SyncState) (*message.SyncStateRsp, error) {
+	result, err := sf.compRequester.RequestToFutureResult(message.ChainSvc, msg, sf.dfltTimeout, "StateFetcher/syncState")
+	if err != nil {
+		return nil, err
+	}
+	rsp := result.(*message.SyncStateRsp)
+	return rsp, rsp.Err
+}
+
+func (sf *StateFetcher) getLibBlock() (*types.Block, error) {
+	sf.compRequester.TellTo(message.P2PSvc, &message.GetSyncLibBlock{ToWhom: sf.ctx.PeerID})
+
+	timer := time.NewTimer(sf.dfltTimeout)
+	select {
+	case result := <-sf.libCh:
+		if result.Err != nil {
+			// the peer may have no lib yet
+			logger.Info().Err(result.Err).Msg("failed to get the lib block of the peer")
+			return nil, nil
+		}
+		return result.Block, nil
+	case <-timer.C:
+		logger.Error().Float64("sec", sf.dfltTimeout.Seconds()).Msg("get lib block response timeout")
+		return nil, ErrStateFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrStateFetcherQuit
+	}
+}
+
+// verifyLibBlock verifies the lib block of the peer by the headers from the
+// genesis block to it. Each header must be signed by its producer and linked
+// to the previous one.
+func (sf *StateFetcher) verifyLibBlock(lib *types.Block) error {
+	if err := verifyHeader(lib); err != nil {
+		return err
+	}
+	prev := &types.BlockInfo{Hash: sf.genesis.BlockHash(), No: sf.genesis.BlockNo()}
+	for prev.No < lib.BlockNo() {
+		count := lib.BlockNo() - prev.No
+		if count > DfltStateHeaderCount {
+			count = DfltStateHeaderCount
+		}
+		hashes, err := sf.getHashes(prev, count)
+		if err != nil {
+			return err
+		}
+		headers, err := sf.getHeaders(hashes)
+		if err != nil {
+			return err
+		}
+		for _, header := range headers {
+			if header.BlockNo() != prev.No+1 || !bytes.Equal(header.GetHeader().GetPrevBlockHash(), prev.Hash) {
+				return ErrStateFetcherLib
+			}
+			if err := verifyHeader(header); err != nil {
+				return err
+			}
+			prev = &types.BlockInfo{Hash: header.BlockHash(), No: header.BlockNo()}
+		}
+	}
+	if !bytes.Equal(prev.Hash, lib.BlockHash()) {
+		return ErrStateFetcherLib
+	}
+	logger.Info().Uint64("no", lib.BlockNo()).Str("hash", lib.ID()).Msg("lib block of the peer is verified by the headers")
+	return nil
+}
+
+// verifyHeader checks the hash of the block given by the peer and the
+// signature of its producer.
+func verifyHeader(block *types.Block) error {
+	if block.GetHeader() == nil {
+		return ErrStateFetcherLib
+	}
+	hash := block.GetHash()
+	block.Hash = nil
+	if !bytes.Equal(block.BlockHash(), hash) {
+		return errors.Errorf("invalid hash of block %d", block.BlockNo())
+	}
+	if valid, err := block.VerifySign(); err != nil || !valid {
+		return errors.Errorf("invalid signature of block %d", block.BlockNo())
+	}
+	return nil
+}
+
+func (sf *StateFetcher) getHashes(prev *types.BlockInfo, count uint64) ([]message.BlockHash, error) {
+	sf.compRequester.TellTo(message.P2PSvc, &message.GetHashes{ToWhom: sf.ctx.PeerID, PrevInfo: prev, Count: count})
+
+	timer := time.NewTimer(sf.dfltTimeout)
+	select {
+	case result := <-sf.hashesCh:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if !result.PrevInfo.Equal(prev) || len(result.Hashes) == 0 || uint64(len(result.Hashes)) > count {
+			return nil, ErrInvalidHashSet
+		}
+		return result.Hashes, nil
+	case <-timer.C:
+		logger.Error().Float64("sec", sf.dfltTimeout.Seconds()).Msg("get hashes response timeout")
+		return nil, ErrStateFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrStateFetcherQuit
+	}
+}
+
+func (sf *StateFetcher) getHeaders(hashes []message.BlockHash) ([]*types.Block, error) {
+	sf.compRequester.TellTo(message.P2PSvc, &message.GetBlockChunks{GetBlockInfos: message.GetBlockInfos{ToWhom: sf.ctx.PeerID, Hashes: hashes}, TTL: sf.dfltTimeout, HeaderOnly: true})
+
+	timer := time.NewTimer(sf.dfltTimeout)
+	select {
+	case result := <-sf.headersCh:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if len(result.Blocks) != len(hashes) {
+			return nil, ErrStateFetcherLib
+		}
+		return result.Blocks, nil
+	case <-timer.C:
+		logger.Error().Float64("sec", sf.dfltTimeout.Seconds()).Msg("get headers response timeout")
+		return nil, ErrStateFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrStateFetcherQuit
+	}
+}
+
+func (sf *StateFetcher) getTrieNodes(root []byte, hashes [][]byte) ([][]byte, error) {
+	sf.compRequester.TellTo(message.P2PSvc, &message.GetSyncTrieNodes{ToWhom: sf.ctx.PeerID, Root: root, Hashes: hashes})
+
+	timer := time.NewTimer(sf.dfltTimeout)
+	select {
+	case result := <-sf.nodesCh:
+		return result.Nodes, result.Err
+	case <-timer.C:
+		logger.Error().Float64("sec", sf.dfltTimeout.Seconds()).Msg("get trie nodes response timeout")
+		return nil, ErrStateFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrStateFetcherQuit
+	}
+}
+
+func (sf *StateFetcher) getStorageChunk(root, start []byte) (*message.GetSyncStorageChunkRsp, error) {
+	sf.compRequester.TellTo(message.P2PSvc, &message.GetSyncStorageChunk{ToWhom: sf.ctx.PeerID, Root: root, Start: start, Size: DfltStorageChunkSize})
+
+	timer := time.NewTimer(sf.dfltTimeout)
+	select {
+	case result := <-sf.storageCh:
+		return result, result.Err
+	case <-timer.C:
+		logger.Error().Float64("sec", sf.dfltTimeout.Seconds()).Msg("get storage chunk response timeout")
+		return nil, ErrStateFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrStateFetcherQuit
+	}
+}
+
+// handleRsp passes a response of the remote peer to the running fetch. A late
+// response, whose request is timed out, is dropped.
+func (sf *StateFetcher) handleRsp(msg interface{}) {
+	switch rsp := msg.(type) {
+	case *message.GetSyncLibBlockRsp:
+		select {
+		case sf.libCh <- rsp:
+		default:
+		}
+	case *message.GetHashesRsp:
+		select {
+		case sf.hashesCh <- rsp:
+		default:
+		}
+	case *message.GetBlockChunksRsp:
+		select {
+		case sf.headersCh <- rsp:
+		default:
+		}
+	case *message.GetSyncTrieNodesRsp:
+		select {
+		case sf.nodesCh <- rsp:
+		default:
+		}
+	case *message.GetSyncStorageChunkRsp:
+		select {
+		case sf.storageCh <- rsp:
+		default:
+		}
+	}
+}
@@ -27,9 +27,15 @@ type Syncer struct {
 	ctx       *types.SyncContext
 
 	finder       *Finder
+	stateFetcher *StateFetcher
 	hashFetcher  *HashFetcher
 	blockFetcher *BlockFetcher
 
+	// useStateSync is true until the state is synced once, or it fails.
+	useStateSync bool
+	// lightNode fetches only the headers of the blocks.
+	lightNode bool
+
 	compRequester component.IComponentRequester //for test
 }
 
@@ -94,6 +100,10 @@ func NewSyncer(cfg *cfg.Config, chain types.ChainAccessor, syncerCfg *SyncerConf
 	}
 
 	syncer := &Syncer{cfg: cfg, syncerCfg: syncerCfg}
+	if cfg != nil && cfg.Blockchain != nil {
+		syncer.lightNode = cfg.Blockchain.LightNode
+		syncer.useStateSync = cfg.Blockchain.StateSync && !syncer.lightNode
+	}
 
 	syncer.BaseComponent = component.NewBaseComponent(message.SyncerSvc, syncer, logger)
 	syncer.compRequester = syncer.BaseComponent
@@ -125,10 +135,12 @@ func (syncer *Syncer) Reset() {
 		logger.Info().Msg("syncer stop#1")
 
 		syncer.finder.stop()
+		syncer.stateFetcher.stop()
 		syncer.hashFetcher.stop()
 		syncer.blockFetcher.stop()
 
 		syncer.finder = nil
+		syncer.stateFetcher = nil
 		syncer.hashFetcher = nil
 		syncer.blockFetcher = nil
 		syncer.isRunning = false
@@ -164,6 +176,10 @@ func (syncer *Syncer) Receive(context actor.Context) {
 			return
 		case *message.GetHashByNoRsp:
 			return
+		case *message.StateFetcherResult:
+			return
+		case *message.GetSyncLibBlockRsp, *message.GetSyncTrieNodesRsp, *message.GetSyncStorageChunkRsp:
+			return
 		case *message.GetBlockChunks:
 			return
 		case *message.AddBlockRsp:
@@ -193,10 +209,29 @@ func (syncer *Syncer) handleMessage(inmsg interface{}) {
 			syncer.Reset()
 			logger.Error().Err(err).Msg("FinderResult failed")
 		}
+	case *message.StateFetcherResult:
+		err := syncer.handleStateFetcherResult(msg)
+		if err != nil {
+			syncer.Reset()
+			logger.Error().Err(err).Msg("StateFetcherResult failed")
+		}
+	case *message.GetSyncLibBlockRsp, *message.GetSyncTrieNodesRsp, *message.GetSyncStorageChunkRsp:
+		if syncer.stateFetcher != nil {
+			syncer.stateFetcher.handleRsp(msg)
+		}
 	case *message.GetHashesRsp:
+		// the state fetcher verifies the lib block before the fetchers start
+		if syncer.stateFetcher != nil {
+			syncer.stateFetcher.handleRsp(msg)
+			return
+		}
 		syncer.hashFetcher.GetHahsesRsp(msg)
 
 	case *message.GetBlockChunksRsp:
+		if syncer.stateFetcher != nil {
+			syncer.stateFetcher.handleRsp(msg)
+			return
+		}
 		err := syncer.blockFetcher.handleBlockRsp(msg)
 		if err != nil {
 			syncer.Reset()
@@ -267,12 +302,44 @@ func (syncer *Syncer) handleSyncStart(msg *message.SyncStart) error {
 	syncer.ctx = types.NewSyncCtx(msg.PeerID, msg.TargetNo, bestBlockNo)
 	syncer.isRunning = true
 
+	// an empty chain starts from the state at the lib block of the peer
+	if syncer.useStateSync && bestBlockNo == 0 {
+		syncer.stateFetcher = newStateFetcher(syncer.ctx, syncer.getCompRequester(), bestBlock, syncer.syncerCfg)
+		syncer.stateFetcher.start()
+		return nil
+	}
+
 	syncer.finder = newFinder(syncer.ctx, syncer.getCompRequester(), syncer.chain, syncer.syncerCfg)
 	syncer.finder.start()
 
 	return err
 }
 
+func (syncer *Syncer) handleStateFetcherResult(msg *message.StateFetcherResult) error {
+	logger.Debug().Msg("syncer received state fetcher result message")
+
+	syncer.stateFetcher.stop()
+	syncer.stateFetcher = nil
+	syncer.useStateSync = false
+
+	if msg.Err != nil || msg.Block == nil {
+		if msg.Err != nil {
+			logger.Warn().Err(msg.Err).Msg("failed to sync the state. all the blocks are synced instead")
+		}
+		syncer.finder = newFinder(syncer.ctx, syncer.getCompRequester(), syncer.chain, syncer.syncerCfg)
+		syncer.finder.start()
+		return nil
+	}
+
+	//the blocks after the block of the state are fetched
+	syncer.ctx.BestNo = msg.Block.BlockNo()
+	syncer.ctx.SetAncestor(msg.Block)
+
+	syncer.startFetchers()
+
+	return nil
+}
+
 func (syncer *Syncer) handleAncestorRsp(msg *message.GetSyncAncestorRsp) {
 	logger.Debug().Msg("syncer received ancestor response")
 
@@ -311,13 +378,18 @@ func (syncer *Syncer) handleFinderResult(msg *message.FinderResult) error {
 		return nil
 	}
 
+	syncer.startFetchers()
+
+	return nil
+}
+
+func (syncer *Syncer) startFetchers() {
 	syncer.blockFetcher = newBlockFetcher(syncer.ctx, syncer.getCompRequester(), syncer.syncerCfg)
+	syncer.blockFetcher.headerOnly = syncer.lightNode
 	syncer.hashFetcher = newHashFetcher(syncer.ctx, syncer.getCompRequester(), syncer.blockFetcher.hfCh, syncer.syncerCfg)
 
 	syncer.blockFetcher.Start()
 	syncer.hashFetcher.Start()
-
-	return nil
 }
 
 func (syncer *Syncer) Statistics() *map[string]interface{} {
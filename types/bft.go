@@ -0,0 +1,125 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/minio/sha256-simd"
+)
+
+// The kinds of the messages signed by the validators of the BFT consensus.
+const (
+	BftPropose uint32 = iota
+	BftPrevote
+	BftPrecommit
+)
+
+// bftDigest computes the hash signed for a BFT consensus message. The chain ID
+// is included so that a message is never replayed on another chain.
+func bftDigest(chainID []byte, kind uint32, height BlockNo, round uint32, blockHash []byte) []byte {
+	digest := sha256.New()
+	digest.Write(chainID)
+	binary.Write(digest, binary.LittleEndian, kind)
+	binary.Write(digest, binary.LittleEndian, height)
+	binary.Write(digest, binary.LittleEndian, round)
+	digest.Write(blockHash)
+
+	return digest.Sum(nil)
+}
+
+// NewBftVote returns a vote of kind signed by privKey. An empty blockHash
+// means a vote for no block (nil vote).
+func NewBftVote(chainID []byte, kind uint32, height BlockNo, round uint32, blockHash []byte, privKey crypto.PrivKey) (*BftVote, error) {
+	pk, err := privKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	vote := &BftVote{
+		Type:      kind,
+		Height:    height,
+		Round:     round,
+		BlockHash: blockHash,
+		PubKey:    pk,
+	}
+	if vote.Sign, err = privKey.Sign(vote.digest(chainID)); err != nil {
+		return nil, err
+	}
+
+	return vote, nil
+}
+
+func (v *BftVote) digest(chainID []byte) []byte {
+	return bftDigest(chainID, v.Type, v.Height, v.Round, v.BlockHash)
+}
+
+// IsNil reports whether v is a vote for no block.
+func (v *BftVote) IsNil() bool {
+	return len(v.GetBlockHash()) == 0
+}
+
+// VerifySign verifies the signature of vote on the chain of chainID.
+func (v *BftVote) VerifySign(chainID []byte) (valid bool, err error) {
+	var pubKey crypto.PubKey
+	if pubKey, err = crypto.UnmarshalPublicKey(v.PubKey); err != nil {
+		return false, err
+	}
+
+	return pubKey.Verify(v.digest(chainID), v.Sign)
+}
+
+// Validator returns the ID of the validator who cast vote.
+func (v *BftVote) Validator() (peer.ID, error) {
+	pubKey, err := crypto.UnmarshalPublicKey(v.PubKey)
+	if err != nil {
+		return peer.ID(""), err
+	}
+
+	return peer.IDFromPublicKey(pubKey)
+}
+
+func (p *BftProposal) digest(chainID []byte) []byte {
+	return bftDigest(chainID, BftPropose, p.GetBlock().BlockNo(), p.Round, p.Block.BlockHash())
+}
+
+// NewBftProposal returns a proposal of block at round signed by privKey. The
+// block may be produced by another validator, when it is proposed again.
+func NewBftProposal(chainID []byte, round uint32, block *Block, privKey crypto.PrivKey) (*BftProposal, error) {
+	pk, err := privKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := &BftProposal{Round: round, Block: block, PubKey: pk}
+	if proposal.Sign, err = privKey.Sign(proposal.digest(chainID)); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// VerifySign verifies the signature of proposal on the chain of chainID.
+func (p *BftProposal) VerifySign(chainID []byte) (valid bool, err error) {
+	var pubKey crypto.PubKey
+	if pubKey, err = crypto.UnmarshalPublicKey(p.PubKey); err != nil {
+		return false, err
+	}
+
+	return pubKey.Verify(p.digest(chainID), p.Sign)
+}
+
+// Proposer returns the ID of the validator who proposed.
+func (p *BftProposal) Proposer() (peer.ID, error) {
+	pubKey, err := crypto.UnmarshalPublicKey(p.PubKey)
+	if err != nil {
+		return peer.ID(""), err
+	}
+
+	return peer.IDFromPublicKey(pubKey)
+}
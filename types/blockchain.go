@@ -22,8 +22,13 @@ import (
 const (
 	// DefaultMaxBlockSize is the maximum block size (currently 1MiB)
 	DefaultMaxBlockSize = 1 << 20
-	DefaultCoinbaseFee  = 1
-	lastFieldOfBH       = "Sign"
+	// DefaultTxGasLimit is the gas limit of a tx which does not set it
+	DefaultTxGasLimit = 1000000
+	// TxBaseGas is the gas charged for every tx before running its payload
+	TxBaseGas = 1000
+	// LegacyTxFee is the flat fee of a normal tx before the v2 hardfork
+	LegacyTxFee   = 1
+	lastFieldOfBH = "Sign"
 )
 
 //MaxAER is maximum value of aergo
@@ -362,6 +367,35 @@ func CalculateTxsRootHash(txs []*Tx) []byte {
 	return merkle.CalculateMerkleRoot(mes)
 }
 
+// CalculateTxMerklePath returns the merkle path of the tx at idx, which proves
+// the tx is in the txs root hash.
+func CalculateTxMerklePath(txs []*Tx, idx int) [][]byte {
+	mes := make([]merkle.MerkleEntry, len(txs))
+	for i, tx := range txs {
+		mes[i] = tx
+	}
+	return merkle.CalculateMerklePath(mes, idx)
+}
+
+// Verify checks the tx of the proof is included in the block of header.
+func (p *TxProof) Verify(header *BlockHeader) error {
+	tx := p.GetTx()
+	if tx == nil || p.GetTxIdx() == nil || header == nil {
+		return ErrTxProofNotMatch
+	}
+	if !bytes.Equal(tx.GetHash(), tx.CalculateTxHash()) {
+		return ErrTxHasInvalidHash
+	}
+	block := &Block{Header: header}
+	if !bytes.Equal(block.BlockHash(), p.TxIdx.GetBlockHash()) {
+		return ErrTxProofNotMatch
+	}
+	if !merkle.VerifyMerklePath(header.GetTxsRootHash(), tx.GetHash(), int(p.TxIdx.GetIdx()), p.GetMerklePath()) {
+		return ErrTxProofNotMatch
+	}
+	return nil
+}
+
 func NewTx() *Tx {
 	tx := &Tx{
 		Body: &TxBody{
@@ -431,7 +465,15 @@ func (tx *Tx) Validate() error {
 	return nil
 }
 
+// ValidateWithSenderState checks the tx against the state of the sender. The
+// sender must be able to pay the fee for the whole gas limit.
 func (tx *Tx) ValidateWithSenderState(senderState *State) error {
+	return tx.ValidateWithSenderStateAndFee(senderState, tx.GetBody().GetMaxFee())
+}
+
+// ValidateWithSenderStateAndFee is ValidateWithSenderState with the max fee of
+// a normal tx given by the caller.
+func (tx *Tx) ValidateWithSenderStateAndFee(senderState *State, maxFee *big.Int) error {
 	if (senderState.GetNonce() + 1) > tx.GetBody().GetNonce() {
 		return ErrTxNonceTooLow
 	}
@@ -439,8 +481,7 @@ func (tx *Tx) ValidateWithSenderState(senderState *State) error {
 	balance := senderState.GetBalanceBigInt()
 	switch tx.GetBody().GetType() {
 	case TxType_NORMAL:
-		fee := new(big.Int).SetInt64(DefaultCoinbaseFee)
-		spending := new(big.Int).Add(amount, fee)
+		spending := new(big.Int).Add(amount, maxFee)
 		if spending.Cmp(balance) > 0 {
 			return ErrInsufficientBalance
 		}
@@ -498,3 +539,22 @@ func (b *TxBody) GetAmountBigInt() *big.Int {
 func (b *TxBody) GetPriceBigInt() *big.Int {
 	return new(big.Int).SetBytes(b.GetPrice())
 }
+
+// GetGasLimit returns the gas limit of the tx. DefaultTxGasLimit is used if
+// the limit is not set.
+func (b *TxBody) GetGasLimit() uint64 {
+	if b.GetLimit() == 0 {
+		return DefaultTxGasLimit
+	}
+	return b.GetLimit()
+}
+
+// GetMaxFee returns the fee which is paid when the tx uses all its gas.
+func (b *TxBody) GetMaxFee() *big.Int {
+	return GasFee(b.GetGasLimit(), b.GetPriceBigInt())
+}
+
+// GasFee returns the fee for the gas at the price.
+func GasFee(gas uint64, price *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), price)
+}
@@ -45,6 +45,7 @@ type Block struct {
 	Hash                 []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Header               *BlockHeader `protobuf:"bytes,2,opt,name=header" json:"header,omitempty"`
 	Body                 *BlockBody   `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	Commit               *BftCommit   `protobuf:"bytes,4,opt,name=commit" json:"commit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -95,6 +96,13 @@ func (m *Block) GetBody() *BlockBody {
 	return nil
 }
 
+func (m *Block) GetCommit() *BftCommit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
 type BlockHeader struct {
 	ChainID              []byte   `protobuf:"bytes,1,opt,name=chainID,proto3" json:"chainID,omitempty"`
 	PrevBlockHash        []byte   `protobuf:"bytes,2,opt,name=prevBlockHash,proto3" json:"prevBlockHash,omitempty"`
@@ -437,6 +445,60 @@ func (m *TxBody) GetSign() []byte {
 	return nil
 }
 
+type MultiSig struct {
+	Threshold            uint32   `protobuf:"varint,1,opt,name=threshold" json:"threshold,omitempty"`
+	Pubkeys              [][]byte `protobuf:"bytes,2,rep,name=pubkeys,proto3" json:"pubkeys,omitempty"`
+	Signs                [][]byte `protobuf:"bytes,3,rep,name=signs,proto3" json:"signs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MultiSig) Reset()         { *m = MultiSig{} }
+func (m *MultiSig) String() string { return proto.CompactTextString(m) }
+func (*MultiSig) ProtoMessage()    {}
+func (*MultiSig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{6}
+}
+func (m *MultiSig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MultiSig.Unmarshal(m, b)
+}
+func (m *MultiSig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MultiSig.Marshal(b, m, deterministic)
+}
+func (dst *MultiSig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiSig.Merge(dst, src)
+}
+func (m *MultiSig) XXX_Size() int {
+	return xxx_messageInfo_MultiSig.Size(m)
+}
+func (m *MultiSig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiSig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MultiSig proto.InternalMessageInfo
+
+func (m *MultiSig) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *MultiSig) GetPubkeys() [][]byte {
+	if m != nil {
+		return m.Pubkeys
+	}
+	return nil
+}
+
+func (m *MultiSig) GetSigns() [][]byte {
+	if m != nil {
+		return m.Signs
+	}
+	return nil
+}
+
 type TxIdx struct {
 	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	Idx                  int32    `protobuf:"varint,2,opt,name=idx" json:"idx,omitempty"`
@@ -449,7 +511,7 @@ func (m *TxIdx) Reset()         { *m = TxIdx{} }
 func (m *TxIdx) String() string { return proto.CompactTextString(m) }
 func (*TxIdx) ProtoMessage()    {}
 func (*TxIdx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{6}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{7}
 }
 func (m *TxIdx) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TxIdx.Unmarshal(m, b)
@@ -495,7 +557,7 @@ func (m *TxInBlock) Reset()         { *m = TxInBlock{} }
 func (m *TxInBlock) String() string { return proto.CompactTextString(m) }
 func (*TxInBlock) ProtoMessage()    {}
 func (*TxInBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{7}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{8}
 }
 func (m *TxInBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TxInBlock.Unmarshal(m, b)
@@ -529,6 +591,60 @@ func (m *TxInBlock) GetTx() *Tx {
 	return nil
 }
 
+type TxProof struct {
+	Tx                   *Tx      `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
+	TxIdx                *TxIdx   `protobuf:"bytes,2,opt,name=txIdx" json:"txIdx,omitempty"`
+	MerklePath           [][]byte `protobuf:"bytes,3,rep,name=merklePath,proto3" json:"merklePath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxProof) Reset()         { *m = TxProof{} }
+func (m *TxProof) String() string { return proto.CompactTextString(m) }
+func (*TxProof) ProtoMessage()    {}
+func (*TxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{9}
+}
+func (m *TxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxProof.Unmarshal(m, b)
+}
+func (m *TxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxProof.Marshal(b, m, deterministic)
+}
+func (dst *TxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxProof.Merge(dst, src)
+}
+func (m *TxProof) XXX_Size() int {
+	return xxx_messageInfo_TxProof.Size(m)
+}
+func (m *TxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxProof proto.InternalMessageInfo
+
+func (m *TxProof) GetTx() *Tx {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *TxProof) GetTxIdx() *TxIdx {
+	if m != nil {
+		return m.TxIdx
+	}
+	return nil
+}
+
+func (m *TxProof) GetMerklePath() [][]byte {
+	if m != nil {
+		return m.MerklePath
+	}
+	return nil
+}
+
 type State struct {
 	Nonce                uint64   `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
 	Balance              []byte   `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
@@ -544,7 +660,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{8}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{10}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_State.Unmarshal(m, b)
@@ -616,7 +732,7 @@ func (m *StateProof) Reset()         { *m = StateProof{} }
 func (m *StateProof) String() string { return proto.CompactTextString(m) }
 func (*StateProof) ProtoMessage()    {}
 func (*StateProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{9}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{11}
 }
 func (m *StateProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateProof.Unmarshal(m, b)
@@ -702,7 +818,7 @@ func (m *ContractVarProof) Reset()         { *m = ContractVarProof{} }
 func (m *ContractVarProof) String() string { return proto.CompactTextString(m) }
 func (*ContractVarProof) ProtoMessage()    {}
 func (*ContractVarProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{10}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{12}
 }
 func (m *ContractVarProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContractVarProof.Unmarshal(m, b)
@@ -783,7 +899,7 @@ func (m *StateQueryProof) Reset()         { *m = StateQueryProof{} }
 func (m *StateQueryProof) String() string { return proto.CompactTextString(m) }
 func (*StateQueryProof) ProtoMessage()    {}
 func (*StateQueryProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{11}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{13}
 }
 func (m *StateQueryProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateQueryProof.Unmarshal(m, b)
@@ -821,6 +937,9 @@ type Receipt struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Status               string   `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
 	Ret                  string   `protobuf:"bytes,3,opt,name=ret" json:"ret,omitempty"`
+	Events               []*Event `protobuf:"bytes,4,rep,name=events" json:"events,omitempty"`
+	FeeUsed              []byte   `protobuf:"bytes,5,opt,name=feeUsed,proto3" json:"feeUsed,omitempty"`
+	GasUsed              uint64   `protobuf:"varint,6,opt,name=gasUsed" json:"gasUsed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -830,7 +949,7 @@ func (m *Receipt) Reset()         { *m = Receipt{} }
 func (m *Receipt) String() string { return proto.CompactTextString(m) }
 func (*Receipt) ProtoMessage()    {}
 func (*Receipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{12}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{14}
 }
 func (m *Receipt) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Receipt.Unmarshal(m, b)
@@ -871,6 +990,121 @@ func (m *Receipt) GetRet() string {
 	return ""
 }
 
+func (m *Receipt) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Receipt) GetFeeUsed() []byte {
+	if m != nil {
+		return m.FeeUsed
+	}
+	return nil
+}
+
+func (m *Receipt) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+type Event struct {
+	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	EventName            string   `protobuf:"bytes,2,opt,name=eventName" json:"eventName,omitempty"`
+	JsonArgs             string   `protobuf:"bytes,3,opt,name=jsonArgs" json:"jsonArgs,omitempty"`
+	EventIdx             int32    `protobuf:"varint,4,opt,name=eventIdx" json:"eventIdx,omitempty"`
+	TxHash               []byte   `protobuf:"bytes,5,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,6,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,7,opt,name=blockNo" json:"blockNo,omitempty"`
+	TxIndex              int32    `protobuf:"varint,8,opt,name=txIndex" json:"txIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{15}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (dst *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(dst, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *Event) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *Event) GetJsonArgs() string {
+	if m != nil {
+		return m.JsonArgs
+	}
+	return ""
+}
+
+func (m *Event) GetEventIdx() int32 {
+	if m != nil {
+		return m.EventIdx
+	}
+	return 0
+}
+
+func (m *Event) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *Event) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Event) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *Event) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
 type FnArgument struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -882,7 +1116,7 @@ func (m *FnArgument) Reset()         { *m = FnArgument{} }
 func (m *FnArgument) String() string { return proto.CompactTextString(m) }
 func (*FnArgument) ProtoMessage()    {}
 func (*FnArgument) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{13}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{16}
 }
 func (m *FnArgument) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FnArgument.Unmarshal(m, b)
@@ -921,7 +1155,7 @@ func (m *Function) Reset()         { *m = Function{} }
 func (m *Function) String() string { return proto.CompactTextString(m) }
 func (*Function) ProtoMessage()    {}
 func (*Function) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{14}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{17}
 }
 func (m *Function) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Function.Unmarshal(m, b)
@@ -967,7 +1201,7 @@ func (m *StateVar) Reset()         { *m = StateVar{} }
 func (m *StateVar) String() string { return proto.CompactTextString(m) }
 func (*StateVar) ProtoMessage()    {}
 func (*StateVar) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{15}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{18}
 }
 func (m *StateVar) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateVar.Unmarshal(m, b)
@@ -1015,7 +1249,7 @@ func (m *ABI) Reset()         { *m = ABI{} }
 func (m *ABI) String() string { return proto.CompactTextString(m) }
 func (*ABI) ProtoMessage()    {}
 func (*ABI) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{16}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{19}
 }
 func (m *ABI) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ABI.Unmarshal(m, b)
@@ -1066,6 +1300,8 @@ func (m *ABI) GetStateVariables() []*StateVar {
 type Query struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Queryinfo            []byte   `protobuf:"bytes,2,opt,name=queryinfo,proto3" json:"queryinfo,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,4,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1075,7 +1311,7 @@ func (m *Query) Reset()         { *m = Query{} }
 func (m *Query) String() string { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()    {}
 func (*Query) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{17}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{20}
 }
 func (m *Query) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Query.Unmarshal(m, b)
@@ -1109,6 +1345,20 @@ func (m *Query) GetQueryinfo() []byte {
 	return nil
 }
 
+func (m *Query) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *Query) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type StateQuery struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	VarName              string   `protobuf:"bytes,2,opt,name=varName" json:"varName,omitempty"`
@@ -1124,7 +1374,7 @@ func (m *StateQuery) Reset()         { *m = StateQuery{} }
 func (m *StateQuery) String() string { return proto.CompactTextString(m) }
 func (*StateQuery) ProtoMessage()    {}
 func (*StateQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9d72b666b7104858, []int{18}
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{21}
 }
 func (m *StateQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateQuery.Unmarshal(m, b)
@@ -1179,6 +1429,230 @@ func (m *StateQuery) GetCompressed() bool {
 	return false
 }
 
+type BftVote struct {
+	Type                 uint32   `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	Round                uint32   `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,4,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	PubKey               []byte   `protobuf:"bytes,5,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Sign                 []byte   `protobuf:"bytes,6,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BftVote) Reset()         { *m = BftVote{} }
+func (m *BftVote) String() string { return proto.CompactTextString(m) }
+func (*BftVote) ProtoMessage()    {}
+func (*BftVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{22}
+}
+func (m *BftVote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BftVote.Unmarshal(m, b)
+}
+func (m *BftVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BftVote.Marshal(b, m, deterministic)
+}
+func (dst *BftVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BftVote.Merge(dst, src)
+}
+func (m *BftVote) XXX_Size() int {
+	return xxx_messageInfo_BftVote.Size(m)
+}
+func (m *BftVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_BftVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BftVote proto.InternalMessageInfo
+
+func (m *BftVote) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *BftVote) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BftVote) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BftVote) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *BftVote) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *BftVote) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type BftCommit struct {
+	Round                uint32     `protobuf:"varint,1,opt,name=round" json:"round,omitempty"`
+	Votes                []*BftVote `protobuf:"bytes,2,rep,name=votes" json:"votes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BftCommit) Reset()         { *m = BftCommit{} }
+func (m *BftCommit) String() string { return proto.CompactTextString(m) }
+func (*BftCommit) ProtoMessage()    {}
+func (*BftCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{23}
+}
+func (m *BftCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BftCommit.Unmarshal(m, b)
+}
+func (m *BftCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BftCommit.Marshal(b, m, deterministic)
+}
+func (dst *BftCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BftCommit.Merge(dst, src)
+}
+func (m *BftCommit) XXX_Size() int {
+	return xxx_messageInfo_BftCommit.Size(m)
+}
+func (m *BftCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BftCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BftCommit proto.InternalMessageInfo
+
+func (m *BftCommit) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BftCommit) GetVotes() []*BftVote {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+type DoubleSignEvidence struct {
+	Header1              *BlockHeader `protobuf:"bytes,1,opt,name=header1" json:"header1,omitempty"`
+	Header2              *BlockHeader `protobuf:"bytes,2,opt,name=header2" json:"header2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DoubleSignEvidence) Reset()         { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()    {}
+func (*DoubleSignEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{24}
+}
+func (m *DoubleSignEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoubleSignEvidence.Unmarshal(m, b)
+}
+func (m *DoubleSignEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoubleSignEvidence.Marshal(b, m, deterministic)
+}
+func (dst *DoubleSignEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoubleSignEvidence.Merge(dst, src)
+}
+func (m *DoubleSignEvidence) XXX_Size() int {
+	return xxx_messageInfo_DoubleSignEvidence.Size(m)
+}
+func (m *DoubleSignEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoubleSignEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoubleSignEvidence proto.InternalMessageInfo
+
+func (m *DoubleSignEvidence) GetHeader1() *BlockHeader {
+	if m != nil {
+		return m.Header1
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetHeader2() *BlockHeader {
+	if m != nil {
+		return m.Header2
+	}
+	return nil
+}
+
+type ChainParam struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value                uint64   `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo" json:"blockNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainParam) Reset()         { *m = ChainParam{} }
+func (m *ChainParam) String() string { return proto.CompactTextString(m) }
+func (*ChainParam) ProtoMessage()    {}
+func (*ChainParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_9d72b666b7104858, []int{25}
+}
+func (m *ChainParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainParam.Unmarshal(m, b)
+}
+func (m *ChainParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainParam.Marshal(b, m, deterministic)
+}
+func (dst *ChainParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainParam.Merge(dst, src)
+}
+func (m *ChainParam) XXX_Size() int {
+	return xxx_messageInfo_ChainParam.Size(m)
+}
+func (m *ChainParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainParam proto.InternalMessageInfo
+
+func (m *ChainParam) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChainParam) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *ChainParam) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Block)(nil), "types.Block")
 	proto.RegisterType((*BlockHeader)(nil), "types.BlockHeader")
@@ -1186,90 +1660,118 @@ func init() {
 	proto.RegisterType((*TxList)(nil), "types.TxList")
 	proto.RegisterType((*Tx)(nil), "types.Tx")
 	proto.RegisterType((*TxBody)(nil), "types.TxBody")
+	proto.RegisterType((*MultiSig)(nil), "types.MultiSig")
 	proto.RegisterType((*TxIdx)(nil), "types.TxIdx")
 	proto.RegisterType((*TxInBlock)(nil), "types.TxInBlock")
+	proto.RegisterType((*TxProof)(nil), "types.TxProof")
 	proto.RegisterType((*State)(nil), "types.State")
 	proto.RegisterType((*StateProof)(nil), "types.StateProof")
 	proto.RegisterType((*ContractVarProof)(nil), "types.ContractVarProof")
 	proto.RegisterType((*StateQueryProof)(nil), "types.StateQueryProof")
 	proto.RegisterType((*Receipt)(nil), "types.Receipt")
+	proto.RegisterType((*Event)(nil), "types.Event")
 	proto.RegisterType((*FnArgument)(nil), "types.FnArgument")
 	proto.RegisterType((*Function)(nil), "types.Function")
 	proto.RegisterType((*StateVar)(nil), "types.StateVar")
 	proto.RegisterType((*ABI)(nil), "types.ABI")
 	proto.RegisterType((*Query)(nil), "types.Query")
 	proto.RegisterType((*StateQuery)(nil), "types.StateQuery")
+	proto.RegisterType((*BftVote)(nil), "types.BftVote")
+	proto.RegisterType((*BftCommit)(nil), "types.BftCommit")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "types.DoubleSignEvidence")
+	proto.RegisterType((*ChainParam)(nil), "types.ChainParam")
 	proto.RegisterEnum("types.TxType", TxType_name, TxType_value)
 }
 
 func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_9d72b666b7104858) }
 
 var fileDescriptor_blockchain_9d72b666b7104858 = []byte{
-	// 1043 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xcf, 0x6e, 0x23, 0xc5,
-	0x13, 0xfe, 0xf9, 0x6f, 0x3c, 0xe5, 0xfc, 0xf1, 0xaf, 0xb5, 0x82, 0x01, 0x56, 0x2b, 0x33, 0x0a,
-	0xc8, 0x8a, 0x44, 0x22, 0x65, 0x0f, 0x8b, 0xc4, 0xc9, 0x59, 0x76, 0xc1, 0xb0, 0x24, 0xa1, 0xb1,
-	0x72, 0x40, 0x42, 0xa8, 0x3d, 0xd3, 0xb1, 0x5b, 0xd8, 0xd3, 0xb3, 0x3d, 0x3d, 0xd6, 0xf8, 0xc4,
-	0xab, 0x80, 0xc4, 0xe3, 0x70, 0xe4, 0x2d, 0x10, 0xef, 0x80, 0xaa, 0xba, 0x3d, 0xe3, 0x64, 0x03,
-	0xd2, 0x1e, 0x39, 0xb9, 0xbf, 0xaf, 0xaa, 0xba, 0xab, 0xea, 0xab, 0xee, 0x31, 0x0c, 0x66, 0x4b,
-	0x1d, 0xff, 0x14, 0x2f, 0x84, 0x4a, 0x4f, 0x33, 0xa3, 0xad, 0x66, 0x1d, 0xbb, 0xc9, 0x64, 0x1e,
-	0xad, 0xa0, 0x73, 0x81, 0x26, 0xc6, 0xa0, 0xbd, 0x10, 0xf9, 0x22, 0x6c, 0x0c, 0x1b, 0xa3, 0x7d,
-	0x4e, 0x6b, 0x76, 0x02, 0xdd, 0x85, 0x14, 0x89, 0x34, 0x61, 0x73, 0xd8, 0x18, 0xf5, 0xcf, 0xd9,
-	0x29, 0x05, 0x9d, 0x52, 0xc4, 0x97, 0x64, 0xe1, 0xde, 0x83, 0x1d, 0x43, 0x7b, 0xa6, 0x93, 0x4d,
-	0xd8, 0x22, 0xcf, 0xc1, 0xae, 0xe7, 0x85, 0x4e, 0x36, 0x9c, 0xac, 0xd1, 0x5f, 0x4d, 0xe8, 0xef,
-	0x44, 0xb3, 0x10, 0xf6, 0x28, 0xa9, 0xc9, 0xe7, 0xfe, 0xe0, 0x2d, 0x64, 0xc7, 0x70, 0x90, 0x19,
-	0xb9, 0x76, 0xce, 0x98, 0x58, 0x93, 0xec, 0x77, 0x49, 0x8c, 0xa7, 0xca, 0x2e, 0x35, 0x1d, 0xdc,
-	0xe6, 0x5b, 0xc8, 0x1e, 0x43, 0x60, 0xd5, 0x4a, 0xe6, 0x56, 0xac, 0xb2, 0xb0, 0x3d, 0x6c, 0x8c,
-	0x5a, 0xbc, 0x26, 0xd8, 0xc7, 0x70, 0x48, 0x8e, 0x39, 0xd7, 0xda, 0xd2, 0xf6, 0x1d, 0xda, 0xfe,
-	0x1e, 0xcb, 0x86, 0xd0, 0xb7, 0x65, 0xed, 0xd4, 0x25, 0xa7, 0x5d, 0x8a, 0x9d, 0xc0, 0xc0, 0xc8,
-	0x58, 0xaa, 0xcc, 0xd6, 0x6e, 0x7b, 0xe4, 0xf6, 0x06, 0xcf, 0xde, 0x87, 0x5e, 0xac, 0xd3, 0x5b,
-	0x65, 0x56, 0x79, 0xd8, 0xa3, 0x74, 0x2b, 0xcc, 0xde, 0x81, 0x6e, 0x56, 0xcc, 0xbe, 0x96, 0x9b,
-	0x30, 0xa0, 0x68, 0x8f, 0x50, 0x97, 0x5c, 0xcd, 0xd3, 0x10, 0x9c, 0x2e, 0xb8, 0x66, 0x23, 0x38,
-	0x8a, 0xb5, 0x4a, 0x67, 0x22, 0x97, 0xe3, 0x38, 0xd6, 0x45, 0x6a, 0xc3, 0x3e, 0x99, 0xef, 0xd3,
-	0xd1, 0x08, 0x82, 0x4a, 0x02, 0xf6, 0x01, 0xb4, 0x6c, 0x99, 0x87, 0x8d, 0x61, 0x6b, 0xd4, 0x3f,
-	0x0f, 0xbc, 0x42, 0xd3, 0x92, 0x23, 0x1b, 0x7d, 0x04, 0xdd, 0x69, 0xf9, 0x4a, 0xe5, 0xf6, 0xdf,
-	0xdd, 0x3e, 0x83, 0xe6, 0xb4, 0x7c, 0x70, 0x58, 0x3e, 0xf4, 0x03, 0xe0, 0x46, 0xe5, 0xa0, 0x8a,
-	0xdb, 0x51, 0xff, 0xcf, 0x06, 0x1e, 0x42, 0xb9, 0x3c, 0x82, 0x4e, 0xaa, 0xd3, 0x58, 0xd2, 0x16,
-	0x6d, 0xee, 0x00, 0xca, 0x29, 0x7c, 0x41, 0x4e, 0xee, 0x2d, 0x44, 0x39, 0x8d, 0x8c, 0x55, 0xa6,
-	0x64, 0x6a, 0x49, 0xea, 0x7d, 0x5e, 0x13, 0xd8, 0x3c, 0xb1, 0xa2, 0xb0, 0xb6, 0x6b, 0x9e, 0x43,
-	0xb8, 0x5f, 0x26, 0x36, 0x4b, 0x2d, 0x12, 0xaf, 0xef, 0x16, 0xe2, 0xf9, 0x4b, 0xb5, 0x52, 0x96,
-	0x24, 0x6d, 0x73, 0x07, 0x90, 0xcd, 0x8c, 0x8a, 0xa5, 0x57, 0xd0, 0x01, 0xac, 0x0c, 0x8b, 0x21,
-	0xc9, 0x0e, 0x77, 0x2a, 0x9b, 0x6e, 0x32, 0xc9, 0xc9, 0x54, 0xa9, 0x14, 0xd4, 0x2a, 0x45, 0xcf,
-	0xa0, 0x33, 0x2d, 0x27, 0x49, 0x89, 0xb9, 0xcf, 0xaa, 0x31, 0x76, 0x2d, 0xab, 0x09, 0x36, 0x80,
-	0x96, 0x4a, 0x4a, 0xaa, 0xb7, 0xc3, 0x71, 0x19, 0x7d, 0x05, 0xc1, 0xb4, 0x9c, 0xa4, 0xee, 0x5e,
-	0x46, 0xd0, 0xb1, 0xb8, 0x0b, 0x05, 0xf6, 0xcf, 0xf7, 0xab, 0xd3, 0x27, 0x49, 0xc9, 0x9d, 0x89,
-	0xbd, 0x07, 0x4d, 0x5b, 0xfa, 0xc6, 0xef, 0x08, 0xd6, 0xb4, 0x65, 0xf4, 0x6b, 0x03, 0x3a, 0xdf,
-	0x59, 0x61, 0xe5, 0x3f, 0x77, 0x7c, 0x26, 0x96, 0x02, 0x79, 0xdf, 0x71, 0x0f, 0xdd, 0xb0, 0x26,
-	0x92, 0x92, 0x76, 0x0d, 0xaf, 0x30, 0x5e, 0x8b, 0xdc, 0x6a, 0x23, 0xe6, 0x12, 0x67, 0xdb, 0x37,
-	0x7d, 0x97, 0xc2, 0x6b, 0x91, 0xbf, 0x5e, 0x72, 0x19, 0xeb, 0xb5, 0x34, 0x9b, 0x6b, 0xad, 0x52,
-	0x4b, 0x12, 0xb4, 0xf9, 0x1b, 0x7c, 0xf4, 0x47, 0x03, 0x80, 0x72, 0xbc, 0x36, 0x5a, 0xdf, 0x62,
-	0xc5, 0x39, 0xa2, 0x7b, 0x15, 0x93, 0x07, 0x77, 0x26, 0x6c, 0xa9, 0x4a, 0xe3, 0x65, 0x91, 0x2b,
-	0x9d, 0x52, 0xe2, 0x3d, 0x5e, 0x13, 0x98, 0x7a, 0x86, 0x5b, 0xe1, 0x6d, 0xf2, 0xa9, 0x6f, 0x71,
-	0x65, 0xbb, 0x11, 0x4b, 0x9f, 0x77, 0x85, 0x71, 0x8c, 0x66, 0xca, 0xae, 0x44, 0xe6, 0xa7, 0xc5,
-	0x23, 0xe4, 0x17, 0x52, 0xcd, 0x17, 0x6e, 0x5a, 0x0e, 0xb8, 0x47, 0x98, 0x85, 0x28, 0x12, 0x65,
-	0xaf, 0x85, 0xc5, 0x4b, 0xdf, 0x42, 0x61, 0x2b, 0x22, 0xfa, 0xbd, 0x01, 0x83, 0xe7, 0x3a, 0xb5,
-	0x46, 0xc4, 0xf6, 0x46, 0x18, 0x57, 0xdc, 0x23, 0xe8, 0xac, 0xc5, 0xb2, 0x90, 0x7e, 0x0e, 0x1c,
-	0xf8, 0x4f, 0x94, 0xf3, 0x33, 0x1c, 0x91, 0x04, 0xdf, 0x16, 0x28, 0x1c, 0x15, 0xf3, 0x0c, 0x0e,
-	0x62, 0x5f, 0x20, 0x11, 0x5e, 0xb1, 0xff, 0xef, 0x2a, 0x46, 0x06, 0x7e, 0xd7, 0x8f, 0x3d, 0x85,
-	0xde, 0xda, 0x77, 0xc4, 0x8f, 0xed, 0xbb, 0x3e, 0xe6, 0x7e, 0xc3, 0x78, 0xe5, 0x18, 0xfd, 0x00,
-	0x7b, 0xdc, 0xbd, 0xa8, 0xee, 0x01, 0x74, 0x8e, 0xe3, 0x24, 0x31, 0x32, 0xcf, 0x7d, 0x3f, 0xef,
-	0xd3, 0x58, 0x2b, 0x4e, 0x4c, 0x91, 0xd3, 0x39, 0x01, 0xf7, 0x08, 0x6f, 0x9d, 0x91, 0xee, 0x25,
-	0x09, 0x38, 0x2e, 0xa3, 0x21, 0xc0, 0xcb, 0x74, 0x6c, 0xe6, 0xc5, 0x0a, 0x5f, 0x14, 0x06, 0xed,
-	0x54, 0xac, 0x9c, 0x4c, 0x01, 0xa7, 0x75, 0x74, 0x05, 0xbd, 0x97, 0x45, 0x1a, 0x5b, 0xd4, 0xe4,
-	0x01, 0x3b, 0x3b, 0x83, 0x40, 0xf8, 0x78, 0x3c, 0xae, 0xb5, 0xd3, 0x8a, 0x7a, 0x67, 0x5e, 0xfb,
-	0x44, 0xe7, 0xd0, 0xa3, 0x1e, 0xdd, 0x08, 0xf3, 0xe0, 0x86, 0xcc, 0x3f, 0x3c, 0x2e, 0x75, 0x5a,
-	0x47, 0xbf, 0x35, 0xa0, 0x35, 0xbe, 0x98, 0xe0, 0xc5, 0x5d, 0x4b, 0x43, 0x03, 0xe3, 0x42, 0xb6,
-	0x10, 0x47, 0x62, 0x29, 0xd2, 0x79, 0x21, 0xe6, 0xdb, 0xc8, 0x0a, 0xb3, 0x4f, 0x20, 0xb8, 0xf5,
-	0x25, 0xe4, 0x61, 0x8b, 0x52, 0x3c, 0xda, 0xa6, 0xe8, 0x79, 0x5e, 0x7b, 0xb0, 0x4f, 0xe1, 0x88,
-	0xee, 0xdb, 0x8f, 0x6b, 0x61, 0x94, 0x98, 0x2d, 0x65, 0x1e, 0xb6, 0xef, 0x04, 0x6d, 0xd3, 0xe7,
-	0x87, 0xb9, 0x5f, 0x39, 0xb7, 0xe8, 0x0a, 0x3a, 0x34, 0x28, 0x6f, 0x21, 0xd5, 0x63, 0x08, 0x5e,
-	0x63, 0x88, 0x4a, 0x6f, 0xb5, 0x7f, 0x8c, 0x6a, 0x22, 0xfa, 0x65, 0xfb, 0x48, 0xbc, 0xed, 0xb6,
-	0xd8, 0x28, 0x61, 0x2e, 0xb1, 0xb7, 0x4d, 0xdf, 0x28, 0x07, 0xb1, 0x51, 0x6b, 0x61, 0x26, 0x69,
-	0x22, 0x4b, 0x3f, 0x08, 0x15, 0xc6, 0xd6, 0x9b, 0xfa, 0x69, 0xa3, 0x35, 0x7b, 0x02, 0x10, 0xeb,
-	0x55, 0x86, 0xbb, 0x4a, 0xf7, 0x41, 0xe9, 0xf1, 0x1d, 0xe6, 0xe4, 0x18, 0xbf, 0x6e, 0xf8, 0x51,
-	0x60, 0x00, 0xdd, 0xcb, 0x2b, 0xfe, 0xcd, 0xf8, 0xd5, 0xe0, 0x7f, 0xec, 0x10, 0xe0, 0x8b, 0xab,
-	0x9b, 0x17, 0xfc, 0x72, 0x7c, 0xf9, 0xfc, 0xc5, 0xa0, 0x71, 0x31, 0xfc, 0xfe, 0xc9, 0x5c, 0xd9,
-	0x45, 0x31, 0x3b, 0x8d, 0xf5, 0xea, 0x4c, 0x48, 0x33, 0xd7, 0x4a, 0xbb, 0xdf, 0x33, 0x6a, 0xea,
-	0xac, 0x4b, 0xff, 0xd0, 0x9e, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x1b, 0x62, 0xee, 0xb5,
-	0x09, 0x00, 0x00,
+	// 1382 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0xcd, 0x6e, 0x1c, 0xc5,
+	0x13, 0xff, 0xcf, 0xee, 0xce, 0x7e, 0x94, 0xe3, 0x8f, 0x7f, 0x2b, 0x82, 0x01, 0xa2, 0x68, 0x19,
+	0x19, 0x64, 0x45, 0xe0, 0x08, 0xe7, 0x10, 0x24, 0x4e, 0xb6, 0xe3, 0x04, 0x43, 0xe2, 0x98, 0x8e,
+	0xb1, 0x10, 0x17, 0xd4, 0x3b, 0xd3, 0xde, 0x1d, 0x32, 0x3b, 0x3d, 0x99, 0xe9, 0x59, 0xcd, 0x9e,
+	0x38, 0xf2, 0x04, 0x88, 0x2b, 0x48, 0xbc, 0x03, 0x2f, 0xc1, 0x91, 0xb7, 0x40, 0x1c, 0x78, 0x03,
+	0x54, 0xd5, 0x3d, 0x1f, 0xde, 0xd8, 0x96, 0x72, 0xe4, 0x34, 0xfd, 0xab, 0xaa, 0xee, 0xae, 0x8f,
+	0x5f, 0x57, 0xf7, 0xc0, 0xd6, 0x24, 0x56, 0xc1, 0xcb, 0x60, 0x26, 0xa2, 0x64, 0x37, 0xcd, 0x94,
+	0x56, 0xcc, 0xd5, 0xcb, 0x54, 0xe6, 0xfe, 0xcf, 0x0e, 0xb8, 0x07, 0xa8, 0x63, 0x0c, 0x7a, 0x33,
+	0x91, 0xcf, 0x3c, 0x67, 0xec, 0xec, 0xdc, 0xe2, 0x34, 0x66, 0xf7, 0xa0, 0x3f, 0x93, 0x22, 0x94,
+	0x99, 0xd7, 0x19, 0x3b, 0x3b, 0x6b, 0x7b, 0x6c, 0x97, 0x66, 0xed, 0xd2, 0x8c, 0xcf, 0x49, 0xc3,
+	0xad, 0x05, 0xdb, 0x86, 0xde, 0x44, 0x85, 0x4b, 0xaf, 0x4b, 0x96, 0x5b, 0x6d, 0xcb, 0x03, 0x15,
+	0x2e, 0x39, 0x69, 0xd9, 0x0e, 0xf4, 0x03, 0x35, 0x9f, 0x47, 0xda, 0xeb, 0x5d, 0xb6, 0xbb, 0xd0,
+	0x87, 0x24, 0xe7, 0x56, 0xef, 0xff, 0xdd, 0x81, 0xb5, 0xd6, 0x3e, 0xcc, 0x83, 0x01, 0xf9, 0x7f,
+	0xfc, 0xc8, 0xba, 0x58, 0x41, 0xb6, 0x0d, 0xeb, 0x69, 0x26, 0x17, 0xc6, 0x18, 0x43, 0xe8, 0x90,
+	0xfe, 0xb2, 0x10, 0xe7, 0x53, 0x12, 0x4e, 0x14, 0xb9, 0xd8, 0xe3, 0x15, 0x64, 0x77, 0x60, 0xa4,
+	0xa3, 0xb9, 0xcc, 0xb5, 0x98, 0xa7, 0xe4, 0x56, 0x97, 0x37, 0x02, 0xf6, 0x21, 0x6c, 0x90, 0x61,
+	0xce, 0x95, 0xd2, 0xb4, 0xbc, 0x4b, 0xcb, 0xaf, 0x48, 0xd9, 0x18, 0xd6, 0x74, 0xd9, 0x18, 0xf5,
+	0xc9, 0xa8, 0x2d, 0x62, 0xf7, 0x60, 0x2b, 0x93, 0x81, 0x8c, 0x52, 0xdd, 0x98, 0x0d, 0xc8, 0xec,
+	0x35, 0x39, 0x7b, 0x17, 0x86, 0x81, 0x4a, 0x2e, 0xa2, 0x6c, 0x9e, 0x7b, 0x43, 0x72, 0xb7, 0xc6,
+	0xec, 0x2d, 0xe8, 0xa7, 0xc5, 0xe4, 0x4b, 0xb9, 0xf4, 0x46, 0x34, 0xdb, 0x22, 0xac, 0x60, 0x1e,
+	0x4d, 0x13, 0x0f, 0x4c, 0x05, 0x71, 0xcc, 0x76, 0x60, 0x33, 0x50, 0x51, 0x32, 0x11, 0xb9, 0xdc,
+	0x0f, 0x02, 0x55, 0x24, 0xda, 0x5b, 0x23, 0xf5, 0xaa, 0xd8, 0xdf, 0x81, 0x51, 0x5d, 0x2c, 0xf6,
+	0x1e, 0x74, 0x75, 0x99, 0x7b, 0xce, 0xb8, 0xbb, 0xb3, 0xb6, 0x37, 0xb2, 0x35, 0x3a, 0x2b, 0x39,
+	0x4a, 0xfd, 0x0f, 0xa0, 0x7f, 0x56, 0x3e, 0x8d, 0x72, 0x7d, 0xb3, 0xd9, 0x67, 0xd0, 0x39, 0x2b,
+	0xaf, 0xa4, 0xd5, 0xfb, 0x96, 0x2a, 0x86, 0x54, 0xeb, 0xf5, 0xbc, 0x86, 0x27, 0xfe, 0x5f, 0x0e,
+	0xf4, 0x8d, 0x80, 0xdd, 0x06, 0x37, 0x51, 0x49, 0x20, 0x69, 0x89, 0x1e, 0x37, 0x00, 0xcb, 0x29,
+	0x6c, 0x40, 0xa6, 0xdc, 0x15, 0xc4, 0x72, 0x66, 0x32, 0x88, 0xd2, 0x48, 0x26, 0x9a, 0x4a, 0x7d,
+	0x8b, 0x37, 0x02, 0x4c, 0x9e, 0x98, 0xd3, 0xb4, 0x9e, 0x49, 0x9e, 0x41, 0xb8, 0x5e, 0x2a, 0x96,
+	0xb1, 0x12, 0xa1, 0xad, 0x6f, 0x05, 0x71, 0xff, 0x38, 0x42, 0xc6, 0xf6, 0xcd, 0xfe, 0x04, 0x50,
+	0x9a, 0x66, 0x51, 0x20, 0x6d, 0x05, 0x0d, 0xc0, 0xc8, 0x30, 0x18, 0x2a, 0xd9, 0x46, 0x2b, 0xb2,
+	0xb3, 0x65, 0x2a, 0x39, 0xa9, 0xea, 0x2a, 0x8d, 0x9a, 0x2a, 0xf9, 0xdf, 0xc0, 0xf0, 0x59, 0x11,
+	0xeb, 0xe8, 0x45, 0x34, 0x25, 0x36, 0xce, 0x32, 0x99, 0xcf, 0x54, 0x1c, 0x52, 0xc8, 0xeb, 0xbc,
+	0x11, 0x90, 0x9b, 0xc5, 0xe4, 0xa5, 0x5c, 0xe6, 0x5e, 0x67, 0xdc, 0x25, 0x37, 0x0d, 0x44, 0x87,
+	0x70, 0xad, 0xdc, 0xeb, 0x92, 0xdc, 0x00, 0xff, 0x21, 0xb8, 0x67, 0xe5, 0x71, 0x58, 0xe2, 0xb2,
+	0x93, 0xfa, 0x80, 0x98, 0x62, 0x34, 0x02, 0xb6, 0x05, 0xdd, 0x28, 0x2c, 0x29, 0x93, 0x2e, 0xc7,
+	0xa1, 0xff, 0x05, 0x8c, 0xce, 0xca, 0xe3, 0xc4, 0xf4, 0x06, 0x1f, 0x5c, 0x8d, 0xab, 0xd0, 0xc4,
+	0xb5, 0xbd, 0x5b, 0x75, 0x5c, 0xc7, 0x61, 0xc9, 0x8d, 0x8a, 0xbd, 0x03, 0x1d, 0x5d, 0xda, 0x92,
+	0xb6, 0xa8, 0xd0, 0xd1, 0xa5, 0x3f, 0x83, 0xc1, 0x59, 0x79, 0x9a, 0x29, 0x75, 0x61, 0xad, 0x9c,
+	0x2b, 0xac, 0x9a, 0x4d, 0x3a, 0xd7, 0x6f, 0x72, 0x17, 0x60, 0x2e, 0xb3, 0x97, 0xb1, 0x3c, 0x15,
+	0x7a, 0x66, 0x23, 0x6d, 0x49, 0xfc, 0x5f, 0x1d, 0x70, 0x5f, 0x68, 0xa1, 0xe5, 0xf5, 0xac, 0x99,
+	0x88, 0x58, 0xa0, 0xdc, 0xb2, 0xc6, 0x42, 0x73, 0xe0, 0x42, 0x49, 0xe9, 0x31, 0xa4, 0xa9, 0x31,
+	0x1e, 0xed, 0x5c, 0xab, 0x4c, 0x4c, 0x25, 0x9e, 0x4f, 0x4b, 0x9c, 0xb6, 0x08, 0x8f, 0x76, 0xfe,
+	0x2a, 0xe6, 0x32, 0x50, 0x0b, 0x99, 0x2d, 0x4f, 0x55, 0x94, 0x68, 0xa2, 0x51, 0x8f, 0xbf, 0x26,
+	0xf7, 0xff, 0x74, 0x00, 0xc8, 0x47, 0x93, 0x11, 0x1f, 0xdc, 0x1c, 0xd1, 0x4a, 0x6e, 0xc9, 0x82,
+	0x1b, 0x15, 0x16, 0x2f, 0x4a, 0x82, 0xb8, 0xc8, 0x23, 0x95, 0x90, 0xe3, 0x43, 0xde, 0x08, 0xd0,
+	0xf5, 0x14, 0x97, 0xc2, 0x8e, 0x60, 0x5d, 0xaf, 0x70, 0xad, 0x3b, 0x17, 0xb1, 0xf5, 0xbb, 0xc6,
+	0x78, 0x14, 0x26, 0x91, 0x9e, 0x8b, 0xd4, 0x32, 0xde, 0x22, 0x94, 0xcf, 0x64, 0x34, 0x9d, 0x19,
+	0xc6, 0xaf, 0x73, 0x8b, 0xd0, 0x0b, 0x51, 0x84, 0x91, 0xa6, 0xdc, 0x0f, 0x28, 0xf7, 0x8d, 0xc0,
+	0xff, 0xc3, 0x81, 0xad, 0x43, 0x95, 0xe8, 0x4c, 0x04, 0xfa, 0x5c, 0x64, 0x26, 0xb8, 0xdb, 0xe0,
+	0x2e, 0x44, 0x5c, 0x48, 0xcb, 0x38, 0x03, 0xfe, 0x13, 0xe1, 0xfc, 0x00, 0x9b, 0x54, 0x82, 0xaf,
+	0x0a, 0x2c, 0x1c, 0x05, 0xf3, 0x10, 0xd6, 0x03, 0x1b, 0x20, 0x09, 0x6c, 0xc5, 0xfe, 0xdf, 0xae,
+	0x18, 0x29, 0xf8, 0x65, 0x3b, 0xf6, 0x00, 0x86, 0x0b, 0x9b, 0x11, 0x4b, 0xee, 0xb7, 0xed, 0x9c,
+	0xd5, 0x84, 0xf1, 0xda, 0xd0, 0xff, 0xdd, 0x81, 0x01, 0x37, 0xd7, 0x82, 0xe9, 0xe2, 0xc6, 0x72,
+	0x3f, 0x0c, 0x33, 0x99, 0xe7, 0x36, 0xa1, 0xab, 0x62, 0x0c, 0x16, 0x29, 0x53, 0xe4, 0xb4, 0xd1,
+	0x88, 0x5b, 0x84, 0x07, 0x3c, 0x93, 0xa6, 0x1d, 0x8e, 0x38, 0x0e, 0xd9, 0x36, 0xf4, 0xe5, 0x42,
+	0x26, 0x3a, 0xf7, 0x7a, 0xe3, 0x6e, 0x8b, 0x78, 0x47, 0x28, 0xe4, 0x56, 0x87, 0x07, 0xe6, 0x42,
+	0xca, 0xaf, 0x73, 0x59, 0xb7, 0x45, 0x0b, 0x51, 0x33, 0x15, 0x39, 0x69, 0x4c, 0x63, 0xac, 0xa0,
+	0xff, 0x8f, 0x03, 0x2e, 0xad, 0xf2, 0x06, 0x7e, 0xdf, 0x81, 0x11, 0xed, 0x78, 0x22, 0xe6, 0xd2,
+	0xba, 0xde, 0x08, 0xb0, 0xec, 0xdf, 0xe7, 0x2a, 0xd9, 0xcf, 0xa6, 0xb9, 0x0d, 0xa1, 0xc6, 0xa8,
+	0x23, 0x43, 0xec, 0x1c, 0x3d, 0xea, 0x5f, 0x35, 0xc6, 0x6c, 0xe8, 0xb2, 0x75, 0x67, 0x5b, 0x74,
+	0xb9, 0x19, 0xf6, 0x57, 0x9b, 0x61, 0xeb, 0xa5, 0x30, 0xb8, 0xfc, 0x52, 0xf0, 0x60, 0xa0, 0xcb,
+	0xe3, 0x24, 0x94, 0x25, 0x75, 0x78, 0x97, 0x57, 0xd0, 0x1f, 0x03, 0x3c, 0x46, 0x7f, 0x8a, 0x39,
+	0xc6, 0xcd, 0xa0, 0x97, 0x60, 0x20, 0x0e, 0xf9, 0x4a, 0x63, 0xff, 0x39, 0x0c, 0x1f, 0x17, 0x49,
+	0xa0, 0x91, 0xe2, 0x57, 0xe8, 0xd9, 0x7d, 0x18, 0x09, 0x3b, 0xdf, 0xf4, 0xf6, 0x86, 0x59, 0xcd,
+	0xca, 0xbc, 0xb1, 0xf1, 0xf7, 0x60, 0x48, 0x94, 0x3b, 0x17, 0xd9, 0x95, 0x0b, 0x32, 0x7b, 0x17,
+	0x99, 0x6c, 0xd2, 0xd8, 0xff, 0xcd, 0x81, 0xee, 0xfe, 0xc1, 0x31, 0x06, 0xb2, 0x90, 0x19, 0x9d,
+	0x3f, 0x33, 0xa5, 0x82, 0x98, 0xce, 0x58, 0x24, 0xd3, 0x42, 0x4c, 0xab, 0x99, 0x35, 0x66, 0x1f,
+	0xc3, 0xe8, 0xc2, 0x86, 0x60, 0xae, 0x99, 0xb5, 0xbd, 0xcd, 0xca, 0x45, 0x2b, 0xe7, 0x8d, 0x05,
+	0xfb, 0x14, 0x36, 0xa9, 0x7d, 0x7d, 0xb7, 0x10, 0x59, 0x24, 0x26, 0xb1, 0xac, 0xa8, 0xb6, 0xd9,
+	0x3e, 0x31, 0xe7, 0x22, 0xe3, 0x1b, 0xb9, 0x1d, 0x19, 0x33, 0xff, 0x47, 0x07, 0x5c, 0x3a, 0x78,
+	0x6f, 0xc6, 0xa0, 0x57, 0x38, 0x25, 0x4a, 0x2e, 0x94, 0x6d, 0xee, 0x8d, 0xe0, 0xe6, 0xd7, 0x5f,
+	0xc3, 0x85, 0xde, 0x0a, 0x17, 0xfc, 0x5f, 0xaa, 0x66, 0xfd, 0xa6, 0xee, 0x60, 0x86, 0x45, 0xd6,
+	0xa2, 0x73, 0x05, 0x31, 0xc3, 0x0b, 0x91, 0x19, 0x16, 0x59, 0x32, 0x57, 0x18, 0x6b, 0x96, 0x35,
+	0x57, 0x0c, 0x8d, 0xf1, 0xce, 0x0b, 0xd4, 0x3c, 0xc5, 0x55, 0xed, 0x29, 0x1c, 0xf2, 0x96, 0xc4,
+	0xff, 0xc9, 0x81, 0xc1, 0xc1, 0x85, 0x3e, 0x57, 0xba, 0xa9, 0xb9, 0x79, 0x37, 0xd0, 0xb8, 0xd5,
+	0xff, 0x3a, 0x14, 0xb9, 0x45, 0xd8, 0x9b, 0x33, 0x55, 0x24, 0x21, 0x39, 0xb1, 0xce, 0x0d, 0xb8,
+	0x39, 0x1d, 0xad, 0xa7, 0xa7, 0x7b, 0xe5, 0xd3, 0xb3, 0xdf, 0x7a, 0xd4, 0x3c, 0x81, 0x51, 0xfd,
+	0xaa, 0x6f, 0x36, 0x73, 0xda, 0x9b, 0x6d, 0x83, 0xbb, 0x50, 0x5a, 0x56, 0x7c, 0xdf, 0x68, 0x7e,
+	0x06, 0x30, 0x1a, 0x6e, 0x94, 0x7e, 0x0a, 0xec, 0x91, 0x2a, 0x26, 0xb1, 0x7c, 0x11, 0x4d, 0x93,
+	0xa3, 0x45, 0x14, 0x4a, 0xbc, 0xb0, 0x3f, 0x82, 0x81, 0xf9, 0xf3, 0xf8, 0xc4, 0x73, 0xae, 0xfd,
+	0x39, 0xa9, 0x4c, 0x1a, 0xeb, 0xbd, 0x1b, 0x7e, 0x65, 0x2a, 0x13, 0xff, 0x14, 0xe0, 0x10, 0x7f,
+	0x2e, 0x4e, 0x45, 0x26, 0xe6, 0x57, 0x1e, 0xae, 0xfa, 0x62, 0x33, 0x39, 0x35, 0xe0, 0x7a, 0x96,
+	0xdd, 0xdb, 0x86, 0xbe, 0x79, 0x05, 0x32, 0x80, 0xfe, 0xc9, 0x73, 0xfe, 0x6c, 0xff, 0xe9, 0xd6,
+	0xff, 0xd8, 0x06, 0xc0, 0x93, 0xe7, 0xe7, 0x47, 0xfc, 0x64, 0xff, 0xe4, 0xf0, 0x68, 0xcb, 0x39,
+	0x18, 0x7f, 0x7b, 0x77, 0x1a, 0xe9, 0x59, 0x31, 0xd9, 0x0d, 0xd4, 0xfc, 0xbe, 0x90, 0xd9, 0x54,
+	0x45, 0xca, 0x7c, 0xef, 0x93, 0xbb, 0x93, 0x3e, 0xfd, 0xbd, 0x3d, 0xf8, 0x77, 0x00, 0xea, 0x9f,
+	0x20, 0x0e, 0xd1, 0x0d, 0x00, 0x00,
 }
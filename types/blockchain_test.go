@@ -91,3 +91,33 @@ func TestBlockSign(t *testing.T) {
 	signAssert.Nil(err)
 	signAssert.True(valid)
 }
+
+func TestTxProof(t *testing.T) {
+	var txs []*Tx
+	for i := 0; i < 5; i++ {
+		tx := NewTx()
+		tx.Body.Nonce = uint64(i + 1)
+		tx.Hash = tx.CalculateTxHash()
+		txs = append(txs, tx)
+	}
+	block := NewBlock(nil, nil, make(Receipts, 0), txs, nil, 0)
+
+	for i, tx := range txs {
+		proof := &TxProof{
+			Tx:         tx,
+			TxIdx:      &TxIdx{BlockHash: block.BlockHash(), Idx: int32(i)},
+			MerklePath: CalculateTxMerklePath(txs, i),
+		}
+		assert.NoError(t, proof.Verify(block.GetHeader()))
+
+		proof.TxIdx.Idx = int32((i + 1) % len(txs))
+		assert.Equal(t, ErrTxProofNotMatch, proof.Verify(block.GetHeader()))
+	}
+
+	proof := &TxProof{
+		Tx:         txs[0],
+		TxIdx:      &TxIdx{BlockHash: []byte("other block"), Idx: 0},
+		MerklePath: CalculateTxMerklePath(txs, 0),
+	}
+	assert.Equal(t, ErrTxProofNotMatch, proof.Verify(block.GetHeader()))
+}
@@ -15,6 +15,15 @@ var (
 	//ErrSameNonceInMempool is returned by MemPool Service if transaction which has same nonce is already exists
 	ErrSameNonceAlreadyInMempool = errors.New("tx with same nonce is already in mempool")
 
+	//ErrTxPriceTooLowToReplace is returned by MemPool Service if transaction with same nonce has not enough price bump to replace
+	ErrTxPriceTooLowToReplace = errors.New("price is too low to replace tx with same nonce")
+
+	//ErrTxPoolFull is returned by MemPool Service if mempool is full and transaction's price is too low to evict another
+	ErrTxPoolFull = errors.New("mempool is full")
+
+	//ErrTxAccountLimit is returned by MemPool Service if the account has too many transactions in mempool
+	ErrTxAccountLimit = errors.New("too many txs of the account in mempool")
+
 	//ErrTxFormatInvalid is returned by MemPool Service if transaction does not exists ErrTxFormatInvalid = errors.New("tx invalid format")
 	ErrTxFormatInvalid = errors.New("tx invalid format")
 
@@ -34,6 +43,27 @@ var (
 
 	ErrSignNotMatch = errors.New("signature not matched")
 
+	//ErrInvalidMultisig
+	ErrInvalidMultisig = errors.New("invalid multisig")
+
+	//ErrNotEnoughSignatures
+	ErrNotEnoughSignatures = errors.New("not enough signatures for multisig")
+
+	//ErrNotMultisigMember
+	ErrNotMultisigMember = errors.New("signer is not a member of multisig")
+
+	//ErrMultisigAddrNotMatch
+	ErrMultisigAddrNotMatch = errors.New("multisig does not match to account")
+
+	//ErrNotMultisigTx
+	ErrNotMultisigTx = errors.New("tx is not from multisig account")
+
+	//ErrMultisigNotAllowed is returned if a tx is sent from a multisig account before the v2 hardfork
+	ErrMultisigNotAllowed = errors.New("multisig is not allowed before the v2 hardfork")
+
+	//ErrTxProofNotMatch is returned if the tx proof does not match to the block header
+	ErrTxProofNotMatch = errors.New("tx proof does not match to block header")
+
 	ErrCouldNotRecoverPubKey = errors.New("could not recover pubkey from sign")
 
 	ErrShouldUnlockAccount = errors.New("should unlock account first")
@@ -52,6 +82,45 @@ var (
 	//ErrMustStakeBeforeUnstake
 	ErrMustStakeBeforeUnstake = errors.New("must stake before unstake")
 
+	//ErrAlreadySlashed
+	ErrAlreadySlashed = errors.New("already slashed by the evidence")
+
+	//ErrNothingToSlash
+	ErrNothingToSlash = errors.New("no staking to slash")
+
+	//ErrNoReward
+	ErrNoReward = errors.New("no reward to claim")
+
+	//ErrUnknownParam
+	ErrUnknownParam = errors.New("unknown chain parameter")
+
+	//ErrParamOutOfRange
+	ErrParamOutOfRange = errors.New("chain parameter out of range")
+
+	//ErrParamChangeTooLate
+	ErrParamChangeTooLate = errors.New("block to change the parameter has already passed")
+
+	//ErrNoProposal
+	ErrNoProposal = errors.New("no proposal to change the parameter")
+
+	//ErrAlreadyApproved
+	ErrAlreadyApproved = errors.New("already approved the proposal")
+
+	//ErrProposalExecuted
+	ErrProposalExecuted = errors.New("proposal already executed")
+
+	//ErrNotEnoughApproval
+	ErrNotEnoughApproval = errors.New("approval less than 2/3 of the total staking")
+
+	//ErrStakingTotalUnderflow
+	ErrStakingTotalUnderflow = errors.New("total staking less than zero")
+
+	//ErrStakingTotalNotSeeded
+	ErrStakingTotalNotSeeded = errors.New("total staking not seeded until the v2 hardfork")
+
 	//ErrVmStart
 	ErrVmStart = errors.New("cannot start a VM")
+
+	//ErrNotEnoughGas
+	ErrNotEnoughGas = errors.New("not enough gas")
 )
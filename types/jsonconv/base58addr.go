@@ -1,4 +1,4 @@
-package util
+package jsonconv
 
 import (
 	"encoding/hex"
@@ -41,6 +41,12 @@ type InOutTxInBlock struct {
 	Tx    *InOutTx
 }
 
+type InOutTxProof struct {
+	TxIdx      *InOutTxIdx
+	Tx         *InOutTx
+	MerklePath []string
+}
+
 type InOutBlockHeader struct {
 	PrevBlockHash string
 	BlockNo       uint64
@@ -62,11 +68,63 @@ type InOutBlock struct {
 	Body   InOutBlockBody
 }
 
+type InOutBlockList struct {
+	Blocks     []*InOutBlock
+	NextCursor string `json:",omitempty"`
+}
+
 type InOutBlockIdx struct {
 	BlockHash string
 	BlockNo   uint64
 }
 
+type InOutState struct {
+	Nonce            uint64
+	Balance          string
+	CodeHash         string
+	StorageRoot      string
+	SqlRecoveryPoint uint64
+}
+
+type InOutStaking struct {
+	Amount string
+	When   uint64
+	Reward string
+}
+
+type InOutVote struct {
+	Candidate string
+	Amount    string
+}
+
+type InOutCommitResult struct {
+	Hash   string
+	Error  string
+	Detail string
+}
+
+type InOutAccountTx struct {
+	TxHash   string
+	BlockNo  uint64
+	TxIdx    int32
+	Sent     bool
+	Received bool
+}
+
+type InOutAccountTxList struct {
+	Account     string
+	Txs         []*InOutAccountTx
+	NextCursor  string `json:",omitempty"`
+	IndexedFrom uint64
+}
+
+type InOutEvidence struct {
+	Hash1   string
+	Header1 InOutBlockHeader
+	Hash2   string
+	Header2 InOutBlockHeader
+}
+
 type InOutPeerAddress struct {
 	Address string
 	Port    string
@@ -205,6 +263,17 @@ func ConvTxInBlock(txInBlock *types.TxInBlock) *InOutTxInBlock {
 	return out
 }
 
+func ConvTxProof(proof *types.TxProof) *InOutTxProof {
+	out := &InOutTxProof{TxIdx: &InOutTxIdx{}, Tx: &InOutTx{}}
+	out.TxIdx.BlockHash = base58.Encode(proof.GetTxIdx().GetBlockHash())
+	out.TxIdx.Idx = proof.GetTxIdx().GetIdx()
+	out.Tx = ConvTx(proof.GetTx())
+	for _, h := range proof.GetMerklePath() {
+		out.MerklePath = append(out.MerklePath, base58.Encode(h))
+	}
+	return out
+}
+
 func ConvBlock(b *types.Block) *InOutBlock {
 	out := &InOutBlock{}
 	if b != nil {
@@ -225,6 +294,86 @@ func ConvBlock(b *types.Block) *InOutBlock {
 	return out
 }
 
+func ConvBlockHeaderList(list *types.BlockHeaderList) *InOutBlockList {
+	out := &InOutBlockList{Blocks: []*InOutBlock{}}
+	for _, b := range list.GetBlocks() {
+		out.Blocks = append(out.Blocks, ConvBlock(b))
+	}
+	if len(list.GetNextCursor()) != 0 {
+		out.NextCursor = base58.Encode(list.GetNextCursor())
+	}
+	return out
+}
+
+func ConvState(state *types.State) *InOutState {
+	return &InOutState{
+		Nonce:            state.GetNonce(),
+		Balance:          new(big.Int).SetBytes(state.GetBalance()).String(),
+		CodeHash:         base58.Encode(state.GetCodeHash()),
+		StorageRoot:      base58.Encode(state.GetStorageRoot()),
+		SqlRecoveryPoint: state.GetSqlRecoveryPoint(),
+	}
+}
+
+func ConvStaking(staking *types.Staking) *InOutStaking {
+	return &InOutStaking{
+		Amount: new(big.Int).SetBytes(staking.GetAmount()).String(),
+		When:   staking.GetWhen(),
+		Reward: new(big.Int).SetBytes(staking.GetReward()).String(),
+	}
+}
+
+func ConvVoteList(votes *types.VoteList) []*InOutVote {
+	out := []*InOutVote{}
+	for _, v := range votes.GetVotes() {
+		out = append(out, &InOutVote{
+			Candidate: base58.Encode(v.GetCandidate()),
+			Amount:    new(big.Int).SetBytes(v.GetAmount()).String(),
+		})
+	}
+	return out
+}
+
+func ConvCommitResult(r *types.CommitResult) *InOutCommitResult {
+	return &InOutCommitResult{
+		Hash:   base58.Encode(r.GetHash()),
+		Error:  r.GetError().String(),
+		Detail: r.GetDetail(),
+	}
+}
+
+func ConvAccountTxList(list *types.AccountTxList) *InOutAccountTxList {
+	out := &InOutAccountTxList{
+		Account:     types.EncodeAddress(list.GetAccount()),
+		Txs:         []*InOutAccountTx{},
+		IndexedFrom: list.GetIndexedFrom(),
+	}
+	if len(list.GetNextCursor()) != 0 {
+		out.NextCursor = base58.Encode(list.GetNextCursor())
+	}
+	for _, tx := range list.GetTxs() {
+		out.Txs = append(out.Txs, &InOutAccountTx{
+			TxHash:   base58.Encode(tx.GetTxHash()),
+			BlockNo:  tx.GetBlockNo(),
+			TxIdx:    tx.GetTxIdx(),
+			Sent:     tx.GetSent(),
+			Received: tx.GetReceived(),
+		})
+	}
+	return out
+}
+
+func ConvEvidence(ev *types.DoubleSignEvidence) *InOutEvidence {
+	b1 := &types.Block{Header: ev.GetHeader1()}
+	b2 := &types.Block{Header: ev.GetHeader2()}
+	return &InOutEvidence{
+		Hash1:   base58.Encode(b1.BlockHash()),
+		Header1: ConvBlock(b1).Header,
+		Hash2:   base58.Encode(b2.BlockHash()),
+		Header2: ConvBlock(b2).Header,
+	}
+}
+
 func ConvPeer(p *types.Peer) *InOutPeer {
 	out := &InOutPeer{}
 	out.Address.Address = net.IP(p.GetAddress().GetAddress()).String()
@@ -258,6 +407,14 @@ func TxInBlockConvBase58Addr(txInBlock *types.TxInBlock) string {
 	return toString(ConvTxInBlock(txInBlock))
 }
 
+func TxProofConvBase58Addr(proof *types.TxProof) string {
+	return toString(ConvTxProof(proof))
+}
+
+func AccountTxListConvBase58Addr(list *types.AccountTxList) string {
+	return toString(ConvAccountTxList(list))
+}
+
 func BlockConvBase58Addr(b *types.Block) string {
 	return toString(ConvBlock(b))
 }
@@ -270,6 +427,14 @@ func PeerListToString(p *types.PeerList) string {
 	return toString(peers)
 }
 
+func EvidenceListToString(list *types.EvidenceList) string {
+	evidences := []*InOutEvidence{}
+	for _, ev := range list.GetEvidences() {
+		evidences = append(evidences, ConvEvidence(ev))
+	}
+	return toString(evidences)
+}
+
 func toString(out interface{}) string {
 	jsonout, err := json.MarshalIndent(out, "", " ")
 	if err != nil {
@@ -1,4 +1,4 @@
-package util
+package jsonconv
 
 import (
 	"math/big"
@@ -905,13 +905,14 @@ func (m *GetTransactionsRequest) GetHashes() [][]byte {
 }
 
 type GetTransactionsResponse struct {
-	Status               ResultStatus `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
-	Hashes               [][]byte     `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
-	Txs                  []*Tx        `protobuf:"bytes,3,rep,name=txs" json:"txs,omitempty"`
-	HasNext              bool         `protobuf:"varint,4,opt,name=hasNext" json:"hasNext,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Status               ResultStatus        `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Hashes               [][]byte            `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Txs                  []*Tx               `protobuf:"bytes,3,rep,name=txs" json:"txs,omitempty"`
+	HasNext              bool                `protobuf:"varint,4,opt,name=hasNext" json:"hasNext,omitempty"`
+	Proofs               []*ContractVarProof `protobuf:"bytes,5,rep,name=proofs" json:"proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *GetTransactionsResponse) Reset()         { *m = GetTransactionsResponse{} }
@@ -1193,6 +1194,514 @@ func (m *GetHashByNoResponse) GetBlockHash() []byte {
 }
 
 // GetHashesRequest
+type GetLibBlockRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLibBlockRequest) Reset()         { *m = GetLibBlockRequest{} }
+func (m *GetLibBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLibBlockRequest) ProtoMessage()    {}
+func (*GetLibBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{21}
+}
+func (m *GetLibBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLibBlockRequest.Unmarshal(m, b)
+}
+func (m *GetLibBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLibBlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetLibBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLibBlockRequest.Merge(dst, src)
+}
+func (m *GetLibBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLibBlockRequest.Size(m)
+}
+func (m *GetLibBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLibBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLibBlockRequest proto.InternalMessageInfo
+
+type GetLibBlockResponse struct {
+	Status               ResultStatus `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Block                *Block       `protobuf:"bytes,2,opt,name=block" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetLibBlockResponse) Reset()         { *m = GetLibBlockResponse{} }
+func (m *GetLibBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLibBlockResponse) ProtoMessage()    {}
+func (*GetLibBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{22}
+}
+func (m *GetLibBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLibBlockResponse.Unmarshal(m, b)
+}
+func (m *GetLibBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLibBlockResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetLibBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLibBlockResponse.Merge(dst, src)
+}
+func (m *GetLibBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLibBlockResponse.Size(m)
+}
+func (m *GetLibBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLibBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLibBlockResponse proto.InternalMessageInfo
+
+func (m *GetLibBlockResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetLibBlockResponse) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type GetTrieNodesRequest struct {
+	Root                 []byte   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Hashes               [][]byte `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTrieNodesRequest) Reset()         { *m = GetTrieNodesRequest{} }
+func (m *GetTrieNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTrieNodesRequest) ProtoMessage()    {}
+func (*GetTrieNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{23}
+}
+func (m *GetTrieNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTrieNodesRequest.Unmarshal(m, b)
+}
+func (m *GetTrieNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTrieNodesRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetTrieNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTrieNodesRequest.Merge(dst, src)
+}
+func (m *GetTrieNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTrieNodesRequest.Size(m)
+}
+func (m *GetTrieNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTrieNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTrieNodesRequest proto.InternalMessageInfo
+
+func (m *GetTrieNodesRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *GetTrieNodesRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type GetTrieNodesResponse struct {
+	Status               ResultStatus `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Nodes                [][]byte     `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetTrieNodesResponse) Reset()         { *m = GetTrieNodesResponse{} }
+func (m *GetTrieNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTrieNodesResponse) ProtoMessage()    {}
+func (*GetTrieNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{24}
+}
+func (m *GetTrieNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTrieNodesResponse.Unmarshal(m, b)
+}
+func (m *GetTrieNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTrieNodesResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetTrieNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTrieNodesResponse.Merge(dst, src)
+}
+func (m *GetTrieNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTrieNodesResponse.Size(m)
+}
+func (m *GetTrieNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTrieNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTrieNodesResponse proto.InternalMessageInfo
+
+func (m *GetTrieNodesResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetTrieNodesResponse) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type GetStorageChunkRequest struct {
+	Root                 []byte   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Size                 uint32   `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStorageChunkRequest) Reset()         { *m = GetStorageChunkRequest{} }
+func (m *GetStorageChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStorageChunkRequest) ProtoMessage()    {}
+func (*GetStorageChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{25}
+}
+func (m *GetStorageChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageChunkRequest.Unmarshal(m, b)
+}
+func (m *GetStorageChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageChunkRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStorageChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageChunkRequest.Merge(dst, src)
+}
+func (m *GetStorageChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStorageChunkRequest.Size(m)
+}
+func (m *GetStorageChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageChunkRequest proto.InternalMessageInfo
+
+func (m *GetStorageChunkRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *GetStorageChunkRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *GetStorageChunkRequest) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type GetStorageChunkResponse struct {
+	Status               ResultStatus        `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Keys                 [][]byte            `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values               [][]byte            `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	HasNext              bool                `protobuf:"varint,4,opt,name=hasNext" json:"hasNext,omitempty"`
+	Proofs               []*ContractVarProof `protobuf:"bytes,5,rep,name=proofs" json:"proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetStorageChunkResponse) Reset()         { *m = GetStorageChunkResponse{} }
+func (m *GetStorageChunkResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStorageChunkResponse) ProtoMessage()    {}
+func (*GetStorageChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{26}
+}
+func (m *GetStorageChunkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageChunkResponse.Unmarshal(m, b)
+}
+func (m *GetStorageChunkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageChunkResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetStorageChunkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageChunkResponse.Merge(dst, src)
+}
+func (m *GetStorageChunkResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStorageChunkResponse.Size(m)
+}
+func (m *GetStorageChunkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageChunkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageChunkResponse proto.InternalMessageInfo
+
+func (m *GetStorageChunkResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetStorageChunkResponse) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStorageChunkResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *GetStorageChunkResponse) GetHasNext() bool {
+	if m != nil {
+		return m.HasNext
+	}
+	return false
+}
+
+func (m *GetStorageChunkResponse) GetProofs() []*ContractVarProof {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+type GetStateProofRequest struct {
+	Root                 []byte   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Account              []byte   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Compressed           bool     `protobuf:"varint,3,opt,name=compressed" json:"compressed,omitempty"`
+	StorageKeys          [][]byte `protobuf:"bytes,4,rep,name=storageKeys,proto3" json:"storageKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateProofRequest) Reset()         { *m = GetStateProofRequest{} }
+func (m *GetStateProofRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateProofRequest) ProtoMessage()    {}
+func (*GetStateProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{27}
+}
+func (m *GetStateProofRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateProofRequest.Unmarshal(m, b)
+}
+func (m *GetStateProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateProofRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateProofRequest.Merge(dst, src)
+}
+func (m *GetStateProofRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateProofRequest.Size(m)
+}
+func (m *GetStateProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateProofRequest proto.InternalMessageInfo
+
+func (m *GetStateProofRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *GetStateProofRequest) GetAccount() []byte {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *GetStateProofRequest) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+func (m *GetStateProofRequest) GetStorageKeys() [][]byte {
+	if m != nil {
+		return m.StorageKeys
+	}
+	return nil
+}
+
+type GetStateProofResponse struct {
+	Status               ResultStatus        `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Proof                *StateProof         `protobuf:"bytes,2,opt,name=proof" json:"proof,omitempty"`
+	VarProofs            []*ContractVarProof `protobuf:"bytes,3,rep,name=varProofs" json:"varProofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetStateProofResponse) Reset()         { *m = GetStateProofResponse{} }
+func (m *GetStateProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateProofResponse) ProtoMessage()    {}
+func (*GetStateProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{28}
+}
+func (m *GetStateProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateProofResponse.Unmarshal(m, b)
+}
+func (m *GetStateProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateProofResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetStateProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateProofResponse.Merge(dst, src)
+}
+func (m *GetStateProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateProofResponse.Size(m)
+}
+func (m *GetStateProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateProofResponse proto.InternalMessageInfo
+
+func (m *GetStateProofResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetStateProofResponse) GetProof() *StateProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *GetStateProofResponse) GetVarProofs() []*ContractVarProof {
+	if m != nil {
+		return m.VarProofs
+	}
+	return nil
+}
+
+type BftProposal struct {
+	Round                uint32   `protobuf:"varint,1,opt,name=round" json:"round,omitempty"`
+	Block                *Block   `protobuf:"bytes,2,opt,name=block" json:"block,omitempty"`
+	PubKey               []byte   `protobuf:"bytes,3,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Sign                 []byte   `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BftProposal) Reset()         { *m = BftProposal{} }
+func (m *BftProposal) String() string { return proto.CompactTextString(m) }
+func (*BftProposal) ProtoMessage()    {}
+func (*BftProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{29}
+}
+func (m *BftProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BftProposal.Unmarshal(m, b)
+}
+func (m *BftProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BftProposal.Marshal(b, m, deterministic)
+}
+func (dst *BftProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BftProposal.Merge(dst, src)
+}
+func (m *BftProposal) XXX_Size() int {
+	return xxx_messageInfo_BftProposal.Size(m)
+}
+func (m *BftProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_BftProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BftProposal proto.InternalMessageInfo
+
+func (m *BftProposal) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BftProposal) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BftProposal) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *BftProposal) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type RaftMessage struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftMessage) Reset()         { *m = RaftMessage{} }
+func (m *RaftMessage) String() string { return proto.CompactTextString(m) }
+func (*RaftMessage) ProtoMessage()    {}
+func (*RaftMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_20f73eee065405b6, []int{30}
+}
+func (m *RaftMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftMessage.Unmarshal(m, b)
+}
+func (m *RaftMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftMessage.Marshal(b, m, deterministic)
+}
+func (dst *RaftMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftMessage.Merge(dst, src)
+}
+func (m *RaftMessage) XXX_Size() int {
+	return xxx_messageInfo_RaftMessage.Size(m)
+}
+func (m *RaftMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftMessage proto.InternalMessageInfo
+
+func (m *RaftMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 type GetHashesRequest struct {
 	// prevHash indicated referenced block hash. server will return hashes after this block.
 	PrevHash []byte `protobuf:"bytes,1,opt,name=prevHash,proto3" json:"prevHash,omitempty"`
@@ -1209,7 +1718,7 @@ func (m *GetHashesRequest) Reset()         { *m = GetHashesRequest{} }
 func (m *GetHashesRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHashesRequest) ProtoMessage()    {}
 func (*GetHashesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_20f73eee065405b6, []int{21}
+	return fileDescriptor_p2p_20f73eee065405b6, []int{31}
 }
 func (m *GetHashesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHashesRequest.Unmarshal(m, b)
@@ -1264,7 +1773,7 @@ func (m *GetHashesResponse) Reset()         { *m = GetHashesResponse{} }
 func (m *GetHashesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHashesResponse) ProtoMessage()    {}
 func (*GetHashesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_20f73eee065405b6, []int{22}
+	return fileDescriptor_p2p_20f73eee065405b6, []int{32}
 }
 func (m *GetHashesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHashesResponse.Unmarshal(m, b)
@@ -1327,6 +1836,16 @@ func init() {
 	proto.RegisterType((*GetAncestorResponse)(nil), "types.GetAncestorResponse")
 	proto.RegisterType((*GetHashByNo)(nil), "types.GetHashByNo")
 	proto.RegisterType((*GetHashByNoResponse)(nil), "types.GetHashByNoResponse")
+	proto.RegisterType((*GetLibBlockRequest)(nil), "types.GetLibBlockRequest")
+	proto.RegisterType((*GetLibBlockResponse)(nil), "types.GetLibBlockResponse")
+	proto.RegisterType((*GetTrieNodesRequest)(nil), "types.GetTrieNodesRequest")
+	proto.RegisterType((*GetTrieNodesResponse)(nil), "types.GetTrieNodesResponse")
+	proto.RegisterType((*GetStorageChunkRequest)(nil), "types.GetStorageChunkRequest")
+	proto.RegisterType((*GetStorageChunkResponse)(nil), "types.GetStorageChunkResponse")
+	proto.RegisterType((*GetStateProofRequest)(nil), "types.GetStateProofRequest")
+	proto.RegisterType((*GetStateProofResponse)(nil), "types.GetStateProofResponse")
+	proto.RegisterType((*BftProposal)(nil), "types.BftProposal")
+	proto.RegisterType((*RaftMessage)(nil), "types.RaftMessage")
 	proto.RegisterType((*GetHashesRequest)(nil), "types.GetHashesRequest")
 	proto.RegisterType((*GetHashesResponse)(nil), "types.GetHashesResponse")
 	proto.RegisterEnum("types.ResultStatus", ResultStatus_name, ResultStatus_value)
@@ -1335,76 +1854,94 @@ func init() {
 func init() { proto.RegisterFile("p2p.proto", fileDescriptor_p2p_20f73eee065405b6) }
 
 var fileDescriptor_p2p_20f73eee065405b6 = []byte{
-	// 1126 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xef, 0x72, 0xda, 0x46,
-	0x10, 0xaf, 0x00, 0x63, 0x58, 0xc0, 0x96, 0xcf, 0x4d, 0xa2, 0x71, 0x33, 0x29, 0xa3, 0xc9, 0xb4,
-	0x34, 0xcd, 0x38, 0x1d, 0xe7, 0x09, 0x64, 0x74, 0x01, 0x35, 0x70, 0x62, 0x0e, 0x91, 0xa6, 0xfd,
-	0x42, 0x05, 0x5c, 0x90, 0x5a, 0x5b, 0xa2, 0xba, 0xa3, 0xb1, 0xf3, 0xa5, 0x33, 0xfd, 0xd0, 0x37,
-	0xe8, 0x2b, 0xf4, 0x31, 0xfa, 0x66, 0x9d, 0xe9, 0xdc, 0x49, 0x02, 0x91, 0x3f, 0xf5, 0xd4, 0x93,
-	0x4f, 0xec, 0xee, 0xed, 0xed, 0xfe, 0xf6, 0xb7, 0x7b, 0x8b, 0xa0, 0xbe, 0x3a, 0x5b, 0x9d, 0xae,
-	0x92, 0x58, 0xc4, 0x68, 0x4f, 0x5c, 0xaf, 0x18, 0x3f, 0xd1, 0x67, 0x17, 0xf1, 0xfc, 0xe7, 0x79,
-	0xe0, 0x87, 0x51, 0x7a, 0x70, 0x02, 0x51, 0xbc, 0x60, 0xa9, 0x6c, 0xfe, 0xa3, 0x41, 0x7d, 0xc8,
-	0x97, 0x7d, 0xe6, 0x2f, 0x58, 0x82, 0x1e, 0x42, 0x6b, 0x7e, 0x11, 0xb2, 0x48, 0xbc, 0x60, 0x09,
-	0x0f, 0xe3, 0xc8, 0xd0, 0xda, 0x5a, 0xa7, 0x4e, 0x77, 0x8d, 0xe8, 0x3e, 0xd4, 0x45, 0x78, 0xc9,
-	0xb8, 0xf0, 0x2f, 0x57, 0x46, 0xa9, 0xad, 0x75, 0xca, 0x74, 0x6b, 0x40, 0x07, 0x50, 0x0a, 0x17,
-	0x46, 0x59, 0x5d, 0x2c, 0x85, 0x0b, 0x74, 0x17, 0xaa, 0xcb, 0x98, 0xf3, 0x70, 0x65, 0x54, 0xda,
-	0x5a, 0xa7, 0x46, 0x33, 0x4d, 0xda, 0x57, 0x8c, 0x25, 0x8e, 0x6d, 0xec, 0xb5, 0xb5, 0x4e, 0x93,
-	0x66, 0x1a, 0x7a, 0x00, 0x0a, 0xdf, 0x68, 0x3d, 0x7b, 0xce, 0xae, 0x8d, 0xaa, 0x3a, 0x2b, 0x58,
-	0x10, 0x82, 0x0a, 0x0f, 0x97, 0x91, 0xb1, 0xaf, 0x4e, 0x94, 0x8c, 0xda, 0xd0, 0xe0, 0xeb, 0x99,
-	0xaa, 0x68, 0x1e, 0x5f, 0x18, 0xb5, 0xb6, 0xd6, 0x69, 0xd1, 0xa2, 0x49, 0x66, 0xbb, 0x60, 0xd1,
-	0x52, 0x04, 0x46, 0x5d, 0x1d, 0x66, 0x9a, 0xf9, 0x2d, 0xc0, 0xe8, 0x6c, 0x34, 0x64, 0x9c, 0xfb,
-	0x4b, 0x86, 0x3a, 0x50, 0x0d, 0x14, 0x13, 0xaa, 0xf0, 0xc6, 0x99, 0x7e, 0xaa, 0x38, 0x3c, 0xdd,
-	0x30, 0x44, 0xb3, 0x73, 0x89, 0x62, 0xe1, 0x0b, 0x5f, 0x95, 0xdf, 0xa4, 0x4a, 0x36, 0x5d, 0xa8,
-	0x8c, 0xc2, 0x68, 0x89, 0xbe, 0x80, 0xc3, 0x19, 0xe3, 0x62, 0xaa, 0x88, 0x9f, 0x06, 0x3e, 0x0f,
-	0x54, 0xb8, 0x26, 0x6d, 0x49, 0xf3, 0xb9, 0xb4, 0xf6, 0x7d, 0x1e, 0xa0, 0xcf, 0xa1, 0xa1, 0xfc,
-	0x02, 0x16, 0x2e, 0x03, 0xa1, 0x42, 0x55, 0x28, 0x48, 0x53, 0x5f, 0x59, 0xcc, 0x01, 0x54, 0x46,
-	0x71, 0xb4, 0x94, 0x6d, 0xd9, 0xb9, 0xf9, 0xfe, 0x70, 0x0f, 0xa0, 0x70, 0xf7, 0x3d, 0xd1, 0xde,
-	0x40, 0x75, 0x2c, 0x7c, 0xb1, 0xe6, 0xe8, 0x11, 0x54, 0x39, 0x8b, 0xb6, 0x65, 0xa2, 0xac, 0xcc,
-	0x11, 0x63, 0x89, 0xb5, 0x58, 0x24, 0x8c, 0x73, 0x9a, 0x79, 0xbc, 0x9b, 0xbb, 0x74, 0x73, 0xee,
-	0xf2, 0x3b, 0xb9, 0x3b, 0xd0, 0xec, 0xc5, 0xd6, 0x6b, 0xff, 0x9a, 0xc4, 0x22, 0x9c, 0x33, 0x64,
-	0xc0, 0xfe, 0x65, 0xca, 0x79, 0x36, 0x62, 0xb9, 0x6a, 0xbe, 0x04, 0x3d, 0x83, 0xc0, 0x38, 0x65,
-	0xbf, 0xac, 0x19, 0x17, 0xff, 0x0b, 0xaf, 0x8c, 0xec, 0x5f, 0x8d, 0xc3, 0x37, 0x4c, 0x21, 0x6d,
-	0xd1, 0x5c, 0x35, 0x7f, 0x82, 0xa3, 0x42, 0x64, 0xbe, 0x8a, 0x23, 0xce, 0xd0, 0xd7, 0x50, 0xe5,
-	0x8a, 0x14, 0x15, 0xfa, 0xe0, 0xec, 0x38, 0x0b, 0x4d, 0x19, 0x5f, 0x5f, 0x88, 0x94, 0x2f, 0x9a,
-	0xb9, 0xa0, 0x0e, 0xec, 0xc9, 0x21, 0xe5, 0x46, 0xa9, 0x5d, 0xfe, 0x00, 0x8c, 0xd4, 0xc1, 0xec,
-	0xc3, 0x01, 0x61, 0xaf, 0x15, 0x3f, 0x59, 0xc5, 0xf7, 0xa1, 0x3e, 0x7b, 0xab, 0x7f, 0x5b, 0x83,
-	0x44, 0x3d, 0x4b, 0x9d, 0xb3, 0xc6, 0xe5, 0xaa, 0xf9, 0xbb, 0x06, 0x77, 0x7b, 0x2c, 0xa3, 0x5a,
-	0xcd, 0xde, 0x86, 0x16, 0x04, 0x95, 0xc2, 0x70, 0x29, 0x59, 0xce, 0xf9, 0xce, 0x38, 0x65, 0x9a,
-	0xb4, 0xc7, 0xaf, 0x5e, 0x71, 0x96, 0x37, 0x27, 0xd3, 0xd2, 0xd7, 0xf4, 0x86, 0xa9, 0xb7, 0xd9,
-	0xa2, 0x4a, 0x46, 0x3a, 0x94, 0x7d, 0x3e, 0x57, 0xcf, 0xb2, 0x46, 0xa5, 0x68, 0xfe, 0xa5, 0xc1,
-	0xbd, 0x77, 0x40, 0xdc, 0x86, 0x41, 0x09, 0xcf, 0xe7, 0x01, 0x4b, 0x29, 0x6c, 0xd2, 0x4c, 0x43,
-	0x8f, 0x61, 0x3f, 0x7d, 0x58, 0xdc, 0x28, 0xef, 0x70, 0x5b, 0x48, 0x49, 0x73, 0x17, 0xc9, 0x56,
-	0xe0, 0x73, 0xc2, 0xae, 0x44, 0xb6, 0x53, 0x72, 0xd5, 0xfc, 0x0a, 0x0e, 0x73, 0x9c, 0x39, 0x4b,
-	0xdb, 0x94, 0x5a, 0x31, 0xa5, 0xf9, 0x1b, 0xe8, 0x5b, 0xd7, 0xdb, 0xd4, 0xf2, 0x10, 0xaa, 0xaa,
-	0x49, 0xf9, 0x38, 0x34, 0x8b, 0x90, 0x69, 0x76, 0x56, 0xc4, 0x5a, 0xde, 0xc5, 0xfa, 0x14, 0xee,
-	0x10, 0xf6, 0xda, 0x4b, 0xfc, 0x88, 0xfb, 0x73, 0x11, 0xc6, 0x11, 0xcf, 0x46, 0xe5, 0x04, 0x6a,
-	0xe2, 0xaa, 0x5f, 0xc4, 0xbc, 0xd1, 0xcd, 0x6f, 0xd4, 0x34, 0x14, 0x2f, 0xdd, 0x54, 0xe7, 0x9f,
-	0x69, 0xef, 0x76, 0xaf, 0x7c, 0xcc, 0xde, 0x7d, 0x06, 0x65, 0x71, 0x95, 0xf7, 0xad, 0x9e, 0x45,
-	0xf0, 0xae, 0xa8, 0xb4, 0xfe, 0x47, 0xab, 0x7a, 0x70, 0xd4, 0x63, 0x62, 0x18, 0x72, 0x1e, 0x46,
-	0xcb, 0x1b, 0x8a, 0x90, 0x94, 0x70, 0x11, 0xaf, 0x82, 0xed, 0x02, 0xda, 0xe8, 0xe6, 0x63, 0x40,
-	0x3d, 0x26, 0xac, 0x68, 0xce, 0xb8, 0x88, 0x93, 0x9b, 0xe8, 0xf8, 0x43, 0x83, 0xe3, 0x1d, 0xf7,
-	0xdb, 0x50, 0x61, 0x42, 0xd3, 0xcf, 0x02, 0x14, 0x76, 0xe2, 0x8e, 0x4d, 0xae, 0xc4, 0x5c, 0x27,
-	0x71, 0xbe, 0x12, 0xb7, 0x16, 0xf3, 0x4b, 0x68, 0xf4, 0x98, 0x90, 0xae, 0xe7, 0xd7, 0x24, 0x2e,
-	0x6e, 0x00, 0x6d, 0x77, 0x03, 0xfc, 0xa8, 0x00, 0xe7, 0x8e, 0xb7, 0x03, 0xbc, 0xb3, 0x7d, 0x4a,
-	0x6f, 0x6d, 0x1f, 0x73, 0xa6, 0x9e, 0x42, 0x3a, 0x61, 0x39, 0x7f, 0x27, 0x50, 0x5b, 0x25, 0xec,
-	0xd7, 0xc2, 0xba, 0xda, 0xe8, 0xb2, 0x34, 0x29, 0x93, 0xf5, 0xe5, 0x8c, 0x25, 0xf9, 0x3f, 0xcd,
-	0xd6, 0xb2, 0x59, 0x2a, 0x69, 0xd1, 0x4a, 0x36, 0x13, 0xd5, 0xee, 0x3c, 0xc7, 0xc7, 0x9c, 0xbf,
-	0x0f, 0xbe, 0xb0, 0x47, 0x7f, 0x97, 0xa0, 0x59, 0x0c, 0x85, 0xaa, 0x50, 0x72, 0x9f, 0xeb, 0x9f,
-	0xa0, 0x26, 0xd4, 0xba, 0x16, 0xe9, 0xe2, 0x01, 0xb6, 0x75, 0x0d, 0x35, 0x60, 0x7f, 0x42, 0x9e,
-	0x13, 0xf7, 0x3b, 0xa2, 0x97, 0xd0, 0xa7, 0xa0, 0x3b, 0xe4, 0x85, 0x35, 0x70, 0xec, 0xa9, 0x45,
-	0x7b, 0x93, 0x21, 0x26, 0x9e, 0x5e, 0x46, 0x77, 0xe0, 0xc8, 0xc6, 0x96, 0x3d, 0x70, 0x08, 0x9e,
-	0xe2, 0x97, 0x5d, 0x8c, 0x6d, 0x6c, 0xeb, 0x15, 0xd4, 0x82, 0x3a, 0x71, 0xbd, 0xe9, 0x33, 0x77,
-	0x42, 0x6c, 0x7d, 0x0f, 0x21, 0x38, 0xb0, 0x06, 0x14, 0x5b, 0xf6, 0xf7, 0x53, 0xfc, 0xd2, 0x19,
-	0x7b, 0x63, 0xbd, 0x2a, 0x6f, 0x8e, 0x30, 0x1d, 0x3a, 0xe3, 0xb1, 0xe3, 0x92, 0xa9, 0x8d, 0x89,
-	0x83, 0x6d, 0x7d, 0x1f, 0xdd, 0x05, 0x44, 0xf1, 0xd8, 0x9d, 0xd0, 0xae, 0x0c, 0xd8, 0xb7, 0x26,
-	0x63, 0x0f, 0xdb, 0x7a, 0x0d, 0xdd, 0x83, 0xe3, 0x67, 0x96, 0x33, 0xc0, 0xf6, 0x74, 0x44, 0x71,
-	0xd7, 0x25, 0xb6, 0xe3, 0x39, 0x2e, 0xd1, 0xeb, 0x12, 0xa4, 0x75, 0xee, 0x52, 0xe9, 0x05, 0x48,
-	0x87, 0xa6, 0x3b, 0xf1, 0xa6, 0xee, 0xb3, 0x29, 0xb5, 0x48, 0x0f, 0xeb, 0x0d, 0x74, 0x04, 0xad,
-	0x09, 0x71, 0x86, 0xa3, 0x01, 0x96, 0x88, 0xb1, 0xad, 0x37, 0x65, 0x91, 0x0e, 0xf1, 0x30, 0x25,
-	0xd6, 0x40, 0x6f, 0xa1, 0x43, 0x68, 0x4c, 0x88, 0xf5, 0xc2, 0x72, 0x06, 0xd6, 0xf9, 0x00, 0xeb,
-	0x07, 0x12, 0xbb, 0x6d, 0x79, 0xd6, 0x74, 0xe0, 0x8e, 0xc7, 0xfa, 0x21, 0x3a, 0x86, 0xc3, 0x09,
-	0xb1, 0x26, 0x5e, 0x1f, 0x13, 0xcf, 0xe9, 0x5a, 0x32, 0x84, 0x7e, 0xde, 0xfe, 0xe1, 0xc1, 0x32,
-	0x14, 0xc1, 0x7a, 0x76, 0x3a, 0x8f, 0x2f, 0x9f, 0xf8, 0x2c, 0x59, 0xc6, 0x61, 0x9c, 0xfe, 0x3e,
-	0x51, 0x9d, 0x9a, 0x55, 0xd5, 0x17, 0xd6, 0xd3, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x10, 0xcd,
-	0xed, 0x5c, 0x78, 0x0a, 0x00, 0x00,
+	// 1421 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x6e, 0xdb, 0xc6,
+	0x12, 0x3e, 0xd4, 0x9f, 0xad, 0x91, 0x64, 0xd3, 0x6b, 0x27, 0x21, 0x7c, 0x82, 0x1c, 0x1d, 0x22,
+	0x38, 0xd1, 0x49, 0x03, 0xa7, 0x70, 0xd0, 0x07, 0xa0, 0x45, 0x46, 0x66, 0x2d, 0x53, 0xc2, 0x4a,
+	0x72, 0x93, 0xde, 0xa8, 0x94, 0xb4, 0x96, 0xd8, 0xc8, 0x5c, 0x95, 0xbb, 0x4a, 0xec, 0xdc, 0x14,
+	0xe8, 0x45, 0xdf, 0xa0, 0x6f, 0x50, 0xf4, 0x2d, 0xda, 0x37, 0x2b, 0x50, 0xec, 0x72, 0x69, 0x91,
+	0x4e, 0x1c, 0xb7, 0x42, 0xae, 0xb4, 0x33, 0x3b, 0x3b, 0xfb, 0xcd, 0xb7, 0x33, 0xc3, 0x11, 0x94,
+	0x17, 0x87, 0x8b, 0x83, 0x45, 0x44, 0x39, 0x45, 0x45, 0x7e, 0xb5, 0x20, 0x6c, 0x5f, 0x1f, 0xcd,
+	0xe9, 0xf8, 0xcd, 0x78, 0xe6, 0x07, 0x61, 0xbc, 0xb1, 0x0f, 0x21, 0x9d, 0x90, 0x78, 0x6d, 0xfe,
+	0xa9, 0x41, 0xf9, 0x94, 0x4d, 0x8f, 0x89, 0x3f, 0x21, 0x11, 0x7a, 0x0c, 0xb5, 0xf1, 0x3c, 0x20,
+	0x21, 0x3f, 0x23, 0x11, 0x0b, 0x68, 0x68, 0x68, 0x75, 0xad, 0x51, 0xc6, 0x59, 0x25, 0x7a, 0x08,
+	0x65, 0x1e, 0x5c, 0x10, 0xc6, 0xfd, 0x8b, 0x85, 0x91, 0xab, 0x6b, 0x8d, 0x3c, 0x5e, 0x29, 0xd0,
+	0x16, 0xe4, 0x82, 0x89, 0x91, 0x97, 0x07, 0x73, 0xc1, 0x04, 0xdd, 0x87, 0xd2, 0x94, 0x32, 0x16,
+	0x2c, 0x8c, 0x42, 0x5d, 0x6b, 0x6c, 0x62, 0x25, 0x09, 0xfd, 0x82, 0x90, 0xc8, 0xb5, 0x8d, 0x62,
+	0x5d, 0x6b, 0x54, 0xb1, 0x92, 0xd0, 0x23, 0x90, 0xf8, 0xba, 0xcb, 0xd1, 0x09, 0xb9, 0x32, 0x4a,
+	0x72, 0x2f, 0xa5, 0x41, 0x08, 0x0a, 0x2c, 0x98, 0x86, 0xc6, 0x86, 0xdc, 0x91, 0x6b, 0x54, 0x87,
+	0x0a, 0x5b, 0x8e, 0x64, 0x44, 0x63, 0x3a, 0x37, 0x36, 0xeb, 0x5a, 0xa3, 0x86, 0xd3, 0x2a, 0x71,
+	0xdb, 0x9c, 0x84, 0x53, 0x3e, 0x33, 0xca, 0x72, 0x53, 0x49, 0xe6, 0xd7, 0x00, 0xdd, 0xc3, 0xee,
+	0x29, 0x61, 0xcc, 0x9f, 0x12, 0xd4, 0x80, 0xd2, 0x4c, 0x32, 0x21, 0x03, 0xaf, 0x1c, 0xea, 0x07,
+	0x92, 0xc3, 0x83, 0x6b, 0x86, 0xb0, 0xda, 0x17, 0x28, 0x26, 0x3e, 0xf7, 0x65, 0xf8, 0x55, 0x2c,
+	0xd7, 0x66, 0x07, 0x0a, 0xdd, 0x20, 0x9c, 0xa2, 0xff, 0xc1, 0xf6, 0x88, 0x30, 0x3e, 0x94, 0xc4,
+	0x0f, 0x67, 0x3e, 0x9b, 0x49, 0x77, 0x55, 0x5c, 0x13, 0xea, 0x23, 0xa1, 0x3d, 0xf6, 0xd9, 0x0c,
+	0xfd, 0x07, 0x2a, 0xd2, 0x6e, 0x46, 0x82, 0xe9, 0x8c, 0x4b, 0x57, 0x05, 0x0c, 0x42, 0x75, 0x2c,
+	0x35, 0x66, 0x1b, 0x0a, 0x5d, 0x1a, 0x4e, 0xc5, 0xb3, 0x64, 0x4e, 0x7e, 0xdc, 0xdd, 0x23, 0x48,
+	0x9d, 0xfd, 0x88, 0xb7, 0xf7, 0x50, 0xea, 0x71, 0x9f, 0x2f, 0x19, 0x7a, 0x0a, 0x25, 0x46, 0xc2,
+	0x55, 0x98, 0x48, 0x85, 0xd9, 0x25, 0x24, 0xb2, 0x26, 0x93, 0x88, 0x30, 0x86, 0x95, 0xc5, 0x87,
+	0x77, 0xe7, 0xee, 0xbe, 0x3b, 0xff, 0xc1, 0xdd, 0x0d, 0xa8, 0xb6, 0xa8, 0xf5, 0xce, 0xbf, 0xf2,
+	0x28, 0x0f, 0xc6, 0x04, 0x19, 0xb0, 0x71, 0x11, 0x73, 0xae, 0x52, 0x2c, 0x11, 0xcd, 0x57, 0xa0,
+	0x2b, 0x08, 0x84, 0x61, 0xf2, 0xc3, 0x92, 0x30, 0xfe, 0x8f, 0xf0, 0x0a, 0xcf, 0xfe, 0x65, 0x2f,
+	0x78, 0x4f, 0x24, 0xd2, 0x1a, 0x4e, 0x44, 0xf3, 0x7b, 0xd8, 0x49, 0x79, 0x66, 0x0b, 0x1a, 0x32,
+	0x82, 0xbe, 0x80, 0x12, 0x93, 0xa4, 0x48, 0xd7, 0x5b, 0x87, 0xbb, 0xca, 0x35, 0x26, 0x6c, 0x39,
+	0xe7, 0x31, 0x5f, 0x58, 0x99, 0xa0, 0x06, 0x14, 0x45, 0x92, 0x32, 0x23, 0x57, 0xcf, 0xdf, 0x02,
+	0x23, 0x36, 0x30, 0x8f, 0x61, 0xcb, 0x23, 0xef, 0x24, 0x3f, 0x2a, 0xe2, 0x87, 0x50, 0x1e, 0xdd,
+	0x78, 0xbf, 0x95, 0x42, 0xa0, 0x1e, 0xc5, 0xc6, 0xea, 0xe1, 0x12, 0xd1, 0xfc, 0x49, 0x83, 0xfb,
+	0x2d, 0xa2, 0xa8, 0x96, 0xb9, 0x77, 0x4d, 0x0b, 0x82, 0x42, 0x2a, 0xb9, 0xe4, 0x5a, 0xe4, 0x79,
+	0x26, 0x9d, 0x94, 0x24, 0xf4, 0xf4, 0xfc, 0x9c, 0x91, 0xe4, 0x71, 0x94, 0x14, 0x57, 0xd3, 0x7b,
+	0x22, 0x6b, 0xb3, 0x86, 0xe5, 0x1a, 0xe9, 0x90, 0xf7, 0xd9, 0x58, 0x96, 0xe5, 0x26, 0x16, 0x4b,
+	0xf3, 0x37, 0x0d, 0x1e, 0x7c, 0x00, 0x62, 0x1d, 0x06, 0x05, 0x3c, 0x9f, 0xcd, 0x48, 0x4c, 0x61,
+	0x15, 0x2b, 0x09, 0x3d, 0x83, 0x8d, 0xb8, 0xb0, 0x98, 0x91, 0xcf, 0x70, 0x9b, 0xba, 0x12, 0x27,
+	0x26, 0x82, 0xad, 0x99, 0xcf, 0x3c, 0x72, 0xc9, 0x55, 0x4f, 0x49, 0x44, 0xf3, 0xff, 0xb0, 0x9d,
+	0xe0, 0x4c, 0x58, 0x5a, 0x5d, 0xa9, 0xa5, 0xaf, 0x34, 0x7f, 0x04, 0x7d, 0x65, 0xba, 0x4e, 0x2c,
+	0x8f, 0xa1, 0x24, 0x1f, 0x29, 0x49, 0x87, 0x6a, 0x1a, 0x32, 0x56, 0x7b, 0x69, 0xac, 0xf9, 0x2c,
+	0xd6, 0x17, 0x70, 0xcf, 0x23, 0xef, 0xfa, 0x91, 0x1f, 0x32, 0x7f, 0xcc, 0x03, 0x1a, 0x32, 0x95,
+	0x2a, 0xfb, 0xb0, 0xc9, 0x2f, 0x8f, 0xd3, 0x98, 0xaf, 0x65, 0xf3, 0x4b, 0x99, 0x0d, 0xe9, 0x43,
+	0x77, 0xc5, 0xf9, 0x4b, 0xfc, 0x76, 0xd9, 0x23, 0x9f, 0xf3, 0xed, 0xfe, 0x0d, 0x79, 0x7e, 0x99,
+	0xbc, 0x5b, 0x59, 0x79, 0xe8, 0x5f, 0x62, 0xa1, 0xfd, 0xc4, 0x53, 0xb5, 0x60, 0xa7, 0x45, 0xf8,
+	0x69, 0xc0, 0x58, 0x10, 0x4e, 0xef, 0x08, 0x42, 0x50, 0xc2, 0x38, 0x5d, 0xcc, 0x56, 0x0d, 0xe8,
+	0x5a, 0x36, 0x9f, 0x01, 0x6a, 0x11, 0x6e, 0x85, 0x63, 0xc2, 0x38, 0x8d, 0xee, 0xa2, 0xe3, 0x67,
+	0x0d, 0x76, 0x33, 0xe6, 0xeb, 0x50, 0x61, 0x42, 0xd5, 0x57, 0x0e, 0x52, 0x3d, 0x31, 0xa3, 0x13,
+	0x2d, 0x31, 0x91, 0x3d, 0x9a, 0xb4, 0xc4, 0x95, 0xc6, 0x7c, 0x02, 0x95, 0x16, 0xe1, 0xc2, 0xf4,
+	0xe8, 0xca, 0xa3, 0xe9, 0x0e, 0xa0, 0x65, 0x3b, 0xc0, 0x77, 0xb0, 0x9b, 0x32, 0x5c, 0x0f, 0x70,
+	0xa6, 0xfb, 0xe4, 0x6e, 0x74, 0x1f, 0x73, 0x4f, 0x32, 0xd8, 0x0e, 0x46, 0xe9, 0xc2, 0x31, 0xcf,
+	0x61, 0x37, 0xa3, 0x5d, 0x8f, 0xa8, 0xa2, 0xbc, 0x46, 0xde, 0x79, 0xb3, 0x44, 0xe2, 0x2d, 0xd3,
+	0x92, 0xf7, 0xf4, 0xa3, 0x80, 0x78, 0x74, 0x42, 0xd2, 0xdd, 0x2d, 0xa2, 0x94, 0x27, 0xdd, 0x4d,
+	0xac, 0x6f, 0x4b, 0x41, 0xf3, 0x35, 0xec, 0x65, 0x5d, 0xac, 0x83, 0x75, 0x0f, 0x8a, 0x62, 0xcc,
+	0x48, 0x7c, 0xc7, 0x82, 0x79, 0x26, 0x0b, 0xae, 0xc7, 0x69, 0xe4, 0x4f, 0x49, 0x73, 0xb6, 0x0c,
+	0xdf, 0x7c, 0x0a, 0xe0, 0x1e, 0x14, 0x19, 0xf7, 0x23, 0xae, 0x38, 0x8e, 0x85, 0xeb, 0x26, 0x9b,
+	0x5f, 0x35, 0x59, 0xf3, 0xf7, 0xb8, 0x2c, 0xb3, 0x8e, 0xd7, 0x81, 0x8d, 0xa0, 0xf0, 0x86, 0x5c,
+	0x25, 0xa8, 0xe5, 0x5a, 0xf0, 0xf4, 0xd6, 0x9f, 0x2f, 0x49, 0x5c, 0x95, 0x55, 0xac, 0xa4, 0xdb,
+	0xab, 0x11, 0x3d, 0x87, 0xd2, 0x22, 0xa2, 0xf4, 0x9c, 0x19, 0x45, 0x59, 0xc7, 0x0f, 0xd4, 0x95,
+	0x4d, 0x1a, 0xf2, 0xc8, 0x1f, 0xf3, 0x33, 0x3f, 0xea, 0x8a, 0x7d, 0xac, 0xcc, 0x44, 0x1d, 0xed,
+	0x49, 0xfc, 0x3e, 0x27, 0xf1, 0xce, 0x27, 0x68, 0x31, 0x60, 0xc3, 0x1f, 0x8f, 0xe9, 0x32, 0x4c,
+	0x88, 0x49, 0x44, 0x51, 0x25, 0x63, 0x7a, 0xb1, 0x90, 0x5f, 0xe5, 0x89, 0xea, 0x90, 0x29, 0x8d,
+	0x9c, 0xec, 0x62, 0x8a, 0x4e, 0x44, 0x90, 0x05, 0x19, 0x4e, 0x5a, 0x65, 0xfe, 0xaa, 0xc1, 0xbd,
+	0x1b, 0x40, 0xd6, 0xa1, 0xf1, 0x09, 0x14, 0x65, 0x64, 0x2a, 0x53, 0x77, 0x94, 0x6d, 0xca, 0x6d,
+	0xbc, 0x8f, 0xbe, 0x82, 0xf2, 0x5b, 0x45, 0x46, 0xd2, 0xf4, 0x6e, 0x25, 0x6b, 0x65, 0x69, 0x32,
+	0xa8, 0x1c, 0x9d, 0xf3, 0x6e, 0x44, 0x17, 0x94, 0xf9, 0x73, 0x91, 0x28, 0x11, 0x5d, 0x86, 0x13,
+	0x09, 0xad, 0x86, 0x63, 0xe1, 0xef, 0x94, 0x8b, 0x9c, 0x9b, 0xe3, 0xd9, 0x38, 0xaf, 0xe6, 0xe6,
+	0xec, 0x5c, 0x5c, 0x58, 0xcd, 0xc5, 0xe6, 0x7f, 0xa1, 0x82, 0xfd, 0x73, 0x9e, 0x8c, 0xb7, 0xc9,
+	0xd0, 0xaa, 0xa5, 0x86, 0xd6, 0x91, 0xfc, 0x0c, 0xc6, 0x5f, 0x97, 0xe4, 0x09, 0xf7, 0x61, 0x73,
+	0x11, 0x91, 0xb7, 0xa9, 0x51, 0xe5, 0x5a, 0x16, 0x0f, 0x26, 0xd6, 0xde, 0xf2, 0x62, 0x44, 0xa2,
+	0x64, 0xca, 0x5c, 0x69, 0x32, 0xb9, 0x5e, 0x50, 0xb9, 0x1e, 0xc9, 0x56, 0x9f, 0xdc, 0xf1, 0x39,
+	0xbf, 0x3d, 0xb7, 0x7e, 0x5d, 0x9f, 0xfe, 0x91, 0x83, 0x6a, 0xda, 0x15, 0x2a, 0x41, 0xae, 0x73,
+	0xa2, 0xff, 0x0b, 0x55, 0x61, 0xb3, 0x69, 0x79, 0x4d, 0xa7, 0xed, 0xd8, 0xba, 0x86, 0x2a, 0xb0,
+	0x31, 0xf0, 0x4e, 0xbc, 0xce, 0x37, 0x9e, 0x9e, 0x43, 0x7b, 0xa0, 0xbb, 0xde, 0x99, 0xd5, 0x76,
+	0xed, 0xa1, 0x85, 0x5b, 0x83, 0x53, 0xc7, 0xeb, 0xeb, 0x79, 0x74, 0x0f, 0x76, 0x6c, 0xc7, 0xb2,
+	0xdb, 0xae, 0xe7, 0x0c, 0x9d, 0x57, 0x4d, 0xc7, 0xb1, 0x1d, 0x5b, 0x2f, 0xa0, 0x1a, 0x94, 0xbd,
+	0x4e, 0x7f, 0xf8, 0xb2, 0x33, 0xf0, 0x6c, 0xbd, 0x88, 0x10, 0x6c, 0x59, 0x6d, 0xec, 0x58, 0xf6,
+	0xeb, 0xa1, 0xf3, 0xca, 0xed, 0xf5, 0x7b, 0x7a, 0x49, 0x9c, 0xec, 0x3a, 0xf8, 0xd4, 0xed, 0xf5,
+	0xdc, 0x8e, 0x37, 0xb4, 0x1d, 0xcf, 0x75, 0x6c, 0x7d, 0x03, 0xdd, 0x07, 0x84, 0x9d, 0x5e, 0x67,
+	0x80, 0x9b, 0xc2, 0xe1, 0xb1, 0x35, 0xe8, 0xf5, 0x1d, 0x5b, 0xdf, 0x44, 0x0f, 0x60, 0xf7, 0xa5,
+	0xe5, 0xb6, 0x1d, 0x7b, 0xd8, 0xc5, 0x4e, 0xb3, 0xe3, 0xd9, 0x6e, 0xdf, 0xed, 0x78, 0x7a, 0x59,
+	0x80, 0xb4, 0x8e, 0x3a, 0x58, 0x58, 0x01, 0xd2, 0xa1, 0xda, 0x19, 0xf4, 0x87, 0x9d, 0x97, 0x43,
+	0x6c, 0x79, 0x2d, 0x47, 0xaf, 0xa0, 0x1d, 0xa8, 0x0d, 0x3c, 0xf7, 0xb4, 0xdb, 0x76, 0x04, 0x62,
+	0xc7, 0xd6, 0xab, 0x22, 0x48, 0xd7, 0xeb, 0x3b, 0xd8, 0xb3, 0xda, 0x7a, 0x0d, 0x6d, 0x43, 0x65,
+	0xe0, 0x59, 0x67, 0x96, 0xdb, 0xb6, 0x8e, 0xda, 0x8e, 0xbe, 0x25, 0xb0, 0xdb, 0x56, 0xdf, 0x1a,
+	0xb6, 0x3b, 0xbd, 0x9e, 0xbe, 0x8d, 0x76, 0x61, 0x7b, 0xe0, 0x59, 0x83, 0xfe, 0xb1, 0xe3, 0xf5,
+	0xdd, 0xa6, 0x25, 0x5c, 0xe8, 0x47, 0xf5, 0x6f, 0x1f, 0x4d, 0x03, 0x3e, 0x5b, 0x8e, 0x0e, 0xc6,
+	0xf4, 0xe2, 0xb9, 0x4f, 0xa2, 0x29, 0x0d, 0x68, 0xfc, 0xfb, 0x5c, 0xbe, 0xd4, 0xa8, 0x24, 0xff,
+	0x5d, 0xbd, 0xf8, 0x6b, 0x00, 0x14, 0x04, 0xc5, 0xee, 0x74, 0x0e, 0x00, 0x00,
 }
@@ -0,0 +1,24 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/minio/sha256-simd"
+)
+
+// RaftID returns the Raft node ID of the member of id in the Raft consensus.
+func RaftID(id peer.ID) uint64 {
+	h := sha256.Sum256([]byte(id))
+	n := binary.BigEndian.Uint64(h[:8])
+	if n == 0 {
+		// 0 is not a valid Raft node ID.
+		n = 1
+	}
+	return n
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
 	"strings"
 
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/internal/merkle"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/minio/sha256-simd"
@@ -19,22 +23,114 @@ func NewReceipt(contractAddress []byte, status string, jsonRet string) *Receipt
 	}
 }
 
+// receiptV2 follows the status in the encoding of version 2, where the legacy
+// encoding has the ret. It never starts a valid UTF-8 text.
+const receiptV2 = byte(0xff)
+
+// MarshalBinary encodes r in version 2, which has the length of the ret, the
+// events and the gas. It is also what r is hashed in since the V2 fork.
 func (r Receipt) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+	l := make([]byte, 4)
+	l8 := make([]byte, 8)
+	b.Write(r.ContractAddress)
+	binary.LittleEndian.PutUint16(l[:2], uint16(len(r.Status)))
+	b.Write(l[:2])
+	b.WriteString(r.Status)
+	b.WriteByte(receiptV2)
+	binary.LittleEndian.PutUint32(l, uint32(len(r.Ret)))
+	b.Write(l)
+	b.WriteString(r.Ret)
+	binary.LittleEndian.PutUint32(l, uint32(len(r.Events)))
+	b.Write(l)
+	for _, ev := range r.Events {
+		evB, err := ev.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b.Write(evB)
+	}
+	binary.LittleEndian.PutUint64(l8, r.GasUsed)
+	b.Write(l8)
+	binary.LittleEndian.PutUint32(l, uint32(len(r.FeeUsed)))
+	b.Write(l)
+	b.Write(r.FeeUsed)
+	return b.Bytes(), nil
+}
+
+// marshalLegacy encodes r as before the V2 fork. The ret takes the rest of
+// the data, and neither the events nor the gas are included.
+func (r Receipt) marshalLegacy() []byte {
 	var b bytes.Buffer
 	l := make([]byte, 2)
 	b.Write(r.ContractAddress)
-	binary.LittleEndian.PutUint16(l[:], uint16(len(r.Status)))
+	binary.LittleEndian.PutUint16(l, uint16(len(r.Status)))
 	b.Write(l)
 	b.WriteString(r.Status)
 	b.WriteString(r.Ret)
-	return b.Bytes(), nil
+	return b.Bytes()
 }
 
+// UnmarshalBinary decodes both the legacy encoding, in which the receipts
+// before the V2 fork are stored, and the encoding of version 2.
 func (r *Receipt) UnmarshalBinary(data []byte) error {
+	if len(data) < 35 {
+		return errors.New("invalid receipt: too short")
+	}
 	r.ContractAddress = data[:33]
-	l := binary.LittleEndian.Uint16(data[33:])
-	r.Status = string(data[35 : 35+l])
-	r.Ret = string(data[35+l:])
+	pos := 33
+	l := int(binary.LittleEndian.Uint16(data[pos:]))
+	pos += 2
+	if len(data) < pos+l {
+		return errors.New("invalid receipt: status")
+	}
+	r.Status = string(data[pos : pos+l])
+	pos += l
+
+	r.Events = nil
+	r.GasUsed = 0
+	r.FeeUsed = nil
+	if pos == len(data) || data[pos] != receiptV2 {
+		r.Ret = string(data[pos:])
+		return nil
+	}
+	pos++
+
+	if len(data) < pos+4 {
+		return errors.New("invalid receipt: ret")
+	}
+	l = int(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	if len(data) < pos+l+4 {
+		return errors.New("invalid receipt: ret")
+	}
+	r.Ret = string(data[pos : pos+l])
+	pos += l
+	evCount := int(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	for i := 0; i < evCount; i++ {
+		ev := new(Event)
+		n, err := ev.unmarshalBinary(data[pos:])
+		if err != nil {
+			return err
+		}
+		ev.EventIdx = int32(i)
+		r.Events = append(r.Events, ev)
+		pos += n
+	}
+	if len(data) < pos+12 {
+		return errors.New("invalid receipt: gas")
+	}
+	r.GasUsed = binary.LittleEndian.Uint64(data[pos:])
+	pos += 8
+	l = int(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	if len(data) < pos+l {
+		return errors.New("invalid receipt: fee")
+	}
+	if l > 0 {
+		r.FeeUsed = data[pos : pos+l]
+	}
 	return nil
 }
 
@@ -49,15 +145,34 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 	b.WriteString(`","status":"`)
 	b.WriteString(strings.Replace(r.Status, "\"", "'", -1))
 	if len(r.Ret) == 0 {
-		b.WriteString(`","ret": {}}`)
+		b.WriteString(`","ret": {}`)
 	} else {
 		b.WriteString(`","ret": `)
 		b.WriteString(r.Ret)
-		b.WriteString(`}`)
 	}
+	b.WriteString(`,"feeUsed":"`)
+	b.WriteString(new(big.Int).SetBytes(r.FeeUsed).String())
+	b.WriteString(`","gasUsed":`)
+	b.WriteString(strconv.FormatUint(r.GasUsed, 10))
+	if len(r.Events) != 0 {
+		b.WriteString(`,"events":[`)
+		for i, ev := range r.Events {
+			if i != 0 {
+				b.WriteString(`,`)
+			}
+			evB, err := ev.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			b.Write(evB)
+		}
+		b.WriteString(`]`)
+	}
+	b.WriteString(`}`)
 	return b.Bytes(), nil
 }
 
+// GetHash returns the hash of r in the encoding of version 2.
 func (r Receipt) GetHash() []byte {
 	h := sha256.New()
 	b, _ := r.MarshalBinary()
@@ -65,8 +180,20 @@ func (r Receipt) GetHash() []byte {
 	return h.Sum(nil)
 }
 
+// legacyReceipt is a receipt hashed as before the V2 fork.
+type legacyReceipt struct {
+	*Receipt
+}
+
+func (r legacyReceipt) GetHash() []byte {
+	h := sha256.New()
+	h.Write(r.marshalLegacy())
+	return h.Sum(nil)
+}
+
 type Receipts []*Receipt
 
+// MerkleRoot returns the receipts root of a block by the rules of version 2.
 func (rs Receipts) MerkleRoot() []byte {
 	mes := make([]merkle.MerkleEntry, len(rs))
 	for i, r := range rs {
@@ -74,3 +201,87 @@ func (rs Receipts) MerkleRoot() []byte {
 	}
 	return merkle.CalculateMerkleRoot(mes)
 }
+
+// LegacyMerkleRoot returns the receipts root of a block before the V2 fork.
+func (rs Receipts) LegacyMerkleRoot() []byte {
+	mes := make([]merkle.MerkleEntry, len(rs))
+	for i, r := range rs {
+		mes[i] = legacyReceipt{r}
+	}
+	return merkle.CalculateMerkleRoot(mes)
+}
+
+// SetLocation records where the event was emitted.
+func (ev *Event) SetLocation(txHash, blockHash []byte, blockNo BlockNo, txIndex int32) {
+	ev.TxHash = txHash
+	ev.BlockHash = blockHash
+	ev.BlockNo = blockNo
+	ev.TxIndex = txIndex
+}
+
+// MarshalBinary encodes only the fields produced by the contract. The
+// location of an event (tx, block, index) is derived from the receipt which
+// contains it.
+func (ev *Event) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+	l := make([]byte, 4)
+	b.Write(ev.ContractAddress)
+	binary.LittleEndian.PutUint16(l[:2], uint16(len(ev.EventName)))
+	b.Write(l[:2])
+	b.WriteString(ev.EventName)
+	binary.LittleEndian.PutUint32(l, uint32(len(ev.JsonArgs)))
+	b.Write(l)
+	b.WriteString(ev.JsonArgs)
+	return b.Bytes(), nil
+}
+
+func (ev *Event) unmarshalBinary(data []byte) (int, error) {
+	if len(data) < 35 {
+		return 0, errors.New("invalid event: too short")
+	}
+	ev.ContractAddress = data[:33]
+	pos := 33
+	l := int(binary.LittleEndian.Uint16(data[pos:]))
+	pos += 2
+	if len(data) < pos+l+4 {
+		return 0, errors.New("invalid event: name")
+	}
+	ev.EventName = string(data[pos : pos+l])
+	pos += l
+	l = int(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	if len(data) < pos+l {
+		return 0, errors.New("invalid event: args")
+	}
+	ev.JsonArgs = string(data[pos : pos+l])
+	pos += l
+	return pos, nil
+}
+
+func (ev *Event) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`{"contractAddress":"`)
+	b.WriteString(EncodeAddress(ev.ContractAddress))
+	b.WriteString(`","eventName":"`)
+	b.WriteString(strings.Replace(ev.EventName, "\"", "'", -1))
+	b.WriteString(`","args":`)
+	if len(ev.JsonArgs) == 0 {
+		b.WriteString(`[]`)
+	} else {
+		b.WriteString(ev.JsonArgs)
+	}
+	b.WriteString(`,"eventIdx":`)
+	b.WriteString(strconv.FormatInt(int64(ev.EventIdx), 10))
+	if len(ev.TxHash) != 0 {
+		b.WriteString(`,"txHash":"`)
+		b.WriteString(enc.ToString(ev.TxHash))
+		b.WriteString(`","blockHash":"`)
+		b.WriteString(enc.ToString(ev.BlockHash))
+		b.WriteString(`","blockNo":`)
+		b.WriteString(strconv.FormatUint(ev.BlockNo, 10))
+		b.WriteString(`,"txIndex":`)
+		b.WriteString(strconv.FormatInt(int64(ev.TxIndex), 10))
+	}
+	b.WriteString(`}`)
+	return b.Bytes(), nil
+}
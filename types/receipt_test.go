@@ -0,0 +1,55 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/minio/sha256-simd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptMarshalBinary(t *testing.T) {
+	addr := bytes.Repeat([]byte{0x0C}, AddressLength)
+	r := NewReceipt(addr, "SUCCESS", `"ret"`)
+	r.Events = []*Event{
+		{ContractAddress: addr, EventName: "inc", JsonArgs: `[1]`},
+		{ContractAddress: addr, EventName: "done", EventIdx: 1},
+	}
+	r.GasUsed = 1234
+	r.FeeUsed = big.NewInt(2468).Bytes()
+
+	b, err := r.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got Receipt
+	assert.NoError(t, got.UnmarshalBinary(b))
+	assert.Equal(t, r.ContractAddress, got.ContractAddress)
+	assert.Equal(t, r.Status, got.Status)
+	assert.Equal(t, r.Ret, got.Ret)
+	assert.Equal(t, 2, len(got.Events))
+	assert.Equal(t, "done", got.Events[1].EventName)
+	assert.Equal(t, int32(1), got.Events[1].EventIdx)
+	assert.Equal(t, r.GasUsed, got.GasUsed)
+	assert.Equal(t, r.FeeUsed, got.FeeUsed)
+
+	// a receipt stored before the V2 fork
+	legacy := NewReceipt(addr, "SUCCESS", `{"a":1}`)
+	got = Receipt{}
+	assert.NoError(t, got.UnmarshalBinary(legacy.marshalLegacy()))
+	assert.Equal(t, legacy.Status, got.Status)
+	assert.Equal(t, legacy.Ret, got.Ret)
+	assert.Equal(t, uint64(0), got.GasUsed)
+	assert.Nil(t, got.Events)
+	got = Receipt{}
+	assert.NoError(t, got.UnmarshalBinary(NewReceipt(addr, "SUCCESS", "").marshalLegacy()))
+	assert.Equal(t, "", got.Ret)
+
+	// the receipts root is computed by the encoding of the block
+	h := sha256.Sum256(legacy.marshalLegacy())
+	assert.Equal(t, h[:], legacyReceipt{legacy}.GetHash())
+	assert.NotEqual(t, Receipts{legacy}.MerkleRoot(), Receipts{legacy}.LegacyMerkleRoot())
+	assert.Equal(t, Receipts{}.MerkleRoot(), Receipts{}.LegacyMerkleRoot())
+
+	assert.Error(t, got.UnmarshalBinary(b[:len(b)-1]))
+}
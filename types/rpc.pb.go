@@ -395,6 +395,61 @@ func (m *AccountAndRoot) GetCompressed() bool {
 	return false
 }
 
+type AccountAtBlock struct {
+	Account              []byte   `protobuf:"bytes,1,opt,name=Account,proto3" json:"Account,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,2,opt,name=BlockNo,proto3" json:"BlockNo,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,3,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountAtBlock) Reset()         { *m = AccountAtBlock{} }
+func (m *AccountAtBlock) String() string { return proto.CompactTextString(m) }
+func (*AccountAtBlock) ProtoMessage()    {}
+func (*AccountAtBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+}
+
+func (m *AccountAtBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountAtBlock.Unmarshal(m, b)
+}
+func (m *AccountAtBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountAtBlock.Marshal(b, m, deterministic)
+}
+func (m *AccountAtBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountAtBlock.Merge(m, src)
+}
+func (m *AccountAtBlock) XXX_Size() int {
+	return xxx_messageInfo_AccountAtBlock.Size(m)
+}
+func (m *AccountAtBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountAtBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountAtBlock proto.InternalMessageInfo
+
+func (m *AccountAtBlock) GetAccount() []byte {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *AccountAtBlock) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *AccountAtBlock) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type Peer struct {
 	Address              *PeerAddress    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Bestblock            *NewBlockNotice `protobuf:"bytes,2,opt,name=bestblock,proto3" json:"bestblock,omitempty"`
@@ -408,7 +463,7 @@ func (m *Peer) Reset()         { *m = Peer{} }
 func (m *Peer) String() string { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()    {}
 func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+	return fileDescriptor_77a6da22d6a3feb1, []int{7}
 }
 
 func (m *Peer) XXX_Unmarshal(b []byte) error {
@@ -461,7 +516,7 @@ func (m *PeerList) Reset()         { *m = PeerList{} }
 func (m *PeerList) String() string { return proto.CompactTextString(m) }
 func (*PeerList) ProtoMessage()    {}
 func (*PeerList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{7}
+	return fileDescriptor_77a6da22d6a3feb1, []int{8}
 }
 
 func (m *PeerList) XXX_Unmarshal(b []byte) error {
@@ -489,12 +544,142 @@ func (m *PeerList) GetPeers() []*Peer {
 	return nil
 }
 
+type MembershipChange struct {
+	Remove               bool     `protobuf:"varint,1,opt,name=remove,proto3" json:"remove,omitempty"`
+	NodeID               string   `protobuf:"bytes,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MembershipChange) Reset()         { *m = MembershipChange{} }
+func (m *MembershipChange) String() string { return proto.CompactTextString(m) }
+func (*MembershipChange) ProtoMessage()    {}
+func (*MembershipChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+}
+
+func (m *MembershipChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MembershipChange.Unmarshal(m, b)
+}
+func (m *MembershipChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MembershipChange.Marshal(b, m, deterministic)
+}
+func (m *MembershipChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipChange.Merge(m, src)
+}
+func (m *MembershipChange) XXX_Size() int {
+	return xxx_messageInfo_MembershipChange.Size(m)
+}
+func (m *MembershipChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembershipChange proto.InternalMessageInfo
+
+func (m *MembershipChange) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+func (m *MembershipChange) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+type EvidenceList struct {
+	Evidences            []*DoubleSignEvidence `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *EvidenceList) Reset()         { *m = EvidenceList{} }
+func (m *EvidenceList) String() string { return proto.CompactTextString(m) }
+func (*EvidenceList) ProtoMessage()    {}
+func (*EvidenceList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+}
+
+func (m *EvidenceList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvidenceList.Unmarshal(m, b)
+}
+func (m *EvidenceList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvidenceList.Marshal(b, m, deterministic)
+}
+func (m *EvidenceList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceList.Merge(m, src)
+}
+func (m *EvidenceList) XXX_Size() int {
+	return xxx_messageInfo_EvidenceList.Size(m)
+}
+func (m *EvidenceList) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidenceList proto.InternalMessageInfo
+
+func (m *EvidenceList) GetEvidences() []*DoubleSignEvidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+type ChainParamList struct {
+	Params               []*ChainParam `protobuf:"bytes,1,rep,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ChainParamList) Reset()         { *m = ChainParamList{} }
+func (m *ChainParamList) String() string { return proto.CompactTextString(m) }
+func (*ChainParamList) ProtoMessage()    {}
+func (*ChainParamList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+}
+
+func (m *ChainParamList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainParamList.Unmarshal(m, b)
+}
+func (m *ChainParamList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainParamList.Marshal(b, m, deterministic)
+}
+func (m *ChainParamList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainParamList.Merge(m, src)
+}
+func (m *ChainParamList) XXX_Size() int {
+	return xxx_messageInfo_ChainParamList.Size(m)
+}
+func (m *ChainParamList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainParamList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainParamList proto.InternalMessageInfo
+
+func (m *ChainParamList) GetParams() []*ChainParam {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
 type ListParams struct {
 	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
 	Size                 uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	Offset               uint32   `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	Asc                  bool     `protobuf:"varint,5,opt,name=asc,proto3" json:"asc,omitempty"`
+	From                 uint64   `protobuf:"varint,6,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,7,opt,name=to,proto3" json:"to,omitempty"`
+	WithBody             bool     `protobuf:"varint,8,opt,name=withBody,proto3" json:"withBody,omitempty"`
+	Cursor               []byte   `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Range                bool     `protobuf:"varint,10,opt,name=range,proto3" json:"range,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -504,7 +689,7 @@ func (m *ListParams) Reset()         { *m = ListParams{} }
 func (m *ListParams) String() string { return proto.CompactTextString(m) }
 func (*ListParams) ProtoMessage()    {}
 func (*ListParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{8}
+	return fileDescriptor_77a6da22d6a3feb1, []int{12}
 }
 
 func (m *ListParams) XXX_Unmarshal(b []byte) error {
@@ -560,8 +745,44 @@ func (m *ListParams) GetAsc() bool {
 	return false
 }
 
+func (m *ListParams) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ListParams) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ListParams) GetWithBody() bool {
+	if m != nil {
+		return m.WithBody
+	}
+	return false
+}
+
+func (m *ListParams) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *ListParams) GetRange() bool {
+	if m != nil {
+		return m.Range
+	}
+	return false
+}
+
 type BlockHeaderList struct {
 	Blocks               []*Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	NextCursor           []byte   `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -571,7 +792,7 @@ func (m *BlockHeaderList) Reset()         { *m = BlockHeaderList{} }
 func (m *BlockHeaderList) String() string { return proto.CompactTextString(m) }
 func (*BlockHeaderList) ProtoMessage()    {}
 func (*BlockHeaderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+	return fileDescriptor_77a6da22d6a3feb1, []int{13}
 }
 
 func (m *BlockHeaderList) XXX_Unmarshal(b []byte) error {
@@ -599,6 +820,13 @@ func (m *BlockHeaderList) GetBlocks() []*Block {
 	return nil
 }
 
+func (m *BlockHeaderList) GetNextCursor() []byte {
+	if m != nil {
+		return m.NextCursor
+	}
+	return nil
+}
+
 type CommitResult struct {
 	Hash                 []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Error                CommitStatus `protobuf:"varint,2,opt,name=error,proto3,enum=types.CommitStatus" json:"error,omitempty"`
@@ -612,7 +840,7 @@ func (m *CommitResult) Reset()         { *m = CommitResult{} }
 func (m *CommitResult) String() string { return proto.CompactTextString(m) }
 func (*CommitResult) ProtoMessage()    {}
 func (*CommitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+	return fileDescriptor_77a6da22d6a3feb1, []int{14}
 }
 
 func (m *CommitResult) XXX_Unmarshal(b []byte) error {
@@ -665,7 +893,7 @@ func (m *CommitResultList) Reset()         { *m = CommitResultList{} }
 func (m *CommitResultList) String() string { return proto.CompactTextString(m) }
 func (*CommitResultList) ProtoMessage()    {}
 func (*CommitResultList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+	return fileDescriptor_77a6da22d6a3feb1, []int{15}
 }
 
 func (m *CommitResultList) XXX_Unmarshal(b []byte) error {
@@ -705,7 +933,7 @@ func (m *VerifyResult) Reset()         { *m = VerifyResult{} }
 func (m *VerifyResult) String() string { return proto.CompactTextString(m) }
 func (*VerifyResult) ProtoMessage()    {}
 func (*VerifyResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{12}
+	return fileDescriptor_77a6da22d6a3feb1, []int{16}
 }
 
 func (m *VerifyResult) XXX_Unmarshal(b []byte) error {
@@ -752,7 +980,7 @@ func (m *Personal) Reset()         { *m = Personal{} }
 func (m *Personal) String() string { return proto.CompactTextString(m) }
 func (*Personal) ProtoMessage()    {}
 func (*Personal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{13}
+	return fileDescriptor_77a6da22d6a3feb1, []int{17}
 }
 
 func (m *Personal) XXX_Unmarshal(b []byte) error {
@@ -800,7 +1028,7 @@ func (m *ImportFormat) Reset()         { *m = ImportFormat{} }
 func (m *ImportFormat) String() string { return proto.CompactTextString(m) }
 func (*ImportFormat) ProtoMessage()    {}
 func (*ImportFormat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{14}
+	return fileDescriptor_77a6da22d6a3feb1, []int{18}
 }
 
 func (m *ImportFormat) XXX_Unmarshal(b []byte) error {
@@ -842,9 +1070,104 @@ func (m *ImportFormat) GetNewpass() string {
 	return ""
 }
 
+type MnemonicFormat struct {
+	Mnemonic             string   `protobuf:"bytes,1,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Passphrase           string   `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MnemonicFormat) Reset()         { *m = MnemonicFormat{} }
+func (m *MnemonicFormat) String() string { return proto.CompactTextString(m) }
+func (*MnemonicFormat) ProtoMessage()    {}
+func (*MnemonicFormat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{19}
+}
+
+func (m *MnemonicFormat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MnemonicFormat.Unmarshal(m, b)
+}
+func (m *MnemonicFormat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MnemonicFormat.Marshal(b, m, deterministic)
+}
+func (m *MnemonicFormat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MnemonicFormat.Merge(m, src)
+}
+func (m *MnemonicFormat) XXX_Size() int {
+	return xxx_messageInfo_MnemonicFormat.Size(m)
+}
+func (m *MnemonicFormat) XXX_DiscardUnknown() {
+	xxx_messageInfo_MnemonicFormat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MnemonicFormat proto.InternalMessageInfo
+
+func (m *MnemonicFormat) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+func (m *MnemonicFormat) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+type MnemonicAccount struct {
+	Mnemonic             string   `protobuf:"bytes,1,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Account              *Account `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MnemonicAccount) Reset()         { *m = MnemonicAccount{} }
+func (m *MnemonicAccount) String() string { return proto.CompactTextString(m) }
+func (*MnemonicAccount) ProtoMessage()    {}
+func (*MnemonicAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{20}
+}
+
+func (m *MnemonicAccount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MnemonicAccount.Unmarshal(m, b)
+}
+func (m *MnemonicAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MnemonicAccount.Marshal(b, m, deterministic)
+}
+func (m *MnemonicAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MnemonicAccount.Merge(m, src)
+}
+func (m *MnemonicAccount) XXX_Size() int {
+	return xxx_messageInfo_MnemonicAccount.Size(m)
+}
+func (m *MnemonicAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_MnemonicAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MnemonicAccount proto.InternalMessageInfo
+
+func (m *MnemonicAccount) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+func (m *MnemonicAccount) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
 type Staking struct {
 	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
 	When                 uint64   `protobuf:"varint,2,opt,name=when,proto3" json:"when,omitempty"`
+	Reward               []byte   `protobuf:"bytes,3,opt,name=reward,proto3" json:"reward,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -854,7 +1177,7 @@ func (m *Staking) Reset()         { *m = Staking{} }
 func (m *Staking) String() string { return proto.CompactTextString(m) }
 func (*Staking) ProtoMessage()    {}
 func (*Staking) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{15}
+	return fileDescriptor_77a6da22d6a3feb1, []int{21}
 }
 
 func (m *Staking) XXX_Unmarshal(b []byte) error {
@@ -889,6 +1212,13 @@ func (m *Staking) GetWhen() uint64 {
 	return 0
 }
 
+func (m *Staking) GetReward() []byte {
+	if m != nil {
+		return m.Reward
+	}
+	return nil
+}
+
 type Vote struct {
 	Candidate            []byte   `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
 	Amount               []byte   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
@@ -901,7 +1231,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{16}
+	return fileDescriptor_77a6da22d6a3feb1, []int{22}
 }
 
 func (m *Vote) XXX_Unmarshal(b []byte) error {
@@ -947,7 +1277,7 @@ func (m *VoteList) Reset()         { *m = VoteList{} }
 func (m *VoteList) String() string { return proto.CompactTextString(m) }
 func (*VoteList) ProtoMessage()    {}
 func (*VoteList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{17}
+	return fileDescriptor_77a6da22d6a3feb1, []int{23}
 }
 
 func (m *VoteList) XXX_Unmarshal(b []byte) error {
@@ -983,45 +1313,376 @@ type NodeReq struct {
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodeReq) Reset()         { *m = NodeReq{} }
-func (m *NodeReq) String() string { return proto.CompactTextString(m) }
-func (*NodeReq) ProtoMessage()    {}
-func (*NodeReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{18}
+func (m *NodeReq) Reset()         { *m = NodeReq{} }
+func (m *NodeReq) String() string { return proto.CompactTextString(m) }
+func (*NodeReq) ProtoMessage()    {}
+func (*NodeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{24}
+}
+
+func (m *NodeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeReq.Unmarshal(m, b)
+}
+func (m *NodeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeReq.Marshal(b, m, deterministic)
+}
+func (m *NodeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeReq.Merge(m, src)
+}
+func (m *NodeReq) XXX_Size() int {
+	return xxx_messageInfo_NodeReq.Size(m)
+}
+func (m *NodeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeReq proto.InternalMessageInfo
+
+func (m *NodeReq) GetTimeout() []byte {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *NodeReq) GetComponent() []byte {
+	if m != nil {
+		return m.Component
+	}
+	return nil
+}
+
+type FilterInfo struct {
+	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	EventName            string   `protobuf:"bytes,2,opt,name=eventName,proto3" json:"eventName,omitempty"`
+	Blockfrom            uint64   `protobuf:"varint,3,opt,name=blockfrom,proto3" json:"blockfrom,omitempty"`
+	Blockto              uint64   `protobuf:"varint,4,opt,name=blockto,proto3" json:"blockto,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FilterInfo) Reset()         { *m = FilterInfo{} }
+func (m *FilterInfo) String() string { return proto.CompactTextString(m) }
+func (*FilterInfo) ProtoMessage()    {}
+func (*FilterInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{25}
+}
+
+func (m *FilterInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterInfo.Unmarshal(m, b)
+}
+func (m *FilterInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterInfo.Marshal(b, m, deterministic)
+}
+func (m *FilterInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterInfo.Merge(m, src)
+}
+func (m *FilterInfo) XXX_Size() int {
+	return xxx_messageInfo_FilterInfo.Size(m)
+}
+func (m *FilterInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterInfo proto.InternalMessageInfo
+
+func (m *FilterInfo) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *FilterInfo) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *FilterInfo) GetBlockfrom() uint64 {
+	if m != nil {
+		return m.Blockfrom
+	}
+	return 0
+}
+
+func (m *FilterInfo) GetBlockto() uint64 {
+	if m != nil {
+		return m.Blockto
+	}
+	return 0
+}
+
+type ReceiptInBlock struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	TxIndex              int32    `protobuf:"varint,4,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	Receipt              *Receipt `protobuf:"bytes,5,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Removed              bool     `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptInBlock) Reset()         { *m = ReceiptInBlock{} }
+func (m *ReceiptInBlock) String() string { return proto.CompactTextString(m) }
+func (*ReceiptInBlock) ProtoMessage()    {}
+func (*ReceiptInBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{26}
+}
+
+func (m *ReceiptInBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptInBlock.Unmarshal(m, b)
+}
+func (m *ReceiptInBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptInBlock.Marshal(b, m, deterministic)
+}
+func (m *ReceiptInBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptInBlock.Merge(m, src)
+}
+func (m *ReceiptInBlock) XXX_Size() int {
+	return xxx_messageInfo_ReceiptInBlock.Size(m)
+}
+func (m *ReceiptInBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptInBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptInBlock proto.InternalMessageInfo
+
+func (m *ReceiptInBlock) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *ReceiptInBlock) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ReceiptInBlock) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *ReceiptInBlock) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *ReceiptInBlock) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *ReceiptInBlock) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type AccountTxsParams struct {
+	Account              []byte   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Size                 uint32   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Cursor               []byte   `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountTxsParams) Reset()         { *m = AccountTxsParams{} }
+func (m *AccountTxsParams) String() string { return proto.CompactTextString(m) }
+func (*AccountTxsParams) ProtoMessage()    {}
+func (*AccountTxsParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{27}
+}
+
+func (m *AccountTxsParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountTxsParams.Unmarshal(m, b)
+}
+func (m *AccountTxsParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountTxsParams.Marshal(b, m, deterministic)
+}
+func (m *AccountTxsParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountTxsParams.Merge(m, src)
+}
+func (m *AccountTxsParams) XXX_Size() int {
+	return xxx_messageInfo_AccountTxsParams.Size(m)
+}
+func (m *AccountTxsParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountTxsParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountTxsParams proto.InternalMessageInfo
+
+func (m *AccountTxsParams) GetAccount() []byte {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *AccountTxsParams) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *AccountTxsParams) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+type AccountTx struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,2,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	TxIdx                int32    `protobuf:"varint,3,opt,name=txIdx,proto3" json:"txIdx,omitempty"`
+	Sent                 bool     `protobuf:"varint,4,opt,name=sent,proto3" json:"sent,omitempty"`
+	Received             bool     `protobuf:"varint,5,opt,name=received,proto3" json:"received,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountTx) Reset()         { *m = AccountTx{} }
+func (m *AccountTx) String() string { return proto.CompactTextString(m) }
+func (*AccountTx) ProtoMessage()    {}
+func (*AccountTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{28}
+}
+
+func (m *AccountTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountTx.Unmarshal(m, b)
+}
+func (m *AccountTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountTx.Marshal(b, m, deterministic)
+}
+func (m *AccountTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountTx.Merge(m, src)
+}
+func (m *AccountTx) XXX_Size() int {
+	return xxx_messageInfo_AccountTx.Size(m)
+}
+func (m *AccountTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountTx proto.InternalMessageInfo
+
+func (m *AccountTx) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *AccountTx) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *AccountTx) GetTxIdx() int32 {
+	if m != nil {
+		return m.TxIdx
+	}
+	return 0
+}
+
+func (m *AccountTx) GetSent() bool {
+	if m != nil {
+		return m.Sent
+	}
+	return false
+}
+
+func (m *AccountTx) GetReceived() bool {
+	if m != nil {
+		return m.Received
+	}
+	return false
+}
+
+type AccountTxList struct {
+	Account              []byte       `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Txs                  []*AccountTx `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+	NextCursor           []byte       `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	IndexedFrom          uint64       `protobuf:"varint,4,opt,name=indexedFrom,proto3" json:"indexedFrom,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *NodeReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NodeReq.Unmarshal(m, b)
+func (m *AccountTxList) Reset()         { *m = AccountTxList{} }
+func (m *AccountTxList) String() string { return proto.CompactTextString(m) }
+func (*AccountTxList) ProtoMessage()    {}
+func (*AccountTxList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{29}
 }
-func (m *NodeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NodeReq.Marshal(b, m, deterministic)
+
+func (m *AccountTxList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountTxList.Unmarshal(m, b)
 }
-func (m *NodeReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeReq.Merge(m, src)
+func (m *AccountTxList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountTxList.Marshal(b, m, deterministic)
 }
-func (m *NodeReq) XXX_Size() int {
-	return xxx_messageInfo_NodeReq.Size(m)
+func (m *AccountTxList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountTxList.Merge(m, src)
 }
-func (m *NodeReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeReq.DiscardUnknown(m)
+func (m *AccountTxList) XXX_Size() int {
+	return xxx_messageInfo_AccountTxList.Size(m)
+}
+func (m *AccountTxList) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountTxList.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeReq proto.InternalMessageInfo
+var xxx_messageInfo_AccountTxList proto.InternalMessageInfo
 
-func (m *NodeReq) GetTimeout() []byte {
+func (m *AccountTxList) GetAccount() []byte {
 	if m != nil {
-		return m.Timeout
+		return m.Account
 	}
 	return nil
 }
 
-func (m *NodeReq) GetComponent() []byte {
+func (m *AccountTxList) GetTxs() []*AccountTx {
 	if m != nil {
-		return m.Component
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *AccountTxList) GetNextCursor() []byte {
+	if m != nil {
+		return m.NextCursor
 	}
 	return nil
 }
 
+func (m *AccountTxList) GetIndexedFrom() uint64 {
+	if m != nil {
+		return m.IndexedFrom
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("types.CommitStatus", CommitStatus_name, CommitStatus_value)
 	proto.RegisterEnum("types.VerifyStatus", VerifyStatus_name, VerifyStatus_value)
@@ -1031,8 +1692,12 @@ func init() {
 	proto.RegisterType((*Empty)(nil), "types.Empty")
 	proto.RegisterType((*SingleBytes)(nil), "types.SingleBytes")
 	proto.RegisterType((*AccountAndRoot)(nil), "types.AccountAndRoot")
+	proto.RegisterType((*AccountAtBlock)(nil), "types.AccountAtBlock")
 	proto.RegisterType((*Peer)(nil), "types.Peer")
 	proto.RegisterType((*PeerList)(nil), "types.PeerList")
+	proto.RegisterType((*MembershipChange)(nil), "types.MembershipChange")
+	proto.RegisterType((*EvidenceList)(nil), "types.EvidenceList")
+	proto.RegisterType((*ChainParamList)(nil), "types.ChainParamList")
 	proto.RegisterType((*ListParams)(nil), "types.ListParams")
 	proto.RegisterType((*BlockHeaderList)(nil), "types.BlockHeaderList")
 	proto.RegisterType((*CommitResult)(nil), "types.CommitResult")
@@ -1040,103 +1705,149 @@ func init() {
 	proto.RegisterType((*VerifyResult)(nil), "types.VerifyResult")
 	proto.RegisterType((*Personal)(nil), "types.Personal")
 	proto.RegisterType((*ImportFormat)(nil), "types.ImportFormat")
+	proto.RegisterType((*MnemonicFormat)(nil), "types.MnemonicFormat")
+	proto.RegisterType((*MnemonicAccount)(nil), "types.MnemonicAccount")
 	proto.RegisterType((*Staking)(nil), "types.Staking")
 	proto.RegisterType((*Vote)(nil), "types.Vote")
 	proto.RegisterType((*VoteList)(nil), "types.VoteList")
 	proto.RegisterType((*NodeReq)(nil), "types.NodeReq")
+	proto.RegisterType((*FilterInfo)(nil), "types.FilterInfo")
+	proto.RegisterType((*ReceiptInBlock)(nil), "types.ReceiptInBlock")
+	proto.RegisterType((*AccountTxsParams)(nil), "types.AccountTxsParams")
+	proto.RegisterType((*AccountTx)(nil), "types.AccountTx")
+	proto.RegisterType((*AccountTxList)(nil), "types.AccountTxList")
 }
 
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
 
 var fileDescriptor_77a6da22d6a3feb1 = []byte{
-	// 1379 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x6d, 0x73, 0xda, 0xc6,
-	0x13, 0x07, 0x6c, 0xc0, 0x2c, 0x60, 0x94, 0x8b, 0xe3, 0xf0, 0xe7, 0x9f, 0x49, 0x5d, 0xb5, 0xd3,
-	0x71, 0xd3, 0xc4, 0x49, 0x48, 0xd3, 0xbe, 0xe9, 0xb4, 0x23, 0x13, 0x6c, 0x33, 0xc5, 0xe0, 0x9e,
-	0x14, 0x97, 0xb4, 0x33, 0xd5, 0xc8, 0xd2, 0x61, 0x34, 0x01, 0x9d, 0x22, 0x1d, 0x7e, 0xe8, 0x9b,
-	0x7e, 0xc7, 0x7e, 0x9f, 0xce, 0x74, 0xee, 0x41, 0x20, 0x11, 0x92, 0x99, 0xf4, 0x15, 0xb7, 0x7b,
-	0xbf, 0x7d, 0xb8, 0xdd, 0xdf, 0xed, 0x09, 0xa8, 0x44, 0xa1, 0x7b, 0x10, 0x46, 0x94, 0x51, 0x54,
-	0x64, 0xb7, 0x21, 0x89, 0x5b, 0xda, 0xc5, 0x94, 0xba, 0x6f, 0xdd, 0x89, 0xe3, 0x07, 0x72, 0xa3,
-	0x55, 0x77, 0x5c, 0x97, 0xce, 0x03, 0xa6, 0x44, 0x08, 0xa8, 0x47, 0xd4, 0xba, 0x12, 0xb6, 0x43,
-	0xb5, 0xac, 0xcd, 0x08, 0x8b, 0x7c, 0xe5, 0x4c, 0xff, 0x1d, 0xb4, 0xc3, 0x85, 0x1f, 0x93, 0x39,
-	0x6c, 0x1e, 0xa3, 0xaf, 0xa0, 0x71, 0x41, 0x62, 0x66, 0x8b, 0x00, 0xf6, 0xc4, 0x89, 0x27, 0xcd,
-	0xfc, 0x5e, 0x7e, 0xbf, 0x86, 0xeb, 0x5c, 0x2d, 0xe0, 0x27, 0x4e, 0x3c, 0x41, 0x9f, 0x41, 0x55,
-	0xe0, 0x26, 0xc4, 0xbf, 0x9c, 0xb0, 0x66, 0x61, 0x2f, 0xbf, 0xbf, 0x89, 0x81, 0xab, 0x4e, 0x84,
-	0x46, 0x77, 0xa1, 0xd8, 0x0b, 0xc2, 0x39, 0x43, 0x08, 0x36, 0x53, 0x6e, 0xc4, 0x1a, 0x35, 0xa1,
-	0xec, 0x78, 0x5e, 0x44, 0xe2, 0xb8, 0x59, 0xd8, 0xdb, 0xd8, 0xaf, 0xe1, 0x44, 0x44, 0x3b, 0x50,
-	0xbc, 0x72, 0xa6, 0x73, 0xd2, 0xdc, 0x10, 0x70, 0x29, 0xa0, 0x5d, 0x28, 0xc5, 0x6e, 0xe4, 0x87,
-	0xac, 0xb9, 0x29, 0xd4, 0x4a, 0xd2, 0xc7, 0x50, 0x1a, 0xce, 0x19, 0x8f, 0xb2, 0x03, 0x45, 0x3f,
-	0xf0, 0xc8, 0x8d, 0x08, 0x53, 0xc7, 0x52, 0xc8, 0xc6, 0xc9, 0xff, 0xf7, 0x38, 0x65, 0x28, 0x76,
-	0x67, 0x21, 0xbb, 0xd5, 0xbf, 0x80, 0xaa, 0xe9, 0x07, 0x97, 0x53, 0x72, 0x78, 0xcb, 0x48, 0xca,
-	0x4b, 0x3e, 0xe5, 0x45, 0xff, 0x03, 0xb6, 0x0d, 0xd9, 0x0d, 0x23, 0xf0, 0x30, 0xa5, 0x8c, 0xe7,
-	0xa1, 0x34, 0x0a, 0x99, 0x88, 0xbc, 0x3a, 0x1c, 0xa1, 0xd2, 0x13, 0x6b, 0xf4, 0x10, 0xa0, 0x43,
-	0x67, 0x21, 0xcf, 0x93, 0x78, 0x22, 0xc1, 0x2d, 0x9c, 0xd2, 0xe8, 0x7f, 0xc1, 0xe6, 0x19, 0x21,
-	0x11, 0x7a, 0xbc, 0x3c, 0x1d, 0xf7, 0x5a, 0x6d, 0xa3, 0x03, 0x41, 0x8f, 0x03, 0xbe, 0x6b, 0xc8,
-	0x9d, 0xe5, 0x89, 0x5f, 0x40, 0x85, 0xb7, 0x47, 0x34, 0x56, 0x84, 0xab, 0xb6, 0xef, 0x29, 0xfc,
-	0x80, 0x5c, 0x8b, 0xce, 0x0e, 0x28, 0xf3, 0x5d, 0x82, 0x97, 0x38, 0x7e, 0xc0, 0x98, 0x39, 0x4c,
-	0x96, 0xa9, 0x88, 0xa5, 0xa0, 0x3f, 0x81, 0x2d, 0x1e, 0xa2, 0xef, 0xc7, 0x0c, 0x7d, 0x0e, 0xc5,
-	0x90, 0x90, 0x88, 0xa7, 0xb0, 0xb1, 0x5f, 0x6d, 0x57, 0x53, 0x29, 0x60, 0xb9, 0xa3, 0x5f, 0x01,
-	0x70, 0xe8, 0x99, 0x13, 0x39, 0xb3, 0x78, 0x2d, 0x1f, 0x76, 0xa1, 0x94, 0x21, 0x92, 0x92, 0x38,
-	0x36, 0xf6, 0xff, 0x94, 0xd1, 0xeb, 0x58, 0xac, 0x39, 0x96, 0x8e, 0xc7, 0x31, 0x91, 0x3d, 0xaa,
-	0x63, 0x25, 0x21, 0x0d, 0x36, 0x9c, 0xd8, 0x6d, 0x16, 0x45, 0xb9, 0xf8, 0x52, 0xff, 0x1e, 0x1a,
-	0x92, 0xb0, 0xc4, 0xf1, 0x54, 0xb6, 0x5f, 0x42, 0x49, 0x1c, 0x2c, 0x49, 0xb7, 0xa6, 0xd2, 0x15,
-	0x38, 0xac, 0xf6, 0x74, 0x02, 0xb5, 0x0e, 0x9d, 0xcd, 0x7c, 0x86, 0x49, 0x3c, 0x9f, 0xae, 0xa7,
-	0xf0, 0xd7, 0x50, 0x24, 0x51, 0x44, 0x23, 0x91, 0xf1, 0x76, 0xfb, 0xae, 0x72, 0x24, 0xed, 0xe4,
-	0x65, 0xc2, 0x12, 0xc1, 0x33, 0xf6, 0x08, 0x73, 0xfc, 0xa9, 0x38, 0x47, 0x05, 0x2b, 0x49, 0x37,
-	0x40, 0x4b, 0x87, 0x11, 0x09, 0x3e, 0x81, 0x72, 0x24, 0xa4, 0x24, 0xc3, 0xac, 0x63, 0x89, 0xc4,
-	0x09, 0x46, 0xb7, 0xa0, 0x76, 0x4e, 0x22, 0x7f, 0x7c, 0xab, 0x32, 0xfd, 0x1f, 0x14, 0xd8, 0x8d,
-	0x62, 0x43, 0x45, 0x59, 0x5a, 0x37, 0xb8, 0xc0, 0x6e, 0x3e, 0x94, 0xb0, 0x34, 0xcf, 0x24, 0xac,
-	0x5b, 0xbc, 0xbf, 0x51, 0x4c, 0x03, 0x67, 0xca, 0xc9, 0x18, 0x3a, 0x71, 0x1c, 0x4e, 0x22, 0x27,
-	0x96, 0x3c, 0xaf, 0xe0, 0x94, 0x06, 0xed, 0x43, 0x59, 0x8d, 0x1e, 0x45, 0xaa, 0x6d, 0xe5, 0x58,
-	0x31, 0x1c, 0x27, 0xdb, 0xfa, 0x04, 0x6a, 0xbd, 0x59, 0x48, 0x23, 0x76, 0x44, 0xa3, 0x99, 0xc3,
-	0x7b, 0xb1, 0x71, 0xed, 0x8f, 0x57, 0xa8, 0x9b, 0xba, 0x5d, 0x98, 0x6f, 0xf3, 0xab, 0x43, 0xa7,
-	0x1e, 0x0f, 0x28, 0xfc, 0x57, 0x70, 0x22, 0xf2, 0x9d, 0x80, 0x5c, 0x8b, 0x1d, 0x59, 0xd7, 0x44,
-	0xd4, 0x5f, 0x42, 0xd9, 0x64, 0xce, 0x5b, 0x3f, 0xb8, 0xe4, 0xb5, 0x77, 0x66, 0xa9, 0x8b, 0xa7,
-	0x24, 0xde, 0xd2, 0xeb, 0x09, 0x09, 0x14, 0xdf, 0xc4, 0x5a, 0xff, 0x01, 0x36, 0xcf, 0x29, 0x23,
-	0xe8, 0x01, 0x54, 0x5c, 0x27, 0xf0, 0x7c, 0x8f, 0x13, 0x5f, 0x9a, 0x2d, 0x15, 0x29, 0x8f, 0x85,
-	0xb4, 0x47, 0x7e, 0x29, 0xb8, 0x75, 0x72, 0x29, 0xae, 0x28, 0x23, 0xab, 0x97, 0x82, 0xef, 0x63,
-	0xb9, 0xa3, 0x1b, 0x50, 0x1e, 0x50, 0x8f, 0x60, 0xf2, 0x8e, 0x1f, 0x84, 0xf9, 0x33, 0x42, 0xe7,
-	0x8b, 0xe9, 0xa0, 0x44, 0x91, 0x09, 0x9d, 0x85, 0x34, 0x20, 0x8b, 0x70, 0x4b, 0xc5, 0xa3, 0xbf,
-	0xf3, 0x09, 0x4f, 0xd5, 0xf0, 0xae, 0x40, 0xd1, 0x1a, 0xd9, 0xc3, 0x9f, 0xb5, 0x1c, 0xda, 0x01,
-	0xcd, 0x1a, 0xd9, 0x83, 0xe1, 0xa0, 0xd3, 0xb5, 0xad, 0xe1, 0xd0, 0xee, 0x0f, 0x7f, 0xd5, 0xf2,
-	0xe8, 0x1e, 0xdc, 0xb1, 0x46, 0xb6, 0xd1, 0xc7, 0x5d, 0xe3, 0xd5, 0x1b, 0xbb, 0x3b, 0xea, 0x99,
-	0x96, 0xa9, 0x15, 0xd0, 0x5d, 0x68, 0x58, 0x23, 0xbb, 0x37, 0x38, 0x37, 0xfa, 0xbd, 0x57, 0xf6,
-	0x89, 0x61, 0x9e, 0x68, 0x1b, 0x2b, 0x4a, 0xb3, 0x77, 0x3c, 0xd0, 0x36, 0x95, 0x83, 0x44, 0x79,
-	0x34, 0xc4, 0xa7, 0x86, 0xa5, 0x15, 0xd1, 0xff, 0xe1, 0xbe, 0x50, 0x9b, 0xaf, 0x8f, 0x8e, 0x7a,
-	0x9d, 0x5e, 0x77, 0x60, 0xd9, 0x87, 0x46, 0xdf, 0x18, 0x74, 0xba, 0x5a, 0x49, 0xd9, 0x9c, 0x18,
-	0xa6, 0x6d, 0x1a, 0xa7, 0x5d, 0x99, 0x93, 0x56, 0x5e, 0xb8, 0xb2, 0xba, 0x78, 0x60, 0xf4, 0xed,
-	0x2e, 0xc6, 0x43, 0xac, 0x55, 0x1e, 0x8d, 0x13, 0x46, 0xab, 0x33, 0xed, 0x80, 0x76, 0xde, 0xc5,
-	0xbd, 0xa3, 0x37, 0xb6, 0x69, 0x19, 0xd6, 0x6b, 0x53, 0x1e, 0x6f, 0x0f, 0x1e, 0x64, 0xb5, 0x3c,
-	0x3f, 0x7b, 0x30, 0xb4, 0xec, 0x53, 0xc3, 0xea, 0x9c, 0x68, 0x79, 0xf4, 0x10, 0x5a, 0x59, 0x44,
-	0xe6, 0x78, 0x85, 0xf6, 0x3f, 0x00, 0x0d, 0x83, 0x44, 0x97, 0x14, 0x9f, 0x75, 0x4c, 0x12, 0x5d,
-	0xf9, 0x2e, 0x41, 0xcf, 0xa1, 0xc2, 0x7b, 0xc2, 0x23, 0x13, 0x94, 0xf0, 0x58, 0x75, 0xa9, 0xb5,
-	0x86, 0xa1, 0x7a, 0x0e, 0x3d, 0x87, 0xd2, 0xa9, 0x78, 0x53, 0x51, 0x32, 0x4c, 0xa5, 0x18, 0x63,
-	0xf2, 0x6e, 0x4e, 0x62, 0xd6, 0xda, 0xce, 0xaa, 0xf5, 0x1c, 0x7a, 0x09, 0xb0, 0x7c, 0x76, 0x51,
-	0x32, 0x81, 0xc4, 0xfb, 0xd2, 0xba, 0x9f, 0x9e, 0x47, 0xa9, 0x77, 0x59, 0xcf, 0xa1, 0x9f, 0x40,
-	0xe3, 0xdc, 0x4a, 0x4d, 0xb4, 0x18, 0xdd, 0x51, 0xf0, 0xe5, 0x78, 0x6d, 0xed, 0xa6, 0x3d, 0x2c,
-	0x27, 0x9f, 0x48, 0xb5, 0xb1, 0x70, 0x60, 0xb2, 0x88, 0x38, 0xb3, 0x95, 0xe0, 0x99, 0x61, 0xa8,
-	0xe7, 0x9e, 0xe5, 0xd1, 0x01, 0x6c, 0x1d, 0x13, 0x69, 0x81, 0xd6, 0x9c, 0x7f, 0xd5, 0x02, 0xed,
-	0x43, 0xf1, 0x98, 0x30, 0x6b, 0xb4, 0x16, 0xbc, 0x9c, 0x47, 0x7a, 0x0e, 0x7d, 0x0b, 0x90, 0x78,
-	0xfe, 0x00, 0x5c, 0x5b, 0xc0, 0x7b, 0x41, 0xe2, 0xbf, 0x2d, 0xac, 0x30, 0x71, 0x89, 0x1f, 0xb2,
-	0xb5, 0x56, 0x49, 0xb9, 0x15, 0x46, 0xcf, 0xa1, 0x47, 0x50, 0x3a, 0x26, 0xcc, 0x38, 0xec, 0xad,
-	0xc5, 0x43, 0x32, 0xad, 0x0e, 0x7b, 0x12, 0x6b, 0x92, 0xc0, 0xb3, 0x46, 0x68, 0x99, 0x6c, 0x6b,
-	0xdd, 0x04, 0x16, 0x27, 0xd8, 0x92, 0x1a, 0x6b, 0x84, 0xea, 0x0b, 0x34, 0xaf, 0xf0, 0xa2, 0x8b,
-	0xab, 0xd3, 0x5d, 0xcf, 0xa9, 0x8a, 0x4a, 0x86, 0x7d, 0xac, 0xa2, 0x02, 0xa1, 0xe7, 0xd0, 0x8f,
-	0xa0, 0x25, 0x78, 0x23, 0xf0, 0xce, 0x22, 0x4a, 0xc7, 0x0b, 0xa6, 0x65, 0x3f, 0x32, 0x5a, 0x77,
-	0xd2, 0xa6, 0x02, 0x29, 0x2a, 0x56, 0xef, 0x44, 0x84, 0x5b, 0xab, 0x0f, 0x8e, 0xc6, 0xe2, 0x81,
-	0x96, 0x03, 0xbe, 0xb5, 0x32, 0xaf, 0x05, 0x51, 0xaa, 0xbc, 0x62, 0x52, 0x8e, 0x57, 0x48, 0x82,
-	0xb2, 0x70, 0x75, 0xac, 0x67, 0x50, 0xed, 0x53, 0xf7, 0xed, 0x27, 0x04, 0x69, 0x43, 0xfd, 0x75,
-	0x30, 0xfd, 0x34, 0x9b, 0xef, 0xa0, 0x2e, 0x5f, 0x90, 0xc4, 0x26, 0x69, 0x4d, 0xfa, 0x5d, 0x59,
-	0x6f, 0xd7, 0xbd, 0x49, 0xdb, 0xbd, 0x17, 0x6b, 0xfd, 0xe5, 0xde, 0x83, 0x92, 0xe9, 0x5f, 0x06,
-	0x59, 0x3a, 0x64, 0x68, 0xfc, 0x18, 0xb6, 0xe4, 0xb4, 0x5a, 0x4f, 0x99, 0xf4, 0xdb, 0xac, 0xe7,
-	0xd0, 0x0b, 0xa8, 0xff, 0x32, 0x27, 0xd1, 0x6d, 0x87, 0x06, 0x2c, 0x72, 0x5c, 0xb6, 0x28, 0xad,
-	0xd0, 0x7e, 0x20, 0x09, 0x03, 0x50, 0xc6, 0x48, 0x72, 0x27, 0xd3, 0x6c, 0x69, 0xbe, 0xfb, 0x9e,
-	0x2a, 0x21, 0xc1, 0x37, 0x82, 0x74, 0xfc, 0x93, 0x6c, 0xb5, 0x9b, 0x8d, 0xd4, 0xe7, 0xda, 0x62,
-	0x4c, 0x70, 0x30, 0x7f, 0xaa, 0xe2, 0xb5, 0x0c, 0x6d, 0xa4, 0x1e, 0x33, 0x65, 0x22, 0xaf, 0x65,
-	0xf2, 0xe4, 0x7e, 0xec, 0x5a, 0x2a, 0x8c, 0x9e, 0x3b, 0xdc, 0xfb, 0xed, 0xe1, 0xa5, 0xcf, 0x26,
-	0xf3, 0x8b, 0x03, 0x97, 0xce, 0x9e, 0x3a, 0x7c, 0x12, 0xfb, 0x54, 0xfe, 0x3e, 0x15, 0xd8, 0x8b,
-	0x92, 0xf8, 0x97, 0xf2, 0xe2, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xac, 0x85, 0x61, 0xb7, 0xff,
-	0x0c, 0x00, 0x00,
+	// 2003 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x18, 0xdb, 0x6e, 0x23, 0x49,
+	0xd5, 0x76, 0x6c, 0xc7, 0x3e, 0xbe, 0xf5, 0xd6, 0x26, 0x33, 0x5e, 0xb3, 0x1a, 0x42, 0x81, 0x50,
+	0x76, 0xd8, 0x99, 0xdd, 0xcd, 0x00, 0x2b, 0xb4, 0x88, 0x55, 0xc7, 0xe3, 0x24, 0x16, 0x89, 0x33,
+	0x94, 0x7b, 0x66, 0xbd, 0x20, 0x61, 0x75, 0xdc, 0x95, 0xb8, 0x35, 0x76, 0x97, 0xb7, 0xbb, 0x9c,
+	0x38, 0xbc, 0xf0, 0xc0, 0x2b, 0x12, 0xcf, 0xfc, 0x0c, 0x1f, 0xc1, 0x37, 0xf0, 0x21, 0xa8, 0x6e,
+	0x7d, 0x1b, 0x67, 0xc4, 0xf0, 0xe4, 0x3e, 0xa7, 0xce, 0xfd, 0x56, 0xa7, 0x0c, 0xf5, 0x70, 0x35,
+	0x7b, 0xbe, 0x0a, 0x19, 0x67, 0xa8, 0xc2, 0xef, 0x57, 0x34, 0xea, 0x59, 0x57, 0x0b, 0x36, 0x7b,
+	0x3b, 0x9b, 0xbb, 0x7e, 0xa0, 0x0e, 0x7a, 0x2d, 0x77, 0x36, 0x63, 0xeb, 0x80, 0x6b, 0x10, 0x02,
+	0xe6, 0x51, 0xfd, 0x5d, 0x5f, 0x1d, 0xad, 0xf4, 0x67, 0x73, 0x49, 0x79, 0xe8, 0x6b, 0x61, 0xf8,
+	0x4f, 0x60, 0x1d, 0xc7, 0x72, 0xc6, 0xdc, 0xe5, 0xeb, 0x08, 0xfd, 0x1c, 0x3a, 0x57, 0x34, 0xe2,
+	0x53, 0xa9, 0x60, 0x3a, 0x77, 0xa3, 0x79, 0xb7, 0x78, 0x50, 0x3c, 0x6c, 0x92, 0x96, 0x40, 0x4b,
+	0xf2, 0x33, 0x37, 0x9a, 0xa3, 0x1f, 0x43, 0x43, 0xd2, 0xcd, 0xa9, 0x7f, 0x33, 0xe7, 0xdd, 0xd2,
+	0x41, 0xf1, 0xb0, 0x4c, 0x40, 0xa0, 0xce, 0x24, 0x06, 0xcf, 0xa0, 0x32, 0x0c, 0x56, 0x6b, 0x8e,
+	0x10, 0x94, 0x53, 0x62, 0xe4, 0x37, 0xea, 0xc2, 0xae, 0xeb, 0x79, 0x21, 0x8d, 0xa2, 0x6e, 0xe9,
+	0x60, 0xe7, 0xb0, 0x49, 0x0c, 0x88, 0xf6, 0xa0, 0x72, 0xeb, 0x2e, 0xd6, 0xb4, 0xbb, 0x23, 0xc9,
+	0x15, 0x80, 0x1e, 0x41, 0x35, 0x9a, 0x85, 0xfe, 0x8a, 0x77, 0xcb, 0x12, 0xad, 0x21, 0x7c, 0x0d,
+	0xd5, 0xcb, 0x35, 0x17, 0x5a, 0xf6, 0xa0, 0xe2, 0x07, 0x1e, 0xdd, 0x48, 0x35, 0x2d, 0xa2, 0x80,
+	0xac, 0x9e, 0xe2, 0xff, 0xaf, 0x67, 0x17, 0x2a, 0x83, 0xe5, 0x8a, 0xdf, 0xe3, 0x9f, 0x42, 0x63,
+	0xec, 0x07, 0x37, 0x0b, 0x7a, 0x7c, 0xcf, 0x69, 0x4a, 0x4a, 0x31, 0x25, 0x05, 0xff, 0x19, 0xda,
+	0xb6, 0xca, 0x86, 0x1d, 0x78, 0x84, 0x31, 0x2e, 0xec, 0xd0, 0x18, 0x4d, 0x69, 0x40, 0x11, 0x1d,
+	0x41, 0xa1, 0xcd, 0x93, 0xdf, 0xe8, 0x09, 0x40, 0x9f, 0x2d, 0x57, 0xc2, 0x4e, 0xea, 0x49, 0x03,
+	0x6b, 0x24, 0x85, 0xc1, 0x57, 0x89, 0x7c, 0x95, 0x91, 0xf7, 0xc8, 0xef, 0xc2, 0xae, 0x24, 0x19,
+	0x31, 0x9d, 0x23, 0x03, 0xa2, 0x4f, 0xa1, 0x1e, 0xa7, 0x53, 0x47, 0x21, 0x41, 0xe0, 0xbf, 0x42,
+	0xf9, 0x15, 0xa5, 0x21, 0xfa, 0x3c, 0x89, 0xa0, 0x90, 0xdc, 0x38, 0x42, 0xcf, 0x65, 0x09, 0x3e,
+	0x17, 0xa7, 0xb6, 0x3a, 0x49, 0xa2, 0xfa, 0x02, 0xea, 0xa2, 0x04, 0x64, 0xf1, 0x48, 0x7d, 0x8d,
+	0xa3, 0x7d, 0x4d, 0x3f, 0xa2, 0x77, 0x5a, 0x33, 0xf7, 0x67, 0x94, 0x24, 0x74, 0x22, 0x88, 0x11,
+	0x77, 0xb9, 0x4a, 0x45, 0x85, 0x28, 0x00, 0x3f, 0x83, 0x9a, 0x50, 0x71, 0xee, 0x47, 0x1c, 0xfd,
+	0x04, 0x2a, 0x2b, 0x4a, 0x43, 0x61, 0xc2, 0xce, 0x61, 0xe3, 0xa8, 0x91, 0x32, 0x81, 0xa8, 0x13,
+	0x7c, 0x0c, 0xd6, 0x05, 0x5d, 0x5e, 0xd1, 0x30, 0x9a, 0xfb, 0xab, 0xfe, 0xdc, 0x0d, 0x6e, 0x64,
+	0x36, 0x43, 0xba, 0x64, 0xb7, 0x2a, 0x3d, 0x35, 0xa2, 0x21, 0x81, 0x17, 0xed, 0x31, 0x7c, 0x29,
+	0x4d, 0xac, 0x13, 0x0d, 0xe1, 0x53, 0x68, 0x0e, 0x6e, 0x7d, 0x8f, 0x06, 0x33, 0x2a, 0xd5, 0x7e,
+	0x0d, 0x75, 0xaa, 0x61, 0xa3, 0xfa, 0x13, 0xad, 0xfa, 0x25, 0x5b, 0x5f, 0x2d, 0xe8, 0xd8, 0xbf,
+	0x09, 0x0c, 0x07, 0x49, 0x68, 0xf1, 0x37, 0xd0, 0xee, 0x8b, 0x9e, 0x7a, 0xe5, 0x86, 0xee, 0x52,
+	0x8a, 0xfa, 0x0c, 0xaa, 0x2b, 0x01, 0x18, 0x39, 0x1f, 0x69, 0x39, 0x09, 0x19, 0xd1, 0x04, 0xf8,
+	0x3f, 0x45, 0x00, 0xc1, 0x23, 0xb1, 0xd1, 0xd6, 0xf6, 0x79, 0x04, 0xd5, 0x4c, 0xdf, 0x69, 0x48,
+	0xd0, 0x46, 0xfe, 0x5f, 0x54, 0x20, 0x5b, 0x44, 0x7e, 0x0b, 0x5a, 0x76, 0x7d, 0x1d, 0x51, 0x55,
+	0xd2, 0x2d, 0xa2, 0x21, 0x64, 0xc1, 0x8e, 0x1b, 0xcd, 0xba, 0x15, 0x19, 0x19, 0xf1, 0x29, 0xb8,
+	0xaf, 0x43, 0xb6, 0xec, 0x56, 0xa5, 0x4c, 0xf9, 0x8d, 0xda, 0x50, 0xe2, 0xac, 0xbb, 0x2b, 0x31,
+	0x25, 0xce, 0x50, 0x0f, 0x6a, 0x77, 0x3e, 0x9f, 0x1f, 0x33, 0xef, 0xbe, 0x5b, 0x93, 0xac, 0x31,
+	0x2c, 0x34, 0xcd, 0xd6, 0x61, 0xc4, 0xc2, 0x6e, 0x5d, 0x35, 0x8f, 0x82, 0x44, 0x7e, 0x43, 0x91,
+	0x8f, 0x2e, 0x48, 0x06, 0x05, 0xe0, 0xef, 0xa0, 0xa3, 0xaa, 0x8d, 0xba, 0x9e, 0x4e, 0xf3, 0xcf,
+	0xa0, 0x2a, 0x2b, 0xc2, 0x04, 0xa9, 0xa9, 0x83, 0x24, 0xe9, 0x88, 0x3e, 0x13, 0xdd, 0x11, 0xd0,
+	0x0d, 0xef, 0x2b, 0x55, 0xaa, 0x6f, 0x52, 0x18, 0x4c, 0xa1, 0xd9, 0x67, 0xcb, 0xa5, 0xcf, 0x09,
+	0x8d, 0xd6, 0x8b, 0xed, 0xf3, 0xe7, 0x33, 0xa8, 0xd0, 0x30, 0xd4, 0xec, 0xed, 0xa3, 0x8f, 0x4d,
+	0x36, 0x24, 0x9f, 0x9a, 0x84, 0x44, 0x51, 0x08, 0xaf, 0x3c, 0xca, 0x5d, 0x7f, 0x21, 0xa3, 0x5a,
+	0x27, 0x1a, 0xc2, 0x36, 0x58, 0x69, 0x35, 0xd2, 0x81, 0x67, 0xb0, 0x1b, 0x4a, 0xc8, 0x78, 0x90,
+	0x15, 0xac, 0x28, 0x89, 0xa1, 0xc1, 0x0e, 0x34, 0xdf, 0xd0, 0xd0, 0xbf, 0xbe, 0xd7, 0x96, 0x7e,
+	0x02, 0x25, 0xbe, 0xd1, 0x6d, 0x56, 0xd7, 0x9c, 0xce, 0x86, 0x94, 0xf8, 0xe6, 0x21, 0x83, 0x15,
+	0x7b, 0xc6, 0x60, 0xec, 0x88, 0xc6, 0x09, 0x23, 0x16, 0xb8, 0x0b, 0x11, 0xab, 0x95, 0x1b, 0x45,
+	0xab, 0x79, 0xe8, 0x46, 0xaa, 0x0b, 0xea, 0x24, 0x85, 0x41, 0x87, 0xb0, 0xab, 0xef, 0x0d, 0xdd,
+	0xad, 0x6d, 0x2d, 0x58, 0x8f, 0x0f, 0x62, 0x8e, 0xf1, 0x1c, 0x9a, 0xc3, 0xe5, 0x8a, 0x85, 0xfc,
+	0x84, 0x85, 0x4b, 0x57, 0xe4, 0x6a, 0xe7, 0xce, 0xbf, 0xce, 0xcd, 0x84, 0xd4, 0x68, 0x24, 0xe2,
+	0x58, 0x4c, 0x1f, 0xb6, 0xf0, 0x84, 0x42, 0xdd, 0x6a, 0x06, 0x14, 0x27, 0x01, 0xbd, 0x93, 0x27,
+	0x2a, 0xae, 0x06, 0xc4, 0xe7, 0xd0, 0xbe, 0x08, 0xe8, 0x92, 0x05, 0xfe, 0x4c, 0xeb, 0xea, 0x41,
+	0x6d, 0xa9, 0x31, 0xda, 0x87, 0x18, 0xce, 0x79, 0x58, 0xca, 0x7b, 0x28, 0xca, 0xcc, 0x48, 0x33,
+	0x23, 0xf1, 0x7d, 0xe2, 0xfe, 0xf7, 0x80, 0x5c, 0xc0, 0xee, 0x98, 0xbb, 0x6f, 0xfd, 0xe0, 0x46,
+	0x94, 0x88, 0xbb, 0x4c, 0x0d, 0x5f, 0x0d, 0x89, 0xca, 0xbb, 0x9b, 0xd3, 0x40, 0x37, 0xa9, 0xfc,
+	0x56, 0x33, 0xe9, 0xce, 0x0d, 0x3d, 0x3d, 0x72, 0x35, 0x84, 0x7f, 0x0b, 0xe5, 0x37, 0x8c, 0x53,
+	0x31, 0x95, 0x67, 0x6e, 0xe0, 0xf9, 0x9e, 0x18, 0x88, 0x4a, 0x5c, 0x82, 0x48, 0x69, 0x2a, 0xa5,
+	0x35, 0x89, 0x61, 0x29, 0xb8, 0xcd, 0xb0, 0xbc, 0x65, 0x9c, 0xe6, 0x87, 0xa5, 0x38, 0x27, 0xea,
+	0x04, 0xdb, 0xb0, 0x3b, 0x62, 0x1e, 0x25, 0xf4, 0x07, 0x91, 0x07, 0xee, 0x2f, 0x29, 0x5b, 0xc7,
+	0x37, 0x87, 0x06, 0xa5, 0x25, 0x6c, 0xb9, 0x62, 0x01, 0x8d, 0xd5, 0x25, 0x08, 0xfc, 0xf7, 0x22,
+	0xc0, 0x89, 0xbf, 0xe0, 0x34, 0x1c, 0x06, 0xd7, 0x0c, 0x1d, 0x42, 0x67, 0xc6, 0x02, 0x1e, 0xba,
+	0x33, 0x6e, 0xa7, 0xae, 0x8b, 0x26, 0xc9, 0xa3, 0x85, 0x58, 0x7a, 0x4b, 0x03, 0x3e, 0x72, 0x97,
+	0x26, 0x5f, 0x09, 0x42, 0x9c, 0xca, 0x36, 0x97, 0x83, 0x68, 0x47, 0xc6, 0x2d, 0x41, 0x08, 0x63,
+	0x25, 0xc0, 0x99, 0x1c, 0x66, 0x65, 0x62, 0x40, 0xfc, 0xaf, 0x22, 0xb4, 0x09, 0x9d, 0x51, 0x7f,
+	0xc5, 0x87, 0x81, 0xba, 0x13, 0x1f, 0x41, 0x95, 0x6f, 0xce, 0x92, 0xce, 0xd7, 0x50, 0xac, 0x42,
+	0x1e, 0x69, 0xbf, 0x62, 0x44, 0xac, 0x62, 0xc4, 0xb4, 0x7a, 0x03, 0x8a, 0x13, 0xbe, 0x19, 0xca,
+	0x1d, 0xa3, 0x2c, 0x2f, 0x2a, 0x03, 0x8a, 0xa2, 0x09, 0x95, 0xee, 0x6e, 0x25, 0x53, 0x34, 0xda,
+	0x22, 0x62, 0x8e, 0x85, 0x0c, 0x75, 0x07, 0x79, 0x72, 0xca, 0xd6, 0x88, 0x01, 0xf1, 0x04, 0x2c,
+	0x5d, 0x62, 0xce, 0x26, 0xd2, 0xa3, 0xbf, 0x9b, 0x14, 0xa3, 0xce, 0x8d, 0x9b, 0x6c, 0x0d, 0x72,
+	0xd0, 0x97, 0xb2, 0x83, 0x5e, 0x8f, 0xdf, 0x72, 0x7a, 0xfc, 0xe2, 0xbf, 0x15, 0xa1, 0x1e, 0x8b,
+	0x7e, 0x30, 0x2a, 0x29, 0xbf, 0x4b, 0x59, 0xbf, 0xf7, 0xa0, 0xc2, 0x37, 0x43, 0x6f, 0x63, 0xae,
+	0x67, 0x09, 0x48, 0x0b, 0x44, 0x61, 0x94, 0xa5, 0x1b, 0xf2, 0x5b, 0x34, 0x96, 0x74, 0x54, 0xb8,
+	0xa7, 0xee, 0x95, 0x18, 0xc6, 0xff, 0x28, 0x42, 0x2b, 0xb6, 0x42, 0xd6, 0xe9, 0xc3, 0xde, 0x61,
+	0xd8, 0xe1, 0x1b, 0xb5, 0x19, 0x36, 0x8e, 0xac, 0x6c, 0x03, 0x3a, 0x1b, 0x22, 0x0e, 0x73, 0xb7,
+	0xc0, 0x4e, 0xfe, 0x16, 0x40, 0x07, 0xd0, 0x90, 0x2b, 0x20, 0xf5, 0x4e, 0x44, 0x29, 0xa9, 0x72,
+	0x49, 0xa3, 0x9e, 0xfe, 0xbb, 0x68, 0x2e, 0x0a, 0xbd, 0xfa, 0xd6, 0xa1, 0xe2, 0x4c, 0xa6, 0x97,
+	0xbf, 0xb7, 0x0a, 0x68, 0x0f, 0x2c, 0x67, 0x32, 0x1d, 0x5d, 0x8e, 0xfa, 0x83, 0xa9, 0x73, 0x79,
+	0x39, 0x3d, 0xbf, 0xfc, 0xce, 0x2a, 0xa2, 0x7d, 0xf8, 0xc8, 0x99, 0x4c, 0xed, 0x73, 0x32, 0xb0,
+	0x5f, 0x7e, 0x3f, 0x1d, 0x4c, 0x86, 0x63, 0x67, 0x6c, 0x95, 0xd0, 0xc7, 0xd0, 0x71, 0x26, 0xd3,
+	0xe1, 0xe8, 0x8d, 0x7d, 0x3e, 0x7c, 0x39, 0x3d, 0xb3, 0xc7, 0x67, 0xd6, 0x4e, 0x0e, 0x39, 0x1e,
+	0x9e, 0x8e, 0xac, 0xb2, 0x16, 0x60, 0x90, 0x27, 0x97, 0xe4, 0xc2, 0x76, 0xac, 0x0a, 0xfa, 0x11,
+	0x3c, 0x96, 0xe8, 0xf1, 0xeb, 0x93, 0x93, 0x61, 0x7f, 0x38, 0x18, 0x39, 0xd3, 0x63, 0xfb, 0xdc,
+	0x1e, 0xf5, 0x07, 0x56, 0x55, 0xf3, 0x9c, 0xd9, 0xe3, 0xe9, 0xd8, 0xbe, 0x18, 0x28, 0x9b, 0xac,
+	0xdd, 0x58, 0x94, 0x33, 0x20, 0x23, 0xfb, 0x7c, 0x3a, 0x20, 0xe4, 0x92, 0x58, 0xf5, 0xa7, 0xd7,
+	0xe6, 0x4a, 0xd1, 0x3e, 0xed, 0x81, 0xf5, 0x66, 0x40, 0x86, 0x27, 0xdf, 0x4f, 0xc7, 0x8e, 0xed,
+	0xbc, 0x1e, 0x2b, 0xf7, 0x0e, 0xe0, 0xd3, 0x2c, 0x56, 0xd8, 0x37, 0x1d, 0x5d, 0x3a, 0xd3, 0x0b,
+	0xdb, 0xe9, 0x9f, 0x59, 0x45, 0xf4, 0x04, 0x7a, 0x59, 0x8a, 0x8c, 0x7b, 0xa5, 0xa3, 0x7f, 0x76,
+	0xa0, 0x63, 0xd3, 0xf0, 0x86, 0x91, 0x57, 0xfd, 0x31, 0x0d, 0x6f, 0xfd, 0x19, 0x45, 0x5f, 0x41,
+	0x5d, 0x4c, 0x15, 0xa1, 0x99, 0x22, 0xd3, 0x02, 0x7a, 0xce, 0xf4, 0xb6, 0x5c, 0x11, 0xb8, 0x80,
+	0xbe, 0x82, 0xea, 0x85, 0x7c, 0x91, 0x20, 0xb3, 0x26, 0x2a, 0x30, 0x22, 0xf4, 0x87, 0x35, 0x8d,
+	0x78, 0xaf, 0x9d, 0x45, 0xe3, 0x02, 0xfa, 0x15, 0x40, 0xf2, 0x68, 0x41, 0x66, 0x45, 0x90, 0xdb,
+	0x79, 0xef, 0x71, 0x7a, 0x61, 0x48, 0xbd, 0x6a, 0x70, 0x01, 0x7d, 0x0b, 0x96, 0xa8, 0xba, 0xd4,
+	0xca, 0x11, 0x21, 0xb3, 0x84, 0x25, 0xdb, 0x56, 0xef, 0x51, 0x5a, 0x42, 0xb2, 0x9a, 0x48, 0x53,
+	0x3b, 0xb1, 0x80, 0x31, 0x0f, 0xa9, 0xbb, 0xcc, 0x29, 0xcf, 0x6c, 0x2b, 0xb8, 0xf0, 0x65, 0x11,
+	0x7d, 0xab, 0x58, 0x06, 0x62, 0xba, 0x69, 0x16, 0xa3, 0x32, 0x19, 0x9d, 0xbd, 0xfd, 0xec, 0xb0,
+	0x18, 0x06, 0x89, 0x80, 0xe7, 0x50, 0x3b, 0xa5, 0x4a, 0x25, 0xda, 0x12, 0xc0, 0xbc, 0x4a, 0x74,
+	0x08, 0x95, 0x53, 0xca, 0x9d, 0xc9, 0x56, 0xe2, 0x64, 0xa3, 0xc0, 0x05, 0xf4, 0x4b, 0x00, 0x23,
+	0xf9, 0x01, 0x72, 0x2b, 0x26, 0x8f, 0x2d, 0x42, 0x47, 0x92, 0xcb, 0x99, 0xbc, 0x0a, 0x19, 0xbb,
+	0xde, 0xca, 0xd5, 0x8e, 0xb9, 0x24, 0x4d, 0xcc, 0xa3, 0x9d, 0x7b, 0x2f, 0x8f, 0xa6, 0xc1, 0x05,
+	0x64, 0x43, 0x5b, 0x04, 0x2e, 0x19, 0x88, 0xe8, 0x71, 0x7e, 0x0a, 0xe8, 0x19, 0xd9, 0xdb, 0xcb,
+	0x1f, 0xe8, 0x74, 0x3d, 0x83, 0xea, 0x29, 0xe5, 0xf6, 0xf1, 0x10, 0xed, 0x67, 0x29, 0xf4, 0x93,
+	0xa9, 0x07, 0x06, 0x7d, 0x3c, 0xc4, 0x05, 0xf4, 0x14, 0xaa, 0x63, 0x1a, 0x78, 0xce, 0x04, 0x25,
+	0x61, 0xea, 0x6d, 0xdb, 0xde, 0x64, 0xec, 0x6a, 0x0a, 0xe3, 0x4c, 0x50, 0x2b, 0xa6, 0x16, 0x7a,
+	0xe3, 0x02, 0xcc, 0x6f, 0x86, 0xb2, 0x7e, 0x44, 0x2e, 0x55, 0x73, 0x3c, 0x60, 0x92, 0x49, 0xa7,
+	0x24, 0xc2, 0x05, 0xf4, 0x3b, 0xb0, 0x0c, 0x8b, 0x1d, 0x78, 0x2a, 0xe8, 0x79, 0x56, 0xf5, 0xc0,
+	0xec, 0x7d, 0x94, 0x66, 0x4d, 0x42, 0xdf, 0xea, 0x87, 0x54, 0x70, 0x2b, 0x62, 0xd4, 0x89, 0x1f,
+	0x4e, 0x6a, 0x3f, 0xec, 0xe5, 0xb6, 0x1b, 0x5c, 0x40, 0xc7, 0xb0, 0xaf, 0x78, 0xf2, 0x5b, 0xd3,
+	0x3b, 0xbc, 0xa6, 0x55, 0x72, 0x84, 0xd2, 0xd5, 0x86, 0x88, 0xbd, 0x82, 0xa3, 0x5c, 0x9b, 0xa0,
+	0xac, 0x4a, 0x1d, 0x9d, 0x2f, 0xa1, 0x71, 0xce, 0x66, 0x6f, 0x3f, 0xc0, 0xd0, 0x23, 0x68, 0xbd,
+	0x0e, 0x16, 0x1f, 0xc6, 0xf3, 0x6b, 0x68, 0xa9, 0x25, 0xd6, 0xf0, 0x98, 0x0c, 0xa7, 0x57, 0xdb,
+	0x2d, 0x7c, 0xbf, 0x81, 0xb6, 0xa2, 0x30, 0xbe, 0x26, 0xe3, 0x2a, 0xb3, 0xa9, 0x6e, 0x37, 0xf3,
+	0x25, 0x0d, 0xfd, 0x5b, 0xfa, 0x61, 0x66, 0x0e, 0x36, 0x69, 0x33, 0xdf, 0xe1, 0xd9, 0x3e, 0x4d,
+	0x0f, 0xa0, 0x2a, 0x5e, 0xa4, 0xd9, 0x22, 0xce, 0xb4, 0xfd, 0xe7, 0x50, 0x53, 0xd7, 0xc3, 0xf6,
+	0x42, 0x4f, 0xbf, 0x46, 0x70, 0x01, 0xbd, 0x80, 0xd6, 0x1f, 0xd6, 0x34, 0xbc, 0xef, 0xeb, 0x15,
+	0x2e, 0xce, 0xa4, 0xc4, 0x3e, 0x60, 0x84, 0x0d, 0x28, 0xc3, 0xa4, 0x2a, 0x3e, 0x53, 0x9f, 0x8a,
+	0xfd, 0xd1, 0x3b, 0x28, 0x53, 0xb7, 0xbf, 0x90, 0xad, 0x22, 0x5e, 0xf7, 0xf9, 0xe2, 0xe9, 0xa4,
+	0x5e, 0xfe, 0xba, 0x72, 0xbe, 0x01, 0x4b, 0x3d, 0xf7, 0x93, 0xe7, 0x7f, 0x3c, 0x2d, 0xf2, 0xff,
+	0x08, 0xf4, 0x32, 0xd2, 0xa4, 0x87, 0x4d, 0x35, 0xa1, 0xd5, 0xcb, 0x3d, 0xa7, 0xcd, 0x84, 0x25,
+	0xfd, 0xa7, 0x00, 0x2e, 0xa0, 0xaf, 0xa1, 0x7d, 0x4a, 0x79, 0xf2, 0x72, 0xcf, 0x1b, 0xb9, 0xff,
+	0xce, 0xdb, 0x3e, 0x33, 0x02, 0xc4, 0x22, 0x1e, 0x6d, 0x1d, 0x84, 0x9d, 0xd4, 0xaa, 0xae, 0x59,
+	0xd4, 0xf4, 0x34, 0x0f, 0x8d, 0xf7, 0x4d, 0x4f, 0x4d, 0x83, 0x0b, 0xc7, 0x07, 0x7f, 0x7c, 0x72,
+	0xe3, 0xf3, 0xf9, 0xfa, 0xea, 0xf9, 0x8c, 0x2d, 0xbf, 0x70, 0xc5, 0x2d, 0xed, 0x33, 0xf5, 0xfb,
+	0x85, 0xa4, 0xbd, 0xaa, 0xca, 0xff, 0xff, 0x5e, 0xfc, 0x77, 0x00, 0xff, 0xaf, 0x55, 0x17, 0x59,
+	0x14, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1156,26 +1867,35 @@ type AergoRPCServiceClient interface {
 	Blockchain(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlockchainStatus, error)
 	ListBlockHeaders(ctx context.Context, in *ListParams, opts ...grpc.CallOption) (*BlockHeaderList, error)
 	ListBlockStream(ctx context.Context, in *Empty, opts ...grpc.CallOption) (AergoRPCService_ListBlockStreamClient, error)
+	ListEventStream(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (AergoRPCService_ListEventStreamClient, error)
 	GetBlock(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Block, error)
 	GetTX(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Tx, error)
 	GetBlockTX(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxInBlock, error)
+	GetTXProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxProof, error)
 	GetReceipt(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Receipt, error)
-	GetABI(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ABI, error)
+	ListAccountTxs(ctx context.Context, in *AccountTxsParams, opts ...grpc.CallOption) (*AccountTxList, error)
+	GetABI(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ABI, error)
 	SendTX(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*CommitResult, error)
 	CommitTX(ctx context.Context, in *TxList, opts ...grpc.CallOption) (*CommitResultList, error)
-	GetState(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*State, error)
+	GetState(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*State, error)
 	GetStateAndProof(ctx context.Context, in *AccountAndRoot, opts ...grpc.CallOption) (*StateProof, error)
 	CreateAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*Account, error)
+	CreateMnemonicAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*MnemonicAccount, error)
 	GetAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error)
 	LockAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*Account, error)
 	UnlockAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*Account, error)
 	ImportAccount(ctx context.Context, in *ImportFormat, opts ...grpc.CallOption) (*Account, error)
+	ImportMnemonic(ctx context.Context, in *MnemonicFormat, opts ...grpc.CallOption) (*Account, error)
+	DeriveAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*Account, error)
 	ExportAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*SingleBytes, error)
 	SignTX(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*Tx, error)
 	VerifyTX(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*VerifyResult, error)
 	QueryContract(ctx context.Context, in *Query, opts ...grpc.CallOption) (*SingleBytes, error)
 	QueryContractState(ctx context.Context, in *StateQuery, opts ...grpc.CallOption) (*StateQueryProof, error)
 	GetPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerList, error)
+	ChangeMembership(ctx context.Context, in *MembershipChange, opts ...grpc.CallOption) (*Empty, error)
+	ListEvidence(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EvidenceList, error)
+	GetChainParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChainParamList, error)
 	GetVotes(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*VoteList, error)
 	GetStaking(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Staking, error)
 }
@@ -1256,6 +1976,38 @@ func (x *aergoRPCServiceListBlockStreamClient) Recv() (*Block, error) {
 	return m, nil
 }
 
+func (c *aergoRPCServiceClient) ListEventStream(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (AergoRPCService_ListEventStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AergoRPCService_serviceDesc.Streams[1], "/types.AergoRPCService/ListEventStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aergoRPCServiceListEventStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AergoRPCService_ListEventStreamClient interface {
+	Recv() (*ReceiptInBlock, error)
+	grpc.ClientStream
+}
+
+type aergoRPCServiceListEventStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aergoRPCServiceListEventStreamClient) Recv() (*ReceiptInBlock, error) {
+	m := new(ReceiptInBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *aergoRPCServiceClient) GetBlock(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Block, error) {
 	out := new(Block)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetBlock", in, out, opts...)
@@ -1283,6 +2035,15 @@ func (c *aergoRPCServiceClient) GetBlockTX(ctx context.Context, in *SingleBytes,
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) GetTXProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxProof, error) {
+	out := new(TxProof)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetTXProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aergoRPCServiceClient) GetReceipt(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Receipt, error) {
 	out := new(Receipt)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetReceipt", in, out, opts...)
@@ -1292,7 +2053,16 @@ func (c *aergoRPCServiceClient) GetReceipt(ctx context.Context, in *SingleBytes,
 	return out, nil
 }
 
-func (c *aergoRPCServiceClient) GetABI(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ABI, error) {
+func (c *aergoRPCServiceClient) ListAccountTxs(ctx context.Context, in *AccountTxsParams, opts ...grpc.CallOption) (*AccountTxList, error) {
+	out := new(AccountTxList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ListAccountTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetABI(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ABI, error) {
 	out := new(ABI)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetABI", in, out, opts...)
 	if err != nil {
@@ -1319,7 +2089,7 @@ func (c *aergoRPCServiceClient) CommitTX(ctx context.Context, in *TxList, opts .
 	return out, nil
 }
 
-func (c *aergoRPCServiceClient) GetState(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*State, error) {
+func (c *aergoRPCServiceClient) GetState(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*State, error) {
 	out := new(State)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetState", in, out, opts...)
 	if err != nil {
@@ -1346,6 +2116,15 @@ func (c *aergoRPCServiceClient) CreateAccount(ctx context.Context, in *Personal,
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) CreateMnemonicAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*MnemonicAccount, error) {
+	out := new(MnemonicAccount)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/CreateMnemonicAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aergoRPCServiceClient) GetAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error) {
 	out := new(AccountList)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetAccounts", in, out, opts...)
@@ -1382,6 +2161,24 @@ func (c *aergoRPCServiceClient) ImportAccount(ctx context.Context, in *ImportFor
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) ImportMnemonic(ctx context.Context, in *MnemonicFormat, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ImportMnemonic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) DeriveAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/DeriveAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aergoRPCServiceClient) ExportAccount(ctx context.Context, in *Personal, opts ...grpc.CallOption) (*SingleBytes, error) {
 	out := new(SingleBytes)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ExportAccount", in, out, opts...)
@@ -1436,6 +2233,33 @@ func (c *aergoRPCServiceClient) GetPeers(ctx context.Context, in *Empty, opts ..
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) ChangeMembership(ctx context.Context, in *MembershipChange, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ChangeMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListEvidence(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EvidenceList, error) {
+	out := new(EvidenceList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ListEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetChainParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChainParamList, error) {
+	out := new(ChainParamList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetChainParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aergoRPCServiceClient) GetVotes(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*VoteList, error) {
 	out := new(VoteList)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetVotes", in, out, opts...)
@@ -1461,26 +2285,35 @@ type AergoRPCServiceServer interface {
 	Blockchain(context.Context, *Empty) (*BlockchainStatus, error)
 	ListBlockHeaders(context.Context, *ListParams) (*BlockHeaderList, error)
 	ListBlockStream(*Empty, AergoRPCService_ListBlockStreamServer) error
+	ListEventStream(*FilterInfo, AergoRPCService_ListEventStreamServer) error
 	GetBlock(context.Context, *SingleBytes) (*Block, error)
 	GetTX(context.Context, *SingleBytes) (*Tx, error)
 	GetBlockTX(context.Context, *SingleBytes) (*TxInBlock, error)
+	GetTXProof(context.Context, *SingleBytes) (*TxProof, error)
 	GetReceipt(context.Context, *SingleBytes) (*Receipt, error)
-	GetABI(context.Context, *SingleBytes) (*ABI, error)
+	ListAccountTxs(context.Context, *AccountTxsParams) (*AccountTxList, error)
+	GetABI(context.Context, *AccountAtBlock) (*ABI, error)
 	SendTX(context.Context, *Tx) (*CommitResult, error)
 	CommitTX(context.Context, *TxList) (*CommitResultList, error)
-	GetState(context.Context, *SingleBytes) (*State, error)
+	GetState(context.Context, *AccountAtBlock) (*State, error)
 	GetStateAndProof(context.Context, *AccountAndRoot) (*StateProof, error)
 	CreateAccount(context.Context, *Personal) (*Account, error)
+	CreateMnemonicAccount(context.Context, *Personal) (*MnemonicAccount, error)
 	GetAccounts(context.Context, *Empty) (*AccountList, error)
 	LockAccount(context.Context, *Personal) (*Account, error)
 	UnlockAccount(context.Context, *Personal) (*Account, error)
 	ImportAccount(context.Context, *ImportFormat) (*Account, error)
+	ImportMnemonic(context.Context, *MnemonicFormat) (*Account, error)
+	DeriveAccount(context.Context, *Personal) (*Account, error)
 	ExportAccount(context.Context, *Personal) (*SingleBytes, error)
 	SignTX(context.Context, *Tx) (*Tx, error)
 	VerifyTX(context.Context, *Tx) (*VerifyResult, error)
 	QueryContract(context.Context, *Query) (*SingleBytes, error)
 	QueryContractState(context.Context, *StateQuery) (*StateQueryProof, error)
 	GetPeers(context.Context, *Empty) (*PeerList, error)
+	ChangeMembership(context.Context, *MembershipChange) (*Empty, error)
+	ListEvidence(context.Context, *Empty) (*EvidenceList, error)
+	GetChainParams(context.Context, *Empty) (*ChainParamList, error)
 	GetVotes(context.Context, *SingleBytes) (*VoteList, error)
 	GetStaking(context.Context, *SingleBytes) (*Staking, error)
 }
@@ -1582,6 +2415,27 @@ func (x *aergoRPCServiceListBlockStreamServer) Send(m *Block) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _AergoRPCService_ListEventStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FilterInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AergoRPCServiceServer).ListEventStream(m, &aergoRPCServiceListEventStreamServer{stream})
+}
+
+type AergoRPCService_ListEventStreamServer interface {
+	Send(*ReceiptInBlock) error
+	grpc.ServerStream
+}
+
+type aergoRPCServiceListEventStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aergoRPCServiceListEventStreamServer) Send(m *ReceiptInBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _AergoRPCService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SingleBytes)
 	if err := dec(in); err != nil {
@@ -1636,6 +2490,24 @@ func _AergoRPCService_GetBlockTX_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_GetTXProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetTXProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetTXProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetTXProof(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SingleBytes)
 	if err := dec(in); err != nil {
@@ -1654,8 +2526,26 @@ func _AergoRPCService_GetReceipt_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_ListAccountTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountTxsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListAccountTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ListAccountTxs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListAccountTxs(ctx, req.(*AccountTxsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_GetABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SingleBytes)
+	in := new(AccountAtBlock)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1667,7 +2557,7 @@ func _AergoRPCService_GetABI_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: "/types.AergoRPCService/GetABI",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AergoRPCServiceServer).GetABI(ctx, req.(*SingleBytes))
+		return srv.(AergoRPCServiceServer).GetABI(ctx, req.(*AccountAtBlock))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1709,7 +2599,7 @@ func _AergoRPCService_CommitTX_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _AergoRPCService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SingleBytes)
+	in := new(AccountAtBlock)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1721,7 +2611,7 @@ func _AergoRPCService_GetState_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/types.AergoRPCService/GetState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AergoRPCServiceServer).GetState(ctx, req.(*SingleBytes))
+		return srv.(AergoRPCServiceServer).GetState(ctx, req.(*AccountAtBlock))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1762,6 +2652,24 @@ func _AergoRPCService_CreateAccount_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_CreateMnemonicAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Personal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).CreateMnemonicAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/CreateMnemonicAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).CreateMnemonicAccount(ctx, req.(*Personal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_GetAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -1834,6 +2742,42 @@ func _AergoRPCService_ImportAccount_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_ImportMnemonic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MnemonicFormat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ImportMnemonic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ImportMnemonic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ImportMnemonic(ctx, req.(*MnemonicFormat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_DeriveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Personal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).DeriveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/DeriveAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).DeriveAccount(ctx, req.(*Personal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_ExportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Personal)
 	if err := dec(in); err != nil {
@@ -1942,6 +2886,60 @@ func _AergoRPCService_GetPeers_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_ChangeMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ChangeMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ChangeMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ChangeMembership(ctx, req.(*MembershipChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ListEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListEvidence(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetChainParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetChainParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetChainParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetChainParams(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_GetVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SingleBytes)
 	if err := dec(in); err != nil {
@@ -2010,10 +3008,18 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockTX",
 			Handler:    _AergoRPCService_GetBlockTX_Handler,
 		},
+		{
+			MethodName: "GetTXProof",
+			Handler:    _AergoRPCService_GetTXProof_Handler,
+		},
 		{
 			MethodName: "GetReceipt",
 			Handler:    _AergoRPCService_GetReceipt_Handler,
 		},
+		{
+			MethodName: "ListAccountTxs",
+			Handler:    _AergoRPCService_ListAccountTxs_Handler,
+		},
 		{
 			MethodName: "GetABI",
 			Handler:    _AergoRPCService_GetABI_Handler,
@@ -2038,6 +3044,10 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateAccount",
 			Handler:    _AergoRPCService_CreateAccount_Handler,
 		},
+		{
+			MethodName: "CreateMnemonicAccount",
+			Handler:    _AergoRPCService_CreateMnemonicAccount_Handler,
+		},
 		{
 			MethodName: "GetAccounts",
 			Handler:    _AergoRPCService_GetAccounts_Handler,
@@ -2054,6 +3064,14 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ImportAccount",
 			Handler:    _AergoRPCService_ImportAccount_Handler,
 		},
+		{
+			MethodName: "ImportMnemonic",
+			Handler:    _AergoRPCService_ImportMnemonic_Handler,
+		},
+		{
+			MethodName: "DeriveAccount",
+			Handler:    _AergoRPCService_DeriveAccount_Handler,
+		},
 		{
 			MethodName: "ExportAccount",
 			Handler:    _AergoRPCService_ExportAccount_Handler,
@@ -2078,6 +3096,18 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPeers",
 			Handler:    _AergoRPCService_GetPeers_Handler,
 		},
+		{
+			MethodName: "ChangeMembership",
+			Handler:    _AergoRPCService_ChangeMembership_Handler,
+		},
+		{
+			MethodName: "ListEvidence",
+			Handler:    _AergoRPCService_ListEvidence_Handler,
+		},
+		{
+			MethodName: "GetChainParams",
+			Handler:    _AergoRPCService_GetChainParams_Handler,
+		},
 		{
 			MethodName: "GetVotes",
 			Handler:    _AergoRPCService_GetVotes_Handler,
@@ -2093,6 +3123,11 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _AergoRPCService_ListBlockStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListEventStream",
+			Handler:       _AergoRPCService_ListEventStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
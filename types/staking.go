@@ -5,3 +5,7 @@ import "math/big"
 func (s *Staking) GetAmountBigInt() *big.Int {
 	return new(big.Int).SetBytes(s.GetAmount())
 }
+
+func (s *Staking) GetRewardBigInt() *big.Int {
+	return new(big.Int).SetBytes(s.GetReward())
+}